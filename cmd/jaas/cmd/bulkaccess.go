@@ -0,0 +1,183 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	grantBulkCommandDoc = `
+grant-bulk grants a user or group access to every model matching a selector.
+
+The user or group is specified as a tag, for example user-bob@canonical.com or
+group-sre. The access level is one of read, write or admin.
+
+Models are selected by owner, controller, name pattern and labels. At least
+one selector must be given, and a model must match all of the given selectors.
+Only models you administer are changed.
+
+Use --dry-run to list the models that would be changed without changing them.
+`
+	grantBulkCommandExamples = `
+    juju grant-bulk group-sre admin --owner alice@canonical.com
+    juju grant-bulk user-bob@canonical.com read --name 'ci-*' --dry-run
+    juju grant-bulk group-observability read --label-selector team=observability,env!=dev
+`
+
+	revokeBulkCommandDoc = `
+revoke-bulk revokes a user's or group's access to every model matching a selector.
+
+The user or group is specified as a tag, for example user-bob@canonical.com or
+group-sre. The access level is one of read, write or admin. Revoking an access
+level also revokes any higher access level.
+
+Models are selected by owner, controller, name pattern and labels. At least
+one selector must be given, and a model must match all of the given selectors.
+Only models you administer are changed.
+
+Use --dry-run to list the models that would be changed without changing them.
+`
+	revokeBulkCommandExamples = `
+    juju revoke-bulk group-sre write --controller controller-1
+    juju revoke-bulk user-bob@canonical.com read --name 'ci-*' --dry-run
+`
+)
+
+// NewGrantBulkCommand returns a command to grant access to many models.
+func NewGrantBulkCommand() cmd.Command {
+	cmd := &bulkAccessCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// NewRevokeBulkCommand returns a command to revoke access to many models.
+func NewRevokeBulkCommand() cmd.Command {
+	cmd := &bulkAccessCommand{
+		store:  jujuclient.NewFileClientStore(),
+		revoke: true,
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// bulkAccessCommand grants or revokes access to every model matching a
+// selector.
+type bulkAccessCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	revoke bool
+
+	entity        string
+	access        string
+	owner         string
+	controller    string
+	name          string
+	labelSelector string
+	dryRun        bool
+}
+
+// Info implements Command.Info.
+func (c *bulkAccessCommand) Info() *cmd.Info {
+	if c.revoke {
+		return jujucmd.Info(&cmd.Info{
+			Name:     "revoke-bulk",
+			Args:     "<user|group> <access>",
+			Purpose:  "Revokes access to all models matching a selector",
+			Examples: revokeBulkCommandExamples,
+			Doc:      revokeBulkCommandDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:     "grant-bulk",
+		Args:     "<user|group> <access>",
+		Purpose:  "Grants access to all models matching a selector",
+		Examples: grantBulkCommandExamples,
+		Doc:      grantBulkCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *bulkAccessCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.owner, "owner", "", "select models owned by the given user")
+	f.StringVar(&c.controller, "controller", "", "select models hosted on the given controller")
+	f.StringVar(&c.name, "name", "", "select models whose name matches the given glob pattern")
+	f.StringVar(&c.labelSelector, "label-selector", "", "select models whose labels match the given selector")
+	f.BoolVar(&c.dryRun, "dry-run", false, "list the affected models without changing any access")
+}
+
+// Init implements the cmd.Command interface.
+func (c *bulkAccessCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("user or group and access level must be specified")
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	c.entity, c.access = args[0], args[1]
+	switch c.access {
+	case "read", "write", "admin":
+	default:
+		return errors.E(`access level must be one of "read", "write" or "admin"`)
+	}
+	if c.owner != "" && !names.IsValidUser(c.owner) {
+		return errors.E("invalid owner")
+	}
+	if c.owner == "" && c.controller == "" && c.name == "" && c.labelSelector == "" {
+		return errors.E("at least one of --owner, --controller, --name or --label-selector must be specified")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *bulkAccessCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return errors.E(err, "failed to dial the controller")
+	}
+
+	req := apiparams.BulkModelAccessRequest{
+		Revoke:        c.revoke,
+		Entity:        c.entity,
+		Access:        c.access,
+		Controller:    c.controller,
+		NameGlob:      c.name,
+		LabelSelector: c.labelSelector,
+		DryRun:        c.dryRun,
+	}
+	if c.owner != "" {
+		req.OwnerTag = names.NewUserTag(c.owner).String()
+	}
+	client := api.NewClient(apiCaller)
+	resp, err := client.BulkModelAccess(&req)
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, resp)
+}
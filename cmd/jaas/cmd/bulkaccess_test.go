@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+type bulkAccessSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&bulkAccessSuite{})
+
+func (s *bulkAccessSuite) TestGrantAndRevokeBulk(c *gc.C) {
+	ctx := context.Background()
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt1 := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "ci-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+	mt2 := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "ci-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	bob := dbmodel.Identity{Name: "bob@canonical.com"}
+	err := s.JIMM.Database.GetIdentity(ctx, &bob)
+	c.Assert(err, gc.IsNil)
+	bobUser := openfga.NewUser(&bob, s.OFGAClient)
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewGrantBulkCommandForTesting(s.ClientStore(), bClient), "user-bob@canonical.com", "read", "--owner", "charlie@canonical.com", "--dry-run")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, `models:
+- uuid: `+mt1.Id()+`
+  name: ci-1
+  owner: charlie@canonical.com
+- uuid: `+mt2.Id()+`
+  name: ci-2
+  owner: charlie@canonical.com
+`)
+	c.Check(bobUser.GetModelAccess(ctx, mt1), gc.Equals, ofganames.NoRelation)
+	c.Check(bobUser.GetModelAccess(ctx, mt2), gc.Equals, ofganames.NoRelation)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewGrantBulkCommandForTesting(s.ClientStore(), bClient), "user-bob@canonical.com", "read", "--owner", "charlie@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `correlation-id: .*\nmodels:\n(.*\n){6}`)
+	c.Check(bobUser.GetModelAccess(ctx, mt1), gc.Equals, ofganames.ReaderRelation)
+	c.Check(bobUser.GetModelAccess(ctx, mt2), gc.Equals, ofganames.ReaderRelation)
+
+	var entries []dbmodel.AuditLogEntry
+	err = s.JIMM.Database.ForEachAuditLogEntry(ctx, db.AuditLogFilter{Method: "BulkModelAccess"}, func(ale *dbmodel.AuditLogEntry) error {
+		// Entries recorded from RPC requests have a conversation ID.
+		if ale.ConversationId == "" {
+			entries = append(entries, *ale)
+		}
+		return nil
+	})
+	c.Assert(err, gc.IsNil)
+	c.Check(entries, gc.HasLen, 1)
+
+	// Granting the same access again changes nothing.
+	context, err = cmdtesting.RunCommand(c, cmd.NewGrantBulkCommandForTesting(s.ClientStore(), bClient), "user-bob@canonical.com", "read", "--owner", "charlie@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "models: []\n")
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRevokeBulkCommandForTesting(s.ClientStore(), bClient), "user-bob@canonical.com", "read", "--name", "ci-2")
+	c.Assert(err, gc.IsNil)
+	c.Check(bobUser.GetModelAccess(ctx, mt1), gc.Equals, ofganames.ReaderRelation)
+	c.Check(bobUser.GetModelAccess(ctx, mt2), gc.Equals, ofganames.NoRelation)
+
+	// bob does not administer any models, so nothing is changed.
+	bClient = jimmtest.NewUserSessionLogin(c, "bob")
+	context, err = cmdtesting.RunCommand(c, cmd.NewRevokeBulkCommandForTesting(s.ClientStore(), bClient), "user-bob@canonical.com", "read", "--name", "ci-*")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "models: []\n")
+	c.Check(bobUser.GetModelAccess(ctx, mt1), gc.Equals, ofganames.ReaderRelation)
+}
+
+func (s *bulkAccessSuite) TestGrantBulkInvalidArguments(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewGrantBulkCommandForTesting(s.ClientStore(), bClient), "user-bob@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `user or group and access level must be specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewGrantBulkCommandForTesting(s.ClientStore(), bClient), "user-bob@canonical.com", "superuser", "--owner", "alice@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `access level must be one of "read", "write" or "admin"`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewGrantBulkCommandForTesting(s.ClientStore(), bClient), "user-bob@canonical.com", "read")
+	c.Assert(err, gc.ErrorMatches, `at least one of --owner, --controller, --name or --label-selector must be specified`)
+}
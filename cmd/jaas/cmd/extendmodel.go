@@ -0,0 +1,132 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	extendModelCommandDoc = `
+extend-model extends the expiry time of an ephemeral model by the given duration.
+
+Ephemeral models are created by setting the jimm-model-ttl model config value
+to a duration when adding a model, for example:
+
+    juju add-model ci-1234 --config jimm-model-ttl=4h
+
+JAAS destroys ephemeral models once their expiry time has passed. If the model
+has already expired, but has not yet been destroyed, the new expiry time is
+calculated from the current time.
+
+The model may be specified by name or by UUID.
+`
+	extendModelCommandExamples = `
+    juju extend-model ci-1234 2h
+    juju extend-model alice@canonical.com/ci-1234 30m
+    juju extend-model 00000002-0000-0000-0000-000000000001 24h
+`
+)
+
+// NewExtendModelCommand returns a command to extend an ephemeral model.
+func NewExtendModelCommand() cmd.Command {
+	cmd := &extendModelCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// extendModelCommand extends the expiry time of an ephemeral model.
+type extendModelCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	model    string
+	duration time.Duration
+}
+
+// Info implements Command.Info.
+func (c *extendModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "extend-model",
+		Args:     "<model> <duration>",
+		Purpose:  "Extends the expiry time of an ephemeral model",
+		Examples: extendModelCommandExamples,
+		Doc:      extendModelCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *extendModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "smart", map[string]cmd.Formatter{
+		"yaml":  cmd.FormatYaml,
+		"json":  cmd.FormatJson,
+		"smart": cmd.FormatSmart,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *extendModelCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("model and duration must be specified")
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	c.model = args[0]
+	var err error
+	c.duration, err = time.ParseDuration(args[1])
+	if err != nil {
+		return errors.E(fmt.Sprintf("invalid duration %q", args[1]))
+	}
+	if c.duration <= 0 {
+		return errors.E("duration must be positive")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *extendModelCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	modelUUID, err := resolveModelUUID(c.store, currentController, c.model)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return errors.E(err, "failed to dial the controller")
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.ExtendModel(&apiparams.ExtendModelRequest{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Duration: c.duration,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, resp.ExpiresAt.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type extendModelSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&extendModelSuite{})
+
+func (s *extendModelSuite) TestExtendModel(c *gc.C) {
+	ctx := context.Background()
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/alice@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("alice@canonical.com"), "model-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewExtendModelCommandForTesting(s.ClientStore(), bClient), mt.Id(), "1h")
+	c.Assert(err, gc.ErrorMatches, `model does not expire`)
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	m := dbmodel.Model{UUID: sql.NullString{String: mt.Id(), Valid: true}}
+	err = s.JIMM.Database.GetModel(ctx, &m)
+	c.Assert(err, gc.IsNil)
+	m.ExpiresAt = sql.NullTime{Time: expiresAt, Valid: true}
+	err = s.JIMM.Database.UpdateModel(ctx, &m)
+	c.Assert(err, gc.IsNil)
+
+	result, err := cmdtesting.RunCommand(c, cmd.NewExtendModelCommandForTesting(s.ClientStore(), bClient), mt.Id(), "2h")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(result), gc.Equals, expiresAt.Add(2*time.Hour).Format(time.RFC3339)+"\n")
+
+	err = s.JIMM.Database.GetModel(ctx, &m)
+	c.Assert(err, gc.IsNil)
+	c.Check(m.ExpiresAt.Time.Equal(expiresAt.Add(2*time.Hour)), gc.Equals, true)
+
+	// bob is not a model administrator.
+	bClient = jimmtest.NewUserSessionLogin(c, "bob")
+	_, err = cmdtesting.RunCommand(c, cmd.NewExtendModelCommandForTesting(s.ClientStore(), bClient), mt.Id(), "2h")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *extendModelSuite) TestExtendModelInvalidArguments(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewExtendModelCommandForTesting(s.ClientStore(), bClient), "model-1")
+	c.Assert(err, gc.ErrorMatches, `model and duration must be specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewExtendModelCommandForTesting(s.ClientStore(), bClient), "model-1", "forever")
+	c.Assert(err, gc.ErrorMatches, `invalid duration "forever"`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewExtendModelCommandForTesting(s.ClientStore(), bClient), "model-1", "-1h")
+	c.Assert(err, gc.ErrorMatches, `duration must be positive`)
+}
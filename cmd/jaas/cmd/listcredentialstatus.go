@@ -0,0 +1,118 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	listCredentialStatusCommandDoc = `
+list-credential-status lists the cloud credentials stored in JIMM along with
+the result of their most recent validity check.
+
+JIMM periodically checks every cloud credential that is used by a model
+against the controllers hosting those models. Credentials that were rejected,
+for example because the cloud keys have expired or been revoked, are reported
+as not valid. Credentials not used by any model are not checked.
+
+JIMM administrators see the credentials of all users, other users only see
+their own. Use --owner to select the credentials of a single user and
+--invalid to only show the credentials that failed their last check.
+`
+	listCredentialStatusCommandExamples = `
+    jaas list-credential-status
+    jaas list-credential-status --invalid
+    jaas list-credential-status --owner alice@canonical.com --format json
+`
+)
+
+// NewListCredentialStatusCommand returns a command to list cloud credentials
+// and their validity.
+func NewListCredentialStatusCommand() cmd.Command {
+	cmd := &listCredentialStatusCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listCredentialStatusCommand lists cloud credentials and their validity.
+type listCredentialStatusCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	owner   string
+	invalid bool
+}
+
+// Info implements Command.Info.
+func (c *listCredentialStatusCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "list-credential-status",
+		Purpose:  "Lists cloud credentials and their validity",
+		Examples: listCredentialStatusCommandExamples,
+		Doc:      listCredentialStatusCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCredentialStatusCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.owner, "owner", "", "list credentials owned by the given user")
+	f.BoolVar(&c.invalid, "invalid", false, "only list credentials that failed their last check")
+}
+
+// Init implements the cmd.Command interface.
+func (c *listCredentialStatusCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	if c.owner != "" && !names.IsValidUser(c.owner) {
+		return errors.E("invalid owner")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listCredentialStatusCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return errors.E(err, "failed to dial the controller")
+	}
+
+	req := apiparams.ListCloudCredentialsRequest{
+		Invalid: c.invalid,
+	}
+	if c.owner != "" {
+		req.OwnerTag = names.NewUserTag(c.owner).String()
+	}
+	client := api.NewClient(apiCaller)
+	resp, err := client.ListCloudCredentials(&req)
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, resp)
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type listCredentialStatusSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&listCredentialStatusSuite{})
+
+func (s *listCredentialStatusSuite) TestListCredentialStatus(c *gc.C) {
+	ctx := context.Background()
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+
+	cred := dbmodel.CloudCredential{
+		CloudName:         jimmtest.TestCloudName,
+		OwnerIdentityName: "charlie@canonical.com",
+		Name:              "cred",
+	}
+	err := s.JIMM.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, gc.IsNil)
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewListCredentialStatusCommandForTesting(s.ClientStore(), bClient), "--invalid")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "credentials: []\n")
+
+	cred.Valid = sql.NullBool{Bool: false, Valid: true}
+	cred.LastChecked = sql.NullTime{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Valid: true}
+	err = s.JIMM.Database.UpdateCloudCredentialValidity(ctx, &cred)
+	c.Assert(err, gc.IsNil)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewListCredentialStatusCommandForTesting(s.ClientStore(), bClient), "--invalid")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, `credentials:
+- tag: cloudcred-`+jimmtest.TestCloudName+`_charlie@canonical.com_cred
+  auth-type: empty
+  valid: false
+  last-checked: 2024-01-02T03:04:05Z
+  models: 0
+`)
+
+	// bob only sees his own credentials.
+	bClient = jimmtest.NewUserSessionLogin(c, "bob")
+	context, err = cmdtesting.RunCommand(c, cmd.NewListCredentialStatusCommandForTesting(s.ClientStore(), bClient), "--invalid")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "credentials: []\n")
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewListCredentialStatusCommandForTesting(s.ClientStore(), bClient), "--owner", "charlie@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *listCredentialStatusSuite) TestListCredentialStatusInvalidArgs(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewListCredentialStatusCommandForTesting(s.ClientStore(), bClient), "cred")
+	c.Assert(err, gc.ErrorMatches, `too many args`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewListCredentialStatusCommandForTesting(s.ClientStore(), bClient), "--owner", "not a user")
+	c.Assert(err, gc.ErrorMatches, `invalid owner`)
+}
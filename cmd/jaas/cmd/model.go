@@ -0,0 +1,34 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// resolveModelUUID returns the UUID of the given model, which may be
+// specified by UUID or by name. Model names are resolved using the
+// models known to the client store for the given controller.
+func resolveModelUUID(store jujuclient.ClientStore, controllerName, model string) (string, error) {
+	if names.IsValidModel(model) {
+		return model, nil
+	}
+	name := model
+	if !jujuclient.IsQualifiedModelName(name) {
+		ad, err := store.AccountDetails(controllerName)
+		if err != nil {
+			return "", errors.E(err, "could not determine current user")
+		}
+		name = jujuclient.JoinOwnerModelName(names.NewUserTag(ad.User), name)
+	}
+	md, err := store.ModelByName(controllerName, name)
+	if err != nil {
+		return "", errors.E(err, fmt.Sprintf("could not find model %q", model))
+	}
+	return md.ModelUUID, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	modelDriftCommandDoc = `
+model-drift reports models whose config differs from the model defaults.
+
+Model defaults set with the identity, cloud and cloud region model defaults
+commands are only applied when a model is added. This command compares the
+current config of each model with the defaults of the model's owner for the
+model's cloud and region, and lists the values that differ. Values set from
+a model template or given explicitly when the model was added take precedence
+over the defaults and are not reported.
+
+Models are selected by owner, controller, name pattern and labels. If no
+selector is given all models are checked. Only models you administer are
+checked.
+
+Use --apply to set the default values on the models that have drifted.
+Models added before JIMM recorded which config values came from a model
+template or were given explicitly are not changed, as the defaults could
+overwrite those values, unless --force is also given.
+`
+	modelDriftCommandExamples = `
+    juju model-drift
+    juju model-drift --owner alice@canonical.com --label-selector env=prod
+    juju model-drift --controller controller-1 --apply
+    juju model-drift --name legacy-* --apply --force
+`
+)
+
+// NewModelDriftCommand returns a command to report model config drift.
+func NewModelDriftCommand() cmd.Command {
+	cmd := &modelDriftCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// modelDriftCommand reports, and optionally corrects, models whose config
+// has drifted from the model defaults.
+type modelDriftCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	owner         string
+	controller    string
+	name          string
+	labelSelector string
+	apply         bool
+	force         bool
+}
+
+// Info implements Command.Info.
+func (c *modelDriftCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "model-drift",
+		Purpose:  "Reports models whose config differs from the model defaults",
+		Examples: modelDriftCommandExamples,
+		Doc:      modelDriftCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *modelDriftCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.owner, "owner", "", "select models owned by the given user")
+	f.StringVar(&c.controller, "controller", "", "select models hosted on the given controller")
+	f.StringVar(&c.name, "name", "", "select models whose name matches the given glob pattern")
+	f.StringVar(&c.labelSelector, "label-selector", "", "select models whose labels match the given selector")
+	f.BoolVar(&c.apply, "apply", false, "set the default values on models that have drifted")
+	f.BoolVar(&c.force, "force", false, "with --apply, also set the default values on models without recorded config sources")
+}
+
+// Init implements the cmd.Command interface.
+func (c *modelDriftCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	if c.owner != "" && !names.IsValidUser(c.owner) {
+		return errors.E("invalid owner")
+	}
+	if c.force && !c.apply {
+		return errors.E("--force can only be used with --apply")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *modelDriftCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return errors.E(err, "failed to dial the controller")
+	}
+
+	req := apiparams.ModelDriftRequest{
+		Controller:    c.controller,
+		NameGlob:      c.name,
+		LabelSelector: c.labelSelector,
+		Apply:         c.apply,
+		Force:         c.force,
+	}
+	if c.owner != "" {
+		req.OwnerTag = names.NewUserTag(c.owner).String()
+	}
+	client := api.NewClient(apiCaller)
+	resp, err := client.ModelDrift(&req)
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, resp)
+}
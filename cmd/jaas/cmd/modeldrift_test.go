@@ -0,0 +1,89 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type modelDriftSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&modelDriftSuite{})
+
+func (s *modelDriftSuite) TestModelDrift(c *gc.C) {
+	ctx := context.Background()
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewModelDriftCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "models: []\n")
+
+	// Defaults set after the model was added are reported as drift.
+	charlie := dbmodel.Identity{Name: "charlie@canonical.com"}
+	err = s.JIMM.Database.GetIdentity(ctx, &charlie)
+	c.Assert(err, gc.IsNil)
+	err = s.JIMM.SetModelDefaults(ctx, &charlie, names.NewCloudTag(jimmtest.TestCloudName), "", map[string]interface{}{
+		"logging-config": "<root>=DEBUG",
+	})
+	c.Assert(err, gc.IsNil)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewModelDriftCommandForTesting(s.ClientStore(), bClient), "--owner", "charlie@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `models:
+- uuid: `+mt.Id()+`
+  name: model-1
+  owner: charlie@canonical.com
+  drift:
+  - key: logging-config
+    expected: <root>=DEBUG
+    actual: .*
+    source: cloud
+`)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewModelDriftCommandForTesting(s.ClientStore(), bClient), "--apply")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `(?s)models:
+- uuid: `+mt.Id()+`
+.*  applied: true
+`)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewModelDriftCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "models: []\n")
+
+	// bob does not administer the model so nothing is checked.
+	bClient = jimmtest.NewUserSessionLogin(c, "bob")
+	context, err = cmdtesting.RunCommand(c, cmd.NewModelDriftCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "models: []\n")
+}
+
+func (s *modelDriftSuite) TestModelDriftInvalidArgs(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewModelDriftCommandForTesting(s.ClientStore(), bClient), "model-1")
+	c.Assert(err, gc.ErrorMatches, `too many args`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewModelDriftCommandForTesting(s.ClientStore(), bClient), "--owner", "not a user")
+	c.Assert(err, gc.ErrorMatches, `invalid owner`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewModelDriftCommandForTesting(s.ClientStore(), bClient), "--force")
+	c.Assert(err, gc.ErrorMatches, `--force can only be used with --apply`)
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	quotaCommandDoc = `
+quota displays the model quotas that apply to a user or service account, along with
+the current usage measured against each quota, or the model quotas assigned to a group.
+
+If no user, service account or group is specified the quotas that apply to the current
+user are displayed. Only JAAS administrators can display the quotas of others.
+
+The maximum number of models is enforced when models are added. The limits on machines,
+cores and units are soft limits: exceeding them is reported to the JAAS administrators
+but does not prevent any operation.
+`
+	quotaCommandExamples = `
+    juju quota
+    juju quota user-alice@canonical.com
+    juju quota group-devops --format yaml
+`
+)
+
+// NewQuotaCommand returns a command to display model quotas.
+func NewQuotaCommand() cmd.Command {
+	cmd := &quotaCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// quotaCommand displays model quotas.
+type quotaCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	entity string
+}
+
+// Info implements Command.Info.
+func (c *quotaCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "quota",
+		Args:     "[<user>|<group>]",
+		Purpose:  "Displays model quotas",
+		Examples: quotaCommandExamples,
+		Doc:      quotaCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *quotaCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatQuotasTabular,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *quotaCommand) Init(args []string) error {
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	if len(args) == 1 {
+		c.entity = args[0]
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *quotaCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return errors.E(err, "failed to dial the controller")
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.ModelQuotas(&apiparams.ModelQuotasRequest{
+		Entity: c.entity,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	if err := c.out.Write(ctxt, resp.Quotas); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatQuotasTabular writes a tabular summary of model quotas.
+func formatQuotasTabular(writer io.Writer, value interface{}) error {
+	quotas, ok := value.([]apiparams.ModelQuota)
+	if !ok {
+		return errors.E(fmt.Sprintf("expected value of type %T, got %T", quotas, value))
+	}
+	if len(quotas) == 0 {
+		fmt.Fprintln(writer, "No model quotas.")
+		return nil
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Assigned to", "Cloud", "Models", "Machines", "Cores", "Units")
+	for _, q := range quotas {
+		cloud := q.Cloud
+		if cloud == "" {
+			cloud = "*"
+		}
+		var usage apiparams.ModelQuotaUsage
+		if q.Usage != nil {
+			usage = *q.Usage
+		}
+		w.Println(
+			q.Entity,
+			cloud,
+			formatQuotaLimit(q.Usage != nil, usage.Models, q.MaxModels),
+			formatQuotaLimit(q.Usage != nil, usage.Machines, q.MaxMachines),
+			formatQuotaLimit(q.Usage != nil, usage.Cores, q.MaxCores),
+			formatQuotaLimit(q.Usage != nil, usage.Units, q.MaxUnits),
+		)
+	}
+	return tw.Flush()
+}
+
+// formatQuotaLimit formats a quota limit, and optionally the usage
+// measured against it, for display.
+func formatQuotaLimit(showUsage bool, usage int64, limit *int64) string {
+	l := "-"
+	if limit != nil {
+		l = strconv.FormatInt(*limit, 10)
+	}
+	if !showUsage {
+		return l
+	}
+	return fmt.Sprintf("%d/%s", usage, l)
+}
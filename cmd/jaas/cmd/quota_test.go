@@ -0,0 +1,60 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type quotaSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&quotaSuite{})
+
+func (s *quotaSuite) TestQuota(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+
+	result, err := cmdtesting.RunCommand(c, cmd.NewQuotaCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(result), gc.Equals, "No model quotas.\n")
+
+	err = s.JIMM.Database.SetModelQuota(context.Background(), &dbmodel.ModelQuota{
+		IdentityName: sql.NullString{String: "bob@canonical.com", Valid: true},
+		MaxModels:    sql.NullInt64{Int64: 3, Valid: true},
+	})
+	c.Assert(err, gc.IsNil)
+
+	result, err = cmdtesting.RunCommand(c, cmd.NewQuotaCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(result), gc.Equals, `Assigned to             Cloud  Models  Machines  Cores  Units
+user-bob@canonical.com  *      0/3     0/-       0/-    0/-
+`)
+
+	result, err = cmdtesting.RunCommand(c, cmd.NewQuotaCommandForTesting(s.ClientStore(), bClient), "--format", "yaml")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(result), gc.Equals, `- max-models: 3
+  entity: user-bob@canonical.com
+  usage:
+    models: 0
+    machines: 0
+    cores: 0
+    units: 0
+`)
+}
+
+func (s *quotaSuite) TestQuotaOtherUser(c *gc.C) {
+	// bob is not superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewQuotaCommandForTesting(s.ClientStore(), bClient), "user-alice@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	rotateCredentialCommandDoc = `
+rotate-credential replaces the secret of a cloud credential stored in JIMM
+without breaking every model using the credential at once.
+
+The new secret is read from the credential with the same name in your local
+client store, update it there first with "juju update-credential --client".
+JIMM then checks the new secret against every model using the credential,
+applies it to the controller hosting the fewest of those models and, if that
+succeeds, to the remaining controllers one at a time. If the new secret is
+rejected at any stage the previous secret is restored on every controller
+that was updated and the rotation is reported as rolled back.
+
+Use --owner to rotate a credential owned by another user, such as a shared
+credential you administer. Use --status to show the most recent rotation of
+the credential without starting a new one.
+`
+	rotateCredentialCommandExamples = `
+    juju rotate-credential aws my-credential
+    juju rotate-credential --owner alice@canonical.com aws team-credential
+    juju rotate-credential --status aws my-credential
+`
+)
+
+// NewRotateCredentialCommand returns a command to rotate a cloud
+// credential.
+func NewRotateCredentialCommand() cmd.Command {
+	cmd := &rotateCredentialCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// rotateCredentialCommand rotates a cloud credential using a staged
+// rollout.
+type rotateCredentialCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	cloud          string
+	credentialName string
+	owner          string
+	status         bool
+}
+
+// Info implements Command.Info.
+func (c *rotateCredentialCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "rotate-credential",
+		Purpose:  "Rotates a cloud credential one controller at a time",
+		Args:     "<cloud> <credential-name>",
+		Examples: rotateCredentialCommandExamples,
+		Doc:      rotateCredentialCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *rotateCredentialCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.owner, "owner", "", "the user owning the credential")
+	f.BoolVar(&c.status, "status", false, "show the most recent rotation instead of starting a new one")
+}
+
+// Init implements the cmd.Command interface.
+func (c *rotateCredentialCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("cloud not specified")
+	}
+	c.cloud = args[0]
+	if len(args) < 2 {
+		return errors.E("credential name not specified")
+	}
+	c.credentialName = args[1]
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	if c.owner != "" && !names.IsValidUser(c.owner) {
+		return errors.E("invalid owner")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *rotateCredentialCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	req := apiparams.CloudCredentialRotationRequest{
+		Cloud:          c.cloud,
+		CredentialName: c.credentialName,
+	}
+	if c.owner != "" {
+		req.OwnerTag = names.NewUserTag(c.owner).String()
+	}
+
+	var credential apiparams.RotateCloudCredentialRequest
+	if !c.status {
+		cred, err := findCredentialsInLocalCache(c.store, c.cloud, c.credentialName)
+		if err != nil {
+			return errors.E(err)
+		}
+		credential = apiparams.RotateCloudCredentialRequest{
+			CloudCredentialRotationRequest: req,
+			Credential:                     *cred,
+		}
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return errors.E(err, "failed to dial the controller")
+	}
+
+	client := api.NewClient(apiCaller)
+	var resp apiparams.CloudCredentialRotation
+	if c.status {
+		resp, err = client.GetCloudCredentialRotation(&req)
+	} else {
+		resp, err = client.RotateCloudCredential(&credential)
+	}
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, resp)
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujucloud "github.com/juju/juju/cloud"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type rotateCredentialSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&rotateCredentialSuite{})
+
+func (s *rotateCredentialSuite) TestRotateCredential(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/alice@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	s.AddModel(c, names.NewUserTag("alice@canonical.com"), "model-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	clientStore := s.ClientStore()
+	err := clientStore.UpdateCredential(jimmtest.TestCloudName, jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{
+			"cred": jujucloud.NewCredential(jujucloud.EmptyAuthType, nil),
+		},
+	})
+	c.Assert(err, gc.IsNil)
+
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err = cmdtesting.RunCommand(c, cmd.NewRotateCredentialCommandForTesting(clientStore, bClient), "--status", jimmtest.TestCloudName, "cred")
+	c.Assert(err, gc.ErrorMatches, `cloud credential rotation not found`)
+
+	context, err := cmdtesting.RunCommand(c, cmd.NewRotateCredentialCommandForTesting(clientStore, bClient), jimmtest.TestCloudName, "cred")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `credential-tag: cloudcred-`+jimmtest.TestCloudName+`_alice@canonical.com_cred
+created-by: alice@canonical.com
+status: completed
+canary-controller: controller-1
+updated-controllers:
+- controller-1
+started-at: .*
+updated-at: .*
+`)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewRotateCredentialCommandForTesting(clientStore, bClient), "--status", jimmtest.TestCloudName, "cred")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `(?s)credential-tag: cloudcred-`+jimmtest.TestCloudName+`_alice@canonical.com_cred
+created-by: alice@canonical.com
+status: completed
+.*`)
+
+	// bob cannot rotate alice's credential.
+	bClient = jimmtest.NewUserSessionLogin(c, "bob")
+	_, err = cmdtesting.RunCommand(c, cmd.NewRotateCredentialCommandForTesting(clientStore, bClient), "--owner", "alice@canonical.com", jimmtest.TestCloudName, "cred")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *rotateCredentialSuite) TestRotateCredentialMissingLocalCredential(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRotateCredentialCommandForTesting(s.ClientStore(), bClient), jimmtest.TestCloudName, "no-such-cred")
+	c.Assert(err, gc.ErrorMatches, `.*failed to fetch local credentials for cloud .*`)
+}
+
+func (s *rotateCredentialSuite) TestRotateCredentialInvalidArgs(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRotateCredentialCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `cloud not specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRotateCredentialCommandForTesting(s.ClientStore(), bClient), "aws")
+	c.Assert(err, gc.ErrorMatches, `credential name not specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRotateCredentialCommandForTesting(s.ClientStore(), bClient), "aws", "cred", "extra")
+	c.Assert(err, gc.ErrorMatches, `too many args`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRotateCredentialCommandForTesting(s.ClientStore(), bClient), "--owner", "not a user", "aws", "cred")
+	c.Assert(err, gc.ErrorMatches, `invalid owner`)
+}
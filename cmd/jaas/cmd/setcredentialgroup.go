@@ -0,0 +1,118 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	setCredentialGroupCommandDoc = `
+set-credential-group makes a group the owner of a cloud credential.
+
+The members of the owning group may select the credential when adding
+models, update it and revoke it. The credential remains available to the
+group even if the user that uploaded it leaves the group.
+
+The owner of a credential may give it to a group they are a member of. The
+members of the owning group may give it to another group, or return it to
+the user that uploaded it by omitting the group. JAAS administrators may set
+the group of any credential.
+
+The credential is specified as <cloud>/<owner>/<name>.
+`
+	setCredentialGroupCommandExamples = `
+    juju set-credential-group aws/alice@canonical.com/team-keys devops
+    juju set-credential-group aws/alice@canonical.com/team-keys
+`
+)
+
+// NewSetCredentialGroupCommand returns a command to set the group that
+// owns a cloud credential.
+func NewSetCredentialGroupCommand() cmd.Command {
+	cmd := &setCredentialGroupCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setCredentialGroupCommand sets the group that owns a cloud credential.
+type setCredentialGroupCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	credential string
+	group      string
+}
+
+// Info implements Command.Info.
+func (c *setCredentialGroupCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "set-credential-group",
+		Args:     "<credential> [<group>]",
+		Purpose:  "Sets the group that owns a cloud credential",
+		Examples: setCredentialGroupCommandExamples,
+		Doc:      setCredentialGroupCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setCredentialGroupCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements the cmd.Command interface.
+func (c *setCredentialGroupCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("credential must be specified")
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	c.credential = args[0]
+	if !names.IsValidCloudCredential(c.credential) {
+		return errors.E(fmt.Sprintf("invalid credential %q, expected <cloud>/<owner>/<name>", c.credential))
+	}
+	if len(args) == 2 {
+		c.group = args[1]
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setCredentialGroupCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return errors.E(err, "failed to dial the controller")
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.SetCloudCredentialGroup(&apiparams.SetCloudCredentialGroupRequest{
+		CloudCredentialTag: names.NewCloudCredentialTag(c.credential).String(),
+		Group:              c.group,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+type setCredentialGroupSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&setCredentialGroupSuite{})
+
+func (s *setCredentialGroupSuite) TestSetCredentialGroup(c *gc.C) {
+	ctx := context.Background()
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+
+	group, err := s.JIMM.Database.AddGroup(ctx, "devops")
+	c.Assert(err, gc.IsNil)
+	err = s.OFGAClient.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("bob@canonical.com")),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group.ResourceTag()),
+	})
+	c.Assert(err, gc.IsNil)
+
+	// bob neither owns the credential nor is a JAAS administrator.
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetCredentialGroupCommandForTesting(s.ClientStore(), bClient), cct.Id(), "devops")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	aClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetCredentialGroupCommandForTesting(s.ClientStore(), aClient), cct.Id(), "devops")
+	c.Assert(err, gc.IsNil)
+
+	cred := dbmodel.CloudCredential{}
+	cred.SetTag(cct)
+	err = s.JIMM.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, gc.IsNil)
+	c.Check(cred.OwnerGroupID, gc.Equals, sql.NullInt32{Int32: int32(group.ID), Valid: true})
+
+	bob := dbmodel.Identity{Name: "bob@canonical.com"}
+	c.Check(openfga.NewUser(&bob, s.OFGAClient).GetCloudCredentialAccess(ctx, cct), gc.Equals, ofganames.AdministratorRelation)
+
+	// bob, as a member of the owning group, can return the credential
+	// to its owner.
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetCredentialGroupCommandForTesting(s.ClientStore(), bClient), cct.Id())
+	c.Assert(err, gc.IsNil)
+	err = s.JIMM.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, gc.IsNil)
+	c.Check(cred.OwnerGroupID.Valid, gc.Equals, false)
+	c.Check(openfga.NewUser(&bob, s.OFGAClient).GetCloudCredentialAccess(ctx, cct), gc.Equals, ofganames.NoRelation)
+}
+
+func (s *setCredentialGroupSuite) TestSetCredentialGroupInvalidArguments(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetCredentialGroupCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `credential must be specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetCredentialGroupCommandForTesting(s.ClientStore(), bClient), "cloud/alice@canonical.com/cred", "devops", "extra")
+	c.Assert(err, gc.ErrorMatches, `too many args`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetCredentialGroupCommandForTesting(s.ClientStore(), bClient), "cred")
+	c.Assert(err, gc.ErrorMatches, `invalid credential "cred", expected <cloud>/<owner>/<name>`)
+}
@@ -0,0 +1,140 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	setModelLabelsCommandDoc = `
+set-model-labels sets and removes labels on a model.
+
+Labels are key/value pairs stored by JAAS that can be used to select models,
+for example by owning team, cost centre or environment. Label keys consist of
+lower case letters, digits and the characters '.', '_', '/' and '-'. Label
+values consist of letters, digits and the characters '.', '_' and '-'.
+
+The resulting labels of the model are printed. If no labels are specified the
+current labels of the model are printed.
+
+The model may be specified by name or by UUID.
+`
+	setModelLabelsCommandExamples = `
+    juju set-model-labels mymodel team=observability env=prod
+    juju set-model-labels alice@canonical.com/mymodel cost-centre=1234 --remove env
+    juju set-model-labels 00000002-0000-0000-0000-000000000001
+`
+)
+
+// NewSetModelLabelsCommand returns a command to set the labels of a model.
+func NewSetModelLabelsCommand() cmd.Command {
+	cmd := &setModelLabelsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setModelLabelsCommand sets and removes labels on a model.
+type setModelLabelsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	model  string
+	labels map[string]string
+	remove string
+}
+
+// Info implements Command.Info.
+func (c *setModelLabelsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "set-model-labels",
+		Args:     "<model> [<key>=<value> ...]",
+		Purpose:  "Sets and removes labels on a model",
+		Examples: setModelLabelsCommandExamples,
+		Doc:      setModelLabelsCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setModelLabelsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.remove, "remove", "", "comma separated list of label keys to remove")
+}
+
+// Init implements the cmd.Command interface.
+func (c *setModelLabelsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("model not specified")
+	}
+	c.model = args[0]
+	c.labels = make(map[string]string)
+	for _, arg := range args[1:] {
+		k, v, ok := strings.Cut(arg, "=")
+		if !ok || k == "" {
+			return errors.E(fmt.Sprintf("invalid label %q, expected <key>=<value>", arg))
+		}
+		c.labels[k] = v
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setModelLabelsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	modelUUID, err := resolveModelUUID(c.store, currentController, c.model)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return errors.E(err, "failed to dial the controller")
+	}
+
+	req := apiparams.SetModelLabelsRequest{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Labels:   c.labels,
+	}
+	for _, k := range strings.Split(c.remove, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			req.Remove = append(req.Remove, k)
+		}
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.SetModelLabels(&req)
+	if err != nil {
+		return errors.E(err)
+	}
+	labels := resp.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return c.out.Write(ctxt, labels)
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type setModelLabelsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&setModelLabelsSuite{})
+
+func (s *setModelLabelsSuite) TestSetModelLabels(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/alice@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("alice@canonical.com"), "model-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	result, err := cmdtesting.RunCommand(c, cmd.NewSetModelLabelsCommandForTesting(s.ClientStore(), bClient), mt.Id(), "team=observability", "env=prod")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(result), gc.Equals, "env: prod\nteam: observability\n")
+
+	result, err = cmdtesting.RunCommand(c, cmd.NewSetModelLabelsCommandForTesting(s.ClientStore(), bClient), mt.Id(), "cost-centre=1234", "--remove", "env")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(result), gc.Equals, "cost-centre: \"1234\"\nteam: observability\n")
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetModelLabelsCommandForTesting(s.ClientStore(), bClient), mt.Id(), "Team=observability")
+	c.Assert(err, gc.ErrorMatches, `invalid label key "Team"`)
+
+	// bob is not a model administrator.
+	bClient = jimmtest.NewUserSessionLogin(c, "bob")
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetModelLabelsCommandForTesting(s.ClientStore(), bClient), mt.Id(), "team=security")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *setModelLabelsSuite) TestSetModelLabelsInvalidArguments(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetModelLabelsCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `model not specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetModelLabelsCommandForTesting(s.ClientStore(), bClient), "model-1", "team")
+	c.Assert(err, gc.ErrorMatches, `invalid label "team", expected <key>=<value>`)
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	transferModelCommandDoc = `
+transfer-model transfers ownership of a model to another user.
+
+Only the current owner of the model or a JAAS administrator can transfer
+ownership of a model. The new owner is granted admin access to the model.
+By default all of the previous owner's access to the model is revoked, use
+--keep-access to allow the previous owner to retain their access.
+
+The new owner must not already own a model with the same name.
+
+The model may be specified by name or by UUID.
+`
+	transferModelCommandExamples = `
+    juju transfer-model mymodel bob@canonical.com
+    juju transfer-model alice@canonical.com/mymodel bob@canonical.com --keep-access
+`
+)
+
+// NewTransferModelCommand returns a command to transfer ownership of a
+// model.
+func NewTransferModelCommand() cmd.Command {
+	cmd := &transferModelCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// transferModelCommand transfers ownership of a model to another user.
+type transferModelCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	model      string
+	newOwner   string
+	keepAccess bool
+}
+
+// Info implements Command.Info.
+func (c *transferModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "transfer-model",
+		Args:     "<model> <new owner>",
+		Purpose:  "Transfers ownership of a model to another user",
+		Examples: transferModelCommandExamples,
+		Doc:      transferModelCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *transferModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.keepAccess, "keep-access", false, "allow the previous owner to retain their access to the model")
+}
+
+// Init implements the cmd.Command interface.
+func (c *transferModelCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("model and new owner must be specified")
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	c.model, c.newOwner = args[0], args[1]
+	if !names.IsValidUser(c.newOwner) {
+		return errors.E(fmt.Sprintf("invalid user %q", c.newOwner))
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *transferModelCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	modelUUID, err := resolveModelUUID(c.store, currentController, c.model)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return errors.E(err, "failed to dial the controller")
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.TransferModelOwnership(&apiparams.TransferModelOwnershipRequest{
+		ModelTag:    names.NewModelTag(modelUUID).String(),
+		NewOwnerTag: names.NewUserTag(c.newOwner).String(),
+		KeepAccess:  c.keepAccess,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
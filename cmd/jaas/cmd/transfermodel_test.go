@@ -0,0 +1,76 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+type transferModelSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&transferModelSuite{})
+
+func (s *transferModelSuite) TestTransferModel(c *gc.C) {
+	ctx := context.Background()
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	bob := dbmodel.Identity{Name: "bob@canonical.com"}
+	err := s.JIMM.Database.GetIdentity(ctx, &bob)
+	c.Assert(err, gc.IsNil)
+
+	// bob is neither the model owner nor a JAAS administrator.
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err = cmdtesting.RunCommand(c, cmd.NewTransferModelCommandForTesting(s.ClientStore(), bClient), mt.Id(), "bob@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	bClient = jimmtest.NewUserSessionLogin(c, "alice")
+	_, err = cmdtesting.RunCommand(c, cmd.NewTransferModelCommandForTesting(s.ClientStore(), bClient), mt.Id(), "bob@canonical.com")
+	c.Assert(err, gc.IsNil)
+
+	m := dbmodel.Model{UUID: sql.NullString{String: mt.Id(), Valid: true}}
+	err = s.JIMM.Database.GetModel(ctx, &m)
+	c.Assert(err, gc.IsNil)
+	c.Check(m.OwnerIdentityName, gc.Equals, "bob@canonical.com")
+
+	c.Check(openfga.NewUser(&bob, s.OFGAClient).GetModelAccess(ctx, mt), gc.Equals, ofganames.AdministratorRelation)
+	charlie := dbmodel.Identity{Name: "charlie@canonical.com"}
+	c.Check(openfga.NewUser(&charlie, s.OFGAClient).GetModelAccess(ctx, mt), gc.Equals, ofganames.NoRelation)
+
+	// bob, as the new owner, can transfer the model back.
+	bClient = jimmtest.NewUserSessionLogin(c, "bob")
+	_, err = cmdtesting.RunCommand(c, cmd.NewTransferModelCommandForTesting(s.ClientStore(), bClient), mt.Id(), "charlie@canonical.com", "--keep-access")
+	c.Assert(err, gc.IsNil)
+	c.Check(openfga.NewUser(&bob, s.OFGAClient).GetModelAccess(ctx, mt), gc.Equals, ofganames.AdministratorRelation)
+	c.Check(openfga.NewUser(&charlie, s.OFGAClient).GetModelAccess(ctx, mt), gc.Equals, ofganames.AdministratorRelation)
+}
+
+func (s *transferModelSuite) TestTransferModelInvalidArguments(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewTransferModelCommandForTesting(s.ClientStore(), bClient), "model-1")
+	c.Assert(err, gc.ErrorMatches, `model and new owner must be specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewTransferModelCommandForTesting(s.ClientStore(), bClient), "model-1", "bob@canonical.com", "extra")
+	c.Assert(err, gc.ErrorMatches, `too many args`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewTransferModelCommandForTesting(s.ClientStore(), bClient), "model-1", "bob@@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `invalid user "bob@@canonical.com"`)
+}
@@ -62,8 +62,9 @@ func (s *updateCredentialsSuite) TestUpdateCredentialsWithLocalCredentials(c *gc
 
 	err = clientStore.UpdateCredential("test-cloud", jujucloud.CloudCredential{
 		AuthCredentials: map[string]jujucloud.Credential{
-			"test-credentials": jujucloud.NewCredential(jujucloud.EmptyAuthType, map[string]string{
-				"foo": "bar",
+			"test-credentials": jujucloud.NewCredential(jujucloud.UserPassAuthType, map[string]string{
+				"username": "foo",
+				"password": "bar",
 			}),
 		},
 	})
@@ -85,7 +86,8 @@ func (s *updateCredentialsSuite) TestUpdateCredentialsWithLocalCredentials(c *gc
 	c.Assert(err, gc.IsNil)
 
 	c.Assert(attrs, gc.DeepEquals, map[string]string{
-		"foo": "bar",
+		"username": "foo",
+		"password": "bar",
 	})
 }
 
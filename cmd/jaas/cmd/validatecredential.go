@@ -0,0 +1,108 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujucloud "github.com/juju/juju/cloud"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/cloudcred"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+var (
+	validateCredentialCommandDoc = `
+validate-credential command checks a credential in your local client store
+against the credential schema of the cloud's provider, without contacting
+JAAS. The auth type must be supported by the provider, every required
+attribute must be set and every attribute must be known to the provider.
+
+The provider is found from the definition of the cloud in your local client
+store or Juju's list of public clouds. The --type option can be used to
+specify the provider instead.
+`
+
+	validateCredentialCommandExamples = `
+    jaas validate-credential aws <credential-name>
+    jaas validate-credential --type openstack mycloud <credential-name>
+`
+)
+
+// NewValidateCredentialCommand returns a command to validate a cloud
+// credential in the local client store.
+func NewValidateCredentialCommand() cmd.Command {
+	return &validateCredentialCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+}
+
+// validateCredentialCommand validates a cloud credential in the local
+// client store.
+type validateCredentialCommand struct {
+	cmd.CommandBase
+
+	store jujuclient.ClientStore
+
+	cloud          string
+	credentialName string
+	provider       string
+}
+
+// Info implements Command.Info.
+func (c *validateCredentialCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "validate-credential",
+		Purpose:  "Validate a cloud credential in the local client store",
+		Args:     "<cloud> <credential-name>",
+		Doc:      validateCredentialCommandDoc,
+		Examples: validateCredentialCommandExamples,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *validateCredentialCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.provider, "type", "", "The provider type of the cloud")
+}
+
+// Init implements the cmd.Command interface.
+func (c *validateCredentialCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("cloud not specified")
+	}
+	c.cloud = args[0]
+	if len(args) < 2 {
+		return errors.E("credential name not specified")
+	}
+	c.credentialName = args[1]
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *validateCredentialCommand) Run(ctxt *cmd.Context) error {
+	credential, err := findCredentialsInLocalCache(c.store, c.cloud, c.credentialName)
+	if err != nil {
+		return errors.E(err)
+	}
+	provider := c.provider
+	if provider == "" {
+		cloud, err := jujucloud.CloudByName(c.cloud)
+		if err != nil {
+			return errors.E(err, fmt.Sprintf("cannot determine provider of cloud %q, use --type to specify it", c.cloud))
+		}
+		provider = cloud.Type
+	}
+	if err := cloudcred.ValidateAttributes(provider, credential.AuthType, credential.Attributes); err != nil {
+		return errors.E(err)
+	}
+	ctxt.Infof("Credential %q is valid for %q clouds.", c.credentialName, provider)
+	return nil
+}
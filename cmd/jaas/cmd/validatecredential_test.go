@@ -0,0 +1,75 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/jujuclient"
+	jujutesting "github.com/juju/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+)
+
+type validateCredentialSuite struct {
+	jujutesting.FakeJujuXDGDataHomeSuite
+
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&validateCredentialSuite{})
+
+func (s *validateCredentialSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	err := s.store.UpdateCredential("aws", jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{
+			"good": jujucloud.NewCredential(jujucloud.AccessKeyAuthType, map[string]string{
+				"access-key": "key",
+				"secret-key": "secret",
+			}),
+			"bad": jujucloud.NewCredential(jujucloud.AccessKeyAuthType, map[string]string{
+				"access-key": "key",
+				"secret":     "secret",
+			}),
+		},
+	})
+	c.Assert(err, gc.IsNil)
+	err = s.store.UpdateCredential("mycloud", jujucloud.CloudCredential{
+		AuthCredentials: map[string]jujucloud.Credential{
+			"cred": jujucloud.NewCredential(jujucloud.UserPassAuthType, map[string]string{
+				"username": "user",
+				"password": "pass",
+			}),
+		},
+	})
+	c.Assert(err, gc.IsNil)
+}
+
+func (s *validateCredentialSuite) TestValidateCredential(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, cmd.NewValidateCredentialCommandForTesting(s.store), "aws", "good")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Credential \"good\" is valid for \"ec2\" clouds.\n")
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewValidateCredentialCommandForTesting(s.store), "aws", "bad")
+	c.Assert(err, gc.ErrorMatches, `invalid "access-key" credential: missing required attribute "secret-key", unknown attribute "secret"`)
+}
+
+func (s *validateCredentialSuite) TestValidateCredentialWithType(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, cmd.NewValidateCredentialCommandForTesting(s.store), "mycloud", "cred")
+	c.Assert(err, gc.ErrorMatches, `cannot determine provider of cloud "mycloud", use --type to specify it`)
+
+	ctx, err := cmdtesting.RunCommand(c, cmd.NewValidateCredentialCommandForTesting(s.store), "mycloud", "cred", "--type", "openstack")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Credential \"cred\" is valid for \"openstack\" clouds.\n")
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewValidateCredentialCommandForTesting(s.store), "mycloud", "cred", "--type", "ec2")
+	c.Assert(err, gc.ErrorMatches, `auth type "userpass" not supported by "ec2" clouds, supported auth types are "access-key", "instance-role"`)
+}
+
+func (s *validateCredentialSuite) TestCredentialNotInLocalStore(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, cmd.NewValidateCredentialCommandForTesting(s.store), "aws", "missing")
+	c.Assert(err, gc.ErrorMatches, `credential "missing" not found on local client.*`)
+}
@@ -30,6 +30,17 @@ func NewSuperCommand() *jujucmd.SuperCommand {
 	serviceAccountCmd.Register(cmd.NewListServiceAccountCredentialsCommand())
 	serviceAccountCmd.Register(cmd.NewUpdateCredentialCommand())
 	serviceAccountCmd.Register(cmd.NewGrantCommand())
+	serviceAccountCmd.Register(cmd.NewQuotaCommand())
+	serviceAccountCmd.Register(cmd.NewExtendModelCommand())
+	serviceAccountCmd.Register(cmd.NewSetModelLabelsCommand())
+	serviceAccountCmd.Register(cmd.NewTransferModelCommand())
+	serviceAccountCmd.Register(cmd.NewGrantBulkCommand())
+	serviceAccountCmd.Register(cmd.NewRevokeBulkCommand())
+	serviceAccountCmd.Register(cmd.NewModelDriftCommand())
+	serviceAccountCmd.Register(cmd.NewListCredentialStatusCommand())
+	serviceAccountCmd.Register(cmd.NewRotateCredentialCommand())
+	serviceAccountCmd.Register(cmd.NewValidateCredentialCommand())
+	serviceAccountCmd.Register(cmd.NewSetCredentialGroupCommand())
 	return serviceAccountCmd
 }
 
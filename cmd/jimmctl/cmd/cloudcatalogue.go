@@ -0,0 +1,414 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	"github.com/juju/juju/cloud"
+	jujucmd "github.com/juju/juju/cmd"
+	jujucmdcloud "github.com/juju/juju/cmd/juju/cloud"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	jimmjujuapi "github.com/canonical/jimm/v3/internal/jujuapi"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	cloudCatalogueDoc = `
+cloud-catalogue command enables management of the cloud definitions held
+by jimm independently of the controllers hosting them.
+
+A cloud is first added to the catalogue and then attached to each
+controller that should host it. Updating a cloud in the catalogue updates
+every controller hosting it.
+`
+
+	addCatalogueCloudDoc = `
+add command adds a cloud, defined in a yaml file, to the cloud catalogue
+without adding it to any controller.
+
+Example:
+	jimmctl cloud-catalogue add <cloud> --cloud <cloud-file>
+`
+
+	attachCloudDoc = `
+attach command adds a cloud in the cloud catalogue to a controller.
+
+Example:
+	jimmctl cloud-catalogue attach <cloud> <controller>
+	jimmctl cloud-catalogue attach <cloud> <controller> --force
+`
+
+	updateCatalogueCloudDoc = `
+update command replaces the definition of a cloud in the cloud catalogue
+with one defined in a yaml file and then updates every controller hosting
+the cloud. The status of the update on each controller is displayed.
+
+Example:
+	jimmctl cloud-catalogue update <cloud> --cloud <cloud-file>
+`
+
+	cloudStatusDoc = `
+status command displays whether each controller hosting a cloud has the
+definition of the cloud held in the cloud catalogue.
+
+Example:
+	jimmctl cloud-catalogue status <cloud>
+`
+)
+
+// NewCloudCatalogueCommand returns a command for cloud catalogue
+// management.
+func NewCloudCatalogueCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "cloud-catalogue",
+		Doc:     cloudCatalogueDoc,
+		Purpose: "Cloud catalogue management.",
+	})
+	cmd.Register(newAddCatalogueCloudCommand())
+	cmd.Register(newAttachCloudCommand())
+	cmd.Register(newUpdateCatalogueCloudCommand())
+	cmd.Register(newCloudStatusCommand())
+
+	return cmd
+}
+
+// newAddCatalogueCloudCommand returns a command to add a cloud to the
+// cloud catalogue.
+func newAddCatalogueCloudCommand() cmd.Command {
+	cmd := &addCatalogueCloudCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// addCatalogueCloudCommand adds a cloud to the cloud catalogue.
+type addCatalogueCloudCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	cloudName           string
+	cloudDefinitionFile string
+}
+
+// Info implements the cmd.Command interface.
+func (c *addCatalogueCloudCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add",
+		Args:    "<cloud>",
+		Purpose: "Add a cloud to the cloud catalogue.",
+		Doc:     addCatalogueCloudDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCatalogueCloudCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.cloudDefinitionFile, "cloud", "", "The path to the cloud's definition file.")
+}
+
+// Init implements the cmd.Command interface.
+func (c *addCatalogueCloudCommand) Init(args []string) error {
+	var err error
+	c.cloudName, err = parseCloudArgs(args, c.cloudDefinitionFile)
+	return err
+}
+
+// Run implements Command.Run.
+func (c *addCatalogueCloudCommand) Run(ctxt *cmd.Context) error {
+	newCloud, err := readCloudFile(ctxt, c.cloudName, c.cloudDefinitionFile)
+	if err != nil {
+		return err
+	}
+
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.AddCatalogueCloud(&apiparams.AddCatalogueCloudRequest{
+		Name:  c.cloudName,
+		Cloud: jimmjujuapi.CloudToParams(*newCloud),
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	ctxt.Infof("Cloud %q added to the cloud catalogue.", c.cloudName)
+	return nil
+}
+
+// newAttachCloudCommand returns a command to add a cloud in the cloud
+// catalogue to a controller.
+func newAttachCloudCommand() cmd.Command {
+	cmd := &attachCloudCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// attachCloudCommand adds a cloud in the cloud catalogue to a controller.
+type attachCloudCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req apiparams.AttachCloudToControllerRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *attachCloudCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "attach",
+		Args:    "<cloud> <controller>",
+		Purpose: "Add a cloud in the cloud catalogue to a controller.",
+		Doc:     attachCloudDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *attachCloudCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.req.Force, "force", false, "Forces the cloud to be added to the controller")
+}
+
+// Init implements the cmd.Command interface.
+func (c *attachCloudCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("cloud and controller must be specified")
+	}
+	c.req.CloudName = args[0]
+	if !names.IsValidCloud(c.req.CloudName) {
+		return errors.E("invalid cloud name " + c.req.CloudName)
+	}
+	c.req.ControllerName = args[1]
+	if !names.IsValidControllerName(c.req.ControllerName) {
+		return errors.E("invalid controller name " + c.req.ControllerName)
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *attachCloudCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.AttachCloudToController(&c.req); err != nil {
+		return errors.E(err)
+	}
+	ctxt.Infof("Cloud %q added to controller %q.", c.req.CloudName, c.req.ControllerName)
+	return nil
+}
+
+// newUpdateCatalogueCloudCommand returns a command to update a cloud in
+// the cloud catalogue.
+func newUpdateCatalogueCloudCommand() cmd.Command {
+	cmd := &updateCatalogueCloudCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// updateCatalogueCloudCommand updates a cloud in the cloud catalogue.
+type updateCatalogueCloudCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	cloudName           string
+	cloudDefinitionFile string
+}
+
+// Info implements the cmd.Command interface.
+func (c *updateCatalogueCloudCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "update",
+		Args:    "<cloud>",
+		Purpose: "Update a cloud in the cloud catalogue.",
+		Doc:     updateCatalogueCloudDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *updateCatalogueCloudCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.cloudDefinitionFile, "cloud", "", "The path to the cloud's definition file.")
+}
+
+// Init implements the cmd.Command interface.
+func (c *updateCatalogueCloudCommand) Init(args []string) error {
+	var err error
+	c.cloudName, err = parseCloudArgs(args, c.cloudDefinitionFile)
+	return err
+}
+
+// Run implements Command.Run.
+func (c *updateCatalogueCloudCommand) Run(ctxt *cmd.Context) error {
+	newCloud, err := readCloudFile(ctxt, c.cloudName, c.cloudDefinitionFile)
+	if err != nil {
+		return err
+	}
+
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.UpdateCatalogueCloud(&apiparams.UpdateCatalogueCloudRequest{
+		Name:  c.cloudName,
+		Cloud: jimmjujuapi.CloudToParams(*newCloud),
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, resp.Controllers)
+}
+
+// newCloudStatusCommand returns a command to display the status of a
+// cloud on the controllers hosting it.
+func newCloudStatusCommand() cmd.Command {
+	cmd := &cloudStatusCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// cloudStatusCommand displays the status of a cloud on the controllers
+// hosting it.
+type cloudStatusCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req apiparams.CloudControllerStatusRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *cloudStatusCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "status",
+		Args:    "<cloud>",
+		Purpose: "Display the status of a cloud on the controllers hosting it.",
+		Doc:     cloudStatusDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *cloudStatusCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *cloudStatusCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("cloud not specified")
+	}
+	c.req.Name = args[0]
+	if !names.IsValidCloud(c.req.Name) {
+		return errors.E("invalid cloud name " + c.req.Name)
+	}
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *cloudStatusCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.CloudControllerStatus(&c.req)
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, resp.Controllers)
+}
+
+// parseCloudArgs parses the arguments of a command that takes the name
+// of a cloud whose definition is read from the given file.
+func parseCloudArgs(args []string, cloudDefinitionFile string) (string, error) {
+	if len(args) < 1 {
+		return "", errors.E("cloud not specified")
+	}
+	if !names.IsValidCloud(args[0]) {
+		return "", errors.E("invalid cloud name " + args[0])
+	}
+	if len(args) > 1 {
+		return "", errors.E("too many args")
+	}
+	if cloudDefinitionFile == "" {
+		return "", errors.E("cloud definition file not specified, use --cloud to specify it")
+	}
+	return args[0], nil
+}
+
+// readCloudFile reads the definition of the named cloud from the given
+// yaml file.
+func readCloudFile(ctxt *cmd.Context, cloudName, path string) (*cloud.Cloud, error) {
+	r := &jujucmdcloud.CloudFileReader{
+		CloudMetadataStore: &cloudToCommandAdapter{},
+		CloudName:          cloudName,
+	}
+	newCloud, err := r.ReadCloudFromFile(path, ctxt)
+	if err != nil {
+		return nil, errors.E(err, "error reading cloud from file")
+	}
+	// All clouds must have at least one default region.
+	if len(newCloud.Regions) == 0 {
+		newCloud.Regions = []cloud.Region{{Name: cloud.DefaultCloudRegion}}
+	}
+	return newCloud, nil
+}
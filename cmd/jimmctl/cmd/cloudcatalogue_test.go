@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type cloudCatalogueSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&cloudCatalogueSuite{})
+
+const catalogueCloud = `
+clouds:
+  test-maas-cloud:
+    type: maas
+    auth-types: [oauth1]
+    endpoint: https://maas.example.com/MAAS
+    regions:
+      default: {}
+`
+
+const updatedCatalogueCloud = `
+clouds:
+  test-maas-cloud:
+    type: maas
+    auth-types: [oauth1]
+    endpoint: https://maas2.example.com/MAAS
+    regions:
+      default: {}
+`
+
+func (s *cloudCatalogueSuite) TestCloudCatalogue(c *gc.C) {
+	ctx := context.Background()
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	cloudFile, cleanup := writeTempFile(c, catalogueCloud)
+	defer cleanup()
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewAddCatalogueCloudCommandForTesting(s.ClientStore(), bClient), "test-maas-cloud", "--cloud", cloudFile)
+	c.Assert(err, gc.IsNil)
+
+	cloud := dbmodel.Cloud{Name: "test-maas-cloud"}
+	err = s.JIMM.Database.GetCloud(ctx, &cloud)
+	c.Assert(err, gc.IsNil)
+	c.Check(cloud.Endpoint, gc.Equals, "https://maas.example.com/MAAS")
+
+	ctxt, err := cmdtesting.RunCommand(c, cmd.NewCloudStatusCommandForTesting(s.ClientStore(), bClient), "test-maas-cloud")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctxt), gc.Equals, "[]\n")
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewAttachCloudCommandForTesting(s.ClientStore(), bClient), "test-maas-cloud", "controller-1", "--force")
+	c.Assert(err, gc.IsNil)
+
+	ctxt, err = cmdtesting.RunCommand(c, cmd.NewCloudStatusCommandForTesting(s.ClientStore(), bClient), "test-maas-cloud")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctxt), gc.Matches, `- controller: controller-1
+  status: synced
+  updated-time: .*
+`)
+
+	updatedFile, cleanup2 := writeTempFile(c, updatedCatalogueCloud)
+	defer cleanup2()
+	ctxt, err = cmdtesting.RunCommand(c, cmd.NewUpdateCatalogueCloudCommandForTesting(s.ClientStore(), bClient), "test-maas-cloud", "--cloud", updatedFile)
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctxt), gc.Matches, `- controller: controller-1
+  status: synced
+  updated-time: .*
+`)
+
+	cloud = dbmodel.Cloud{Name: "test-maas-cloud"}
+	err = s.JIMM.Database.GetCloud(ctx, &cloud)
+	c.Assert(err, gc.IsNil)
+	c.Check(cloud.Endpoint, gc.Equals, "https://maas2.example.com/MAAS")
+}
+
+func (s *cloudCatalogueSuite) TestAddCatalogueCloudUnauthorized(c *gc.C) {
+	cloudFile, cleanup := writeTempFile(c, catalogueCloud)
+	defer cleanup()
+
+	// bob is not superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewAddCatalogueCloudCommandForTesting(s.ClientStore(), bClient), "test-maas-cloud", "--cloud", cloudFile)
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *cloudCatalogueSuite) TestAddCatalogueCloudNoFile(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewAddCatalogueCloudCommandForTesting(s.ClientStore(), bClient), "test-maas-cloud")
+	c.Assert(err, gc.ErrorMatches, `cloud definition file not specified, use --cloud to specify it`)
+}
@@ -0,0 +1,177 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var controllerVersionsCommandDoc = `
+	controller-versions command displays the agent version of each
+	controller known to JIMM and the agent versions of the models
+	hosted on those controllers.
+
+	If the --check-upgrades flag is given every model is validated
+	to see whether it can be upgraded and any models that would
+	block an upgrade are reported.
+
+	Example:
+		jimmctl controller-versions
+		jimmctl controller-versions mycontroller --check-upgrades
+		jimmctl controller-versions --format json --output ~/tmp/versions.json
+`
+
+// NewControllerVersionsCommand returns a command to display the versions
+// of controllers and models.
+func NewControllerVersionsCommand() cmd.Command {
+	cmd := &controllerVersionsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// controllerVersionsCommand displays the agent versions of controllers
+// and models known to JIMM.
+type controllerVersionsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	controllerName string
+	checkUpgrades  bool
+}
+
+func (c *controllerVersionsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "controller-versions",
+		Args:    "[<controller name>]",
+		Purpose: "Displays controller and model agent versions.",
+		Doc:     controllerVersionsCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *controllerVersionsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatControllerVersionsTabular,
+	})
+	f.BoolVar(&c.checkUpgrades, "check-upgrades", false, "validate whether each model can be upgraded")
+}
+
+// Init implements the cmd.Command interface.
+func (c *controllerVersionsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.controllerName, args = args[0], args[1:]
+	}
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *controllerVersionsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.ControllerVersions(&apiparams.ControllerVersionsRequest{
+		Name:             c.controllerName,
+		ValidateUpgrades: c.checkUpgrades,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+func formatControllerVersionsTabular(writer io.Writer, value interface{}) error {
+	resp, ok := value.(apiparams.ControllerVersionsResponse)
+	if !ok {
+		return errors.E(fmt.Sprintf("expected value of type %T, got %T", resp, value))
+	}
+
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.Wrap = true
+
+	table.AddRow("Controller", "Version", "Models", "Model versions", "Upgrade blocked")
+	for _, ctl := range resp.Controllers {
+		versions := make(map[string]int)
+		var order []string
+		for _, m := range ctl.Models {
+			if versions[m.AgentVersion] == 0 {
+				order = append(order, m.AgentVersion)
+			}
+			versions[m.AgentVersion]++
+		}
+		modelVersions := make([]string, len(order))
+		for i, v := range order {
+			name := v
+			if name == "" {
+				name = "unknown"
+			}
+			modelVersions[i] = fmt.Sprintf("%s (%d)", name, versions[v])
+		}
+		blocked := "-"
+		switch {
+		case ctl.Error != "":
+			blocked = "error"
+		case ctl.UpgradeSummary != nil:
+			blocked = fmt.Sprintf("%d/%d", ctl.UpgradeSummary.Blocked, ctl.UpgradeSummary.Checked)
+		}
+		table.AddRow(ctl.Name, ctl.AgentVersion, len(ctl.Models), strings.Join(modelVersions, ", "), blocked)
+	}
+	fmt.Fprint(writer, table)
+
+	var failures []string
+	for _, ctl := range resp.Controllers {
+		if ctl.Error != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", ctl.Name, ctl.Error))
+		}
+		for _, m := range ctl.Models {
+			if m.UpgradeError != "" {
+				failures = append(failures, fmt.Sprintf("%s/%s/%s: %s", ctl.Name, m.Owner, m.Name, m.UpgradeError))
+			}
+		}
+	}
+	if len(failures) != 0 {
+		fmt.Fprintf(writer, "\n\n")
+		fmt.Fprintln(writer, "Upgrade blockers")
+		for _, msg := range failures {
+			fmt.Fprintln(writer, msg)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type controllerVersionsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&controllerVersionsSuite{})
+
+func (s *controllerVersionsSuite) TestControllerVersionsSuperuser(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewControllerVersionsCommandForTesting(s.ClientStore(), bClient), "controller-1", "--format", "yaml")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches, `(?s)controllers:
+- name: controller-1
+  uuid: deadbeef-1bad-500d-9000-4b1d0d06f00d
+  agentversion: .*
+`)
+}
+
+func (s *controllerVersionsSuite) TestControllerVersionsTabular(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewControllerVersionsCommandForTesting(s.ClientStore(), bClient), "--check-upgrades")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches, `(?s)Controller\s+Version\s+Models\s+Model versions\s+Upgrade blocked\s*
+controller-1\s+.*\s+\d+/\d+\s*
+`)
+}
+
+func (s *controllerVersionsSuite) TestControllerVersions(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// bob is not superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewControllerVersionsCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *controllerVersionsSuite) TestControllerVersionsUnknownArguments(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewControllerVersionsCommandForTesting(s.ClientStore(), bClient), "controller-1", "controller-2")
+	c.Assert(err, gc.ErrorMatches, `unknown arguments`)
+}
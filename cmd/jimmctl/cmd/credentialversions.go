@@ -0,0 +1,229 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	//nolint:gosec // Thinks a credential is exposed.
+	credentialVersionsDoc = `
+credential-versions command enables management of the previous versions
+of cloud credentials kept by jimm's credential store.
+
+Previous versions are only kept when jimm stores credentials in a vault
+KV v2 secrets engine.
+`
+
+	//nolint:gosec // Thinks a credential is exposed.
+	listCredentialVersionsDoc = `
+list command lists the versions of a cloud credential's attributes.
+
+Example:
+	jimmctl credential-versions list <cloud>/<owner>/<credential>
+`
+
+	//nolint:gosec // Thinks a credential is exposed.
+	restoreCredentialVersionDoc = `
+restore command replaces the attributes of a cloud credential with a
+previous version. The restored attributes are applied to every controller
+hosting models that use the credential.
+
+Example:
+	jimmctl credential-versions restore <cloud>/<owner>/<credential> <version>
+`
+)
+
+// NewCredentialVersionsCommand returns a command for cloud credential
+// version management.
+func NewCredentialVersionsCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "credential-versions",
+		Doc:     credentialVersionsDoc,
+		Purpose: "Cloud credential version management.",
+	})
+	cmd.Register(newListCredentialVersionsCommand())
+	cmd.Register(newRestoreCredentialVersionCommand())
+
+	return cmd
+}
+
+// newListCredentialVersionsCommand returns a command to list the versions
+// of a cloud credential.
+func newListCredentialVersionsCommand() cmd.Command {
+	cmd := &listCredentialVersionsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listCredentialVersionsCommand lists the versions of a cloud credential.
+type listCredentialVersionsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req apiparams.ListCloudCredentialVersionsRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *listCredentialVersionsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Args:    "<cloud>/<owner>/<credential>",
+		Purpose: "List the versions of a cloud credential.",
+		Doc:     listCredentialVersionsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listCredentialVersionsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *listCredentialVersionsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("credential not specified")
+	}
+	tag, err := parseCredentialTag(args[0])
+	if err != nil {
+		return err
+	}
+	c.req.CredentialTag = tag.String()
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listCredentialVersionsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.ListCloudCredentialVersions(&c.req)
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, resp.Versions)
+}
+
+// newRestoreCredentialVersionCommand returns a command to restore a
+// previous version of a cloud credential.
+func newRestoreCredentialVersionCommand() cmd.Command {
+	cmd := &restoreCredentialVersionCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// restoreCredentialVersionCommand restores a previous version of a cloud
+// credential.
+type restoreCredentialVersionCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req apiparams.RestoreCloudCredentialVersionRequest
+}
+
+// Info implements the cmd.Command interface.
+func (c *restoreCredentialVersionCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "restore",
+		Args:    "<cloud>/<owner>/<credential> <version>",
+		Purpose: "Restore a previous version of a cloud credential.",
+		Doc:     restoreCredentialVersionDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *restoreCredentialVersionCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *restoreCredentialVersionCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("credential and version must be specified")
+	}
+	tag, err := parseCredentialTag(args[0])
+	if err != nil {
+		return err
+	}
+	c.req.CredentialTag = tag.String()
+	c.req.Version, err = strconv.Atoi(args[1])
+	if err != nil || c.req.Version < 1 {
+		return errors.E("invalid version " + args[1])
+	}
+	if len(args) > 2 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *restoreCredentialVersionCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.RestoreCloudCredentialVersion(&c.req)
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, resp.Models)
+}
+
+// parseCredentialTag parses a cloud credential specified as
+// <cloud>/<owner>/<credential>.
+func parseCredentialTag(s string) (names.CloudCredentialTag, error) {
+	if !names.IsValidCloudCredential(s) {
+		return names.CloudCredentialTag{}, errors.E("invalid credential " + s)
+	}
+	return names.NewCloudCredentialTag(s), nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type credentialVersionsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&credentialVersionsSuite{})
+
+func (s *credentialVersionsSuite) TestCredentialVersions(c *gc.C) {
+	ctx := context.Background()
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	credName := jimmtest.TestCloudName + "/charlie@canonical.com/cred"
+	cct := names.NewCloudCredentialTag(credName)
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{
+		AuthType:   "userpass",
+		Attributes: map[string]string{"username": "charlie", "password": "one"},
+	})
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{
+		AuthType:   "userpass",
+		Attributes: map[string]string{"username": "charlie", "password": "two"},
+	})
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	ctxt, err := cmdtesting.RunCommand(c, cmd.NewListCredentialVersionsCommandForTesting(s.ClientStore(), bClient), credName)
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctxt), gc.Matches, `(?s)- version: 1
+  created-time: .*
+- version: 2
+  created-time: .*
+`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRestoreCredentialVersionCommandForTesting(s.ClientStore(), bClient), credName, "1")
+	c.Assert(err, gc.IsNil)
+
+	attrs, err := s.JIMM.CredentialStore.Get(ctx, cct)
+	c.Assert(err, gc.IsNil)
+	c.Check(attrs, gc.DeepEquals, map[string]string{"username": "charlie", "password": "one"})
+
+	ctxt, err = cmdtesting.RunCommand(c, cmd.NewListCredentialVersionsCommandForTesting(s.ClientStore(), bClient), credName, "--format", "json")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctxt), gc.Matches, `\[.*"version":3.*\]\n`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRestoreCredentialVersionCommandForTesting(s.ClientStore(), bClient), credName, "10")
+	c.Assert(err, gc.ErrorMatches, `.*not found.*`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRestoreCredentialVersionCommandForTesting(s.ClientStore(), bClient), credName, "latest")
+	c.Assert(err, gc.ErrorMatches, `invalid version latest`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewListCredentialVersionsCommandForTesting(s.ClientStore(), bClient), "not-a-credential")
+	c.Assert(err, gc.ErrorMatches, `invalid credential not-a-credential`)
+}
+
+func (s *credentialVersionsSuite) TestCredentialVersionsUnauthorized(c *gc.C) {
+	credName := jimmtest.TestCloudName + "/charlie@canonical.com/cred"
+	s.UpdateCloudCredential(c, names.NewCloudCredentialTag(credName), jujuparams.CloudCredential{
+		AuthType:   "userpass",
+		Attributes: map[string]string{"username": "charlie", "password": "one"},
+	})
+
+	// bob is not superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewListCredentialVersionsCommandForTesting(s.ClientStore(), bClient), credName)
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRestoreCredentialVersionCommandForTesting(s.ClientStore(), bClient), credName, "1")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
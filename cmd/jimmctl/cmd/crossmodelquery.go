@@ -27,8 +27,13 @@ as such you can format your query against an output like this.
 
 The queries will expect a JQ query string.
 
+The models queried may be restricted to those matching a label selector
+using the --label-selector flag. A selector is a comma separated list of
+requirements of the form key=value, key!=value, key or !key.
+
 Example:
 	jimmctl query-models '.applications | with_entries(select(.key=="nginx-ingress-integrator"))'
+	jimmctl query-models --label-selector team=observability,env!=dev '.applications'
 `
 )
 
@@ -41,6 +46,9 @@ type crossModelQueryCommand struct {
 	query string
 	// queryType holds the type of query the user wishes to use.
 	queryType string
+	// labelSelector holds the label selector used to select the models
+	// to query.
+	labelSelector string
 
 	store    jujuclient.ClientStore
 	dialOpts *jujuapi.DialOpts
@@ -74,6 +82,7 @@ func (c *crossModelQueryCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
 	})
+	f.StringVar(&c.labelSelector, "label-selector", "", "only query models whose labels match the selector")
 	c.file.StdinMarkers = stdinMarkers
 }
 
@@ -99,8 +108,9 @@ func (c *crossModelQueryCommand) Run(ctxt *cmd.Context) error {
 	}
 
 	req := apiparams.CrossModelQueryRequest{
-		Type:  c.queryType,
-		Query: c.query,
+		Type:          c.queryType,
+		Query:         c.query,
+		LabelSelector: c.labelSelector,
 	}
 
 	client := api.NewClient(apiCaller)
@@ -0,0 +1,447 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+	"gopkg.in/yaml.v2"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	setGroupModelDefaultsDoc = `
+	set-group-model-defaults sets default model config values for the
+	members of a group. Existing values for other keys are kept.
+
+	Model config is layered when a model is added, with later layers
+	taking precedence over earlier ones:
+		group < identity < cloud < region < template < explicit
+	When a user is a member of several groups with defaults, the
+	defaults of the group with the highest --priority take precedence.
+	Groups with the same priority are applied in order of name.
+
+	Example:
+		jimmctl set-group-model-defaults devops logging-config="<root>=DEBUG"
+		jimmctl set-group-model-defaults devops --priority 10 automatically-retry-hooks=false
+`
+
+	unsetGroupModelDefaultsDoc = `
+	unset-group-model-defaults removes default model config values
+	from a group.
+
+	Example:
+		jimmctl unset-group-model-defaults devops logging-config
+`
+
+	groupModelDefaultsDoc = `
+	group-model-defaults displays the default model config values of a
+	group.
+
+	Example:
+		jimmctl group-model-defaults devops
+		jimmctl group-model-defaults devops --format json
+`
+
+	effectiveModelConfigDoc = `
+	effective-model-config displays the config a model would be added
+	with if the current user added it, along with the source of each
+	value. Values given as arguments are treated as the config passed
+	when adding the model, so a model template can be selected with
+	jimm-model-template=<name>. Region defaults are only included if
+	a region is specified, either with --region or by a template.
+
+	Example:
+		jimmctl effective-model-config --cloud aws --region eu-west-1
+		jimmctl effective-model-config --cloud aws jimm-model-template=production
+`
+)
+
+// NewSetGroupModelDefaultsCommand returns a command to set the model
+// defaults of a group.
+func NewSetGroupModelDefaultsCommand() cmd.Command {
+	cmd := &setGroupModelDefaultsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setGroupModelDefaultsCommand sets the model defaults of a group.
+type setGroupModelDefaultsCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req apiparams.SetGroupModelDefaultsRequest
+}
+
+// Info implements Command.Info.
+func (c *setGroupModelDefaultsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-group-model-defaults",
+		Args:    "<group> [<key>=<value> ...]",
+		Purpose: "Set the model defaults of a group.",
+		Doc:     setGroupModelDefaultsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setGroupModelDefaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.Var(priorityValue{&c.req.Priority}, "priority", "precedence of the group's defaults over those of other groups")
+}
+
+// Init implements the cmd.Command interface.
+func (c *setGroupModelDefaultsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing group name")
+	}
+	c.req.Group, args = args[0], args[1:]
+	config, err := parseConfigPairs(args)
+	if err != nil {
+		return err
+	}
+	if len(config) == 0 && c.req.Priority == nil {
+		return errors.E("no defaults or priority specified")
+	}
+	c.req.Config = config
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setGroupModelDefaultsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.SetGroupModelDefaults(&c.req); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// NewUnsetGroupModelDefaultsCommand returns a command to unset model
+// defaults of a group.
+func NewUnsetGroupModelDefaultsCommand() cmd.Command {
+	cmd := &unsetGroupModelDefaultsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// unsetGroupModelDefaultsCommand unsets model defaults of a group.
+type unsetGroupModelDefaultsCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req apiparams.UnsetGroupModelDefaultsRequest
+}
+
+// Info implements Command.Info.
+func (c *unsetGroupModelDefaultsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "unset-group-model-defaults",
+		Args:    "<group> <key> [<key> ...]",
+		Purpose: "Unset model defaults of a group.",
+		Doc:     unsetGroupModelDefaultsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *unsetGroupModelDefaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements the cmd.Command interface.
+func (c *unsetGroupModelDefaultsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing group name")
+	}
+	c.req.Group, args = args[0], args[1:]
+	if len(args) == 0 {
+		return errors.E("no keys specified")
+	}
+	c.req.Keys = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *unsetGroupModelDefaultsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.UnsetGroupModelDefaults(&c.req); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// NewGroupModelDefaultsCommand returns a command to display the model
+// defaults of a group.
+func NewGroupModelDefaultsCommand() cmd.Command {
+	cmd := &groupModelDefaultsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// groupModelDefaultsCommand displays the model defaults of a group.
+type groupModelDefaultsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req apiparams.GroupModelDefaultsRequest
+}
+
+// Info implements Command.Info.
+func (c *groupModelDefaultsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "group-model-defaults",
+		Args:    "<group>",
+		Purpose: "Display the model defaults of a group.",
+		Doc:     groupModelDefaultsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *groupModelDefaultsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *groupModelDefaultsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing group name")
+	}
+	c.req.Group, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *groupModelDefaultsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.GroupModelDefaults(&c.req)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// NewEffectiveModelConfigCommand returns a command to display the config
+// a new model would be added with.
+func NewEffectiveModelConfigCommand() cmd.Command {
+	cmd := &effectiveModelConfigCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// effectiveModelConfigCommand displays the config a new model would be
+// added with.
+type effectiveModelConfigCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	cloud string
+	req   apiparams.EffectiveModelConfigRequest
+}
+
+// Info implements Command.Info.
+func (c *effectiveModelConfigCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "effective-model-config",
+		Args:    "[<key>=<value> ...]",
+		Purpose: "Display the config a new model would be added with.",
+		Doc:     effectiveModelConfigDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *effectiveModelConfigCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatEffectiveModelConfigTabular,
+	})
+	f.StringVar(&c.cloud, "cloud", "", "cloud the model would be added to")
+	f.StringVar(&c.req.CloudRegion, "region", "", "cloud region the model would be added to")
+}
+
+// Init implements the cmd.Command interface.
+func (c *effectiveModelConfigCommand) Init(args []string) error {
+	if c.cloud != "" {
+		if !names.IsValidCloud(c.cloud) {
+			return errors.E(fmt.Sprintf("invalid cloud name %q", c.cloud))
+		}
+		c.req.CloudTag = names.NewCloudTag(c.cloud).String()
+	}
+	config, err := parseConfigPairs(args)
+	if err != nil {
+		return err
+	}
+	c.req.Config = config
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *effectiveModelConfigCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.EffectiveModelConfig(&c.req)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+func formatEffectiveModelConfigTabular(writer io.Writer, value interface{}) error {
+	resp, ok := value.(apiparams.EffectiveModelConfigResponse)
+	if !ok {
+		return errors.E(fmt.Sprintf("expected value of type %T, got %T", resp, value))
+	}
+
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.Wrap = true
+
+	table.AddRow("Key", "Value", "Source")
+	for _, v := range resp.Config {
+		source := v.Source
+		if v.Group != "" {
+			source = fmt.Sprintf("%s (%s)", source, v.Group)
+		}
+		table.AddRow(v.Key, fmt.Sprint(v.Value), source)
+	}
+	fmt.Fprint(writer, table)
+	return nil
+}
+
+// parseConfigPairs parses arguments of the form key=value into a config
+// map. Values are parsed as YAML so that booleans and numbers have the
+// correct type, any other value is kept as a string.
+func parseConfigPairs(args []string) (map[string]interface{}, error) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+	config := make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		k, v, ok := strings.Cut(arg, "=")
+		if !ok || k == "" {
+			return nil, errors.E(fmt.Sprintf("expected key=value, got %q", arg))
+		}
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(v), &value); err != nil {
+			value = v
+		}
+		switch value.(type) {
+		case bool, int, float64:
+		default:
+			value = v
+		}
+		config[k] = value
+	}
+	return config, nil
+}
+
+// priorityValue is a gnuflag.Value that sets an optional priority only
+// when the flag is specified.
+type priorityValue struct {
+	v **int
+}
+
+// Set implements gnuflag.Value.
+func (v priorityValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return errors.E("invalid priority " + strconv.Quote(s))
+	}
+	*v.v = &n
+	return nil
+}
+
+// String implements gnuflag.Value.
+func (v priorityValue) String() string {
+	if v.v == nil || *v.v == nil {
+		return ""
+	}
+	return strconv.Itoa(**v.v)
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+type groupModelDefaultsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&groupModelDefaultsSuite{})
+
+func (s *groupModelDefaultsSuite) TestGroupModelDefaultsSuperuser(c *gc.C) {
+	ctx := context.Background()
+
+	group, err := s.JIMM.Database.AddGroup(ctx, "devops")
+	c.Assert(err, gc.IsNil)
+	err = s.OFGAClient.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("alice@canonical.com")),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(jimmnames.NewGroupTag(group.UUID)),
+	})
+	c.Assert(err, gc.IsNil)
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetGroupModelDefaultsCommandForTesting(s.ClientStore(), bClient), "devops", "--priority", "3", "key1=value1", "key2=true", "key3=10")
+	c.Assert(err, gc.IsNil)
+
+	defaults := dbmodel.GroupModelDefaults{GroupUUID: group.UUID}
+	err = s.JIMM.Database.GetGroupModelDefaults(ctx, &defaults)
+	c.Assert(err, gc.IsNil)
+	c.Check(defaults.Priority, gc.Equals, 3)
+	c.Check(defaults.Defaults, gc.DeepEquals, dbmodel.Map{"key1": "value1", "key2": true, "key3": float64(10)})
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewUnsetGroupModelDefaultsCommandForTesting(s.ClientStore(), bClient), "devops", "key2", "key3")
+	c.Assert(err, gc.IsNil)
+
+	cmdCtx, err := cmdtesting.RunCommand(c, cmd.NewGroupModelDefaultsCommandForTesting(s.ClientStore(), bClient), "devops")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(cmdCtx), gc.Equals, `group: devops
+priority: 3
+config:
+  key1: value1
+`)
+
+	cmdCtx, err = cmdtesting.RunCommand(c, cmd.NewEffectiveModelConfigCommandForTesting(s.ClientStore(), bClient), "key4=value4", "--format", "yaml")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(cmdCtx), gc.Equals, `config:
+- key: key1
+  value: value1
+  source: group
+  group: devops
+- key: key4
+  value: value4
+  source: explicit
+`)
+}
+
+func (s *groupModelDefaultsSuite) TestSetGroupModelDefaults(c *gc.C) {
+	_, err := s.JIMM.Database.AddGroup(context.Background(), "devops")
+	c.Assert(err, gc.IsNil)
+
+	// bob is not superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetGroupModelDefaultsCommandForTesting(s.ClientStore(), bClient), "devops", "key1=value1")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *groupModelDefaultsSuite) TestSetGroupModelDefaultsInvalidArguments(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetGroupModelDefaultsCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `missing group name`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetGroupModelDefaultsCommandForTesting(s.ClientStore(), bClient), "devops")
+	c.Assert(err, gc.ErrorMatches, `no defaults or priority specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetGroupModelDefaultsCommandForTesting(s.ClientStore(), bClient), "devops", "key1")
+	c.Assert(err, gc.ErrorMatches, `expected key=value, got "key1"`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetGroupModelDefaultsCommandForTesting(s.ClientStore(), bClient), "devops", "--priority", "high")
+	c.Assert(err, gc.ErrorMatches, `invalid value "high" for flag --priority: invalid priority "high"`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewUnsetGroupModelDefaultsCommandForTesting(s.ClientStore(), bClient), "devops")
+	c.Assert(err, gc.ErrorMatches, `no keys specified`)
+}
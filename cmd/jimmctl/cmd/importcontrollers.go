@@ -0,0 +1,314 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+const (
+	importResultImported = "imported"
+	importResultFailed   = "failed"
+)
+
+var importControllersCommandDoc = `
+	import-controllers command adds controllers known to a local juju
+	client to JIMM.
+
+	The controller details and the credentials JIMM should use to
+	connect to them are read from the controllers.yaml and accounts.yaml
+	files of a juju client. By default the current juju client data
+	directory is used, use --juju-data to read the files from another
+	directory.
+
+	Controllers to import can be given as arguments, or all controllers
+	can be imported with --all. If neither is given the known controllers
+	are listed and the controllers to import are read from standard
+	input. The controller currently used to connect to JIMM is never
+	imported, whatever name it has in the imported juju client data.
+
+	Controllers are added in sequence and a summary of the controllers
+	that were, and were not, imported is displayed.
+
+	Examples:
+		jimmctl import-controllers
+		jimmctl import-controllers controller-1 controller-2
+		jimmctl import-controllers --all --juju-data /path/to/juju
+`
+
+// NewImportControllersCommand returns a command to import controllers
+// from a juju client store.
+func NewImportControllersCommand() cmd.Command {
+	cmd := &importControllersCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// importControllersCommand adds controllers known to a juju client to
+// JIMM.
+type importControllersCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	jujuData    string
+	all         bool
+	local       bool
+	tlsHostname string
+	names       []string
+}
+
+// importControllerResult holds the result of importing a single
+// controller.
+type importControllerResult struct {
+	Name   string `json:"name" yaml:"name"`
+	UUID   string `json:"uuid" yaml:"uuid"`
+	Result string `json:"result" yaml:"result"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (c *importControllersCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import-controllers",
+		Args:    "[<controller name>...]",
+		Purpose: "Import controllers from a juju client to JIMM.",
+		Doc:     importControllersCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *importControllersCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatImportControllersTabular,
+	})
+	f.StringVar(&c.jujuData, "juju-data", "", "directory containing the controllers.yaml and accounts.yaml files to import from")
+	f.BoolVar(&c.all, "all", false, "import all controllers")
+	f.BoolVar(&c.local, "local", true, "use the API addresses and CA certificate of each controller, if false the controller's public hostname is used")
+	f.StringVar(&c.tlsHostname, "tls-hostname", "", "hostname to use for TLS verification of all imported controllers")
+}
+
+// Init implements the cmd.Command interface.
+func (c *importControllersCommand) Init(args []string) error {
+	if c.all && len(args) > 0 {
+		return errors.E("cannot specify controller names with --all")
+	}
+	c.names = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *importControllersCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	jimmDetails, err := c.store.ControllerByName(currentController)
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	controllers, accounts, err := c.readClientStore(ctxt)
+	if err != nil {
+		return errors.E(err)
+	}
+	// The controllers may be read from a different client store in
+	// which JIMM is known by another name, so match it by UUID.
+	for name, cd := range controllers {
+		if cd.ControllerUUID == jimmDetails.ControllerUUID {
+			delete(controllers, name)
+		}
+	}
+
+	names, err := c.selectControllers(ctxt, controllers)
+	if err != nil {
+		return errors.E(err)
+	}
+	if len(names) == 0 {
+		return errors.E("no controllers selected")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+	client := api.NewClient(apiCaller)
+
+	results := make([]importControllerResult, len(names))
+	var failed int
+	for i, name := range names {
+		results[i].Name = name
+		req, err := c.addControllerRequest(name, controllers[name], accounts[name])
+		if err == nil {
+			results[i].UUID = req.UUID
+			_, err = client.AddController(req)
+		}
+		if err != nil {
+			failed++
+			results[i].Result = importResultFailed
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Result = importResultImported
+	}
+
+	if err := c.out.Write(ctxt, results); err != nil {
+		return errors.E(err)
+	}
+	if failed > 0 {
+		return errors.E(fmt.Sprintf("failed to import %d of %d controllers", failed, len(names)))
+	}
+	return nil
+}
+
+// readClientStore reads the controllers and accounts to import from.
+func (c *importControllersCommand) readClientStore(ctxt *cmd.Context) (map[string]jujuclient.ControllerDetails, map[string]jujuclient.AccountDetails, error) {
+	if c.jujuData == "" {
+		controllers, err := c.store.AllControllers()
+		if err != nil {
+			return nil, nil, err
+		}
+		accounts := make(map[string]jujuclient.AccountDetails, len(controllers))
+		for name := range controllers {
+			ad, err := c.store.AccountDetails(name)
+			if err != nil {
+				continue
+			}
+			accounts[name] = *ad
+		}
+		return controllers, accounts, nil
+	}
+
+	dir := ctxt.AbsPath(c.jujuData)
+	controllers, err := jujuclient.ReadControllersFile(filepath.Join(dir, "controllers.yaml"))
+	if err != nil {
+		return nil, nil, err
+	}
+	accounts, err := jujuclient.ReadAccountsFile(filepath.Join(dir, "accounts.yaml"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if controllers.Controllers == nil {
+		controllers.Controllers = make(map[string]jujuclient.ControllerDetails)
+	}
+	return controllers.Controllers, accounts, nil
+}
+
+// selectControllers returns the names of the controllers to import. If
+// no controllers were specified on the command line, and --all was not
+// given, the user is asked to choose from the available controllers.
+func (c *importControllersCommand) selectControllers(ctxt *cmd.Context, controllers map[string]jujuclient.ControllerDetails) ([]string, error) {
+	available := make([]string, 0, len(controllers))
+	for name := range controllers {
+		available = append(available, name)
+	}
+	sort.Strings(available)
+
+	if c.all {
+		return available, nil
+	}
+	if len(c.names) > 0 {
+		for _, name := range c.names {
+			if _, ok := controllers[name]; !ok {
+				return nil, errors.E(fmt.Sprintf("controller %q not found", name))
+			}
+		}
+		return c.names, nil
+	}
+
+	if len(available) == 0 {
+		return nil, errors.E("no controllers available to import")
+	}
+	fmt.Fprintln(ctxt.Stderr, "Available controllers:")
+	for i, name := range available {
+		fmt.Fprintf(ctxt.Stderr, "  %d. %s (%s)\n", i+1, name, controllers[name].ControllerUUID)
+	}
+	fmt.Fprint(ctxt.Stderr, "Select controllers to import (numbers or names, separated by spaces or commas): ")
+
+	line, err := bufio.NewReader(ctxt.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, errors.E(err)
+	}
+	var names []string
+	for _, field := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r' }) {
+		if n, err := strconv.Atoi(field); err == nil {
+			if n < 1 || n > len(available) {
+				return nil, errors.E(fmt.Sprintf("invalid selection %d", n))
+			}
+			names = append(names, available[n-1])
+			continue
+		}
+		if _, ok := controllers[field]; !ok {
+			return nil, errors.E(fmt.Sprintf("controller %q not found", field))
+		}
+		names = append(names, field)
+	}
+	return names, nil
+}
+
+// addControllerRequest builds the request to add the named controller
+// from its details in the juju client store.
+func (c *importControllersCommand) addControllerRequest(name string, cd jujuclient.ControllerDetails, ad jujuclient.AccountDetails) (*apiparams.AddControllerRequest, error) {
+	if ad.User == "" || ad.Password == "" {
+		return nil, errors.E("no password credentials for controller")
+	}
+	req := apiparams.AddControllerRequest{
+		UUID:        cd.ControllerUUID,
+		Name:        name,
+		Username:    ad.User,
+		Password:    ad.Password,
+		TLSHostname: c.tlsHostname,
+	}
+	if c.local {
+		req.APIAddresses = cd.APIEndpoints
+		req.CACertificate = cd.CACert
+	} else {
+		if cd.PublicDNSName == "" {
+			return nil, errors.E("controller has no public hostname")
+		}
+		req.PublicAddress = cd.PublicDNSName
+	}
+	return &req, nil
+}
+
+func formatImportControllersTabular(writer io.Writer, value interface{}) error {
+	results, ok := value.([]importControllerResult)
+	if !ok {
+		return errors.E(fmt.Sprintf("expected value of type %T, got %T", results, value))
+	}
+
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.Wrap = true
+
+	table.AddRow("Controller", "UUID", "Result", "Error")
+	for _, r := range results {
+		table.AddRow(r.Name, r.UUID, r.Result, r.Error)
+	}
+	fmt.Fprint(writer, table)
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/juju/jujuclient"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type importControllersSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&importControllersSuite{})
+
+// writeJujuData writes controllers.yaml and accounts.yaml files
+// describing the test juju controller as "controller-1", and a
+// controller without credentials as "controller-2", to a new directory.
+// JIMM itself is also included as "jimm-prod".
+func (s *importControllersSuite) writeJujuData(c *gc.C) string {
+	info := s.APIInfo(c)
+	controllers := jujuclient.Controllers{
+		Controllers: map[string]jujuclient.ControllerDetails{
+			"controller-1": {
+				ControllerUUID: info.ControllerUUID,
+				APIEndpoints:   info.Addrs,
+				CACert:         info.CACert,
+			},
+			"controller-2": {
+				ControllerUUID: "00000000-0000-0000-0000-000000000002",
+				APIEndpoints:   info.Addrs,
+				CACert:         info.CACert,
+			},
+			"jimm-prod": {
+				ControllerUUID: "914487b5-60e7-42bb-bd63-1adc3fd3a388",
+				APIEndpoints:   info.Addrs,
+				CACert:         info.CACert,
+			},
+		},
+	}
+	accounts := map[string]map[string]jujuclient.AccountDetails{
+		"controllers": {
+			"controller-1": {
+				User:     info.Tag.Id(),
+				Password: info.Password,
+			},
+		},
+	}
+	dir := c.MkDir()
+	for name, v := range map[string]interface{}{"controllers.yaml": controllers, "accounts.yaml": accounts} {
+		data, err := yaml.Marshal(v)
+		c.Assert(err, gc.IsNil)
+		err = os.WriteFile(filepath.Join(dir, name), data, 0600)
+		c.Assert(err, gc.IsNil)
+	}
+	return dir
+}
+
+func (s *importControllersSuite) TestImportControllersSuperuser(c *gc.C) {
+	dir := s.writeJujuData(c)
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	ctx, err := cmdtesting.RunCommand(c, cmd.NewImportControllersCommandForTesting(s.ClientStore(), bClient), "--juju-data", dir, "controller-1")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Matches, `(?s)Controller\s+UUID\s+Result\s+Error\s+controller-1\s+deadbeef-1bad-500d-9000-4b1d0d06f00d\s+imported\s+`)
+
+	ctl := dbmodel.Controller{Name: "controller-1"}
+	err = s.JIMM.Database.GetController(context.Background(), &ctl)
+	c.Assert(err, gc.IsNil)
+	c.Check(ctl.UUID, gc.Equals, "deadbeef-1bad-500d-9000-4b1d0d06f00d")
+}
+
+func (s *importControllersSuite) TestImportControllersAll(c *gc.C) {
+	dir := s.writeJujuData(c)
+
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	ctx, err := cmdtesting.RunCommand(c, cmd.NewImportControllersCommandForTesting(s.ClientStore(), bClient), "--juju-data", dir, "--all", "--format", "yaml")
+	c.Assert(err, gc.ErrorMatches, `failed to import 1 of 2 controllers`)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `- name: controller-1
+  uuid: deadbeef-1bad-500d-9000-4b1d0d06f00d
+  result: imported
+- name: controller-2
+  uuid: ""
+  result: failed
+  error: no password credentials for controller
+`)
+}
+
+func (s *importControllersSuite) TestImportControllersInteractive(c *gc.C) {
+	dir := s.writeJujuData(c)
+
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("1\n")
+	code := cmd.NewImportControllersCommandForTesting(s.ClientStore(), bClient)
+	err := cmdtesting.InitCommand(code, []string{"--juju-data", dir, "--format", "json"})
+	c.Assert(err, gc.IsNil)
+	err = code.Run(ctx)
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Matches, `(?s)Available controllers:\n  1\. controller-1 \(deadbeef-1bad-500d-9000-4b1d0d06f00d\)\n  2\. controller-2 .*`)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `[{"name":"controller-1","uuid":"deadbeef-1bad-500d-9000-4b1d0d06f00d","result":"imported"}]`+"\n")
+}
+
+func (s *importControllersSuite) TestImportControllersUnknownController(c *gc.C) {
+	dir := s.writeJujuData(c)
+
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewImportControllersCommandForTesting(s.ClientStore(), bClient), "--juju-data", dir, "controller-3")
+	c.Assert(err, gc.ErrorMatches, `controller "controller-3" not found`)
+}
+
+func (s *importControllersSuite) TestImportControllersJIMM(c *gc.C) {
+	dir := s.writeJujuData(c)
+
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewImportControllersCommandForTesting(s.ClientStore(), bClient), "--juju-data", dir, "jimm-prod")
+	c.Assert(err, gc.ErrorMatches, `controller "jimm-prod" not found`)
+}
+
+func (s *importControllersSuite) TestImportControllers(c *gc.C) {
+	dir := s.writeJujuData(c)
+
+	// bob is not superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	ctx, err := cmdtesting.RunCommand(c, cmd.NewImportControllersCommandForTesting(s.ClientStore(), bClient), "--juju-data", dir, "controller-1")
+	c.Assert(err, gc.ErrorMatches, `failed to import 1 of 1 controllers`)
+	c.Check(cmdtesting.Stdout(ctx), gc.Matches, `(?s).*controller-1\s+deadbeef-1bad-500d-9000-4b1d0d06f00d\s+failed\s+unauthorized \(unauthorized access\).*`)
+}
+
+func (s *importControllersSuite) TestImportControllersAllWithNames(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewImportControllersCommandForTesting(s.ClientStore(), bClient), "--all", "controller-1")
+	c.Assert(err, gc.ErrorMatches, `cannot specify controller names with --all`)
+}
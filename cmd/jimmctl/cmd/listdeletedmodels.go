@@ -0,0 +1,112 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var listDeletedModelsCommandDoc = `
+	list-deleted-models command displays the tombstones of models that
+	have been removed from JIMM.
+
+	When a model is destroyed JIMM keeps a record of the model and of the
+	relationship tuples that referred to it for a configurable retention
+	period, so that its ownership and access control can be inspected
+	after the fact. Neither the model nor its tuples can be restored.
+
+	If a model UUID is given only the tombstones of that model are shown.
+
+	Example:
+		jimmctl list-deleted-models
+		jimmctl list-deleted-models 00000002-0000-0000-0000-000000000001 --format json
+`
+
+// NewListDeletedModelsCommand returns a command to list deleted models.
+func NewListDeletedModelsCommand() cmd.Command {
+	cmd := &listDeletedModelsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listDeletedModelsCommand displays the tombstones of deleted models.
+type listDeletedModelsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	modelUUID string
+}
+
+// Info implements Command.Info.
+func (c *listDeletedModelsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list-deleted-models",
+		Args:    "[<model uuid>]",
+		Purpose: "Lists models that have been removed from JIMM.",
+		Doc:     listDeletedModelsCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listDeletedModelsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *listDeletedModelsCommand) Init(args []string) error {
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	if len(args) == 1 {
+		if !names.IsValidModel(args[0]) {
+			return errors.E("invalid model uuid")
+		}
+		c.modelUUID = args[0]
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listDeletedModelsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.ListDeletedModels(&apiparams.ListDeletedModelsRequest{
+		ModelUUID: c.modelUUID,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp.Models)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type listDeletedModelsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&listDeletedModelsSuite{})
+
+func (s *listDeletedModelsSuite) TestListDeletedModels(c *gc.C) {
+	ctx := context.Background()
+	deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	err := s.JIMM.Database.AddDeletedModel(ctx, &dbmodel.DeletedModel{
+		DeletedAt:         deletedAt,
+		ModelCreatedAt:    deletedAt.Add(-time.Hour),
+		UUID:              "00000002-0000-0000-0000-000000000001",
+		Name:              "model-1",
+		OwnerIdentityName: "alice@canonical.com",
+		ControllerName:    "controller-1",
+		Life:              "dead",
+		Tuples: dbmodel.DeletedModelTuples{{
+			Object:   "user:bob@canonical.com",
+			Relation: "writer",
+			Target:   "model:00000002-0000-0000-0000-000000000001",
+		}},
+	})
+	c.Assert(err, gc.IsNil)
+	err = s.JIMM.Database.AddDeletedModel(ctx, &dbmodel.DeletedModel{
+		DeletedAt:         deletedAt.Add(-time.Minute),
+		UUID:              "00000002-0000-0000-0000-000000000002",
+		Name:              "model-2",
+		OwnerIdentityName: "alice@canonical.com",
+	})
+	c.Assert(err, gc.IsNil)
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewListDeletedModelsCommandForTesting(s.ClientStore(), bClient), "00000002-0000-0000-0000-000000000001")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, `- uuid: 00000002-0000-0000-0000-000000000001
+  name: model-1
+  owner: alice@canonical.com
+  controller: controller-1
+  life: dead
+  created-at: 2024-01-02T02:04:05Z
+  deleted-at: 2024-01-02T03:04:05Z
+  tuples:
+  - object: user-bob@canonical.com
+    relation: writer
+    target_object: model-00000002-0000-0000-0000-000000000001
+`)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewListDeletedModelsCommandForTesting(s.ClientStore(), bClient), "--format", "json")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, `\[\{"uuid":"00000002-0000-0000-0000-000000000001".*\},\{"uuid":"00000002-0000-0000-0000-000000000002".*\}\]\n`)
+}
+
+func (s *listDeletedModelsSuite) TestListDeletedModelsInvalidUUID(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewListDeletedModelsCommandForTesting(s.ClientStore(), bClient), "not-a-uuid")
+	c.Assert(err, gc.ErrorMatches, `invalid model uuid`)
+}
+
+func (s *listDeletedModelsSuite) TestListDeletedModelsNotAuthorized(c *gc.C) {
+	// bob is not superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewListDeletedModelsCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
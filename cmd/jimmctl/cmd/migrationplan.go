@@ -0,0 +1,368 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"time"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	migrationPlanDoc = `
+migration-plan command enables scheduled model migrations in jimm.
+
+A migration plan migrates a batch of models to a target controller, in the
+given order, once its start time has been reached. JIMM limits the number
+of migrations in progress at the same time and pauses the plan if too many
+consecutive migrations fail. Migrations are performed on behalf of the
+administrator that added the plan.
+`
+
+	addMigrationPlanDoc = `
+add command adds a migration plan to jimm. The models are migrated in the
+order they are specified.
+
+Example:
+	jimmctl migration-plan add <name> <controller-name> <model-uuid> <model-uuid>
+	jimmctl migration-plan add <name> <controller-name> <model-uuid> --start-at 2024-06-01T01:00:00Z --max-concurrency 3 --max-failures 2
+`
+
+	listMigrationPlansDoc = `
+list command lists all migration plans in jimm.
+
+Example:
+	jimmctl migration-plan list
+`
+
+	showMigrationPlanDoc = `
+show command shows the progress of a migration plan.
+
+Example:
+	jimmctl migration-plan show <name>
+`
+
+	pauseMigrationPlanDoc = `
+pause command pauses a migration plan. Migrations already in progress are
+allowed to finish, but no further migrations are started.
+
+Example:
+	jimmctl migration-plan pause <name>
+`
+
+	resumeMigrationPlanDoc = `
+resume command resumes a paused migration plan.
+
+Example:
+	jimmctl migration-plan resume <name>
+`
+
+	cancelMigrationPlanDoc = `
+cancel command cancels a migration plan. Migrations already in progress are
+allowed to finish, but the remaining models are not migrated.
+
+Example:
+	jimmctl migration-plan cancel <name>
+`
+)
+
+// NewMigrationPlanCommand returns a command for migration plan
+// management.
+func NewMigrationPlanCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "migration-plan",
+		Doc:     migrationPlanDoc,
+		Purpose: "Scheduled model migration management.",
+	})
+	cmd.Register(newAddMigrationPlanCommand())
+	cmd.Register(newListMigrationPlansCommand())
+	cmd.Register(newShowMigrationPlanCommand())
+	cmd.Register(newUpdateMigrationPlanCommand("pause"))
+	cmd.Register(newUpdateMigrationPlanCommand("resume"))
+	cmd.Register(newUpdateMigrationPlanCommand("cancel"))
+
+	return cmd
+}
+
+// newAddMigrationPlanCommand returns a command to add a migration plan.
+func newAddMigrationPlanCommand() cmd.Command {
+	cmd := &addMigrationPlanCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// addMigrationPlanCommand adds a migration plan.
+type addMigrationPlanCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req     apiparams.AddMigrationPlanRequest
+	startAt string
+}
+
+// Info implements the cmd.Command interface.
+func (c *addMigrationPlanCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add",
+		Args:    "<name> <controller-name> <model-uuid> [<model-uuid>...]",
+		Purpose: "Add a migration plan.",
+		Doc:     addMigrationPlanDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addMigrationPlanCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.startAt, "start-at", "", "time at which to start migrating, in RFC3339 format (default now)")
+	f.IntVar(&c.req.MaxConcurrency, "max-concurrency", 1, "maximum number of models to migrate at the same time")
+	f.IntVar(&c.req.MaxFailures, "max-failures", 0, "number of consecutive failed migrations after which the plan is paused (0 never pauses)")
+}
+
+// Init implements the cmd.Command interface.
+func (c *addMigrationPlanCommand) Init(args []string) error {
+	if len(args) < 3 {
+		return errors.E("plan name, controller name and model uuids must be specified")
+	}
+	c.req.Name, c.req.TargetController, args = args[0], args[1], args[2:]
+	for _, arg := range args {
+		if !names.IsValidModel(arg) {
+			return errors.E("invalid model uuid " + arg)
+		}
+		c.req.ModelUUIDs = append(c.req.ModelUUIDs, arg)
+	}
+	if c.startAt != "" {
+		t, err := time.Parse(time.RFC3339, c.startAt)
+		if err != nil {
+			return errors.E(err, "invalid start time")
+		}
+		c.req.StartAt = t
+	}
+	if c.req.MaxConcurrency < 1 {
+		return errors.E("max-concurrency must be at least 1")
+	}
+	if c.req.MaxFailures < 0 {
+		return errors.E("max-failures cannot be negative")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *addMigrationPlanCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	plan, err := client.AddMigrationPlan(&c.req)
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, plan)
+}
+
+// newListMigrationPlansCommand returns a command to list all migration
+// plans.
+func newListMigrationPlansCommand() cmd.Command {
+	cmd := &listMigrationPlansCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// newShowMigrationPlanCommand returns a command to show a migration
+// plan.
+func newShowMigrationPlanCommand() cmd.Command {
+	cmd := &listMigrationPlansCommand{
+		store: jujuclient.NewFileClientStore(),
+		show:  true,
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listMigrationPlansCommand lists all migration plans, or shows a single
+// migration plan.
+type listMigrationPlansCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	name string
+	show bool
+}
+
+// Info implements the cmd.Command interface.
+func (c *listMigrationPlansCommand) Info() *cmd.Info {
+	if c.show {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "show",
+			Args:    "<name>",
+			Purpose: "Show a migration plan.",
+			Doc:     showMigrationPlanDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List all migration plans.",
+		Doc:     listMigrationPlansDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listMigrationPlansCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *listMigrationPlansCommand) Init(args []string) error {
+	if c.show {
+		if len(args) < 1 {
+			return errors.E("plan name not specified")
+		}
+		c.name, args = args[0], args[1:]
+	}
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listMigrationPlansCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if c.show {
+		plan, err := client.GetMigrationPlan(&apiparams.GetMigrationPlanRequest{
+			Name: c.name,
+		})
+		if err != nil {
+			return errors.E(err)
+		}
+		return c.out.Write(ctxt, plan)
+	}
+	plans, err := client.ListMigrationPlans()
+	if err != nil {
+		return errors.E(err)
+	}
+	return c.out.Write(ctxt, plans)
+}
+
+// newUpdateMigrationPlanCommand returns a command that performs the
+// given action, one of "pause", "resume" or "cancel", on a migration
+// plan.
+func newUpdateMigrationPlanCommand(action string) cmd.Command {
+	cmd := &updateMigrationPlanCommand{
+		store:  jujuclient.NewFileClientStore(),
+		action: action,
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// updateMigrationPlanCommand pauses, resumes or cancels a migration plan.
+type updateMigrationPlanCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	action string
+	name   string
+}
+
+// Info implements the cmd.Command interface.
+func (c *updateMigrationPlanCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name: c.action,
+		Args: "<name>",
+	}
+	switch c.action {
+	case "pause":
+		info.Purpose = "Pause a migration plan."
+		info.Doc = pauseMigrationPlanDoc
+	case "resume":
+		info.Purpose = "Resume a paused migration plan."
+		info.Doc = resumeMigrationPlanDoc
+	case "cancel":
+		info.Purpose = "Cancel a migration plan."
+		info.Doc = cancelMigrationPlanDoc
+	}
+	return jujucmd.Info(info)
+}
+
+// Init implements the cmd.Command interface.
+func (c *updateMigrationPlanCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("plan name not specified")
+	}
+	c.name, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *updateMigrationPlanCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.UpdateMigrationPlan(&apiparams.UpdateMigrationPlanRequest{
+		Name:   c.name,
+		Action: c.action,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
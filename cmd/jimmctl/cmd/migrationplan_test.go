@@ -0,0 +1,119 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type migrationPlanSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&migrationPlanSuite{})
+
+func (s *migrationPlanSuite) TestMigrationPlans(c *gc.C) {
+	ctx := context.Background()
+	s.AddController(c, "controller-1", s.APIInfo(c))
+	err := s.JIMM.Database.AddController(ctx, &dbmodel.Controller{
+		Name: "controller-2",
+		UUID: "00000001-0000-0000-0000-000000000002",
+	})
+	c.Assert(err, gc.IsNil)
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt1 := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+	mt2 := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	ctxt, err := cmdtesting.RunCommand(c, cmd.NewAddMigrationPlanCommandForTesting(s.ClientStore(), bClient), "overnight", "controller-2", mt2.Id(), mt1.Id(), "--start-at", "2030-01-01T01:00:00Z", "--max-concurrency", "2", "--max-failures", "3")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctxt), gc.Matches, `(?s)name: overnight
+target-controller: controller-2
+start-at: 2030-01-01T01:00:00Z
+max-concurrency: 2
+max-failures: 3
+status: pending
+created-by: alice@canonical.com
+created-at: .*
+models:
+- model-uuid: `+mt2.Id()+`
+  status: pending
+- model-uuid: `+mt1.Id()+`
+  status: pending
+`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewAddMigrationPlanCommandForTesting(s.ClientStore(), bClient), "overnight", "controller-2", mt1.Id())
+	c.Assert(err, gc.ErrorMatches, `migration plan "overnight" already exists`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewAddMigrationPlanCommandForTesting(s.ClientStore(), bClient), "other", "controller-1", mt1.Id())
+	c.Assert(err, gc.ErrorMatches, `model ".*" is already hosted on controller "controller-1"`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewUpdateMigrationPlanCommandForTesting(s.ClientStore(), bClient, "pause"), "overnight")
+	c.Assert(err, gc.IsNil)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewUpdateMigrationPlanCommandForTesting(s.ClientStore(), bClient, "pause"), "overnight")
+	c.Assert(err, gc.ErrorMatches, `migration plan "overnight" is paused`)
+
+	ctxt, err = cmdtesting.RunCommand(c, cmd.NewShowMigrationPlanCommandForTesting(s.ClientStore(), bClient), "overnight", "--format", "json")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctxt), gc.Matches, `.*"status":"paused","status-message":"paused by alice@canonical.com".*`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewUpdateMigrationPlanCommandForTesting(s.ClientStore(), bClient, "resume"), "overnight")
+	c.Assert(err, gc.IsNil)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewUpdateMigrationPlanCommandForTesting(s.ClientStore(), bClient, "cancel"), "overnight")
+	c.Assert(err, gc.IsNil)
+
+	ctxt, err = cmdtesting.RunCommand(c, cmd.NewListMigrationPlansCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctxt), gc.Matches, `(?s)- name: overnight
+.*  status: cancelled
+  status-message: cancelled by alice@canonical.com
+.*`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewShowMigrationPlanCommandForTesting(s.ClientStore(), bClient), "no-such-plan")
+	c.Assert(err, gc.ErrorMatches, `migration plan not found`)
+}
+
+func (s *migrationPlanSuite) TestMigrationPlansUnauthorized(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// bob is not superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewAddMigrationPlanCommandForTesting(s.ClientStore(), bClient), "overnight", "controller-1", "00000002-0000-0000-0000-000000000001")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewListMigrationPlansCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewUpdateMigrationPlanCommandForTesting(s.ClientStore(), bClient, "pause"), "overnight")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *migrationPlanSuite) TestAddMigrationPlanInvalidArgs(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewAddMigrationPlanCommandForTesting(s.ClientStore(), bClient), "overnight", "controller-1")
+	c.Assert(err, gc.ErrorMatches, `plan name, controller name and model uuids must be specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewAddMigrationPlanCommandForTesting(s.ClientStore(), bClient), "overnight", "controller-1", "001")
+	c.Assert(err, gc.ErrorMatches, `invalid model uuid 001`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewAddMigrationPlanCommandForTesting(s.ClientStore(), bClient), "overnight", "controller-1", "00000002-0000-0000-0000-000000000001", "--start-at", "tomorrow")
+	c.Assert(err, gc.ErrorMatches, `invalid start time`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewAddMigrationPlanCommandForTesting(s.ClientStore(), bClient), "overnight", "controller-1", "00000002-0000-0000-0000-000000000001", "--max-concurrency", "0")
+	c.Assert(err, gc.ErrorMatches, `max-concurrency must be at least 1`)
+}
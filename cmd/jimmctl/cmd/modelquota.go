@@ -0,0 +1,209 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	setModelQuotaDoc = `
+	set-model-quota sets the model quota for a user, service account or
+	group. A quota assigned to a group applies to each member of the
+	group individually. If --cloud is specified the quota only applies
+	to models on that cloud, otherwise it applies to models on all
+	clouds. Any existing quota for the same entity and cloud is replaced.
+
+	The maximum number of models is enforced when models are added. The
+	limits on machines, cores and units are soft limits that are reported
+	when they are exceeded. Limits that are not specified are unlimited.
+
+	Example:
+		jimmctl set-model-quota user-alice@canonical.com --max-models 10
+		jimmctl set-model-quota group-devops --cloud aws --max-models 5 --max-cores 64
+`
+
+	removeModelQuotaDoc = `
+	remove-model-quota removes the model quota for a user, service account
+	or group. If --cloud is specified the quota for that cloud is removed,
+	otherwise the quota that applies to all clouds is removed.
+
+	Example:
+		jimmctl remove-model-quota user-alice@canonical.com
+		jimmctl remove-model-quota group-devops --cloud aws
+`
+)
+
+// NewSetModelQuotaCommand returns a command to set a model quota.
+func NewSetModelQuotaCommand() cmd.Command {
+	cmd := &setModelQuotaCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setModelQuotaCommand sets a model quota.
+type setModelQuotaCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req apiparams.SetModelQuotaRequest
+}
+
+// Info implements Command.Info.
+func (c *setModelQuotaCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-model-quota",
+		Args:    "<entity>",
+		Purpose: "Set a model quota.",
+		Doc:     setModelQuotaDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setModelQuotaCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.req.Cloud, "cloud", "", "cloud the quota applies to")
+	f.Var(quotaLimitValue{&c.req.MaxModels}, "max-models", "maximum number of models")
+	f.Var(quotaLimitValue{&c.req.MaxMachines}, "max-machines", "maximum number of machines in all models")
+	f.Var(quotaLimitValue{&c.req.MaxCores}, "max-cores", "maximum number of cores in all models")
+	f.Var(quotaLimitValue{&c.req.MaxUnits}, "max-units", "maximum number of units in all models")
+}
+
+// Init implements the cmd.Command interface.
+func (c *setModelQuotaCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing entity")
+	}
+	c.req.Entity, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	if c.req.MaxModels == nil && c.req.MaxMachines == nil && c.req.MaxCores == nil && c.req.MaxUnits == nil {
+		return errors.E("at least one limit must be specified")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setModelQuotaCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.SetModelQuota(&c.req); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// NewRemoveModelQuotaCommand returns a command to remove a model quota.
+func NewRemoveModelQuotaCommand() cmd.Command {
+	cmd := &removeModelQuotaCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeModelQuotaCommand removes a model quota.
+type removeModelQuotaCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	req apiparams.RemoveModelQuotaRequest
+}
+
+// Info implements Command.Info.
+func (c *removeModelQuotaCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-model-quota",
+		Args:    "<entity>",
+		Purpose: "Remove a model quota.",
+		Doc:     removeModelQuotaDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeModelQuotaCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.StringVar(&c.req.Cloud, "cloud", "", "cloud the quota applies to")
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeModelQuotaCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing entity")
+	}
+	c.req.Entity, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeModelQuotaCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.RemoveModelQuota(&c.req); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// quotaLimitValue is a gnuflag.Value that sets an optional quota limit
+// only when the flag is specified.
+type quotaLimitValue struct {
+	v **int64
+}
+
+// Set implements gnuflag.Value.
+func (v quotaLimitValue) Set(s string) error {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return errors.E("invalid limit " + strconv.Quote(s))
+	}
+	*v.v = &n
+	return nil
+}
+
+// String implements gnuflag.Value.
+func (v quotaLimitValue) String() string {
+	if v.v == nil || *v.v == nil {
+		return ""
+	}
+	return strconv.FormatInt(**v.v, 10)
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type modelQuotaSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&modelQuotaSuite{})
+
+func (s *modelQuotaSuite) TestSetAndRemoveModelQuotaSuperuser(c *gc.C) {
+	ctx := context.Background()
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetModelQuotaCommandForTesting(s.ClientStore(), bClient), "user-alice@canonical.com", "--max-models", "2", "--max-cores", "8")
+	c.Assert(err, gc.IsNil)
+
+	quotas, err := s.JIMM.Database.GetModelQuotas(ctx, "alice@canonical.com", nil)
+	c.Assert(err, gc.IsNil)
+	c.Assert(quotas, gc.HasLen, 1)
+	c.Check(quotas[0].MaxModels.Int64, gc.Equals, int64(2))
+	c.Check(quotas[0].MaxCores.Int64, gc.Equals, int64(8))
+	c.Check(quotas[0].MaxMachines.Valid, gc.Equals, false)
+	c.Check(quotas[0].MaxUnits.Valid, gc.Equals, false)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRemoveModelQuotaCommandForTesting(s.ClientStore(), bClient), "user-alice@canonical.com")
+	c.Assert(err, gc.IsNil)
+
+	quotas, err = s.JIMM.Database.GetModelQuotas(ctx, "alice@canonical.com", nil)
+	c.Assert(err, gc.IsNil)
+	c.Check(quotas, gc.HasLen, 0)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRemoveModelQuotaCommandForTesting(s.ClientStore(), bClient), "user-alice@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `model quota not found`)
+}
+
+func (s *modelQuotaSuite) TestSetModelQuota(c *gc.C) {
+	// bob is not superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetModelQuotaCommandForTesting(s.ClientStore(), bClient), "user-bob@canonical.com", "--max-models", "100")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *modelQuotaSuite) TestSetModelQuotaInvalidArguments(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetModelQuotaCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `missing entity`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetModelQuotaCommandForTesting(s.ClientStore(), bClient), "user-alice@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `at least one limit must be specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetModelQuotaCommandForTesting(s.ClientStore(), bClient), "user-alice@canonical.com", "--max-models", "many")
+	c.Assert(err, gc.ErrorMatches, `invalid value "many" for flag --max-models: invalid limit "many"`)
+}
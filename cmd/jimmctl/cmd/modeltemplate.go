@@ -0,0 +1,369 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	modelTemplateDoc = `
+model-template command enables model template management for jimm.
+
+Model templates bundle the cloud, region, cloud credentials, model config,
+group access and labels to use when adding a model. To add a model using a
+template set the jimm-model-template model config value to the name of the
+template, for example:
+
+	juju add-model mymodel --config jimm-model-template=production
+
+Any cloud, region, credential or config values specified when adding the
+model override those in the template.
+`
+
+	modelTemplateFileDoc = `
+The template file is a YAML document of the following form, all fields
+are optional:
+
+	description: Production models
+	cloud: aws
+	region: eu-west-1
+	credentials:
+	- production
+	- default
+	config:
+	  logging-config: <root>=INFO
+	access:
+	  sre: writer
+	  developers: reader
+	labels:
+	  env: prod
+
+Credentials are the names of the model owner's cloud credentials to use, in
+order of preference. Access grants the members of each group either reader
+or writer access to the model.
+`
+
+	addModelTemplateDoc = `
+add command adds a model template to jimm.
+` + modelTemplateFileDoc + `
+Example:
+	jimmctl model-template add <name> <filename>
+`
+
+	updateModelTemplateDoc = `
+update command replaces an existing model template in jimm. Models already
+created from the template are not changed.
+` + modelTemplateFileDoc + `
+Example:
+	jimmctl model-template update <name> <filename>
+`
+
+	removeModelTemplateDoc = `
+remove command removes a model template from jimm.
+
+Example:
+	jimmctl model-template remove <name>
+`
+
+	listModelTemplatesDoc = `
+list command lists all model templates in jimm.
+
+Example:
+	jimmctl model-template list
+`
+
+	showModelTemplateDoc = `
+show command shows a model template.
+
+Example:
+	jimmctl model-template show <name>
+`
+)
+
+// NewModelTemplateCommand returns a command for model template management.
+func NewModelTemplateCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "model-template",
+		Doc:     modelTemplateDoc,
+		Purpose: "Model template management.",
+	})
+	cmd.Register(newAddModelTemplateCommand())
+	cmd.Register(newUpdateModelTemplateCommand())
+	cmd.Register(newRemoveModelTemplateCommand())
+	cmd.Register(newListModelTemplatesCommand())
+	cmd.Register(newShowModelTemplateCommand())
+
+	return cmd
+}
+
+// newAddModelTemplateCommand returns a command to add a model template.
+func newAddModelTemplateCommand() cmd.Command {
+	cmd := &setModelTemplateCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// newUpdateModelTemplateCommand returns a command to update a model
+// template.
+func newUpdateModelTemplateCommand() cmd.Command {
+	cmd := &setModelTemplateCommand{
+		store:  jujuclient.NewFileClientStore(),
+		update: true,
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setModelTemplateCommand adds or updates a model template.
+type setModelTemplateCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	file     cmd.FileVar
+
+	name   string
+	update bool
+}
+
+// Info implements the cmd.Command interface.
+func (c *setModelTemplateCommand) Info() *cmd.Info {
+	if c.update {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "update",
+			Args:    "<name> <filename>",
+			Purpose: "Update a model template.",
+			Doc:     updateModelTemplateDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add",
+		Args:    "<name> <filename>",
+		Purpose: "Add a model template.",
+		Doc:     addModelTemplateDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setModelTemplateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.file.StdinMarkers = stdinMarkers
+}
+
+// Init implements the cmd.Command interface.
+func (c *setModelTemplateCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("template name and filename must be specified")
+	}
+	c.name, c.file.Path, args = args[0], args[1], args[2:]
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setModelTemplateCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	var template apiparams.ModelTemplate
+	if err := unmarshalYAMLFile(ctxt, &template, c.file); err != nil {
+		return errors.E(err)
+	}
+	template.Name = c.name
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if c.update {
+		err = client.UpdateModelTemplate(&apiparams.UpdateModelTemplateRequest{ModelTemplate: template})
+	} else {
+		err = client.AddModelTemplate(&apiparams.AddModelTemplateRequest{ModelTemplate: template})
+	}
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newRemoveModelTemplateCommand returns a command to remove a model
+// template.
+func newRemoveModelTemplateCommand() cmd.Command {
+	cmd := &removeModelTemplateCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeModelTemplateCommand removes a model template.
+type removeModelTemplateCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	name string
+}
+
+// Info implements the cmd.Command interface.
+func (c *removeModelTemplateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove",
+		Args:    "<name>",
+		Purpose: "Remove a model template.",
+		Doc:     removeModelTemplateDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeModelTemplateCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("template name not specified")
+	}
+	c.name, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeModelTemplateCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.RemoveModelTemplate(&apiparams.RemoveModelTemplateRequest{
+		Name: c.name,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newListModelTemplatesCommand returns a command to list all model
+// templates.
+func newListModelTemplatesCommand() cmd.Command {
+	cmd := &listModelTemplatesCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// newShowModelTemplateCommand returns a command to show a model
+// template.
+func newShowModelTemplateCommand() cmd.Command {
+	cmd := &listModelTemplatesCommand{
+		store: jujuclient.NewFileClientStore(),
+		show:  true,
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listModelTemplatesCommand lists all model templates, or shows a single
+// model template.
+type listModelTemplatesCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	name string
+	show bool
+}
+
+// Info implements the cmd.Command interface.
+func (c *listModelTemplatesCommand) Info() *cmd.Info {
+	if c.show {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "show",
+			Args:    "<name>",
+			Purpose: "Show a model template.",
+			Doc:     showModelTemplateDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List all model templates.",
+		Doc:     listModelTemplatesDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listModelTemplatesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *listModelTemplatesCommand) Init(args []string) error {
+	if c.show {
+		if len(args) < 1 {
+			return errors.E("template name not specified")
+		}
+		c.name, args = args[0], args[1:]
+	}
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listModelTemplatesCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.ListModelTemplates(&apiparams.ListModelTemplatesRequest{
+		Name: c.name,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	if c.show && len(resp.Templates) == 1 {
+		return c.out.Write(ctxt, resp.Templates[0])
+	}
+	return c.out.Write(ctxt, resp.Templates)
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type modelTemplateSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&modelTemplateSuite{})
+
+const testModelTemplate = `description: Production models
+cloud: ` + jimmtest.TestCloudName + `
+region: ` + jimmtest.TestCloudRegionName + `
+credentials:
+- production
+config:
+  logging-config: <root>=INFO
+access:
+  sre: writer
+labels:
+  env: prod
+`
+
+func (s *modelTemplateSuite) writeTemplate(c *gc.C, data string) string {
+	fn := filepath.Join(c.MkDir(), "template.yaml")
+	err := os.WriteFile(fn, []byte(data), 0600)
+	c.Assert(err, gc.IsNil)
+	return fn
+}
+
+func (s *modelTemplateSuite) TestModelTemplates(c *gc.C) {
+	ctx := context.Background()
+	s.AddController(c, "controller-1", s.APIInfo(c))
+	_, err := s.JIMM.Database.AddGroup(ctx, "sre")
+	c.Assert(err, gc.IsNil)
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err = cmdtesting.RunCommand(c, cmd.NewAddModelTemplateCommandForTesting(s.ClientStore(), bClient), "production", s.writeTemplate(c, testModelTemplate))
+	c.Assert(err, gc.IsNil)
+
+	t := dbmodel.ModelTemplate{Name: "production"}
+	err = s.JIMM.Database.GetModelTemplate(ctx, &t)
+	c.Assert(err, gc.IsNil)
+	c.Check(t.CloudName.String, gc.Equals, jimmtest.TestCloudName)
+	c.Check(t.CloudRegion, gc.Equals, jimmtest.TestCloudRegionName)
+	c.Check([]string(t.CloudCredentials), gc.DeepEquals, []string{"production"})
+	c.Check(map[string]string(t.Access), gc.DeepEquals, map[string]string{"sre": "writer"})
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewAddModelTemplateCommandForTesting(s.ClientStore(), bClient), "production", s.writeTemplate(c, testModelTemplate))
+	c.Assert(err, gc.ErrorMatches, `model template "production" already exists`)
+
+	result, err := cmdtesting.RunCommand(c, cmd.NewShowModelTemplateCommandForTesting(s.ClientStore(), bClient), "production")
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(result), gc.Equals, `name: production
+description: Production models
+cloud: dummy
+region: dummy-region
+credentials:
+- production
+config:
+  logging-config: <root>=INFO
+access:
+  sre: writer
+labels:
+  env: prod
+`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewUpdateModelTemplateCommandForTesting(s.ClientStore(), bClient), "production", s.writeTemplate(c, "description: Updated\n"))
+	c.Assert(err, gc.IsNil)
+
+	result, err = cmdtesting.RunCommand(c, cmd.NewListModelTemplatesCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(result), gc.Equals, `- name: production
+  description: Updated
+`)
+
+	// bob is not a superuser, but can list templates.
+	bobClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err = cmdtesting.RunCommand(c, cmd.NewListModelTemplatesCommandForTesting(s.ClientStore(), bobClient))
+	c.Assert(err, gc.IsNil)
+	_, err = cmdtesting.RunCommand(c, cmd.NewRemoveModelTemplateCommandForTesting(s.ClientStore(), bobClient), "production")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRemoveModelTemplateCommandForTesting(s.ClientStore(), bClient), "production")
+	c.Assert(err, gc.IsNil)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewShowModelTemplateCommandForTesting(s.ClientStore(), bClient), "production")
+	c.Assert(err, gc.ErrorMatches, `model template not found`)
+}
+
+func (s *modelTemplateSuite) TestAddModelTemplateInvalid(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewAddModelTemplateCommandForTesting(s.ClientStore(), bClient), "production")
+	c.Assert(err, gc.ErrorMatches, `template name and filename must be specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewAddModelTemplateCommandForTesting(s.ClientStore(), bClient), "production", s.writeTemplate(c, "access:\n  sre: admin\n"))
+	c.Assert(err, gc.ErrorMatches, `invalid access "admin" for group "sre", expected reader or writer`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewAddModelTemplateCommandForTesting(s.ClientStore(), bClient), "production", s.writeTemplate(c, "region: somewhere\n"))
+	c.Assert(err, gc.ErrorMatches, `region specified without a cloud`)
+}
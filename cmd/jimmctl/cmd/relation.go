@@ -82,6 +82,24 @@ If target_object is an application offer, the relation can be one of:
 	consumer
 	administrator
 
+If target_object is a cloud credential, of the form "cloudcred-<cloud>/<owner>/<name>",
+the relation can be one of:
+
+	user
+	administrator
+
+Users of a cloud credential may select it when adding models, administrators may
+also update it.
+
+If target_object is a cloud region, of the form "cloudregion-<cloud>/<region>",
+the relation can only be:
+
+	can_addmodel
+
+Users allowed to add models to a cloud region may only add models to that
+region, users allowed to add models to the cloud may add models to all of
+its regions.
+
 
 Additionally, if the object is a group, a userset can be applied by adding #member as follows.
 This will grant/revoke the relation to all users within TeamA:
@@ -0,0 +1,95 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var rotateControllerCredentialsDoc = `
+	rotate-controller-credentials generates a new password for the user
+	JIMM uses to connect to a controller. The new password is set on the
+	controller and stored by JIMM. If JIMM cannot log in to the controller
+	with the new password the previous password is restored.
+
+	Example:
+		jimmctl rotate-controller-credentials <name>
+`
+
+// NewRotateControllerCredentialsCommand returns a command used to rotate
+// the credentials JIMM uses to connect to a controller.
+func NewRotateControllerCredentialsCommand() cmd.Command {
+	cmd := &rotateControllerCredentialsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// rotateControllerCredentialsCommand rotates the credentials JIMM uses
+// to connect to a controller.
+type rotateControllerCredentialsCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	controllerName string
+}
+
+func (c *rotateControllerCredentialsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "rotate-controller-credentials",
+		Purpose: "Rotates the credentials JIMM uses to connect to a controller.",
+		Doc:     rotateControllerCredentialsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *rotateControllerCredentialsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+}
+
+// Init implements the cmd.Command interface.
+func (c *rotateControllerCredentialsCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing controller name")
+	}
+	c.controllerName, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *rotateControllerCredentialsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.RotateControllerCredentials(&apiparams.RotateControllerCredentialsRequest{
+		Name: c.controllerName,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+
+	return nil
+}
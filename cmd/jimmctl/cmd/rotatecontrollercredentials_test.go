@@ -0,0 +1,49 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/cmdtest"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+type rotateControllerCredentialsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&rotateControllerCredentialsSuite{})
+
+func (s *rotateControllerCredentialsSuite) TestRotateControllerCredentialsSuperuser(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// alice is superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRotateControllerCredentialsCommandForTesting(s.ClientStore(), bClient), "controller-1")
+	c.Assert(err, gc.IsNil)
+
+	username, password, err := s.JIMM.CredentialStore.GetControllerCredentials(context.Background(), "controller-1")
+	c.Assert(err, gc.IsNil)
+	c.Check(username, gc.Equals, s.APIInfo(c).Tag.Id())
+	c.Check(password, gc.Not(gc.Equals), s.APIInfo(c).Password)
+}
+
+func (s *rotateControllerCredentialsSuite) TestRotateControllerCredentials(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// bob is not superuser
+	bClient := jimmtest.NewUserSessionLogin(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRotateControllerCredentialsCommandForTesting(s.ClientStore(), bClient), "controller-1")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *rotateControllerCredentialsSuite) TestMissingControllerName(c *gc.C) {
+	bClient := jimmtest.NewUserSessionLogin(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRotateControllerCredentialsCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `missing controller name`)
+}
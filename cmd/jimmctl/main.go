@@ -21,16 +21,30 @@ func NewSuperCommand() *jujucmd.SuperCommand {
 		Doc:  jimmctlDoc,
 	})
 	jimmcmd.Register(cmd.NewAddControllerCommand())
+	jimmcmd.Register(cmd.NewCloudCatalogueCommand())
 	jimmcmd.Register(cmd.NewControllerInfoCommand())
+	jimmcmd.Register(cmd.NewControllerVersionsCommand())
+	jimmcmd.Register(cmd.NewCredentialVersionsCommand())
+	jimmcmd.Register(cmd.NewEffectiveModelConfigCommand())
 	jimmcmd.Register(cmd.NewGrantAuditLogAccessCommand())
+	jimmcmd.Register(cmd.NewGroupModelDefaultsCommand())
 	jimmcmd.Register(cmd.NewImportCloudCredentialsCommand())
+	jimmcmd.Register(cmd.NewImportControllersCommand())
 	jimmcmd.Register(cmd.NewImportModelCommand())
 	jimmcmd.Register(cmd.NewListAuditEventsCommand())
 	jimmcmd.Register(cmd.NewListControllersCommand())
+	jimmcmd.Register(cmd.NewListDeletedModelsCommand())
+	jimmcmd.Register(cmd.NewMigrationPlanCommand())
 	jimmcmd.Register(cmd.NewModelStatusCommand())
+	jimmcmd.Register(cmd.NewModelTemplateCommand())
 	jimmcmd.Register(cmd.NewRemoveControllerCommand())
+	jimmcmd.Register(cmd.NewRemoveModelQuotaCommand())
 	jimmcmd.Register(cmd.NewRevokeAuditLogAccessCommand())
+	jimmcmd.Register(cmd.NewRotateControllerCredentialsCommand())
 	jimmcmd.Register(cmd.NewSetControllerDeprecatedCommand())
+	jimmcmd.Register(cmd.NewSetGroupModelDefaultsCommand())
+	jimmcmd.Register(cmd.NewSetModelQuotaCommand())
+	jimmcmd.Register(cmd.NewUnsetGroupModelDefaultsCommand())
 	jimmcmd.Register(cmd.NewUpdateMigratedModelCommand())
 	jimmcmd.Register(cmd.NewAddCloudToControllerCommand())
 	jimmcmd.Register(cmd.NewRemoveCloudFromControllerCommand())
@@ -0,0 +1,93 @@
+// Copyright 2024 Canonical.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	jimmsvc "github.com/canonical/jimm/v3/cmd/jimmsrv/service"
+	"github.com/canonical/jimm/v3/internal/jimm"
+)
+
+const migrateCredentialStoreUsage = `usage: jimmsrv migrate-credential-store --from <store> --to <store> [--dry-run]
+
+Copies the cloud credentials, controller credentials and JWKS material
+held in one credential store to another. The store is one of "database",
+"encrypted" or "vault", and is configured using the same environment
+variables as the JIMM server (JIMM_DSN, JIMM_SECRET_ENCRYPTION_KEYS,
+JIMM_SECRET_ENCRYPTION_KEYS_FILE, JIMM_SECRET_ALLOW_PLAINTEXT, VAULT_ADDR,
+VAULT_PATH, VAULT_ROLE_ID, VAULT_ROLE_SECRET_ID, VAULT_AUTH_METHOD,
+VAULT_AUTH_ROLE, VAULT_AUTH_MOUNT and VAULT_AUTH_TOKEN_PATH).
+
+Every copied secret is verified by reading it back and comparing it with
+the source. Only the outcome for each secret is reported, never its
+value. Secrets already present in the destination are skipped, so an
+interrupted migration can be resumed by running it again. The source
+store is not modified.
+
+`
+
+// migrateCredentialStore runs the migrate-credential-store subcommand
+// with the given arguments, returning the process exit code.
+func migrateCredentialStore(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("migrate-credential-store", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), migrateCredentialStoreUsage)
+		fs.PrintDefaults()
+	}
+	from := fs.String("from", "", `credential store to copy from, "database", "encrypted" or "vault"`)
+	to := fs.String("to", "", `credential store to copy to, "database", "encrypted" or "vault"`)
+	dryRun := fs.Bool("dry-run", false, "report what would be copied without writing anything")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *from == "" || *to == "" || fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	_, secretAllowPlaintext := os.LookupEnv("JIMM_SECRET_ALLOW_PLAINTEXT")
+	results, err := jimmsvc.MigrateCredentialStore(ctx, jimmsvc.Params{
+		DSN:                      os.Getenv("JIMM_DSN"),
+		VaultRoleID:              os.Getenv("VAULT_ROLE_ID"),
+		VaultRoleSecretID:        os.Getenv("VAULT_ROLE_SECRET_ID"),
+		VaultAuthMethod:          os.Getenv("VAULT_AUTH_METHOD"),
+		VaultAuthRole:            os.Getenv("VAULT_AUTH_ROLE"),
+		VaultAuthMount:           os.Getenv("VAULT_AUTH_MOUNT"),
+		VaultAuthTokenPath:       os.Getenv("VAULT_AUTH_TOKEN_PATH"),
+		VaultAddress:             os.Getenv("VAULT_ADDR"),
+		VaultPath:                os.Getenv("VAULT_PATH"),
+		SecretEncryptionKeys:     os.Getenv("JIMM_SECRET_ENCRYPTION_KEYS"),
+		SecretEncryptionKeysFile: os.Getenv("JIMM_SECRET_ENCRYPTION_KEYS_FILE"),
+		SecretAllowPlaintext:     secretAllowPlaintext,
+	}, *from, *to, *dryRun)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tSTATUS\tERROR")
+	failed := 0
+	for _, r := range results {
+		var msg string
+		if r.Err != nil {
+			msg = r.Err.Error()
+		}
+		if r.Status == jimm.SecretFailed {
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Kind, r.Name, r.Status, msg)
+	}
+	w.Flush()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %s\n", err)
+		return 1
+	}
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "error: %d secrets could not be migrated\n", failed)
+		return 1
+	}
+	return 0
+}
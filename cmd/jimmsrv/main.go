@@ -4,6 +4,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"os"
@@ -22,6 +23,9 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate-credential-store" {
+		os.Exit(migrateCredentialStore(context.Background(), os.Args[2:]))
+	}
 	ctx, s := service.NewService(context.Background(), os.Interrupt, syscall.SIGTERM)
 	s.Go(func() error {
 		return start(ctx, s)
@@ -123,6 +127,11 @@ func start(ctx context.Context, s *service.Service) error {
 		insecureSecretStorage = true
 	}
 
+	secretAllowPlaintext := false
+	if _, ok := os.LookupEnv("JIMM_SECRET_ALLOW_PLAINTEXT"); ok {
+		secretAllowPlaintext = true
+	}
+
 	secureSessionCookies := false
 	if _, ok := os.LookupEnv("JIMM_SECURE_SESSION_COOKIES"); ok {
 		secureSessionCookies = true
@@ -142,16 +151,59 @@ func start(ctx context.Context, s *service.Service) error {
 		return errors.E("jimm session store secret must be at least 64 characters")
 	}
 
+	controllerConnectionParams, err := controllerConnectionParamsFromEnv()
+	if err != nil {
+		zapctx.Error(ctx, "failed to parse controller connection parameters", zap.Error(err))
+		return err
+	}
+
+	modelReaperParams, err := modelReaperParamsFromEnv()
+	if err != nil {
+		zapctx.Error(ctx, "failed to parse model reaper parameters", zap.Error(err))
+		return err
+	}
+
+	var deletedModelRetentionPeriod time.Duration
+	if v := os.Getenv("JIMM_DELETED_MODEL_RETENTION_PERIOD"); v != "" {
+		deletedModelRetentionPeriod, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse deleted model retention period", zap.Error(err))
+			return err
+		}
+	}
+
+	var migrationTimeout time.Duration
+	if v := os.Getenv("JIMM_MIGRATION_TIMEOUT"); v != "" {
+		migrationTimeout, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse migration timeout", zap.Error(err))
+			return err
+		}
+	}
+
+	var credentialCheckInterval time.Duration
+	if v := os.Getenv("JIMM_CREDENTIAL_CHECK_INTERVAL"); v != "" {
+		credentialCheckInterval, err = time.ParseDuration(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse credential check interval", zap.Error(err))
+			return err
+		}
+	}
+
 	jimmsvc, err := jimmsvc.NewService(ctx, jimmsvc.Params{
-		ControllerUUID:    os.Getenv("JIMM_UUID"),
-		DSN:               os.Getenv("JIMM_DSN"),
-		ControllerAdmins:  strings.Fields(os.Getenv("JIMM_ADMINS")),
-		VaultRoleID:       os.Getenv("VAULT_ROLE_ID"),
-		VaultRoleSecretID: os.Getenv("VAULT_ROLE_SECRET_ID"),
-		VaultAddress:      os.Getenv("VAULT_ADDR"),
-		VaultPath:         os.Getenv("VAULT_PATH"),
-		DashboardLocation: os.Getenv("JIMM_DASHBOARD_LOCATION"),
-		PublicDNSName:     os.Getenv("JIMM_DNS_NAME"),
+		ControllerUUID:     os.Getenv("JIMM_UUID"),
+		DSN:                os.Getenv("JIMM_DSN"),
+		ControllerAdmins:   strings.Fields(os.Getenv("JIMM_ADMINS")),
+		VaultRoleID:        os.Getenv("VAULT_ROLE_ID"),
+		VaultRoleSecretID:  os.Getenv("VAULT_ROLE_SECRET_ID"),
+		VaultAuthMethod:    os.Getenv("VAULT_AUTH_METHOD"),
+		VaultAuthRole:      os.Getenv("VAULT_AUTH_ROLE"),
+		VaultAuthMount:     os.Getenv("VAULT_AUTH_MOUNT"),
+		VaultAuthTokenPath: os.Getenv("VAULT_AUTH_TOKEN_PATH"),
+		VaultAddress:       os.Getenv("VAULT_ADDR"),
+		VaultPath:          os.Getenv("VAULT_PATH"),
+		DashboardLocation:  os.Getenv("JIMM_DASHBOARD_LOCATION"),
+		PublicDNSName:      os.Getenv("JIMM_DNS_NAME"),
 		OpenFGAParams: jimmsvc.OpenFGAParams{
 			Scheme:    os.Getenv("OPENFGA_SCHEME"),
 			Host:      os.Getenv("OPENFGA_HOST"),
@@ -166,6 +218,9 @@ func start(ctx context.Context, s *service.Service) error {
 		MacaroonExpiryDuration:        macaroonExpiryDuration,
 		JWTExpiryDuration:             jwtExpiryDuration,
 		InsecureSecretStorage:         insecureSecretStorage,
+		SecretEncryptionKeys:          os.Getenv("JIMM_SECRET_ENCRYPTION_KEYS"),
+		SecretEncryptionKeysFile:      os.Getenv("JIMM_SECRET_ENCRYPTION_KEYS_FILE"),
+		SecretAllowPlaintext:          secretAllowPlaintext,
 		OAuthAuthenticatorParams: jimmsvc.OAuthAuthenticatorParams{
 			IssuerURL:           issuerURL,
 			ClientID:            clientID,
@@ -175,9 +230,15 @@ func start(ctx context.Context, s *service.Service) error {
 			SessionCookieMaxAge: sessionCookieMaxAgeInt,
 			JWTSessionKey:       sessionSecretKey,
 		},
-		DashboardFinalRedirectURL: os.Getenv("JIMM_DASHBOARD_FINAL_REDIRECT_URL"),
-		SecureSessionCookies:      secureSessionCookies,
-		CookieSessionKey:          []byte(sessionSecretKey),
+		DashboardFinalRedirectURL:   os.Getenv("JIMM_DASHBOARD_FINAL_REDIRECT_URL"),
+		SecureSessionCookies:        secureSessionCookies,
+		CookieSessionKey:            []byte(sessionSecretKey),
+		ControllerConnectionParams:  controllerConnectionParams,
+		ModelReaperParams:           modelReaperParams,
+		DeletedModelRetentionPeriod: deletedModelRetentionPeriod,
+		MigrationTimeout:            migrationTimeout,
+		CredentialCheckInterval:     credentialCheckInterval,
+		CredentialNotificationURL:   os.Getenv("JIMM_CREDENTIAL_NOTIFICATION_URL"),
 	})
 	if err != nil {
 		return err
@@ -186,8 +247,24 @@ func start(ctx context.Context, s *service.Service) error {
 	isLeader := os.Getenv("JIMM_IS_LEADER") != ""
 	if isLeader {
 		s.Go(func() error { return jimmsvc.WatchControllers(ctx) }) // Deletes dead/dying models, updates model config.
+		// Destroys expired ephemeral models.
+		s.Go(func() error { return jimmsvc.ReapExpiredModels(ctx) })
+		// Purges the tombstones of deleted models.
+		s.Go(func() error { return jimmsvc.PurgeDeletedModels(ctx) })
+		// Performs scheduled model migrations.
+		s.Go(func() error { return jimmsvc.RunMigrationPlans(ctx) })
+		// Checks that cloud credentials are still valid.
+		s.Go(func() error { return jimmsvc.RevalidateCloudCredentials(ctx) })
+		// Rolls back abandoned cloud credential rotations.
+		s.Go(func() error { return jimmsvc.RecoverCloudCredentialRotations(ctx) })
+		// Re-encrypts secrets stored with an old encryption key.
+		s.Go(func() error { return jimmsvc.ReencryptSecrets(ctx) })
 	}
 	s.Go(func() error { return jimmsvc.WatchModelSummaries(ctx) })
+	// Keeps the vault token renewed.
+	s.Go(func() error { return jimmsvc.RenewVaultToken(ctx) })
+	// Closes cached controller connections that have expired.
+	s.Go(func() error { return jimmsvc.EvictControllerConnections(ctx) })
 
 	if isLeader {
 		zapctx.Info(ctx, "attempting to start JWKS rotator and generate OAuth secret key")
@@ -225,3 +302,106 @@ func start(ctx context.Context, s *service.Service) error {
 	zapctx.Info(ctx, "Successfully started JIMM server")
 	return nil
 }
+
+// controllerConnectionParamsFromEnv reads the parameters used to configure
+// connections to juju controllers from the environment. Any unset values
+// are left as nil so that the service will use its defaults. A value of
+// zero disables the corresponding feature. The following environment
+// variables are read:
+//
+//   - JIMM_CONTROLLER_CONNECT_TIMEOUT: the time allowed for a single
+//     attempt to connect and log in to a controller (default 30s).
+//   - JIMM_CONTROLLER_CALL_TIMEOUT: the time allowed for a single RPC
+//     call to a controller (default 5m).
+//   - JIMM_CONTROLLER_DIAL_ATTEMPTS: the number of attempts made to
+//     connect to an unreachable controller (default 3).
+//   - JIMM_CONTROLLER_DIAL_INITIAL_BACKOFF: the wait before retrying a
+//     failed connection attempt, doubling after each failure
+//     (default 500ms).
+//   - JIMM_CONTROLLER_DIAL_MAX_BACKOFF: the maximum wait between
+//     connection attempts (default 5s).
+//   - JIMM_CONTROLLER_BREAKER_THRESHOLD: the number of consecutive
+//     connection failures after which a controller is considered down
+//     (default 5).
+//   - JIMM_CONTROLLER_BREAKER_COOLDOWN: the time for which connections
+//     to a controller that is down fail immediately (default 30s).
+//   - JIMM_CONTROLLER_POOL_SIZE: the number of cached connections kept
+//     to each controller (default 4).
+//   - JIMM_CONTROLLER_POOL_IDLE_TIMEOUT: the time an unused cached
+//     connection is kept (default 10m).
+//   - JIMM_CONTROLLER_POOL_MAX_LIFETIME: the maximum age of a cached
+//     connection (default 1h).
+func controllerConnectionParamsFromEnv() (jimmsvc.ControllerConnectionParams, error) {
+	var p jimmsvc.ControllerConnectionParams
+	durations := []struct {
+		env string
+		d   **time.Duration
+	}{
+		{"JIMM_CONTROLLER_CONNECT_TIMEOUT", &p.ConnectTimeout},
+		{"JIMM_CONTROLLER_CALL_TIMEOUT", &p.CallTimeout},
+		{"JIMM_CONTROLLER_DIAL_INITIAL_BACKOFF", &p.InitialBackoff},
+		{"JIMM_CONTROLLER_DIAL_MAX_BACKOFF", &p.MaxBackoff},
+		{"JIMM_CONTROLLER_BREAKER_COOLDOWN", &p.BreakerCooldown},
+		{"JIMM_CONTROLLER_POOL_IDLE_TIMEOUT", &p.PoolIdleTimeout},
+		{"JIMM_CONTROLLER_POOL_MAX_LIFETIME", &p.PoolMaxLifetime},
+	}
+	for _, d := range durations {
+		v := os.Getenv(d.env)
+		if v == "" {
+			continue
+		}
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			return p, errors.E(err, fmt.Sprintf("unable to parse %s", d.env))
+		}
+		*d.d = &dur
+	}
+	ints := []struct {
+		env string
+		i   **int
+	}{
+		{"JIMM_CONTROLLER_DIAL_ATTEMPTS", &p.DialAttempts},
+		{"JIMM_CONTROLLER_BREAKER_THRESHOLD", &p.BreakerThreshold},
+		{"JIMM_CONTROLLER_POOL_SIZE", &p.PoolSize},
+	}
+	for _, i := range ints {
+		v := os.Getenv(i.env)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return p, errors.E(err, fmt.Sprintf("unable to parse %s", i.env))
+		}
+		*i.i = &n
+	}
+	return p, nil
+}
+
+// modelReaperParamsFromEnv reads the parameters used to configure the
+// destruction of expired ephemeral models from the environment. Any unset
+// values are left as zero so that the service will use its defaults.
+func modelReaperParamsFromEnv() (jimmsvc.ModelReaperParams, error) {
+	var p jimmsvc.ModelReaperParams
+	durations := []struct {
+		env string
+		d   *time.Duration
+	}{
+		{"JIMM_MODEL_REAPER_INTERVAL", &p.Interval},
+		{"JIMM_MODEL_REAPER_WARNING_PERIOD", &p.WarningPeriod},
+		{"JIMM_MODEL_REAPER_MAX_WAIT", &p.MaxWait},
+	}
+	for _, d := range durations {
+		v := os.Getenv(d.env)
+		if v == "" {
+			continue
+		}
+		var err error
+		if *d.d, err = time.ParseDuration(v); err != nil {
+			return p, errors.E(err, fmt.Sprintf("unable to parse %s", d.env))
+		}
+	}
+	_, p.Force = os.LookupEnv("JIMM_MODEL_REAPER_FORCE")
+	_, p.DestroyStorage = os.LookupEnv("JIMM_MODEL_REAPER_DESTROY_STORAGE")
+	return p, nil
+}
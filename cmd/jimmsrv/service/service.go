@@ -6,6 +6,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -26,9 +27,11 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/auth"
 	"github.com/canonical/jimm/v3/internal/dashboard"
+	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/debugapi"
 	"github.com/canonical/jimm/v3/internal/discharger"
+	"github.com/canonical/jimm/v3/internal/encryptedstore"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
 	jimmcreds "github.com/canonical/jimm/v3/internal/jimm/credentials"
@@ -87,6 +90,76 @@ type OAuthAuthenticatorParams struct {
 	JWTSessionKey string
 }
 
+// ControllerConnectionParams holds parameters used to configure how JIMM
+// connects to juju controllers. Any nil values are replaced with
+// defaults. A zero value disables the corresponding feature, as
+// described for jujuclient.Dialer and jimm.CacheDialerParams.
+type ControllerConnectionParams struct {
+	// ConnectTimeout is the maximum time allowed for a single attempt
+	// to connect and log in to a controller. The default is 30s.
+	ConnectTimeout *time.Duration
+
+	// CallTimeout is the maximum time allowed for a single RPC call to
+	// a controller. The default is 5m.
+	CallTimeout *time.Duration
+
+	// DialAttempts is the number of attempts made to connect to an
+	// unreachable controller before giving up. The default is 3.
+	DialAttempts *int
+
+	// InitialBackoff is the time to wait before retrying a failed
+	// connection attempt, this doubles with every failure. The default
+	// is 500ms.
+	InitialBackoff *time.Duration
+
+	// MaxBackoff is the maximum time to wait between connection
+	// attempts. The default is 5s.
+	MaxBackoff *time.Duration
+
+	// BreakerThreshold is the number of consecutive connection failures
+	// after which a controller is considered down. The default is 5.
+	BreakerThreshold *int
+
+	// BreakerCooldown is the time for which connections to a controller
+	// that is considered down fail immediately. The default is 30s.
+	BreakerCooldown *time.Duration
+
+	// PoolSize is the maximum number of cached connections kept to each
+	// controller. The default is 4.
+	PoolSize *int
+
+	// PoolIdleTimeout is the time an unused cached connection is kept
+	// before it is closed. The default is 10m.
+	PoolIdleTimeout *time.Duration
+
+	// PoolMaxLifetime is the maximum age of a cached connection. The
+	// default is 1h.
+	PoolMaxLifetime *time.Duration
+}
+
+// ModelReaperParams holds parameters used to configure how JIMM destroys
+// expired ephemeral models. Any zero durations are replaced with
+// defaults.
+type ModelReaperParams struct {
+	// Interval is the time between checks for expired models.
+	Interval time.Duration
+
+	// WarningPeriod is how long before a model expires its owner is
+	// warned.
+	WarningPeriod time.Duration
+
+	// DestroyStorage determines whether storage in expired models is
+	// destroyed.
+	DestroyStorage bool
+
+	// Force determines whether expired models are forcibly destroyed.
+	Force bool
+
+	// MaxWait is the maximum time to wait for each step of a forced
+	// model destruction.
+	MaxWait time.Duration
+}
+
 // A Params structure contains the parameters required to initialise a new
 // Service.
 type Params struct {
@@ -116,6 +189,26 @@ type Params struct {
 	// VaultRoleSecretID is the AppRole secret ID.
 	VaultRoleSecretID string
 
+	// VaultAuthMethod is the vault auth method JIMM uses to log in to
+	// vault. This is one of "approle", "kubernetes" or "jwt". If this is
+	// empty the AppRole auth method is used.
+	VaultAuthMethod string
+
+	// VaultAuthRole is the role JIMM logs in as when using the
+	// kubernetes or JWT auth methods.
+	VaultAuthRole string
+
+	// VaultAuthMount is the path at which the kubernetes or JWT auth
+	// method is mounted. If this is empty the auth method's default path
+	// is used.
+	VaultAuthMount string
+
+	// VaultAuthTokenPath is the path of the file holding the token JIMM
+	// presents when using the kubernetes or JWT auth methods. If this is
+	// empty when using the kubernetes auth method the pod's service
+	// account token is used.
+	VaultAuthTokenPath string
+
 	// VaultAddress is the URL of a vault server that will be used to
 	// store secrets for JIMM. If this is empty then the default
 	// address of the vault server is used.
@@ -167,6 +260,24 @@ type Params struct {
 	// instead of dedicated secure storage. SHOULD NOT BE USED IN PRODUCTION.
 	InsecureSecretStorage bool
 
+	// SecretEncryptionKeys holds the keyring used to encrypt secrets
+	// stored in JIMM's database, in the format described by
+	// encryptedstore.ParseKeyring. If this, or SecretEncryptionKeysFile,
+	// is set secrets are stored encrypted in the database instead of in
+	// Vault.
+	SecretEncryptionKeys string
+
+	// SecretEncryptionKeysFile holds the path of a file containing the
+	// keyring used to encrypt secrets stored in JIMM's database. It is
+	// only used if SecretEncryptionKeys is not set.
+	SecretEncryptionKeysFile string
+
+	// SecretAllowPlaintext allows secrets stored in plain text in JIMM's
+	// database to be read when secrets are encrypted. This should only
+	// be set while migrating an existing deployment to encrypted
+	// secrets, until ReencryptSecrets has completed without error.
+	SecretAllowPlaintext bool
+
 	// OAuthAuthenticatorParams holds parameters needed to configure an OAuthAuthenticator
 	// implementation.
 	OAuthAuthenticatorParams OAuthAuthenticatorParams
@@ -183,11 +294,44 @@ type Params struct {
 	// cookie data. The recommended length is 32/64 characters from the Gorilla securecookie lib.
 	// https://github.com/gorilla/securecookie/blob/main/securecookie.go#L124
 	CookieSessionKey []byte
+
+	// ControllerConnectionParams holds parameters used to configure
+	// connections to juju controllers.
+	ControllerConnectionParams ControllerConnectionParams
+
+	// ModelReaperParams holds parameters used to configure the
+	// destruction of expired ephemeral models.
+	ModelReaperParams ModelReaperParams
+
+	// DeletedModelRetentionPeriod is how long the tombstones of models
+	// removed from JIMM are kept before they are purged. If this is zero
+	// tombstones are kept for 30 days.
+	DeletedModelRetentionPeriod time.Duration
+
+	// MigrationTimeout is how long a scheduled model migration may be in
+	// progress before it is considered to have failed. If this is zero
+	// migrations time out after 6 hours.
+	MigrationTimeout time.Duration
+
+	// CredentialCheckInterval is how often every cloud credential is
+	// checked against the controllers hosting the models using it. If
+	// this is zero credentials are checked once a day.
+	CredentialCheckInterval time.Duration
+
+	// CredentialNotificationURL, if set, is the URL a JSON notification
+	// is posted to whenever a cloud credential is found to have become
+	// invalid, so that its owner can be told.
+	CredentialNotificationURL string
 }
 
 // A Service is the implementation of a JIMM server.
 type Service struct {
-	jimm jimm.JIMM
+	jimm                        jimm.JIMM
+	modelReaper                 ModelReaperParams
+	deletedModelRetentionPeriod time.Duration
+	migrationTimeout            time.Duration
+	credentialCheckInterval     time.Duration
+	credentialNotificationURL   string
 
 	mux      *chi.Mux
 	cleanups []func() error
@@ -207,12 +351,142 @@ func (s *Service) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 // given context is canceled, or there is a fatal error watching models.
 func (s *Service) WatchControllers(ctx context.Context) error {
 	w := jimm.Watcher{
-		Database: s.jimm.Database,
-		Dialer:   s.jimm.Dialer,
+		Database:         s.jimm.Database,
+		Dialer:           s.jimm.Dialer,
+		SoftQuotaChecker: &s.jimm,
+		OpenFGAClient:    s.jimm.OpenFGAClient,
 	}
 	return w.Watch(ctx, 10*time.Minute)
 }
 
+// ReapExpiredModels periodically destroys ephemeral models whose expiry
+// time has passed, warning their owners beforehand. ReapExpiredModels
+// finishes when the given context is canceled.
+func (s *Service) ReapExpiredModels(ctx context.Context) error {
+	p := s.modelReaper
+	if p.Interval == 0 {
+		p.Interval = time.Minute
+	}
+	if p.WarningPeriod == 0 {
+		p.WarningPeriod = time.Hour
+	}
+	r := jimm.ModelReaper{
+		JIMM:           &s.jimm,
+		WarningPeriod:  p.WarningPeriod,
+		DestroyStorage: p.DestroyStorage,
+		Force:          p.Force,
+		MaxWait:        p.MaxWait,
+	}
+	return r.Run(ctx, p.Interval)
+}
+
+// PurgeDeletedModels periodically removes the tombstones of deleted
+// models that are older than the retention period. PurgeDeletedModels
+// finishes when the given context is canceled.
+func (s *Service) PurgeDeletedModels(ctx context.Context) error {
+	p := jimm.DeletedModelPurger{
+		Database:        s.jimm.Database,
+		RetentionPeriod: s.deletedModelRetentionPeriod,
+	}
+	if p.RetentionPeriod == 0 {
+		p.RetentionPeriod = 30 * 24 * time.Hour
+	}
+	return p.Run(ctx, time.Hour)
+}
+
+// RunMigrationPlans periodically performs the model migrations in
+// scheduled migration plans. RunMigrationPlans finishes when the given
+// context is canceled.
+func (s *Service) RunMigrationPlans(ctx context.Context) error {
+	ms := jimm.MigrationScheduler{
+		JIMM:             &s.jimm,
+		MigrationTimeout: s.migrationTimeout,
+	}
+	if ms.MigrationTimeout == 0 {
+		ms.MigrationTimeout = 6 * time.Hour
+	}
+	return ms.Run(ctx, time.Minute)
+}
+
+// RevalidateCloudCredentials periodically checks that every cloud
+// credential is still accepted by its cloud. RevalidateCloudCredentials
+// finishes when the given context is canceled.
+func (s *Service) RevalidateCloudCredentials(ctx context.Context) error {
+	v := jimm.CloudCredentialValidator{
+		JIMM: &s.jimm,
+	}
+	if s.credentialNotificationURL != "" {
+		v.Notifier = &jimm.WebhookCloudCredentialNotifier{
+			URL: s.credentialNotificationURL,
+		}
+	}
+	interval := s.credentialCheckInterval
+	if interval == 0 {
+		interval = 24 * time.Hour
+	}
+	return v.Run(ctx, interval)
+}
+
+// RecoverCloudCredentialRotations periodically rolls back cloud credential
+// rotations that were abandoned part way through, for example by a JIMM
+// instance that was restarted. RecoverCloudCredentialRotations finishes
+// when the given context is canceled.
+func (s *Service) RecoverCloudCredentialRotations(ctx context.Context) error {
+	r := jimm.CloudCredentialRotationRecoverer{
+		JIMM: &s.jimm,
+	}
+	return r.Run(ctx, 5*time.Minute)
+}
+
+// ReencryptSecrets re-encrypts any secrets stored in JIMM's database that
+// are held in plain text, or encrypted with an old key, using the primary
+// secret encryption key. It does nothing if JIMM is not configured to
+// encrypt secrets in its database.
+func (s *Service) ReencryptSecrets(ctx context.Context) error {
+	es, ok := s.jimm.CredentialStore.(*encryptedstore.EncryptedStore)
+	if !ok {
+		return nil
+	}
+	n, err := es.Reencrypt(ctx)
+	if err != nil {
+		// Secrets that could not be re-encrypted remain readable
+		// while plain text secrets are allowed, so this does not
+		// stop JIMM.
+		zapctx.Error(ctx, "failed to re-encrypt secrets", zap.Error(err))
+	}
+	zapctx.Info(ctx, "re-encrypted secrets", zap.Int("count", n))
+	return nil
+}
+
+// RenewVaultToken keeps the token used to access vault renewed, logging
+// in again whenever the token can no longer be renewed. It does nothing
+// if JIMM does not store secrets in vault. RenewVaultToken finishes when
+// the given context is canceled.
+func (s *Service) RenewVaultToken(ctx context.Context) error {
+	vs, ok := s.jimm.CredentialStore.(*vault.VaultStore)
+	if !ok {
+		return nil
+	}
+	err := vs.RenewToken(ctx)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// EvictControllerConnections closes cached controller connections once
+// they exceed the configured idle timeout or maximum lifetime, even when
+// no new connections are requested. It does nothing if the connection
+// cache is disabled. EvictControllerConnections finishes when the given
+// context is canceled.
+func (s *Service) EvictControllerConnections(ctx context.Context) error {
+	ce, ok := s.jimm.Dialer.(jimm.ConnectionEvictor)
+	if !ok {
+		return nil
+	}
+	return ce.EvictConnections(ctx)
+}
+
 // WatchModelSummaries connects to all controllers and starts a
 // ModelSummaryWatcher for all models. WatchModelSummaries finishes when
 // the given context is canceled, or there is a fatal error watching model
@@ -282,6 +556,11 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		p.ControllerUUID = controllerUUID.String()
 	}
 	s.jimm.UUID = p.ControllerUUID
+	s.modelReaper = p.ModelReaperParams
+	s.deletedModelRetentionPeriod = p.DeletedModelRetentionPeriod
+	s.migrationTimeout = p.MigrationTimeout
+	s.credentialCheckInterval = p.CredentialCheckInterval
+	s.credentialNotificationURL = p.CredentialNotificationURL
 	s.jimm.Pubsub = &pubsub.Hub{MaxConcurrency: 50}
 
 	if p.DSN == "" {
@@ -368,12 +647,25 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		Store:  s.jimm.CredentialStore,
 		Expiry: p.JWTExpiryDuration,
 	})
-	s.jimm.Dialer = &jujuclient.Dialer{
-		JWTService: s.jimm.JWTService,
+	ccp := p.ControllerConnectionParams
+	jujuDialer := &jujuclient.Dialer{
+		JWTService:       s.jimm.JWTService,
+		ConnectTimeout:   valueOrDefault(ccp.ConnectTimeout, 30*time.Second),
+		CallTimeout:      valueOrDefault(ccp.CallTimeout, 5*time.Minute),
+		DialAttempts:     valueOrDefault(ccp.DialAttempts, 3),
+		InitialBackoff:   valueOrDefault(ccp.InitialBackoff, 500*time.Millisecond),
+		MaxBackoff:       valueOrDefault(ccp.MaxBackoff, 5*time.Second),
+		BreakerThreshold: valueOrDefault(ccp.BreakerThreshold, 5),
+		BreakerCooldown:  valueOrDefault(ccp.BreakerCooldown, 30*time.Second),
 	}
+	s.jimm.Dialer = jujuDialer
 
 	if !p.DisableConnectionCache {
-		s.jimm.Dialer = jimm.CacheDialer(s.jimm.Dialer)
+		s.jimm.Dialer = jimm.NewCacheDialer(s.jimm.Dialer, jimm.CacheDialerParams{
+			MaxConnections: valueOrDefault(ccp.PoolSize, 4),
+			IdleTimeout:    valueOrDefault(ccp.PoolIdleTimeout, 10*time.Minute),
+			MaxLifetime:    valueOrDefault(ccp.PoolMaxLifetime, time.Hour),
+		})
 	}
 
 	if _, err := url.Parse(p.DashboardFinalRedirectURL); err != nil {
@@ -392,6 +684,9 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		debugapi.NewDebugHandler(
 			map[string]debugapi.StatusCheck{
 				"start_time": debugapi.ServerStartTime,
+				"controller_circuit_breakers": debugapi.MakeStatusCheck("controller circuit breakers", func(context.Context) (interface{}, error) {
+					return jujuDialer.BreakerStatus(), nil
+				}),
 			},
 		),
 	)
@@ -508,6 +803,17 @@ func openDB(ctx context.Context, dsn string) (*gorm.DB, error) {
 func (s *Service) setupCredentialStore(ctx context.Context, p Params) error {
 	const op = errors.Op("newSecretStore")
 
+	es, err := newEncryptedStore(&s.jimm.Database, p)
+	if err != nil {
+		zapctx.Error(ctx, "Encrypted Store error", zap.Error(err))
+		return errors.E(op, err)
+	}
+	if es != nil {
+		zapctx.Info(ctx, "using encrypted postgres for secret storage")
+		s.jimm.CredentialStore = es
+		return nil
+	}
+
 	// Only enable Postgres storage for secrets if explicitly enabled.
 	if p.InsecureSecretStorage {
 		zapctx.Warn(ctx, "using plaintext postgres for secret storage")
@@ -528,14 +834,130 @@ func (s *Service) setupCredentialStore(ctx context.Context, p Params) error {
 	return errors.E(op, "jimm cannot start without a credential store")
 }
 
-func newVaultStore(ctx context.Context, p Params) (jimmcreds.CredentialStore, error) {
-	if p.VaultRoleID == "" || p.VaultRoleSecretID == "" {
+// Names of the credential stores that can be passed to
+// MigrateCredentialStore.
+const (
+	CredentialStoreDatabase  = "database"
+	CredentialStoreEncrypted = "encrypted"
+	CredentialStoreVault     = "vault"
+)
+
+// MigrateCredentialStore copies the secrets held in the credential store
+// named from to the credential store named to, using the database and
+// Vault configuration in the given parameters. See
+// jimm.MigrateCredentialStore for details.
+func MigrateCredentialStore(ctx context.Context, p Params, from, to string, dryRun bool) ([]jimm.MigratedSecret, error) {
+	const op = errors.Op("service.MigrateCredentialStore")
+
+	if from == to {
+		return nil, errors.E(op, errors.CodeBadRequest, "source and destination credential stores must be different")
+	}
+	if (from == CredentialStoreDatabase && to == CredentialStoreEncrypted) || (from == CredentialStoreEncrypted && to == CredentialStoreDatabase) {
+		// Both stores use the secrets table. Plain text secrets are
+		// encrypted by JIMM when it starts with an encrypted store.
+		return nil, errors.E(op, errors.CodeBadRequest, "the database and encrypted credential stores share the same storage")
+	}
+	if p.DSN == "" {
+		return nil, errors.E(op, "missing DSN")
+	}
+	var database db.Database
+	var err error
+	database.DB, err = openDB(ctx, p.DSN)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := database.Migrate(ctx, false); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	stores := make(map[string]jimmcreds.CredentialStore)
+	for _, name := range []string{from, to} {
+		switch name {
+		case CredentialStoreDatabase:
+			stores[name] = &database
+		case CredentialStoreEncrypted:
+			es, err := newEncryptedStore(&database, p)
+			if err != nil {
+				return nil, errors.E(op, err)
+			}
+			if es == nil {
+				return nil, errors.E(op, errors.CodeServerConfiguration, "secret encryption keys are not configured")
+			}
+			stores[name] = es
+		case CredentialStoreVault:
+			vs, err := newVaultStore(ctx, p)
+			if err != nil {
+				return nil, errors.E(op, err)
+			}
+			if vs == nil {
+				return nil, errors.E(op, errors.CodeServerConfiguration, "vault is not configured")
+			}
+			stores[name] = vs
+		default:
+			return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("unknown credential store %q", name))
+		}
+	}
+
+	results, err := jimm.MigrateCredentialStore(ctx, &database, jimm.CredentialStoreMigrationParams{
+		Source:      stores[from],
+		Destination: stores[to],
+		DryRun:      dryRun,
+	})
+	if err != nil {
+		return results, errors.E(op, err)
+	}
+	return results, nil
+}
+
+func newEncryptedStore(database *db.Database, p Params) (jimmcreds.CredentialStore, error) {
+	var kr *encryptedstore.Keyring
+	var err error
+	switch {
+	case p.SecretEncryptionKeys != "":
+		kr, err = encryptedstore.ParseKeyring(p.SecretEncryptionKeys)
+	case p.SecretEncryptionKeysFile != "":
+		kr, err = encryptedstore.LoadKeyring(p.SecretEncryptionKeysFile)
+	default:
 		return nil, nil
 	}
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedstore.EncryptedStore{
+		DB:             database,
+		Keys:           kr,
+		AllowPlaintext: p.SecretAllowPlaintext,
+	}, nil
+}
+
+// valueOrDefault returns the value v points to, or def if v is nil.
+func valueOrDefault[T any](v *T, def T) T {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+func newVaultStore(ctx context.Context, p Params) (jimmcreds.CredentialStore, error) {
+	var authMethod vaultapi.AuthMethod
+	switch p.VaultAuthMethod {
+	case "", "approle":
+		if p.VaultRoleID == "" || p.VaultRoleSecretID == "" {
+			return nil, nil
+		}
+	case "kubernetes":
+		authMethod = vault.NewKubernetesAuth(p.VaultAuthRole, p.VaultAuthMount, p.VaultAuthTokenPath)
+	case "jwt":
+		authMethod = vault.NewJWTAuth(p.VaultAuthRole, p.VaultAuthMount, p.VaultAuthTokenPath)
+	default:
+		return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("unsupported vault auth method %q", p.VaultAuthMethod))
+	}
 	zapctx.Info(ctx, "configuring vault client",
 		zap.String("VaultAddress", p.VaultAddress),
 		zap.String("VaultPath", p.VaultPath),
+		zap.String("VaultAuthMethod", p.VaultAuthMethod),
 		zap.String("VaultRoleID", p.VaultRoleID),
+		zap.String("VaultAuthRole", p.VaultAuthRole),
 	)
 
 	cfg := vaultapi.DefaultConfig()
@@ -552,6 +974,7 @@ func newVaultStore(ctx context.Context, p Params) (jimmcreds.CredentialStore, er
 		Client:       client,
 		RoleID:       p.VaultRoleID,
 		RoleSecretID: p.VaultRoleSecretID,
+		AuthMethod:   authMethod,
 		KVPath:       strings.ReplaceAll(p.VaultPath, "/", ""),
 	}, nil
 }
@@ -4,10 +4,78 @@
 
 package cloudcred
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
 
 // IsVisibleAttribute returns whether a cloud-credential attribute is known
 // not to be hidden and can therefore does not need to be redacted.
 func IsVisibleAttribute(provider, authtype, attribute string) bool {
 	return attr[fmt.Sprintf("%s\x1e%s\x1e%s", provider, authtype, attribute)]
 }
+
+// authTypes holds the supported auth types of every known provider.
+var authTypes = func() map[string][]string {
+	m := make(map[string][]string)
+	for k := range required {
+		provider, authtype, _ := strings.Cut(k, "\x1e")
+		m[provider] = append(m[provider], authtype)
+	}
+	for _, v := range m {
+		sort.Strings(v)
+	}
+	return m
+}()
+
+// ValidateAttributes checks that the given attributes are valid for a
+// credential with the given auth type used with a cloud of the given
+// provider type. The auth type must be supported by the provider, every
+// attribute required by the auth type must be present and every
+// attribute must be known to the auth type. Credentials for providers
+// that are not known are not checked. Any error returned has the code
+// errors.CodeBadRequest and describes every problem found.
+func ValidateAttributes(provider, authtype string, attrs map[string]string) error {
+	const op = errors.Op("cloudcred.ValidateAttributes")
+
+	supported, ok := authTypes[provider]
+	if !ok {
+		return nil
+	}
+	requiredAttrs, ok := required[fmt.Sprintf("%s\x1e%s", provider, authtype)]
+	if !ok {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("auth type %q not supported by %q clouds, supported auth types are %s", authtype, provider, quoteAll(supported)))
+	}
+
+	var problems []string
+	for _, name := range requiredAttrs {
+		if _, ok := attrs[name]; !ok {
+			problems = append(problems, fmt.Sprintf("missing required attribute %q", name))
+		}
+	}
+	var unknown []string
+	for name := range attrs {
+		if _, ok := attr[fmt.Sprintf("%s\x1e%s\x1e%s", provider, authtype, name)]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		problems = append(problems, fmt.Sprintf("unknown attribute %q", name))
+	}
+	if len(problems) > 0 {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid %q credential: %s", authtype, strings.Join(problems, ", ")))
+	}
+	return nil
+}
+
+func quoteAll(ss []string) string {
+	quoted := make([]string, len(ss))
+	for i, s := range ss {
+		quoted[i] = fmt.Sprintf("%q", s)
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -8,6 +8,7 @@ import (
 	qt "github.com/frankban/quicktest"
 
 	"github.com/canonical/jimm/v3/internal/cloudcred"
+	"github.com/canonical/jimm/v3/internal/errors"
 )
 
 func TestIsVisibleAttribute(t *testing.T) {
@@ -15,3 +16,88 @@ func TestIsVisibleAttribute(t *testing.T) {
 	qt.Check(t, cloudcred.IsVisibleAttribute("ec2", "access-key", "secret-key"), qt.Equals, false)
 	qt.Check(t, cloudcred.IsVisibleAttribute("ec2", "unknown-auth", "access-key"), qt.Equals, false)
 }
+
+func TestIsVisibleAttributeRedaction(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		provider  string
+		authtype  string
+		attribute string
+		visible   bool
+	}{
+		// Providers removed in Juju 3 are no longer known, so all of
+		// their attributes are redacted.
+		{"cloudsigma", "userpass", "username", false},
+		{"cloudsigma", "userpass", "password", false},
+		{"rackspace", "userpass", "username", false},
+		{"rackspace", "userpass", "tenant-name", false},
+		{"rackspace", "userpass", "password", false},
+		// Attributes added in Juju 3 that are not hidden are shown.
+		{"azure", "service-principal-secret", "application-object-id", true},
+		{"azure", "service-principal-secret", "managed-subscription-id", true},
+		{"ec2", "instance-role", "instance-profile-name", true},
+		{"equinix", "access-key", "project-id", true},
+		{"kubernetes", "clientcertificate", "ClientCertificateData", true},
+		{"kubernetes", "clientcertificate", "rbac-id", true},
+		{"kubernetes", "oauth2", "rbac-id", true},
+		// Hidden attributes added in Juju 3 are redacted.
+		{"azure", "service-principal-secret", "application-password", false},
+		{"equinix", "access-key", "api-token", false},
+		{"kubernetes", "clientcertificate", "ClientKeyData", false},
+		{"kubernetes", "oauth2", "Token", false},
+	}
+	for _, test := range tests {
+		c.Check(cloudcred.IsVisibleAttribute(test.provider, test.authtype, test.attribute), qt.Equals, test.visible, qt.Commentf("%s/%s/%s", test.provider, test.authtype, test.attribute))
+	}
+}
+
+func TestValidateAttributes(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		provider    string
+		authtype    string
+		attrs       map[string]string
+		expectError string
+	}{{
+		provider: "ec2",
+		authtype: "access-key",
+		attrs:    map[string]string{"access-key": "key", "secret-key": "secret"},
+	}, {
+		provider: "manual",
+		authtype: "empty",
+	}, {
+		provider: "openstack",
+		authtype: "userpass",
+		attrs:    map[string]string{"username": "user", "password": "pass", "tenant-name": "tenant"},
+	}, {
+		provider: "test-provider",
+		authtype: "anything",
+		attrs:    map[string]string{"anything": "goes"},
+	}, {
+		provider:    "ec2",
+		authtype:    "userpass",
+		attrs:       map[string]string{"username": "user", "password": "pass"},
+		expectError: `auth type "userpass" not supported by "ec2" clouds, supported auth types are "access-key", "instance-role"`,
+	}, {
+		provider:    "ec2",
+		authtype:    "access-key",
+		attrs:       map[string]string{"access-key": "key"},
+		expectError: `invalid "access-key" credential: missing required attribute "secret-key"`,
+	}, {
+		provider:    "ec2",
+		authtype:    "access-key",
+		attrs:       map[string]string{"secret-key": "secret", "region": "us-east-1", "access_key": "key"},
+		expectError: `invalid "access-key" credential: missing required attribute "access-key", unknown attribute "access_key", unknown attribute "region"`,
+	}}
+	for _, test := range tests {
+		err := cloudcred.ValidateAttributes(test.provider, test.authtype, test.attrs)
+		if test.expectError == "" {
+			c.Check(err, qt.IsNil)
+			continue
+		}
+		c.Check(err, qt.ErrorMatches, test.expectError)
+		c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+	}
+}
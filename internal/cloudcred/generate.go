@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"os"
 	"runtime/debug"
+	"sort"
 	"text/template"
 
 	"github.com/juju/juju/environs"
@@ -24,21 +25,29 @@ func main() {
 	flag.Parse()
 
 	visibleAttributes := make(map[string]bool)
+	requiredAttributes := make(map[string][]string)
 	for _, pname := range environs.RegisteredProviders() {
 		p, err := environs.Provider(pname)
 		if err != nil {
 			panic(err)
 		}
 		for authtype, s := range p.CredentialSchemas() {
+			required := []string{}
 			for _, attr := range s {
 				visibleAttributes[fmt.Sprintf("%s\x1e%s\x1e%s", pname, authtype, attr.Name)] = !attr.Hidden
+				if !attr.Optional {
+					required = append(required, attr.Name)
+				}
 			}
+			sort.Strings(required)
+			requiredAttributes[fmt.Sprintf("%s\x1e%s", pname, authtype)] = required
 		}
 	}
 
 	p := params{
-		JujuVersion: version.Current.String(),
-		Attributes:  visibleAttributes,
+		JujuVersion:        version.Current.String(),
+		Attributes:         visibleAttributes,
+		RequiredAttributes: requiredAttributes,
 	}
 
 	bi, ok := debug.ReadBuildInfo()
@@ -78,6 +87,10 @@ type params struct {
 	JujuVersion   string
 	ModuleVersion string
 	Attributes    map[string]bool
+
+	// RequiredAttributes holds the required attributes of every
+	// credential schema, indexed by provider and auth type.
+	RequiredAttributes map[string][]string
 }
 
 var tmpl = template.Must(template.New("").Parse(`
@@ -93,4 +106,9 @@ var attr = map[string]bool {
 {{range $name, $value := .Attributes}}	{{printf "%q" $name}}: {{$value}},
 {{end -}}
 }
+
+var required = map[string][]string {
+{{range $name, $value := .RequiredAttributes}}	{{printf "%q" $name}}: { {{- range $i, $attr := $value}}{{if $i}}, {{end}}{{printf "%q" $attr}}{{end -}} },
+{{end -}}
+}
 `[1:]))
@@ -194,6 +194,27 @@ func (d *Database) DeleteCloud(ctx context.Context, c *dbmodel.Cloud) (err error
 	return nil
 }
 
+// DeleteCloudRegion permanently deletes the given cloud-region, along with
+// the controller priorities for the region. The region must not be used
+// by any model.
+func (d *Database) DeleteCloudRegion(ctx context.Context, cr *dbmodel.CloudRegion) (err error) {
+	const op = errors.Op("db.DeleteCloudRegion")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Unscoped().Delete(cr).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
 // DeleteCloudRegionControllerPriority deletes the given cloud region controller priority entry.
 func (d *Database) DeleteCloudRegionControllerPriority(ctx context.Context, c *dbmodel.CloudRegionControllerPriority) (err error) {
 	const op = errors.Op("db.DeleteCloudRegionControllerPriority")
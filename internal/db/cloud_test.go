@@ -313,6 +313,56 @@ func (s *dbSuite) TestDeleteCloud(c *qt.C) {
 	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
 }
 
+func TestDeleteCloudRegionUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.DeleteCloudRegion(context.Background(), nil)
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestDeleteCloudRegion(c *qt.C) {
+	ctx := context.Background()
+
+	cl := dbmodel.Cloud{
+		Name: "test-cloud",
+		Type: "test-provider",
+		Regions: []dbmodel.CloudRegion{{
+			Name: "test-cloud-region-1",
+		}, {
+			Name: "test-cloud-region-2",
+		}},
+	}
+
+	err := s.Database.DeleteCloudRegion(ctx, &cl.Regions[0])
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.AddCloud(ctx, &cl)
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.DeleteCloudRegion(ctx, &cl.Regions[0])
+	c.Assert(err, qt.IsNil)
+
+	cl2 := dbmodel.Cloud{
+		Name: cl.Name,
+	}
+	err = s.Database.GetCloud(ctx, &cl2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(cl2.Regions, qt.HasLen, 1)
+	c.Check(cl2.Regions[0].Name, qt.Equals, "test-cloud-region-2")
+
+	// The region is deleted permanently, so it can be added again.
+	err = s.Database.AddCloudRegion(ctx, &dbmodel.CloudRegion{
+		CloudName: cl.Name,
+		Name:      "test-cloud-region-1",
+	})
+	c.Assert(err, qt.IsNil)
+}
+
 func TestDeleteCloudRegionControllerPriorityUnconfiguredDatabase(t *testing.T) {
 	c := qt.New(t)
 
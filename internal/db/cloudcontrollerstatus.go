@@ -0,0 +1,62 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// SetCloudControllerStatus stores the given status of a cloud on a
+// controller, replacing any status previously stored for the same cloud
+// and controller.
+func (d *Database) SetCloudControllerStatus(ctx context.Context, s *dbmodel.CloudControllerStatus) (err error) {
+	const op = errors.Op("db.SetCloudControllerStatus")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "cloud_name"}, {Name: "controller_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "status", "message"}),
+	})
+	if err := db.Omit("Cloud", "Controller").Create(s).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetCloudControllerStatuses returns the stored statuses of the cloud
+// with the given name on the controllers hosting it, ordered by
+// controller name.
+func (d *Database) GetCloudControllerStatuses(ctx context.Context, cloudName string) (_ []dbmodel.CloudControllerStatus, err error) {
+	const op = errors.Op("db.GetCloudControllerStatuses")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var statuses []dbmodel.CloudControllerStatus
+	db := d.DB.WithContext(ctx)
+	db = db.Preload("Controller")
+	db = db.Joins("JOIN controllers ON controllers.id = cloud_controller_statuses.controller_id")
+	db = db.Where("cloud_controller_statuses.cloud_name = ?", cloudName).Order("controllers.name")
+	if err := db.Find(&statuses).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return statuses, nil
+}
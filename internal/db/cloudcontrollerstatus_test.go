@@ -0,0 +1,97 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+func TestSetCloudControllerStatusUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.SetCloudControllerStatus(context.Background(), &dbmodel.CloudControllerStatus{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+const cloudControllerStatusEnv = `clouds:
+- name: test
+  type: test-provider
+  regions:
+  - name: test-region
+controllers:
+- name: test2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test
+  region: test-region
+- name: test1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test
+  region: test-region
+`
+
+func (s *dbSuite) TestCloudControllerStatuses(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.SetCloudControllerStatus(ctx, &dbmodel.CloudControllerStatus{})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, cloudControllerStatusEnv)
+	env.PopulateDB(c, *s.Database)
+
+	statuses, err := s.Database.GetCloudControllerStatuses(ctx, "test")
+	c.Assert(err, qt.IsNil)
+	c.Check(statuses, qt.HasLen, 0)
+
+	ctl1 := env.Controller("test1").DBObject(c, *s.Database)
+	ctl2 := env.Controller("test2").DBObject(c, *s.Database)
+
+	err = s.Database.SetCloudControllerStatus(ctx, &dbmodel.CloudControllerStatus{
+		CloudName:    "test",
+		ControllerID: ctl2.ID,
+		Status:       dbmodel.CloudControllerError,
+		Message:      "test error",
+	})
+	c.Assert(err, qt.IsNil)
+	err = s.Database.SetCloudControllerStatus(ctx, &dbmodel.CloudControllerStatus{
+		CloudName:    "test",
+		ControllerID: ctl1.ID,
+		Status:       dbmodel.CloudControllerSynced,
+	})
+	c.Assert(err, qt.IsNil)
+
+	statuses, err = s.Database.GetCloudControllerStatuses(ctx, "test")
+	c.Assert(err, qt.IsNil)
+	c.Assert(statuses, qt.HasLen, 2)
+	c.Check(statuses[0].Controller.Name, qt.Equals, "test1")
+	c.Check(statuses[0].Status, qt.Equals, dbmodel.CloudControllerSynced)
+	c.Check(statuses[1].Controller.Name, qt.Equals, "test2")
+	c.Check(statuses[1].Status, qt.Equals, dbmodel.CloudControllerError)
+	c.Check(statuses[1].Message, qt.Equals, "test error")
+
+	// Setting the status again replaces the previous status.
+	err = s.Database.SetCloudControllerStatus(ctx, &dbmodel.CloudControllerStatus{
+		CloudName:    "test",
+		ControllerID: ctl2.ID,
+		Status:       dbmodel.CloudControllerSynced,
+	})
+	c.Assert(err, qt.IsNil)
+	statuses, err = s.Database.GetCloudControllerStatuses(ctx, "test")
+	c.Assert(err, qt.IsNil)
+	c.Assert(statuses, qt.HasLen, 2)
+	c.Check(statuses[1].Controller.Name, qt.Equals, "test2")
+	c.Check(statuses[1].Status, qt.Equals, dbmodel.CloudControllerSynced)
+	c.Check(statuses[1].Message, qt.Equals, "")
+}
@@ -116,6 +116,94 @@ func (d *Database) ForEachCloudCredential(ctx context.Context, identityName, clo
 	return nil
 }
 
+// A CloudCredentialFilter restricts the cloud credentials returned by
+// ListCloudCredentials. Empty fields match all cloud credentials.
+type CloudCredentialFilter struct {
+	// Owner restricts the credentials to those owned by the named
+	// identity.
+	Owner string
+
+	// Cloud restricts the credentials to those for the named cloud.
+	Cloud string
+
+	// Invalid restricts the credentials to those known to be invalid.
+	Invalid bool
+}
+
+// ListCloudCredentials returns the cloud credentials matching the given
+// filter, ordered by ID. The models using each credential, and the
+// controllers hosting them, are also loaded.
+func (d *Database) ListCloudCredentials(ctx context.Context, filter CloudCredentialFilter) (_ []dbmodel.CloudCredential, err error) {
+	const op = errors.Op("db.ListCloudCredentials")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	db = db.Preload("Cloud")
+	db = db.Preload("Models")
+	db = db.Preload("Models.Controller")
+	if filter.Owner != "" {
+		db = db.Where("owner_identity_name = ?", filter.Owner)
+	}
+	if filter.Cloud != "" {
+		db = db.Where("cloud_name = ?", filter.Cloud)
+	}
+	if filter.Invalid {
+		db = db.Where("valid = ?", false)
+	}
+	var creds []dbmodel.CloudCredential
+	if err := db.Order("id").Find(&creds).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return creds, nil
+}
+
+// UpdateCloudCredentialValidity stores the Valid and LastChecked fields
+// of the given cloud credential, the remaining fields are not changed.
+func (d *Database) UpdateCloudCredentialValidity(ctx context.Context, cred *dbmodel.CloudCredential) (err error) {
+	const op = errors.Op("db.UpdateCloudCredentialValidity")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Model(cred).Select("valid", "last_checked").Updates(cred).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// UpdateCloudCredentialOwnerGroup stores the OwnerGroupID field of the
+// given cloud credential, the remaining fields are not changed.
+func (d *Database) UpdateCloudCredentialOwnerGroup(ctx context.Context, cred *dbmodel.CloudCredential) (err error) {
+	const op = errors.Op("db.UpdateCloudCredentialOwnerGroup")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Model(cred).Select("owner_group_id").Updates(cred).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
 // DeleteCloudCredential removes the given CloudCredential from the database.
 func (d *Database) DeleteCloudCredential(ctx context.Context, cred *dbmodel.CloudCredential) (err error) {
 	const op = errors.Op("db.DeleteCloudCredential")
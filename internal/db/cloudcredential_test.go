@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/juju/names/v5"
@@ -302,3 +303,90 @@ func (s *dbSuite) TestForEachCloudCredential(c *qt.C) {
 		})
 	}
 }
+
+func (s *dbSuite) TestListCloudCredentials(c *qt.C) {
+	ctx := context.Background()
+
+	env := jimmtest.ParseEnvironment(c, forEachCloudCredentialEnv)
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env.PopulateDB(c, *s.Database)
+
+	paths := func(creds []dbmodel.CloudCredential) []string {
+		var paths []string
+		for _, cred := range creds {
+			paths = append(paths, cred.Path())
+		}
+		return paths
+	}
+
+	creds, err := s.Database.ListCloudCredentials(ctx, db.CloudCredentialFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Check(paths(creds), qt.DeepEquals, []string{
+		"cloud-1/alice@canonical.com/cred-1",
+		"cloud-1/bob@canonical.com/cred-2",
+		"cloud-2/alice@canonical.com/cred-3",
+		"cloud-2/bob@canonical.com/cred-4",
+		"cloud-1/alice@canonical.com/cred-5",
+	})
+
+	creds, err = s.Database.ListCloudCredentials(ctx, db.CloudCredentialFilter{
+		Owner: "alice@canonical.com",
+		Cloud: "cloud-1",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(paths(creds), qt.DeepEquals, []string{
+		"cloud-1/alice@canonical.com/cred-1",
+		"cloud-1/alice@canonical.com/cred-5",
+	})
+
+	creds, err = s.Database.ListCloudCredentials(ctx, db.CloudCredentialFilter{Invalid: true})
+	c.Assert(err, qt.IsNil)
+	c.Check(creds, qt.HasLen, 0)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	cred := env.CloudCredential("bob@canonical.com", "cloud-2", "cred-4").DBObject(c, *s.Database)
+	cred.Valid = sql.NullBool{Bool: false, Valid: true}
+	cred.LastChecked = sql.NullTime{Time: now, Valid: true}
+	err = s.Database.UpdateCloudCredentialValidity(ctx, &cred)
+	c.Assert(err, qt.IsNil)
+
+	creds, err = s.Database.ListCloudCredentials(ctx, db.CloudCredentialFilter{Invalid: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 1)
+	c.Check(creds[0].Path(), qt.Equals, "cloud-2/bob@canonical.com/cred-4")
+	c.Check(creds[0].LastChecked.Time.Equal(now), qt.IsTrue)
+}
+
+func (s *dbSuite) TestUpdateCloudCredentialOwnerGroup(c *qt.C) {
+	ctx := context.Background()
+
+	env := jimmtest.ParseEnvironment(c, forEachCloudCredentialEnv)
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env.PopulateDB(c, *s.Database)
+
+	group, err := s.Database.AddGroup(ctx, "test-group")
+	c.Assert(err, qt.IsNil)
+
+	cred := env.CloudCredential("bob@canonical.com", "cloud-2", "cred-4").DBObject(c, *s.Database)
+	cred.OwnerGroupID = sql.NullInt32{Int32: int32(group.ID), Valid: true}
+	err = s.Database.UpdateCloudCredentialOwnerGroup(ctx, &cred)
+	c.Assert(err, qt.IsNil)
+
+	dbCred := dbmodel.CloudCredential{
+		CloudName:         "cloud-2",
+		OwnerIdentityName: "bob@canonical.com",
+		Name:              "cred-4",
+	}
+	err = s.Database.GetCloudCredential(ctx, &dbCred)
+	c.Assert(err, qt.IsNil)
+	c.Check(dbCred.OwnerGroupID, qt.Equals, sql.NullInt32{Int32: int32(group.ID), Valid: true})
+
+	// Removing the group returns the credential to its owner.
+	err = s.Database.RemoveGroup(ctx, group)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.GetCloudCredential(ctx, &dbCred)
+	c.Assert(err, qt.IsNil)
+	c.Check(dbCred.OwnerGroupID.Valid, qt.IsFalse)
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// inProgressRotationStatuses are the statuses of cloud credential
+// rotations that have not yet finished. The database allows at most one
+// rotation of each credential to have one of these statuses.
+var inProgressRotationStatuses = []string{
+	dbmodel.CredentialRotationValidating,
+	dbmodel.CredentialRotationCanary,
+	dbmodel.CredentialRotationRollingOut,
+}
+
+// AddCloudCredentialRotation stores the given cloud credential rotation.
+// If the rotation is in progress and another rotation of the same
+// credential is already in progress an error with a code of
+// CodeAlreadyExists is returned.
+func (d *Database) AddCloudCredentialRotation(ctx context.Context, r *dbmodel.CloudCredentialRotation) (err error) {
+	const op = errors.Op("db.AddCloudCredentialRotation")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Omit("CloudCredential").Create(r).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetLatestCloudCredentialRotation fills in the given rotation with the
+// most recent rotation of the cloud credential with the ID
+// r.CloudCredentialID. If the credential has never been rotated an error
+// with a code of CodeNotFound is returned.
+func (d *Database) GetLatestCloudCredentialRotation(ctx context.Context, r *dbmodel.CloudCredentialRotation) (err error) {
+	const op = errors.Op("db.GetLatestCloudCredentialRotation")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	db = db.Preload("CloudCredential")
+	if err := db.Where("cloud_credential_id = ?", r.CloudCredentialID).Order("id DESC").First(r).Error; err != nil {
+		err = dbError(err)
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, err, "cloud credential rotation not found")
+		}
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// UpdateCloudCredentialRotation updates the stored cloud credential
+// rotation.
+func (d *Database) UpdateCloudCredentialRotation(ctx context.Context, r *dbmodel.CloudCredentialRotation) (err error) {
+	const op = errors.Op("db.UpdateCloudCredentialRotation")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Omit("CloudCredential").Save(r).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListStaleCloudCredentialRotations returns the cloud credential rotations
+// that are in progress but have not been updated since the given time.
+// The credential of each rotation is also loaded.
+func (d *Database) ListStaleCloudCredentialRotations(ctx context.Context, before time.Time) (_ []dbmodel.CloudCredentialRotation, err error) {
+	const op = errors.Op("db.ListStaleCloudCredentialRotations")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	db = db.Preload("CloudCredential")
+	var rotations []dbmodel.CloudCredentialRotation
+	if err := db.Where("status IN ? AND updated_at < ?", inProgressRotationStatuses, before).Order("id").Find(&rotations).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return rotations, nil
+}
+
+// ClaimStaleCloudCredentialRotation sets the updated time of the given
+// rotation to now, but only if it is still in progress and has not been
+// updated since the given time. This stops a stale rotation being
+// recovered by more than one JIMM instance. The returned value reports
+// whether the rotation was claimed.
+func (d *Database) ClaimStaleCloudCredentialRotation(ctx context.Context, r *dbmodel.CloudCredentialRotation, before, now time.Time) (_ bool, err error) {
+	const op = errors.Op("db.ClaimStaleCloudCredentialRotation")
+
+	if err := d.ready(); err != nil {
+		return false, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Model(r).Where("status IN ? AND updated_at < ?", inProgressRotationStatuses, before)
+	result := db.UpdateColumn("updated_at", now)
+	if result.Error != nil {
+		return false, errors.E(op, dbError(result.Error))
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+func TestAddCloudCredentialRotationUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.AddCloudCredentialRotation(context.Background(), &dbmodel.CloudCredentialRotation{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestCloudCredentialRotations(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.AddCloudCredentialRotation(ctx, &dbmodel.CloudCredentialRotation{})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, forEachCloudCredentialEnv)
+	env.PopulateDB(c, *s.Database)
+
+	cred := dbmodel.CloudCredential{
+		CloudName:         "cloud-1",
+		OwnerIdentityName: "alice@canonical.com",
+		Name:              "cred-1",
+	}
+	err = s.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, qt.IsNil)
+
+	r := dbmodel.CloudCredentialRotation{CloudCredentialID: cred.ID}
+	err = s.Database.GetLatestCloudCredentialRotation(ctx, &r)
+	c.Check(err, qt.ErrorMatches, `cloud credential rotation not found`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	r1 := dbmodel.CloudCredentialRotation{
+		CloudCredentialID: cred.ID,
+		CreatedBy:         "alice@canonical.com",
+		Status:            dbmodel.CredentialRotationFailed,
+		AuthType:          "userpass",
+	}
+	err = s.Database.AddCloudCredentialRotation(ctx, &r1)
+	c.Assert(err, qt.IsNil)
+
+	r2 := dbmodel.CloudCredentialRotation{
+		CloudCredentialID: cred.ID,
+		CreatedBy:         "alice@canonical.com",
+		Status:            dbmodel.CredentialRotationValidating,
+		AuthType:          "userpass",
+		Attributes:        dbmodel.StringMap{"username": "bob", "password": "secret"},
+	}
+	err = s.Database.AddCloudCredentialRotation(ctx, &r2)
+	c.Assert(err, qt.IsNil)
+
+	r2.Status = dbmodel.CredentialRotationCompleted
+	r2.Attributes = nil
+	r2.UpdatedControllers = dbmodel.Strings{"controller-1"}
+	err = s.Database.UpdateCloudCredentialRotation(ctx, &r2)
+	c.Assert(err, qt.IsNil)
+
+	r = dbmodel.CloudCredentialRotation{CloudCredentialID: cred.ID}
+	err = s.Database.GetLatestCloudCredentialRotation(ctx, &r)
+	c.Assert(err, qt.IsNil)
+	c.Check(r.ID, qt.Equals, r2.ID)
+	c.Check(r.Status, qt.Equals, dbmodel.CredentialRotationCompleted)
+	c.Check(r.Attributes, qt.IsNil)
+	c.Check(r.UpdatedControllers, qt.DeepEquals, dbmodel.Strings{"controller-1"})
+	c.Check(r.CloudCredential.Path(), qt.Equals, "cloud-1/alice@canonical.com/cred-1")
+
+	// Only one rotation of a credential may be in progress.
+	r3 := dbmodel.CloudCredentialRotation{
+		CloudCredentialID: cred.ID,
+		CreatedBy:         "alice@canonical.com",
+		Status:            dbmodel.CredentialRotationCanary,
+		AuthType:          "userpass",
+	}
+	err = s.Database.AddCloudCredentialRotation(ctx, &r3)
+	c.Assert(err, qt.IsNil)
+	r4 := dbmodel.CloudCredentialRotation{
+		CloudCredentialID: cred.ID,
+		CreatedBy:         "alice@canonical.com",
+		Status:            dbmodel.CredentialRotationValidating,
+		AuthType:          "userpass",
+	}
+	err = s.Database.AddCloudCredentialRotation(ctx, &r4)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	rotations, err := s.Database.ListStaleCloudCredentialRotations(ctx, r3.UpdatedAt)
+	c.Assert(err, qt.IsNil)
+	c.Check(rotations, qt.HasLen, 0)
+
+	now := time.Now().Add(2 * time.Hour)
+	before := now.Add(-time.Hour)
+	rotations, err = s.Database.ListStaleCloudCredentialRotations(ctx, before)
+	c.Assert(err, qt.IsNil)
+	c.Assert(rotations, qt.HasLen, 1)
+	c.Check(rotations[0].ID, qt.Equals, r3.ID)
+	c.Check(rotations[0].CloudCredential.Path(), qt.Equals, "cloud-1/alice@canonical.com/cred-1")
+
+	// A stale rotation can only be claimed once.
+	claimed, err := s.Database.ClaimStaleCloudCredentialRotation(ctx, &rotations[0], before, now)
+	c.Assert(err, qt.IsNil)
+	c.Check(claimed, qt.IsTrue)
+	claimed, err = s.Database.ClaimStaleCloudCredentialRotation(ctx, &rotations[0], before, now)
+	c.Assert(err, qt.IsNil)
+	c.Check(claimed, qt.IsFalse)
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// AddDeletedModel stores the given deleted model tombstone.
+func (d *Database) AddDeletedModel(ctx context.Context, dm *dbmodel.DeletedModel) (err error) {
+	const op = errors.Op("db.AddDeletedModel")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Create(dm).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ListDeletedModels returns the stored deleted model tombstones, most
+// recently deleted first. If uuid is not empty only the tombstones for
+// the model with that UUID are returned.
+func (d *Database) ListDeletedModels(ctx context.Context, uuid string) (_ []dbmodel.DeletedModel, err error) {
+	const op = errors.Op("db.ListDeletedModels")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if uuid != "" {
+		db = db.Where("uuid = ?", uuid)
+	}
+	var models []dbmodel.DeletedModel
+	if err := db.Order("deleted_at DESC").Order("id DESC").Find(&models).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return models, nil
+}
+
+// DeleteDeletedModelsBefore removes the deleted model tombstones for
+// models deleted before the given time. The number of tombstones removed
+// is returned.
+func (d *Database) DeleteDeletedModelsBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	const op = errors.Op("db.DeleteDeletedModelsBefore")
+
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	tx := d.DB.
+		WithContext(ctx).
+		Where("deleted_at < ?", before).
+		Delete(&dbmodel.DeletedModel{})
+	if tx.Error != nil {
+		return 0, errors.E(op, dbError(tx.Error))
+	}
+	return tx.RowsAffected, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAddDeletedModelUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.AddDeletedModel(context.Background(), &dbmodel.DeletedModel{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestDeletedModels(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.AddDeletedModel(ctx, &dbmodel.DeletedModel{})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	dm1 := dbmodel.DeletedModel{
+		DeletedAt:         now.Add(-48 * time.Hour),
+		UUID:              "00000002-0000-0000-0000-000000000001",
+		Name:              "model-1",
+		OwnerIdentityName: "alice@canonical.com",
+		Labels:            dbmodel.StringMap{"env": "test"},
+		Tuples: dbmodel.DeletedModelTuples{{
+			Object:   "user:alice@canonical.com",
+			Relation: "administrator",
+			Target:   "model:00000002-0000-0000-0000-000000000001",
+		}},
+	}
+	err = s.Database.AddDeletedModel(ctx, &dm1)
+	c.Assert(err, qt.IsNil)
+
+	dm2 := dbmodel.DeletedModel{
+		DeletedAt:         now,
+		UUID:              "00000002-0000-0000-0000-000000000002",
+		Name:              "model-2",
+		OwnerIdentityName: "bob@canonical.com",
+	}
+	err = s.Database.AddDeletedModel(ctx, &dm2)
+	c.Assert(err, qt.IsNil)
+
+	models, err := s.Database.ListDeletedModels(ctx, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(models, qt.HasLen, 2)
+	c.Check(models[0].Name, qt.Equals, "model-2")
+	c.Check(models[1].Name, qt.Equals, "model-1")
+	c.Check(models[1].Labels, qt.DeepEquals, dm1.Labels)
+	c.Check(models[1].Tuples, qt.DeepEquals, dm1.Tuples)
+
+	models, err = s.Database.ListDeletedModels(ctx, "00000002-0000-0000-0000-000000000001")
+	c.Assert(err, qt.IsNil)
+	c.Assert(models, qt.HasLen, 1)
+	c.Check(models[0].ID, qt.Equals, dm1.ID)
+
+	n, err := s.Database.DeleteDeletedModelsBefore(ctx, now.Add(-24*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, int64(1))
+
+	models, err = s.Database.ListDeletedModels(ctx, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(models, qt.HasLen, 1)
+	c.Check(models[0].ID, qt.Equals, dm2.ID)
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
@@ -141,7 +142,15 @@ func (d *Database) RemoveGroup(ctx context.Context, group *dbmodel.GroupEntry) (
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
 
-	if err := d.DB.WithContext(ctx).Delete(group).Error; err != nil {
+	err = d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Credentials owned by the group revert to being owned by the
+		// identity named in their tag.
+		if err := tx.Model(&dbmodel.CloudCredential{}).Where("owner_group_id = ?", group.ID).Update("owner_group_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(group).Error
+	})
+	if err != nil {
 		return errors.E(op, dbError(err))
 	}
 	return nil
@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// SetGroupModelDefaults stores the given group model defaults, replacing
+// the defaults and priority of any existing defaults for the same group.
+func (d *Database) SetGroupModelDefaults(ctx context.Context, defaults *dbmodel.GroupModelDefaults) (err error) {
+	const op = errors.Op("db.SetGroupModelDefaults")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	err = d.Transaction(func(d *Database) error {
+		db := d.DB.WithContext(ctx)
+
+		var existing dbmodel.GroupModelDefaults
+		if err := db.Where("group_uuid = ?", defaults.GroupUUID).First(&existing).Error; err == nil {
+			defaults.ID = existing.ID
+			defaults.CreatedAt = existing.CreatedAt
+		} else if err != gorm.ErrRecordNotFound {
+			return dbError(err)
+		}
+		if err := db.Omit("Group").Save(defaults).Error; err != nil {
+			return dbError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// GetGroupModelDefaults fetches the model defaults of the group with the
+// UUID in the given defaults. If the group has no defaults an error with
+// a code of CodeNotFound is returned.
+func (d *Database) GetGroupModelDefaults(ctx context.Context, defaults *dbmodel.GroupModelDefaults) (err error) {
+	const op = errors.Op("db.GetGroupModelDefaults")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	db = db.Where("group_uuid = ?", defaults.GroupUUID)
+	if err := db.Preload("Group").First(defaults).Error; err != nil {
+		err := dbError(err)
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, errors.CodeNotFound, "groupmodeldefaults not found", err)
+		}
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListGroupModelDefaults returns the model defaults of the groups with
+// the given UUIDs in order of increasing precedence, that is by priority
+// and then by group name.
+func (d *Database) ListGroupModelDefaults(ctx context.Context, groupUUIDs []string) (_ []dbmodel.GroupModelDefaults, err error) {
+	const op = errors.Op("db.ListGroupModelDefaults")
+
+	if len(groupUUIDs) == 0 {
+		return nil, nil
+	}
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Preload("Group")
+	// Groups are soft-deleted, so the defaults of removed groups
+	// remain in the database and must be skipped.
+	db = db.Joins("JOIN groups ON groups.uuid = group_model_defaults.group_uuid AND groups.deleted_at IS NULL")
+	db = db.Where("group_model_defaults.group_uuid IN ?", groupUUIDs)
+	db = db.Order("group_model_defaults.priority, groups.name")
+	var defaults []dbmodel.GroupModelDefaults
+	if err := db.Find(&defaults).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return defaults, nil
+}
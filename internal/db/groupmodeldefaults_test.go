@@ -0,0 +1,99 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestSetGroupModelDefaultsUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.SetGroupModelDefaults(context.Background(), &dbmodel.GroupModelDefaults{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestGroupModelDefaults(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.SetGroupModelDefaults(ctx, &dbmodel.GroupModelDefaults{})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.IsNil)
+
+	g1, err := s.Database.AddGroup(ctx, "group-b")
+	c.Assert(err, qt.IsNil)
+	g2, err := s.Database.AddGroup(ctx, "group-a")
+	c.Assert(err, qt.IsNil)
+	g3, err := s.Database.AddGroup(ctx, "group-c")
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.GetGroupModelDefaults(ctx, &dbmodel.GroupModelDefaults{GroupUUID: g1.UUID})
+	c.Check(err, qt.ErrorMatches, `groupmodeldefaults not found`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	d1 := dbmodel.GroupModelDefaults{
+		GroupUUID: g1.UUID,
+		Defaults:  map[string]interface{}{"key1": "value1"},
+	}
+	err = s.Database.SetGroupModelDefaults(ctx, &d1)
+	c.Assert(err, qt.IsNil)
+
+	// Setting the defaults again replaces the existing defaults.
+	d1 = dbmodel.GroupModelDefaults{
+		GroupUUID: g1.UUID,
+		Priority:  10,
+		Defaults:  map[string]interface{}{"key2": "value2"},
+	}
+	err = s.Database.SetGroupModelDefaults(ctx, &d1)
+	c.Assert(err, qt.IsNil)
+
+	d := dbmodel.GroupModelDefaults{GroupUUID: g1.UUID}
+	err = s.Database.GetGroupModelDefaults(ctx, &d)
+	c.Assert(err, qt.IsNil)
+	c.Check(d.ID, qt.Equals, d1.ID)
+	c.Check(d.Group.Name, qt.Equals, "group-b")
+	c.Check(d.Priority, qt.Equals, 10)
+	c.Check(d.Defaults, qt.DeepEquals, dbmodel.Map{"key2": "value2"})
+
+	err = s.Database.SetGroupModelDefaults(ctx, &dbmodel.GroupModelDefaults{
+		GroupUUID: g2.UUID,
+		Priority:  10,
+		Defaults:  map[string]interface{}{"key2": "value3"},
+	})
+	c.Assert(err, qt.IsNil)
+	err = s.Database.SetGroupModelDefaults(ctx, &dbmodel.GroupModelDefaults{
+		GroupUUID: g3.UUID,
+		Defaults:  map[string]interface{}{"key3": "value4"},
+	})
+	c.Assert(err, qt.IsNil)
+
+	defaults, err := s.Database.ListGroupModelDefaults(ctx, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(defaults, qt.HasLen, 0)
+
+	defaults, err = s.Database.ListGroupModelDefaults(ctx, []string{g1.UUID, g2.UUID, g3.UUID})
+	c.Assert(err, qt.IsNil)
+	c.Assert(defaults, qt.HasLen, 3)
+	c.Check(defaults[0].Group.Name, qt.Equals, "group-c")
+	c.Check(defaults[1].Group.Name, qt.Equals, "group-a")
+	c.Check(defaults[2].Group.Name, qt.Equals, "group-b")
+
+	// Removing a group removes its defaults.
+	err = s.Database.RemoveGroup(ctx, g3)
+	c.Assert(err, qt.IsNil)
+	defaults, err = s.Database.ListGroupModelDefaults(ctx, []string{g1.UUID, g3.UUID})
+	c.Assert(err, qt.IsNil)
+	c.Assert(defaults, qt.HasLen, 1)
+	c.Check(defaults[0].Group.Name, qt.Equals, "group-b")
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// preloadMigrationPlanModels preloads the models in a migration plan in
+// migration order.
+func preloadMigrationPlanModels(db *gorm.DB) *gorm.DB {
+	return db.Order("position").Order("id")
+}
+
+// AddMigrationPlan stores the given migration plan along with its models.
+// If a plan with the same name already exists an error with a code of
+// CodeAlreadyExists is returned.
+func (d *Database) AddMigrationPlan(ctx context.Context, p *dbmodel.MigrationPlan) (err error) {
+	const op = errors.Op("db.AddMigrationPlan")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Create(p).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetMigrationPlan fills in the given migration plan, which is looked up
+// by name, including its models. If there is no such plan an error with
+// a code of CodeNotFound is returned.
+func (d *Database) GetMigrationPlan(ctx context.Context, p *dbmodel.MigrationPlan) (err error) {
+	const op = errors.Op("db.GetMigrationPlan")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	db = db.Preload("Models", preloadMigrationPlanModels)
+	if err := db.Where("name = ?", p.Name).First(p).Error; err != nil {
+		err = dbError(err)
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, err, "migration plan not found")
+		}
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListMigrationPlans returns the migration plans, including their
+// models, ordered by start time. If any statuses are specified only the
+// plans with one of those statuses are returned.
+func (d *Database) ListMigrationPlans(ctx context.Context, statuses ...string) (_ []dbmodel.MigrationPlan, err error) {
+	const op = errors.Op("db.ListMigrationPlans")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if len(statuses) > 0 {
+		db = db.Where("status IN ?", statuses)
+	}
+	db = db.Preload("Models", preloadMigrationPlanModels)
+	var plans []dbmodel.MigrationPlan
+	if err := db.Order("start_at").Order("id").Find(&plans).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return plans, nil
+}
+
+// UpdateMigrationPlan updates the stored migration plan. The models in
+// the plan are not updated, use UpdateMigrationPlanModel to update those.
+func (d *Database) UpdateMigrationPlan(ctx context.Context, p *dbmodel.MigrationPlan) (err error) {
+	const op = errors.Op("db.UpdateMigrationPlan")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Omit("Models").Save(p).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// UpdateMigrationPlanStatus updates the status, status message and count
+// of consecutive failures of the given migration plan, but only if the
+// stored status of the plan is still the given previous status. This
+// stops a status change made while the plan was being processed, for
+// example pausing or cancelling the plan, from being overwritten. The
+// returned value reports whether the plan was updated.
+func (d *Database) UpdateMigrationPlanStatus(ctx context.Context, p *dbmodel.MigrationPlan, previous string) (_ bool, err error) {
+	const op = errors.Op("db.UpdateMigrationPlanStatus")
+
+	if err := d.ready(); err != nil {
+		return false, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Model(p).Where("status = ?", previous)
+	result := db.Updates(map[string]interface{}{
+		"status":               p.Status,
+		"status_message":       p.StatusMessage,
+		"consecutive_failures": p.ConsecutiveFailures,
+	})
+	if result.Error != nil {
+		return false, errors.E(op, dbError(result.Error))
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateMigrationPlanModel updates the stored migration plan model.
+func (d *Database) UpdateMigrationPlanModel(ctx context.Context, m *dbmodel.MigrationPlanModel) (err error) {
+	const op = errors.Op("db.UpdateMigrationPlanModel")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Save(m).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAddMigrationPlanUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.AddMigrationPlan(context.Background(), &dbmodel.MigrationPlan{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestMigrationPlans(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.AddMigrationPlan(ctx, &dbmodel.MigrationPlan{})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	p1 := dbmodel.MigrationPlan{
+		Name:                 "overnight",
+		TargetControllerName: "controller-2",
+		StartAt:              now.Add(time.Hour),
+		MaxConcurrency:       2,
+		MaxFailures:          3,
+		Status:               dbmodel.MigrationPlanPending,
+		CreatedBy:            "alice@canonical.com",
+		Models: []dbmodel.MigrationPlanModel{{
+			Position:  1,
+			ModelUUID: "00000002-0000-0000-0000-000000000002",
+			Status:    dbmodel.MigrationPending,
+		}, {
+			Position:  0,
+			ModelUUID: "00000002-0000-0000-0000-000000000001",
+			Status:    dbmodel.MigrationPending,
+		}},
+	}
+	err = s.Database.AddMigrationPlan(ctx, &p1)
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.AddMigrationPlan(ctx, &dbmodel.MigrationPlan{Name: "overnight", StartAt: now, Status: dbmodel.MigrationPlanPending})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	p2 := dbmodel.MigrationPlan{
+		Name:                 "done",
+		TargetControllerName: "controller-2",
+		StartAt:              now.Add(-time.Hour),
+		MaxConcurrency:       1,
+		Status:               dbmodel.MigrationPlanCompleted,
+		CreatedBy:            "alice@canonical.com",
+	}
+	err = s.Database.AddMigrationPlan(ctx, &p2)
+	c.Assert(err, qt.IsNil)
+
+	p := dbmodel.MigrationPlan{Name: "overnight"}
+	err = s.Database.GetMigrationPlan(ctx, &p)
+	c.Assert(err, qt.IsNil)
+	c.Check(p.ID, qt.Equals, p1.ID)
+	c.Assert(p.Models, qt.HasLen, 2)
+	c.Check(p.Models[0].ModelUUID, qt.Equals, "00000002-0000-0000-0000-000000000001")
+	c.Check(p.Models[1].ModelUUID, qt.Equals, "00000002-0000-0000-0000-000000000002")
+
+	err = s.Database.GetMigrationPlan(ctx, &dbmodel.MigrationPlan{Name: "no-such-plan"})
+	c.Check(err, qt.ErrorMatches, `migration plan not found`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	plans, err := s.Database.ListMigrationPlans(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(plans, qt.HasLen, 2)
+	c.Check(plans[0].Name, qt.Equals, "done")
+	c.Check(plans[1].Name, qt.Equals, "overnight")
+	c.Check(plans[1].Models, qt.HasLen, 2)
+
+	plans, err = s.Database.ListMigrationPlans(ctx, dbmodel.MigrationPlanPending, dbmodel.MigrationPlanRunning)
+	c.Assert(err, qt.IsNil)
+	c.Assert(plans, qt.HasLen, 1)
+	c.Check(plans[0].Name, qt.Equals, "overnight")
+
+	p.Status = dbmodel.MigrationPlanRunning
+	err = s.Database.UpdateMigrationPlan(ctx, &p)
+	c.Assert(err, qt.IsNil)
+
+	m := p.Models[0]
+	m.Status = dbmodel.MigrationInProgress
+	m.StartedAt = sql.NullTime{Time: now, Valid: true}
+	err = s.Database.UpdateMigrationPlanModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+
+	p = dbmodel.MigrationPlan{Name: "overnight"}
+	err = s.Database.GetMigrationPlan(ctx, &p)
+	c.Assert(err, qt.IsNil)
+	c.Check(p.Status, qt.Equals, dbmodel.MigrationPlanRunning)
+	c.Check(p.Models[0].Status, qt.Equals, dbmodel.MigrationInProgress)
+	c.Check(p.Models[0].StartedAt.Time.Equal(now), qt.IsTrue)
+	c.Check(p.Models[1].Status, qt.Equals, dbmodel.MigrationPending)
+
+	// The status is only updated if it has not changed since it was read.
+	stale := p
+	p.Status = dbmodel.MigrationPlanPaused
+	err = s.Database.UpdateMigrationPlan(ctx, &p)
+	c.Assert(err, qt.IsNil)
+	stale.Status = dbmodel.MigrationPlanCompleted
+	stale.ConsecutiveFailures = 1
+	updated, err := s.Database.UpdateMigrationPlanStatus(ctx, &stale, dbmodel.MigrationPlanRunning)
+	c.Assert(err, qt.IsNil)
+	c.Check(updated, qt.IsFalse)
+
+	p = dbmodel.MigrationPlan{Name: "overnight"}
+	err = s.Database.GetMigrationPlan(ctx, &p)
+	c.Assert(err, qt.IsNil)
+	c.Check(p.Status, qt.Equals, dbmodel.MigrationPlanPaused)
+	c.Check(p.ConsecutiveFailures, qt.Equals, 0)
+
+	p.ConsecutiveFailures = 2
+	p.StatusMessage = "paused after 2 consecutive migration failures"
+	updated, err = s.Database.UpdateMigrationPlanStatus(ctx, &p, dbmodel.MigrationPlanPaused)
+	c.Assert(err, qt.IsNil)
+	c.Check(updated, qt.IsTrue)
+
+	p = dbmodel.MigrationPlan{Name: "overnight"}
+	err = s.Database.GetMigrationPlan(ctx, &p)
+	c.Assert(err, qt.IsNil)
+	c.Check(p.ConsecutiveFailures, qt.Equals, 2)
+	c.Check(p.StatusMessage, qt.Equals, "paused after 2 consecutive migration failures")
+}
@@ -4,7 +4,10 @@ package db
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/juju/juju/core/life"
 	"gorm.io/gorm"
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
@@ -201,6 +204,131 @@ func (d *Database) GetModelsByUUID(ctx context.Context, modelUUIDs []string) (_
 	return models, nil
 }
 
+// GetExpiringModels returns all alive models that expire at or before
+// the given time, ordered by expiry time.
+func (d *Database) GetExpiringModels(ctx context.Context, before time.Time) (_ []dbmodel.Model, err error) {
+	const op = errors.Op("db.GetExpiringModels")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var models []dbmodel.Model
+	db := d.DB.WithContext(ctx)
+	db = preloadModel("", db)
+	db = db.Where("expires_at IS NOT NULL AND expires_at <= ?", before)
+	db = db.Where("life = ?", string(life.Alive))
+	if err := db.Order("expires_at").Find(&models).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return models, nil
+}
+
+// A ModelFilter restricts the models returned by ListModels. Empty
+// fields match all models.
+type ModelFilter struct {
+	// UUIDs, if not nil, restricts the models to those with one of the
+	// given UUIDs. Callers should keep the number of UUIDs bounded,
+	// splitting larger sets across several calls.
+	UUIDs []string
+
+	// Controller restricts the models to those hosted on the named
+	// controller.
+	Controller string
+
+	// Cloud restricts the models to those hosted on the named cloud.
+	Cloud string
+
+	// CloudRegion restricts the models to those hosted in the named
+	// cloud region.
+	CloudRegion string
+
+	// Owner restricts the models to those owned by the named identity.
+	Owner string
+
+	// Life restricts the models to those with the given life status.
+	Life string
+
+	// Status restricts the models to those with the given status.
+	Status string
+
+	// NamePrefix restricts the models to those whose name starts with
+	// the given prefix.
+	NamePrefix string
+
+	// AfterID restricts the models to those with an ID greater than the
+	// given ID. Models are always returned in ID order, so this can be
+	// used to page through the results.
+	AfterID uint
+
+	// Limit is the maximum number of models to return. A value of zero
+	// will ignore the limit.
+	Limit int
+}
+
+// likeEscaper escapes the special characters in a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ListModels returns the models that match the given filter, ordered by
+// ID.
+func (d *Database) ListModels(ctx context.Context, filter ModelFilter) (_ []dbmodel.Model, err error) {
+	const op = errors.Op("db.ListModels")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if filter.UUIDs != nil && len(filter.UUIDs) == 0 {
+		return nil, nil
+	}
+
+	db := d.DB.WithContext(ctx)
+	db = preloadModel("", db)
+	if filter.UUIDs != nil {
+		db = db.Where("uuid IN ?", filter.UUIDs)
+	}
+	if filter.Controller != "" {
+		db = db.Where("controller_id IN (SELECT id FROM controllers WHERE name = ?)", filter.Controller)
+	}
+	if filter.Cloud != "" {
+		db = db.Where("cloud_region_id IN (SELECT id FROM cloud_regions WHERE cloud_name = ?)", filter.Cloud)
+	}
+	if filter.CloudRegion != "" {
+		db = db.Where("cloud_region_id IN (SELECT id FROM cloud_regions WHERE name = ?)", filter.CloudRegion)
+	}
+	if filter.Owner != "" {
+		db = db.Where("owner_identity_name = ?", filter.Owner)
+	}
+	if filter.Life != "" {
+		db = db.Where("life = ?", filter.Life)
+	}
+	if filter.Status != "" {
+		db = db.Where("status_status = ?", filter.Status)
+	}
+	if filter.NamePrefix != "" {
+		db = db.Where("name LIKE ?", likeEscaper.Replace(filter.NamePrefix)+"%")
+	}
+	if filter.AfterID != 0 {
+		db = db.Where("id > ?", filter.AfterID)
+	}
+	if filter.Limit > 0 {
+		db = db.Limit(filter.Limit)
+	}
+	var models []dbmodel.Model
+	if err := db.Order("id").Find(&models).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return models, nil
+}
+
 func preloadModel(prefix string, db *gorm.DB) *gorm.DB {
 	if len(prefix) > 0 && prefix[len(prefix)-1] != '.' {
 		prefix += "."
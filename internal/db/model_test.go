@@ -554,6 +554,81 @@ func (s *dbSuite) TestForEachModel(c *qt.C) {
 	})
 }
 
+func (s *dbSuite) TestListModels(c *qt.C) {
+	ctx := context.Background()
+
+	_, err := s.Database.ListModels(ctx, db.ModelFilter{})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(context.Background(), true)
+	c.Assert(err, qt.Equals, nil)
+
+	env := jimmtest.ParseEnvironment(c, testForEachModelEnv)
+	env.PopulateDB(c, *s.Database)
+
+	uuids := func(models []dbmodel.Model) []string {
+		var res []string
+		for _, m := range models {
+			res = append(res, m.UUID.String)
+		}
+		return res
+	}
+
+	models, err := s.Database.ListModels(ctx, db.ModelFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Check(uuids(models), qt.DeepEquals, []string{
+		"00000002-0000-0000-0000-000000000001",
+		"00000002-0000-0000-0000-000000000002",
+		"00000002-0000-0000-0000-000000000003",
+	})
+	c.Check(models[0].Controller.Name, qt.Equals, "test")
+	c.Check(models[0].CloudRegion.Cloud.Name, qt.Equals, "test")
+
+	models, err = s.Database.ListModels(ctx, db.ModelFilter{
+		Controller:  "test",
+		Cloud:       "test",
+		CloudRegion: "test-region",
+		Owner:       "bob@canonical.com",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(uuids(models), qt.DeepEquals, []string{
+		"00000002-0000-0000-0000-000000000002",
+		"00000002-0000-0000-0000-000000000003",
+	})
+
+	models, err = s.Database.ListModels(ctx, db.ModelFilter{Controller: "no-such-controller"})
+	c.Assert(err, qt.IsNil)
+	c.Check(models, qt.HasLen, 0)
+
+	models, err = s.Database.ListModels(ctx, db.ModelFilter{NamePrefix: "test-3"})
+	c.Assert(err, qt.IsNil)
+	c.Check(uuids(models), qt.DeepEquals, []string{"00000002-0000-0000-0000-000000000003"})
+
+	models, err = s.Database.ListModels(ctx, db.ModelFilter{NamePrefix: "test_"})
+	c.Assert(err, qt.IsNil)
+	c.Check(models, qt.HasLen, 0)
+
+	models, err = s.Database.ListModels(ctx, db.ModelFilter{UUIDs: []string{}})
+	c.Assert(err, qt.IsNil)
+	c.Check(models, qt.HasLen, 0)
+
+	models, err = s.Database.ListModels(ctx, db.ModelFilter{
+		UUIDs: []string{"00000002-0000-0000-0000-000000000001", "00000002-0000-0000-0000-000000000003"},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(uuids(models), qt.DeepEquals, []string{
+		"00000002-0000-0000-0000-000000000001",
+		"00000002-0000-0000-0000-000000000003",
+	})
+
+	models, err = s.Database.ListModels(ctx, db.ModelFilter{Limit: 2})
+	c.Assert(err, qt.IsNil)
+	c.Assert(models, qt.HasLen, 2)
+	models, err = s.Database.ListModels(ctx, db.ModelFilter{AfterID: models[1].ID, Limit: 2})
+	c.Assert(err, qt.IsNil)
+	c.Check(uuids(models), qt.DeepEquals, []string{"00000002-0000-0000-0000-000000000003"})
+}
+
 const testGetModelsByUUIDEnv = `clouds:
 - name: test
   type: test
@@ -0,0 +1,146 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// modelQuotaSubject restricts the given query to the quota with the same
+// subject and cloud as q.
+func modelQuotaSubject(db *gorm.DB, q *dbmodel.ModelQuota) *gorm.DB {
+	if q.IdentityName.Valid {
+		db = db.Where("identity_name = ?", q.IdentityName.String)
+	} else {
+		db = db.Where("group_uuid = ?", q.GroupUUID.String)
+	}
+	if q.CloudName.Valid {
+		db = db.Where("cloud_name = ?", q.CloudName.String)
+	} else {
+		db = db.Where("cloud_name IS NULL")
+	}
+	return db
+}
+
+// SetModelQuota stores the given model quota, replacing any existing
+// quota for the same identity or group and cloud.
+func (d *Database) SetModelQuota(ctx context.Context, q *dbmodel.ModelQuota) (err error) {
+	const op = errors.Op("db.SetModelQuota")
+
+	if q.IdentityName.Valid == q.GroupUUID.Valid {
+		return errors.E(op, errors.CodeBadRequest, "quota must be assigned to either an identity or a group")
+	}
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	err = d.Transaction(func(d *Database) error {
+		db := d.DB.WithContext(ctx)
+
+		var existing dbmodel.ModelQuota
+		if err := modelQuotaSubject(db, q).First(&existing).Error; err == nil {
+			q.ID = existing.ID
+			q.CreatedAt = existing.CreatedAt
+		} else if err != gorm.ErrRecordNotFound {
+			return dbError(err)
+		}
+		if err := db.Save(q).Error; err != nil {
+			return dbError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RemoveModelQuota removes the model quota for the identity or group and
+// cloud of the given quota. If there is no such quota an error with a
+// code of CodeNotFound is returned.
+func (d *Database) RemoveModelQuota(ctx context.Context, q *dbmodel.ModelQuota) (err error) {
+	const op = errors.Op("db.RemoveModelQuota")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := modelQuotaSubject(d.DB.WithContext(ctx), q)
+	result := db.Delete(&dbmodel.ModelQuota{})
+	if result.Error != nil {
+		return errors.E(op, dbError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return errors.E(op, errors.CodeNotFound, "model quota not found")
+	}
+	return nil
+}
+
+// GetModelQuotas returns all the model quotas assigned to the named
+// identity or any of the groups with the given UUIDs. The quotas are
+// ordered by ID.
+func (d *Database) GetModelQuotas(ctx context.Context, identityName string, groupUUIDs []string) (_ []dbmodel.ModelQuota, err error) {
+	const op = errors.Op("db.GetModelQuotas")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	db = db.Where("identity_name = ?", identityName)
+	if len(groupUUIDs) > 0 {
+		db = db.Or("group_uuid IN ?", groupUUIDs)
+	}
+	var quotas []dbmodel.ModelQuota
+	if err := db.Order("id").Find(&quotas).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return quotas, nil
+}
+
+// GetModelUsage returns the resources used by the models owned by the
+// named identity. If cloudName is not empty only models on that cloud
+// are included.
+func (d *Database) GetModelUsage(ctx context.Context, identityName, cloudName string) (_ dbmodel.ModelUsage, err error) {
+	const op = errors.Op("db.GetModelUsage")
+
+	var usage dbmodel.ModelUsage
+	if err := d.ready(); err != nil {
+		return usage, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx).Model(&dbmodel.Model{})
+	db = db.Select("COUNT(*) AS models, COALESCE(SUM(models.machines), 0) AS machines, COALESCE(SUM(models.cores), 0) AS cores, COALESCE(SUM(models.units), 0) AS units")
+	db = db.Where("models.owner_identity_name = ?", identityName)
+	if cloudName != "" {
+		db = db.Joins("JOIN cloud_regions ON cloud_regions.id = models.cloud_region_id")
+		db = db.Where("cloud_regions.cloud_name = ?", cloudName)
+	}
+	if err := db.Scan(&usage).Error; err != nil {
+		return usage, errors.E(op, dbError(err))
+	}
+	return usage, nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+func TestSetModelQuotaUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.SetModelQuota(context.Background(), &dbmodel.ModelQuota{
+		IdentityName: sql.NullString{String: "bob@canonical.com", Valid: true},
+	})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+const testModelQuotaEnv = `clouds:
+- name: test
+  type: test
+  regions:
+  - name: test-region
+- name: test2
+  type: test
+  regions:
+  - name: test2-region
+cloud-credentials:
+- name: test-cred
+  cloud: test
+  owner: alice@canonical.com
+  type: empty
+- name: test2-cred
+  cloud: test2
+  owner: alice@canonical.com
+  type: empty
+controllers:
+- name: test
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test
+  region: test-region
+models:
+- name: test-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  owner: alice@canonical.com
+  cloud: test
+  region: test-region
+  cloud-credential: test-cred
+  controller: test
+  machines: 2
+  cores: 4
+  units: 3
+- name: test-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  owner: alice@canonical.com
+  cloud: test2
+  region: test2-region
+  cloud-credential: test2-cred
+  controller: test
+  machines: 1
+  cores: 2
+  units: 1
+- name: test-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  owner: bob@canonical.com
+  cloud: test
+  region: test-region
+  cloud-credential: test-cred
+  controller: test
+  machines: 5
+  cores: 5
+  units: 5
+`
+
+func (s *dbSuite) TestModelQuotas(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.SetModelQuota(ctx, &dbmodel.ModelQuota{
+		IdentityName: sql.NullString{String: "alice@canonical.com", Valid: true},
+	})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testModelQuotaEnv)
+	env.PopulateDB(c, *s.Database)
+
+	group, err := s.Database.AddGroup(ctx, "test-group")
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.SetModelQuota(ctx, &dbmodel.ModelQuota{})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	q1 := dbmodel.ModelQuota{
+		IdentityName: sql.NullString{String: "alice@canonical.com", Valid: true},
+		MaxModels:    sql.NullInt64{Int64: 1, Valid: true},
+	}
+	err = s.Database.SetModelQuota(ctx, &q1)
+	c.Assert(err, qt.IsNil)
+
+	// Setting the quota again replaces the existing quota.
+	q1 = dbmodel.ModelQuota{
+		IdentityName: sql.NullString{String: "alice@canonical.com", Valid: true},
+		MaxModels:    sql.NullInt64{Int64: 5, Valid: true},
+		MaxUnits:     sql.NullInt64{Int64: 10, Valid: true},
+	}
+	err = s.Database.SetModelQuota(ctx, &q1)
+	c.Assert(err, qt.IsNil)
+
+	q2 := dbmodel.ModelQuota{
+		IdentityName: sql.NullString{String: "alice@canonical.com", Valid: true},
+		CloudName:    sql.NullString{String: "test", Valid: true},
+		MaxMachines:  sql.NullInt64{Int64: 3, Valid: true},
+	}
+	err = s.Database.SetModelQuota(ctx, &q2)
+	c.Assert(err, qt.IsNil)
+
+	q3 := dbmodel.ModelQuota{
+		GroupUUID: sql.NullString{String: group.UUID, Valid: true},
+		MaxCores:  sql.NullInt64{Int64: 8, Valid: true},
+	}
+	err = s.Database.SetModelQuota(ctx, &q3)
+	c.Assert(err, qt.IsNil)
+
+	quotas, err := s.Database.GetModelQuotas(ctx, "alice@canonical.com", nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(quotas, qt.HasLen, 2)
+	c.Check(quotas[0].ID, qt.Equals, q1.ID)
+	c.Check(quotas[0].MaxModels, qt.Equals, sql.NullInt64{Int64: 5, Valid: true})
+	c.Check(quotas[0].MaxUnits, qt.Equals, sql.NullInt64{Int64: 10, Valid: true})
+	c.Check(quotas[0].AppliesToCloud("test2"), qt.IsTrue)
+	c.Check(quotas[1].ID, qt.Equals, q2.ID)
+	c.Check(quotas[1].AppliesToCloud("test"), qt.IsTrue)
+	c.Check(quotas[1].AppliesToCloud("test2"), qt.IsFalse)
+
+	quotas, err = s.Database.GetModelQuotas(ctx, "alice@canonical.com", []string{group.UUID})
+	c.Assert(err, qt.IsNil)
+	c.Assert(quotas, qt.HasLen, 3)
+	c.Check(quotas[2].ID, qt.Equals, q3.ID)
+
+	quotas, err = s.Database.GetModelQuotas(ctx, "bob@canonical.com", nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(quotas, qt.HasLen, 0)
+
+	usage, err := s.Database.GetModelUsage(ctx, "alice@canonical.com", "")
+	c.Assert(err, qt.IsNil)
+	c.Check(usage, qt.Equals, dbmodel.ModelUsage{Models: 2, Machines: 3, Cores: 6, Units: 4})
+
+	usage, err = s.Database.GetModelUsage(ctx, "alice@canonical.com", "test")
+	c.Assert(err, qt.IsNil)
+	c.Check(usage, qt.Equals, dbmodel.ModelUsage{Models: 1, Machines: 2, Cores: 4, Units: 3})
+
+	usage, err = s.Database.GetModelUsage(ctx, "charlie@canonical.com", "")
+	c.Assert(err, qt.IsNil)
+	c.Check(usage, qt.Equals, dbmodel.ModelUsage{})
+
+	err = s.Database.RemoveModelQuota(ctx, &q2)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.RemoveModelQuota(ctx, &q2)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	quotas, err = s.Database.GetModelQuotas(ctx, "alice@canonical.com", nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(quotas, qt.HasLen, 1)
+}
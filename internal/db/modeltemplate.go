@@ -0,0 +1,118 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// AddModelTemplate stores the given model template. If a template with
+// the same name already exists an error with a code of CodeAlreadyExists
+// is returned.
+func (d *Database) AddModelTemplate(ctx context.Context, t *dbmodel.ModelTemplate) (err error) {
+	const op = errors.Op("db.AddModelTemplate")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Create(t).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetModelTemplate fills in the given model template, which is looked up
+// by name. If there is no such template an error with a code of
+// CodeNotFound is returned.
+func (d *Database) GetModelTemplate(ctx context.Context, t *dbmodel.ModelTemplate) (err error) {
+	const op = errors.Op("db.GetModelTemplate")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("name = ?", t.Name).First(t).Error; err != nil {
+		err = dbError(err)
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, err, "model template not found")
+		}
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// UpdateModelTemplate updates the stored model template.
+func (d *Database) UpdateModelTemplate(ctx context.Context, t *dbmodel.ModelTemplate) (err error) {
+	const op = errors.Op("db.UpdateModelTemplate")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Save(t).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// RemoveModelTemplate removes the named model template. If there is no
+// such template an error with a code of CodeNotFound is returned.
+func (d *Database) RemoveModelTemplate(ctx context.Context, t *dbmodel.ModelTemplate) (err error) {
+	const op = errors.Op("db.RemoveModelTemplate")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	result := d.DB.WithContext(ctx).Where("name = ?", t.Name).Delete(&dbmodel.ModelTemplate{})
+	if result.Error != nil {
+		return errors.E(op, dbError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return errors.E(op, errors.CodeNotFound, "model template not found")
+	}
+	return nil
+}
+
+// ListModelTemplates returns all the model templates ordered by name.
+func (d *Database) ListModelTemplates(ctx context.Context) (_ []dbmodel.ModelTemplate, err error) {
+	const op = errors.Op("db.ListModelTemplates")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var templates []dbmodel.ModelTemplate
+	if err := d.DB.WithContext(ctx).Order("name").Find(&templates).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return templates, nil
+}
@@ -87,6 +87,67 @@ func (d *Database) GetSecret(ctx context.Context, secret *dbmodel.Secret) (err e
 	return nil
 }
 
+// ForEachSecret iterates through every secret calling the given function
+// for each one. If the given function returns an error the iteration will
+// stop immediately and the error will be returned unmodified.
+func (d *Database) ForEachSecret(ctx context.Context, f func(*dbmodel.Secret) error) (err error) {
+	const op = errors.Op("db.ForEachSecret")
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	rows, err := db.Model(&dbmodel.Secret{}).Order("id asc").Rows()
+	if err != nil {
+		return errors.E(op, dbError(err))
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var secret dbmodel.Secret
+		if err := db.ScanRows(rows, &secret); err != nil {
+			return errors.E(op, dbError(err))
+		}
+		if err := f(&secret); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// UpdateSecretIfUnmodified updates the time and data of the given secret,
+// identified by its ID, but only if the secret's time is still the given
+// time. This allows a secret to be rewritten without losing a concurrent
+// update. The returned boolean reports whether the secret was updated.
+func (d *Database) UpdateSecretIfUnmodified(ctx context.Context, secret *dbmodel.Secret, modified time.Time) (_ bool, err error) {
+	const op = errors.Op("db.UpdateSecretIfUnmodified")
+
+	if err := d.ready(); err != nil {
+		return false, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	result := db.Model(&dbmodel.Secret{}).Where("id = ? AND time = ?", secret.ID, modified).Updates(map[string]interface{}{
+		"time": secret.Time,
+		"data": secret.Data,
+	})
+	if result.Error != nil {
+		return false, errors.E(op, dbError(result.Error))
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // Delete secret deletes the secret with the specified type and tag.
 func (d *Database) DeleteSecret(ctx context.Context, secret *dbmodel.Secret) (err error) {
 	const op = errors.Op("db.DeleteSecret")
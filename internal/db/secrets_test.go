@@ -120,6 +120,44 @@ func (s *dbSuite) TestDeleteSecret(c *qt.C) {
 	c.Assert(count, qt.Equals, int64(0))
 }
 
+func (s *dbSuite) TestForEachSecretAndUpdateSecretIfUnmodified(c *qt.C) {
+	err := s.Database.Migrate(context.Background(), true)
+	c.Assert(err, qt.Equals, nil)
+	ctx := context.Background()
+
+	for _, tag := range []string{"1", "2"} {
+		secret := dbmodel.Secret{Time: testTime, Type: "generic", Tag: tag, Data: []byte(`"` + tag + `"`)}
+		c.Assert(s.Database.UpsertSecret(ctx, &secret), qt.IsNil)
+	}
+
+	var secrets []dbmodel.Secret
+	err = s.Database.ForEachSecret(ctx, func(secret *dbmodel.Secret) error {
+		secrets = append(secrets, *secret)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(secrets, qt.HasLen, 2)
+	c.Check(secrets[0].Tag, qt.Equals, "1")
+	c.Check(secrets[1].Tag, qt.Equals, "2")
+
+	updated := secrets[0]
+	updated.Time = testTime.Add(time.Hour)
+	updated.Data = []byte(`"updated"`)
+	ok, err := s.Database.UpdateSecretIfUnmodified(ctx, &updated, testTime)
+	c.Assert(err, qt.IsNil)
+	c.Check(ok, qt.IsTrue)
+
+	// The secret has been modified, so a second update is not applied.
+	updated.Data = []byte(`"stale"`)
+	ok, err = s.Database.UpdateSecretIfUnmodified(ctx, &updated, testTime)
+	c.Assert(err, qt.IsNil)
+	c.Check(ok, qt.IsFalse)
+
+	secret := dbmodel.Secret{Type: "generic", Tag: "1"}
+	c.Assert(s.Database.GetSecret(ctx, &secret), qt.IsNil)
+	c.Check(string(secret.Data), qt.Equals, `"updated"`)
+}
+
 func (s *dbSuite) TestPutAndGetCloudCredential(c *qt.C) {
 	err := s.Database.Migrate(context.Background(), true)
 	c.Assert(err, qt.Equals, nil)
@@ -0,0 +1,42 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"time"
+)
+
+// Statuses of a cloud on a controller hosting it.
+const (
+	// CloudControllerSynced is the status of a controller whose copy of
+	// the cloud matches the definition held in JIMM's cloud catalogue.
+	CloudControllerSynced = "synced"
+
+	// CloudControllerError is the status of a controller that could not
+	// be updated with the definition held in JIMM's cloud catalogue.
+	CloudControllerError = "error"
+)
+
+// A CloudControllerStatus records whether a controller hosting a cloud
+// has the latest definition of the cloud held in JIMM's cloud catalogue.
+type CloudControllerStatus struct {
+	// Note that we do not use gorm.Model to avoid the use of soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Cloud is the cloud hosted by the controller.
+	CloudName string
+	Cloud     Cloud `gorm:"foreignKey:CloudName;references:Name;constraint:OnDelete:CASCADE"`
+
+	// Controller is the controller hosting the cloud.
+	ControllerID uint
+	Controller   Controller `gorm:"constraint:OnDelete:CASCADE"`
+
+	// Status is the status of the cloud on the controller.
+	Status string
+
+	// Message holds a human readable explanation of the status, for
+	// example the error returned by the controller.
+	Message string
+}
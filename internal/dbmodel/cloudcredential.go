@@ -25,6 +25,13 @@ type CloudCredential struct {
 	OwnerIdentityName string
 	Owner             Identity `gorm:"foreignKey:OwnerIdentityName;references:Name"`
 
+	// OwnerGroupID, if valid, is the ID of the group that owns this
+	// credential. A group-owned credential is administered by the
+	// members of the group rather than by the identity named in its
+	// tag, so it remains available to the group if that identity loses
+	// access to it.
+	OwnerGroupID sql.NullInt32
+
 	// AuthType is the type of the credential.
 	AuthType string
 
@@ -41,6 +48,10 @@ type CloudCredential struct {
 	// Valid stores whether the cloud-credential is known to be valid.
 	Valid sql.NullBool
 
+	// LastChecked is the time the credential was last checked against
+	// a controller.
+	LastChecked sql.NullTime
+
 	// Models contains the models using this credential.
 	Models []Model
 }
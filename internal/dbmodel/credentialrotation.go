@@ -0,0 +1,89 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"time"
+)
+
+// Cloud credential rotation statuses.
+const (
+	// CredentialRotationValidating is the status of a rotation whose new
+	// attributes are being checked against the models using the
+	// credential.
+	CredentialRotationValidating = "validating"
+
+	// CredentialRotationCanary is the status of a rotation whose new
+	// attributes are being applied to the canary controller.
+	CredentialRotationCanary = "canary"
+
+	// CredentialRotationRollingOut is the status of a rotation whose new
+	// attributes are being applied to the remaining controllers.
+	CredentialRotationRollingOut = "rolling-out"
+
+	// CredentialRotationCompleted is the status of a rotation whose new
+	// attributes have been applied everywhere.
+	CredentialRotationCompleted = "completed"
+
+	// CredentialRotationFailed is the status of a rotation whose new
+	// attributes were rejected before being applied to any controller.
+	CredentialRotationFailed = "failed"
+
+	// CredentialRotationRolledBack is the status of a rotation that
+	// failed part way through and whose previous attributes have been
+	// restored.
+	CredentialRotationRolledBack = "rolled-back"
+)
+
+// A CloudCredentialRotation is a staged update of the attributes of a
+// cloud credential. The new attributes are validated, applied to a
+// single canary controller and then to every other controller hosting
+// models that use the credential.
+type CloudCredentialRotation struct {
+	// Note that we do not use gorm.Model to avoid the use of soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// CloudCredential is the credential being rotated.
+	CloudCredentialID uint
+	CloudCredential   CloudCredential `gorm:"constraint:OnDelete:CASCADE"`
+
+	// CreatedBy is the name of the identity that started the rotation.
+	CreatedBy string
+
+	// Status is the status of the rotation.
+	Status string
+
+	// StatusMessage holds a human readable explanation of the status,
+	// for example why the rotation was rolled back.
+	StatusMessage string
+
+	// AuthType is the authentication type of the new attributes.
+	AuthType string
+
+	// PreviousAuthType is the authentication type of the credential
+	// before the rotation.
+	PreviousAuthType string
+
+	// AttributesInVault indicates whether the new and previous
+	// attributes are stored in the configured vault key-value store,
+	// rather than this database.
+	AttributesInVault bool
+
+	// Attributes holds the new attributes of the credential while the
+	// rotation is in progress.
+	Attributes StringMap
+
+	// PreviousAttributes holds the attributes of the credential before
+	// the rotation while the rotation is in progress.
+	PreviousAttributes StringMap
+
+	// CanaryController is the name of the controller the new attributes
+	// are applied to first.
+	CanaryController string
+
+	// UpdatedControllers holds the names of the controllers the new
+	// attributes have been applied to.
+	UpdatedControllers Strings
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// A DeletedModel is a tombstone recording a model that has been removed
+// from JIMM. Tombstones are kept for a limited period so that the
+// ownership and access control of destroyed models can be inspected
+// after the fact.
+type DeletedModel struct {
+	ID uint `gorm:"primarykey"`
+
+	// DeletedAt is the time the model was removed from JIMM.
+	DeletedAt time.Time
+
+	// ModelCreatedAt is the time the model was originally added to
+	// JIMM.
+	ModelCreatedAt time.Time
+
+	// UUID is the UUID of the deleted model.
+	UUID string
+
+	// Name is the name of the deleted model.
+	Name string
+
+	// OwnerIdentityName is the name of the identity that owned the
+	// model.
+	OwnerIdentityName string
+
+	// ControllerName is the name of the controller that hosted the
+	// model.
+	ControllerName string
+
+	// CloudName is the name of the cloud that hosted the model.
+	CloudName string
+
+	// CloudRegionName is the name of the cloud region that hosted the
+	// model.
+	CloudRegionName string
+
+	// CloudCredentialName is the name of the cloud credential used by
+	// the model.
+	CloudCredentialName string
+
+	// Type is the type of the model.
+	Type string
+
+	// Life is the life status of the model when it was removed.
+	Life string
+
+	// Labels holds the labels that were attached to the model.
+	Labels StringMap
+
+	// Tuples holds the OpenFGA tuples that referred to the model when
+	// it was removed.
+	Tuples DeletedModelTuples
+}
+
+// FromModel fills in the tombstone from the given model.
+func (dm *DeletedModel) FromModel(m *Model) {
+	dm.ModelCreatedAt = m.CreatedAt
+	dm.UUID = m.UUID.String
+	dm.Name = m.Name
+	dm.OwnerIdentityName = m.OwnerIdentityName
+	dm.ControllerName = m.Controller.Name
+	dm.CloudName = m.CloudRegion.Cloud.Name
+	dm.CloudRegionName = m.CloudRegion.Name
+	dm.CloudCredentialName = m.CloudCredential.Name
+	dm.Type = m.Type
+	dm.Life = m.Life
+	dm.Labels = m.Labels
+}
+
+// A DeletedModelTuple is an OpenFGA tuple recorded in a DeletedModel.
+type DeletedModelTuple struct {
+	Object   string `json:"object"`
+	Relation string `json:"relation"`
+	Target   string `json:"target"`
+}
+
+// DeletedModelTuples is a list of tuples stored in a single column. The
+// list is encoded as a JSON array and stored in a BLOB data type.
+type DeletedModelTuples []DeletedModelTuple
+
+// Value implements driver.Valuer.
+func (t DeletedModelTuples) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return json.Marshal(t)
+}
+
+// Scan implements sql.Scanner.
+func (t *DeletedModelTuples) Scan(src interface{}) error {
+	if src == nil {
+		*t = nil
+		return nil
+	}
+	var buf []byte
+	switch v := src.(type) {
+	case []byte:
+		buf = v
+	case string:
+		buf = []byte(v)
+	default:
+		return fmt.Errorf("cannot unmarshal %T as DeletedModelTuples", src)
+	}
+	return json.Unmarshal(buf, t)
+}
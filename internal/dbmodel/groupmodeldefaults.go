@@ -0,0 +1,26 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import "time"
+
+// GroupModelDefaults holds the model defaults of a group. The defaults
+// apply to models added by any member of the group. When an identity is
+// a member of several groups with defaults, the defaults of groups with
+// a higher Priority take precedence, groups with the same priority are
+// ordered by name.
+type GroupModelDefaults struct {
+	// Note that we do not use gorm.Model to avoid the use of soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	GroupUUID string
+	Group     GroupEntry `gorm:"foreignKey:GroupUUID;references:UUID"`
+
+	// Priority orders the defaults of the groups an identity is a
+	// member of.
+	Priority int
+
+	Defaults Map
+}
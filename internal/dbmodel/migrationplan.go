@@ -0,0 +1,125 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Migration plan statuses.
+const (
+	// MigrationPlanPending is the status of a plan whose start time has
+	// not yet been reached.
+	MigrationPlanPending = "pending"
+
+	// MigrationPlanRunning is the status of a plan whose models are
+	// being migrated.
+	MigrationPlanRunning = "running"
+
+	// MigrationPlanPaused is the status of a plan that has been paused,
+	// either by an administrator or because too many migrations failed.
+	MigrationPlanPaused = "paused"
+
+	// MigrationPlanCompleted is the status of a plan in which every
+	// model has either been migrated or failed.
+	MigrationPlanCompleted = "completed"
+
+	// MigrationPlanCancelled is the status of a plan that has been
+	// cancelled by an administrator.
+	MigrationPlanCancelled = "cancelled"
+)
+
+// Migration plan model statuses.
+const (
+	// MigrationPending is the status of a model that is waiting to be
+	// migrated.
+	MigrationPending = "pending"
+
+	// MigrationInProgress is the status of a model whose migration has
+	// been initiated.
+	MigrationInProgress = "migrating"
+
+	// MigrationDone is the status of a model that has been migrated to
+	// the target controller.
+	MigrationDone = "done"
+
+	// MigrationFailed is the status of a model whose migration failed.
+	MigrationFailed = "failed"
+)
+
+// A MigrationPlan is a scheduled batch of model migrations to a single
+// target controller.
+type MigrationPlan struct {
+	// Note that we do not use gorm.Model to avoid the use of soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Name is the unique name of the plan.
+	Name string
+
+	// TargetControllerName is the name of the controller the models in
+	// the plan are migrated to.
+	TargetControllerName string
+
+	// StartAt is the earliest time at which migrations in the plan are
+	// started.
+	StartAt time.Time
+
+	// MaxConcurrency is the maximum number of models in the plan that
+	// are migrated at the same time.
+	MaxConcurrency int
+
+	// MaxFailures is the number of consecutive failed migrations after
+	// which the plan is automatically paused.
+	MaxFailures int
+
+	// ConsecutiveFailures is the number of migrations that have failed
+	// since the last successful migration in the plan.
+	ConsecutiveFailures int
+
+	// Status is the status of the plan.
+	Status string
+
+	// StatusMessage holds a human readable explanation of the plan
+	// status, for example why it was paused.
+	StatusMessage string
+
+	// CreatedBy is the name of the identity that created the plan.
+	// Migrations in the plan are performed on behalf of this identity.
+	CreatedBy string
+
+	// Models holds the models in the plan in the order they are
+	// migrated.
+	Models []MigrationPlanModel `gorm:"foreignKey:PlanID"`
+}
+
+// A MigrationPlanModel is a model that is migrated as part of a
+// MigrationPlan.
+type MigrationPlanModel struct {
+	ID uint `gorm:"primarykey"`
+
+	// PlanID is the ID of the plan the model belongs to.
+	PlanID uint
+
+	// Position is the position of the model in the migration order of
+	// the plan.
+	Position int
+
+	// ModelUUID is the UUID of the model to migrate.
+	ModelUUID string
+
+	// Status is the migration status of the model.
+	Status string
+
+	// Error holds the error from the last failed migration attempt.
+	Error string
+
+	// StartedAt is the time the migration of the model was initiated.
+	StartedAt sql.NullTime
+
+	// CompletedAt is the time the migration of the model either
+	// completed or failed.
+	CompletedAt sql.NullTime
+}
@@ -79,6 +79,25 @@ type Model struct {
 
 	// Offers are the ApplicationOffers attached to the model.
 	Offers []ApplicationOffer
+
+	// ExpiresAt holds the time after which an ephemeral model will be
+	// destroyed by JIMM. Models that do not expire have a NULL value.
+	ExpiresAt sql.NullTime
+
+	// ExpiryWarnedAt holds the time at which the owner was warned that
+	// the model is about to expire.
+	ExpiryWarnedAt sql.NullTime
+
+	// Labels holds the key/value labels attached to the model by its
+	// administrators.
+	Labels StringMap
+
+	// ConfigSources holds the source, either "template" or "explicit",
+	// of each config value the model was added with that did not come
+	// from model defaults. Models added before sources were recorded
+	// have nil sources, other models have a non-nil, possibly empty,
+	// map.
+	ConfigSources StringMap
 }
 
 // Tag returns a names.Tag for the model.
@@ -157,11 +176,19 @@ func (m *Model) FromJujuModelInfo(info jujuparams.ModelInfo) error {
 	return nil
 }
 
-// FromModelUpdate updates the model from the given ModelUpdate.
+// FromModelUpdate updates the model from the given ModelUpdate. The
+// model's agent version is taken from the update's Version, the status
+// version reported for models is always empty. If the update has no
+// version the current version is kept.
 func (m *Model) FromJujuModelUpdate(info jujuparams.ModelUpdate) {
 	m.Name = info.Name
 	m.Life = string(info.Life)
+	version := m.Status.Version
+	if info.Version != "" {
+		version = info.Version
+	}
 	m.Status.FromJujuStatusInfo(info.Status)
+	m.Status.Version = version
 	m.SLA.FromJujuModelSLAInfo(info.SLA)
 }
 
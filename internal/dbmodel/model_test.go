@@ -456,6 +456,7 @@ func TestModelFromJujuModelUpdate(t *testing.T) {
 		SLA: jujuparams.ModelSLAInfo{
 			Level: "unsupported",
 		},
+		Version: "3.5.1",
 	}
 
 	model := dbmodel.Model{}
@@ -464,7 +465,8 @@ func TestModelFromJujuModelUpdate(t *testing.T) {
 		Name: "test-model",
 		Life: state.Alive.String(),
 		Status: dbmodel.Status{
-			Status: "available",
+			Status:  "available",
+			Version: "3.5.1",
 			Since: sql.NullTime{
 				Time:  now,
 				Valid: true,
@@ -474,4 +476,9 @@ func TestModelFromJujuModelUpdate(t *testing.T) {
 			Level: "unsupported",
 		},
 	})
+
+	// An update without a version keeps the current version.
+	info.Version = ""
+	model.FromJujuModelUpdate(info)
+	c.Check(model.Status.Version, qt.Equals, "3.5.1")
 }
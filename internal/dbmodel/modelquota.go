@@ -0,0 +1,67 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+)
+
+// A ModelQuota limits the models that may be owned by an identity. A
+// quota is assigned either to a single identity, which may be a user or
+// a service account, or to a group in which case it applies separately
+// to each member of the group.
+type ModelQuota struct {
+	// Note that we do not use gorm.Model to avoid the use of soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// IdentityName is the name of the identity the quota is assigned
+	// to. Exactly one of IdentityName and GroupUUID is valid.
+	IdentityName sql.NullString
+
+	// GroupUUID is the UUID of the group the quota is assigned to.
+	GroupUUID sql.NullString
+
+	// CloudName is the name of the cloud the quota applies to. If this
+	// is not valid the quota applies to models on all clouds.
+	CloudName sql.NullString
+
+	// MaxModels is the maximum number of models that may be created. It
+	// is enforced when models are added.
+	MaxModels sql.NullInt64
+
+	// MaxMachines is the soft limit on the total number of machines in
+	// the models.
+	MaxMachines sql.NullInt64
+
+	// MaxCores is the soft limit on the total number of cores in the
+	// models.
+	MaxCores sql.NullInt64
+
+	// MaxUnits is the soft limit on the total number of units in the
+	// models.
+	MaxUnits sql.NullInt64
+}
+
+// AppliesToCloud reports whether the quota applies to models on the
+// named cloud.
+func (q ModelQuota) AppliesToCloud(cloudName string) bool {
+	return !q.CloudName.Valid || q.CloudName.String == cloudName
+}
+
+// ModelUsage holds the resources used by a set of models.
+type ModelUsage struct {
+	// Models is the number of models.
+	Models int64
+
+	// Machines is the total number of machines in the models.
+	Machines int64
+
+	// Cores is the total number of cores in the models.
+	Cores int64
+
+	// Units is the total number of units in the models.
+	Units int64
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+)
+
+// A ModelTemplate is a named set of model settings that can be applied
+// when a model is created.
+type ModelTemplate struct {
+	// Note that we do not use gorm.Model to avoid the use of soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Name is the unique name of the template.
+	Name string
+
+	// Description is a human readable description of the template.
+	Description string
+
+	// CloudName is the name of the cloud models created from the
+	// template are hosted on. If this is not valid the cloud is
+	// selected in the same way as for models created without a
+	// template.
+	CloudName sql.NullString
+
+	// CloudRegion is the name of the cloud region models created from
+	// the template are hosted in.
+	CloudRegion string
+
+	// CloudCredentials holds the names of the cloud credentials, in
+	// order of preference, to use for models created from the template.
+	// The first valid credential with one of these names owned by the
+	// model owner is used.
+	CloudCredentials Strings
+
+	// Config holds the model config applied to models created from the
+	// template.
+	Config Map
+
+	// Access holds the relation, either "reader" or "writer", granted
+	// on models created from the template to the members of each named
+	// group.
+	Access StringMap
+
+	// Labels holds the labels attached to models created from the
+	// template.
+	Labels StringMap
+}
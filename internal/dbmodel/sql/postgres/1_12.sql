@@ -0,0 +1,18 @@
+-- 1_12.sql is a migration that adds a table holding model quotas.
+CREATE TABLE IF NOT EXISTS model_quotas (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	identity_name TEXT REFERENCES identities (name) ON DELETE CASCADE,
+	group_uuid TEXT REFERENCES groups (uuid) ON DELETE CASCADE,
+	cloud_name TEXT REFERENCES clouds (name) ON DELETE CASCADE,
+	max_models BIGINT,
+	max_machines BIGINT,
+	max_cores BIGINT,
+	max_units BIGINT,
+	CHECK ((identity_name IS NULL) <> (group_uuid IS NULL))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_model_quotas_identity_cloud ON model_quotas (identity_name, COALESCE(cloud_name, '')) WHERE identity_name IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_model_quotas_group_cloud ON model_quotas (group_uuid, COALESCE(cloud_name, '')) WHERE group_uuid IS NOT NULL;
+
+UPDATE versions SET major=1, minor=12 WHERE component='jimmdb';
@@ -0,0 +1,7 @@
+-- 1_13.sql is a migration that adds expiry times to ephemeral models.
+ALTER TABLE models ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE models ADD COLUMN IF NOT EXISTS expiry_warned_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_models_expires_at ON models (expires_at) WHERE expires_at IS NOT NULL;
+
+UPDATE versions SET major=1, minor=13 WHERE component='jimmdb';
@@ -0,0 +1,4 @@
+-- 1_14.sql is a migration that adds labels to models.
+ALTER TABLE models ADD COLUMN IF NOT EXISTS labels BYTEA;
+
+UPDATE versions SET major=1, minor=14 WHERE component='jimmdb';
@@ -0,0 +1,16 @@
+-- 1_15.sql is a migration that adds a table holding model templates.
+CREATE TABLE IF NOT EXISTS model_templates (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	name TEXT NOT NULL UNIQUE,
+	description TEXT NOT NULL DEFAULT '',
+	cloud_name TEXT REFERENCES clouds (name) ON DELETE SET NULL,
+	cloud_region TEXT NOT NULL DEFAULT '',
+	cloud_credentials BYTEA,
+	config BYTEA,
+	access BYTEA,
+	labels BYTEA
+);
+
+UPDATE versions SET major=1, minor=15 WHERE component='jimmdb';
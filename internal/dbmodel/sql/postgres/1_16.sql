@@ -0,0 +1,22 @@
+-- 1_16.sql is a migration that adds a table holding tombstones of models
+-- that have been removed from JIMM.
+CREATE TABLE IF NOT EXISTS deleted_models (
+	id BIGSERIAL PRIMARY KEY,
+	deleted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	model_created_at TIMESTAMP WITH TIME ZONE,
+	uuid TEXT NOT NULL,
+	name TEXT NOT NULL,
+	owner_identity_name TEXT NOT NULL,
+	controller_name TEXT NOT NULL DEFAULT '',
+	cloud_name TEXT NOT NULL DEFAULT '',
+	cloud_region_name TEXT NOT NULL DEFAULT '',
+	cloud_credential_name TEXT NOT NULL DEFAULT '',
+	type TEXT NOT NULL DEFAULT '',
+	life TEXT NOT NULL DEFAULT '',
+	labels BYTEA,
+	tuples BYTEA
+);
+CREATE INDEX IF NOT EXISTS idx_deleted_models_deleted_at ON deleted_models (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_deleted_models_uuid ON deleted_models (uuid);
+
+UPDATE versions SET major=1, minor=16 WHERE component='jimmdb';
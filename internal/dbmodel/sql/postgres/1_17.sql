@@ -0,0 +1,32 @@
+-- 1_17.sql is a migration that adds tables holding scheduled model
+-- migration plans.
+CREATE TABLE IF NOT EXISTS migration_plans (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	name TEXT NOT NULL UNIQUE,
+	target_controller_name TEXT NOT NULL,
+	start_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	max_concurrency INTEGER NOT NULL DEFAULT 1,
+	max_failures INTEGER NOT NULL DEFAULT 0,
+	consecutive_failures INTEGER NOT NULL DEFAULT 0,
+	status TEXT NOT NULL,
+	status_message TEXT NOT NULL DEFAULT '',
+	created_by TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_migration_plans_status ON migration_plans (status);
+
+CREATE TABLE IF NOT EXISTS migration_plan_models (
+	id BIGSERIAL PRIMARY KEY,
+	plan_id BIGINT NOT NULL REFERENCES migration_plans (id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	model_uuid TEXT NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	started_at TIMESTAMP WITH TIME ZONE,
+	completed_at TIMESTAMP WITH TIME ZONE,
+	UNIQUE (plan_id, model_uuid)
+);
+CREATE INDEX IF NOT EXISTS idx_migration_plan_models_plan_id ON migration_plan_models (plan_id);
+
+UPDATE versions SET major=1, minor=17 WHERE component='jimmdb';
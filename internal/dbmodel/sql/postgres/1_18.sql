@@ -0,0 +1,6 @@
+-- 1_18.sql is a migration that records when each cloud credential was
+-- last checked against a controller.
+ALTER TABLE cloud_credentials ADD COLUMN IF NOT EXISTS last_checked TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_cloud_credentials_valid ON cloud_credentials (valid);
+
+UPDATE versions SET major=1, minor=18 WHERE component='jimmdb';
@@ -0,0 +1,21 @@
+-- 1_19.sql is a migration that adds a table holding staged rotations of
+-- cloud credentials.
+CREATE TABLE IF NOT EXISTS cloud_credential_rotations (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	cloud_credential_id BIGINT NOT NULL REFERENCES cloud_credentials (id) ON DELETE CASCADE,
+	created_by TEXT NOT NULL,
+	status TEXT NOT NULL,
+	status_message TEXT NOT NULL DEFAULT '',
+	auth_type TEXT NOT NULL,
+	previous_auth_type TEXT NOT NULL DEFAULT '',
+	attributes_in_vault BOOLEAN NOT NULL DEFAULT FALSE,
+	attributes BYTEA,
+	previous_attributes BYTEA,
+	canary_controller TEXT NOT NULL DEFAULT '',
+	updated_controllers BYTEA
+);
+CREATE INDEX IF NOT EXISTS idx_cloud_credential_rotations_cloud_credential_id ON cloud_credential_rotations (cloud_credential_id);
+
+UPDATE versions SET major=1, minor=19 WHERE component='jimmdb';
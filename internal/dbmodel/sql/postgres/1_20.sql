@@ -0,0 +1,14 @@
+-- 1_20.sql is a migration that adds a table holding the status of each
+-- cloud in the cloud catalogue on the controllers hosting it.
+CREATE TABLE IF NOT EXISTS cloud_controller_statuses (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	cloud_name TEXT NOT NULL REFERENCES clouds (name) ON DELETE CASCADE,
+	controller_id INTEGER NOT NULL REFERENCES controllers (id) ON DELETE CASCADE,
+	status TEXT NOT NULL,
+	message TEXT NOT NULL DEFAULT '',
+	UNIQUE(cloud_name, controller_id)
+);
+
+UPDATE versions SET major=1, minor=20 WHERE component='jimmdb';
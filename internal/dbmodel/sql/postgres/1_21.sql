@@ -0,0 +1,12 @@
+-- 1_21.sql is a migration that adds a table holding the model defaults
+-- of groups.
+CREATE TABLE IF NOT EXISTS group_model_defaults (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	group_uuid TEXT NOT NULL UNIQUE REFERENCES groups (uuid) ON DELETE CASCADE,
+	priority INTEGER NOT NULL DEFAULT 0,
+	defaults BYTEA
+);
+
+UPDATE versions SET major=1, minor=21 WHERE component='jimmdb';
@@ -0,0 +1,5 @@
+-- 1_22.sql is a migration that records the source of the config values
+-- a model was added with.
+ALTER TABLE models ADD COLUMN IF NOT EXISTS config_sources BYTEA;
+
+UPDATE versions SET major=1, minor=22 WHERE component='jimmdb';
@@ -0,0 +1,5 @@
+-- 1_23.sql is a migration that allows cloud credentials to be owned by
+-- a group.
+ALTER TABLE cloud_credentials ADD COLUMN IF NOT EXISTS owner_group_id INTEGER REFERENCES groups (id) ON DELETE SET NULL;
+
+UPDATE versions SET major=1, minor=23 WHERE component='jimmdb';
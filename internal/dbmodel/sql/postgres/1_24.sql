@@ -0,0 +1,9 @@
+-- 1_24.sql is a migration that allows at most one rotation of each cloud
+-- credential to be in progress at a time. Any older rotations left in
+-- progress are marked as failed first.
+UPDATE cloud_credential_rotations SET status = 'failed', status_message = 'abandoned: superseded by a later rotation'
+	WHERE status IN ('validating', 'canary', 'rolling-out')
+	AND id NOT IN (SELECT MAX(id) FROM cloud_credential_rotations WHERE status IN ('validating', 'canary', 'rolling-out') GROUP BY cloud_credential_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cloud_credential_rotations_in_progress ON cloud_credential_rotations (cloud_credential_id) WHERE status IN ('validating', 'canary', 'rolling-out');
+
+UPDATE versions SET major=1, minor=24 WHERE component='jimmdb';
@@ -0,0 +1,379 @@
+// Copyright 2024 Canonical.
+
+// Package encryptedstore provides a credential store that keeps secrets
+// in JIMM's database encrypted at rest. It is intended for deployments
+// where Vault is not available.
+package encryptedstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+const (
+	usernameKey = "username"
+	passwordKey = "password"
+)
+
+// The types and tags of the stored secrets. These match the values used
+// by db.Database so that secrets stored before encryption was enabled
+// can still be read, and then re-encrypted by Reencrypt.
+const (
+	jwksKind          = "jwks"
+	jwksPublicKeyTag  = "jwksPublicKey"
+	jwksPrivateKeyTag = "jwksPrivateKey"
+	jwksExpiryTag     = "jwksExpiry"
+)
+
+// algorithm identifies the encryption scheme used for stored secrets.
+const algorithm = "AES-256-GCM"
+
+// An envelope is the stored form of an encrypted secret. The secret is
+// encrypted with a data-encryption key generated for that secret, which
+// is in turn encrypted with a key-encryption key from the keyring. Both
+// are bound to the secret's type and tag so that an encrypted value
+// cannot be moved to another secret.
+type envelope struct {
+	Algorithm  string `json:"alg"`
+	KeyID      string `json:"kid"`
+	DataKey    []byte `json:"dek"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// An EncryptedStore stores cloud credential attributes, controller
+// credentials and JWKS material in the database's secrets table,
+// envelope-encrypting every value with AES-GCM.
+//
+// Secrets that are not encrypted are rejected unless AllowPlaintext is
+// set. An existing deployment switching from plain text storage should
+// set AllowPlaintext until Reencrypt has encrypted every secret, and
+// then unset it. Reencrypt encrypts any such secrets, along with any
+// secrets encrypted with an old key, using the keyring's primary key.
+type EncryptedStore struct {
+	// DB is the database holding the secrets.
+	DB *db.Database
+
+	// Keys holds the key-encryption keys.
+	Keys *Keyring
+
+	// AllowPlaintext allows secrets stored in plain text to be read.
+	// This is only intended for use while migrating an existing
+	// deployment to encrypted storage.
+	AllowPlaintext bool
+}
+
+// Get retrieves the attributes for the given cloud credential.
+func (s *EncryptedStore) Get(ctx context.Context, tag names.CloudCredentialTag) (map[string]string, error) {
+	const op = errors.Op("encryptedstore.Get")
+
+	var attr map[string]string
+	if err := s.get(ctx, tag.Kind(), tag.String(), &attr); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return attr, nil
+}
+
+// Put stores the attributes associated with a cloud credential.
+func (s *EncryptedStore) Put(ctx context.Context, tag names.CloudCredentialTag, attr map[string]string) error {
+	const op = errors.Op("encryptedstore.Put")
+
+	if err := s.put(ctx, tag.Kind(), tag.String(), attr); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// GetControllerCredentials retrieves the credentials for the given
+// controller. A controller with no stored credentials returns an empty
+// username and password.
+func (s *EncryptedStore) GetControllerCredentials(ctx context.Context, controllerName string) (string, string, error) {
+	const op = errors.Op("encryptedstore.GetControllerCredentials")
+
+	var data map[string]string
+	err := s.get(ctx, names.ControllerTagKind, controllerName, &data)
+	if errors.ErrorCode(err) == errors.CodeNotFound {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", errors.E(op, err)
+	}
+	username, ok := data[usernameKey]
+	if !ok {
+		return "", "", errors.E(op, "missing username")
+	}
+	password, ok := data[passwordKey]
+	if !ok {
+		return "", "", errors.E(op, "missing password")
+	}
+	return username, password, nil
+}
+
+// PutControllerCredentials stores the credentials for the given
+// controller.
+func (s *EncryptedStore) PutControllerCredentials(ctx context.Context, controllerName string, username string, password string) error {
+	const op = errors.Op("encryptedstore.PutControllerCredentials")
+
+	data := map[string]string{
+		usernameKey: username,
+		passwordKey: password,
+	}
+	if err := s.put(ctx, names.ControllerTagKind, controllerName, data); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// CleanupJWKS removes all secrets associated with the JWKS process.
+func (s *EncryptedStore) CleanupJWKS(ctx context.Context) error {
+	const op = errors.Op("encryptedstore.CleanupJWKS")
+
+	for _, tag := range []string{jwksPublicKeyTag, jwksPrivateKeyTag, jwksExpiryTag} {
+		secret := dbmodel.NewSecret(jwksKind, tag, nil)
+		if err := s.DB.DeleteSecret(ctx, &secret); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	return nil
+}
+
+// GetJWKS returns the current key set.
+func (s *EncryptedStore) GetJWKS(ctx context.Context) (jwk.Set, error) {
+	const op = errors.Op("encryptedstore.GetJWKS")
+
+	var raw json.RawMessage
+	if err := s.get(ctx, jwksKind, jwksPublicKeyTag, &raw); err != nil {
+		return nil, errors.E(op, err)
+	}
+	ks, err := jwk.Parse(raw)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return ks, nil
+}
+
+// GetJWKSPrivateKey returns the current private key for the active JWKS.
+func (s *EncryptedStore) GetJWKSPrivateKey(ctx context.Context) ([]byte, error) {
+	const op = errors.Op("encryptedstore.GetJWKSPrivateKey")
+
+	var pem []byte
+	if err := s.get(ctx, jwksKind, jwksPrivateKeyTag, &pem); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return pem, nil
+}
+
+// GetJWKSExpiry returns the expiry of the active JWKS.
+func (s *EncryptedStore) GetJWKSExpiry(ctx context.Context) (time.Time, error) {
+	const op = errors.Op("encryptedstore.GetJWKSExpiry")
+
+	var expiry time.Time
+	if err := s.get(ctx, jwksKind, jwksExpiryTag, &expiry); err != nil {
+		return time.Time{}, errors.E(op, err)
+	}
+	return expiry, nil
+}
+
+// PutJWKS stores the given key set.
+func (s *EncryptedStore) PutJWKS(ctx context.Context, jwks jwk.Set) error {
+	const op = errors.Op("encryptedstore.PutJWKS")
+
+	if err := s.put(ctx, jwksKind, jwksPublicKeyTag, jwks); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// PutJWKSPrivateKey stores the private key associated with the current
+// JWKS.
+func (s *EncryptedStore) PutJWKSPrivateKey(ctx context.Context, pem []byte) error {
+	const op = errors.Op("encryptedstore.PutJWKSPrivateKey")
+
+	if err := s.put(ctx, jwksKind, jwksPrivateKeyTag, pem); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// PutJWKSExpiry stores the expiry time for the current JWKS.
+func (s *EncryptedStore) PutJWKSExpiry(ctx context.Context, expiry time.Time) error {
+	const op = errors.Op("encryptedstore.PutJWKSExpiry")
+
+	if err := s.put(ctx, jwksKind, jwksExpiryTag, expiry); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// Reencrypt encrypts every stored secret that is held in plain text, or
+// that was encrypted with a key other than the keyring's primary key,
+// with the primary key. Once Reencrypt has completed successfully any
+// old keys may be removed from the keyring. Secrets that are updated
+// while Reencrypt is running are left as written, as they will already
+// have been encrypted with the primary key. Reencrypt returns the number
+// of secrets that were re-encrypted.
+func (s *EncryptedStore) Reencrypt(ctx context.Context) (int, error) {
+	const op = errors.Op("encryptedstore.Reencrypt")
+
+	var updated, failed int
+	err := s.DB.ForEachSecret(ctx, func(secret *dbmodel.Secret) error {
+		switch secret.Type {
+		case names.CloudCredentialTagKind, names.ControllerTagKind, jwksKind:
+		default:
+			return nil
+		}
+		env, encrypted := parseEnvelope(secret.Data)
+		if encrypted && env.KeyID == s.Keys.primary().ID {
+			return nil
+		}
+		ok, err := s.reencrypt(ctx, secret)
+		if err != nil {
+			zapctx.Error(ctx, "failed to re-encrypt secret", zap.String("type", secret.Type), zap.String("tag", secret.Tag), zaputil.Error(err))
+			failed++
+			return nil
+		}
+		if ok {
+			updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return updated, errors.E(op, err)
+	}
+	if failed > 0 {
+		return updated, errors.E(op, fmt.Sprintf("%d secrets could not be re-encrypted", failed))
+	}
+	return updated, nil
+}
+
+func (s *EncryptedStore) reencrypt(ctx context.Context, secret *dbmodel.Secret) (bool, error) {
+	// Reencrypt always reads plain text secrets, as that is how they
+	// are migrated.
+	plaintext, err := s.decrypt(secret, true)
+	if err != nil {
+		return false, err
+	}
+	data, err := s.encrypt(secret.Type, secret.Tag, plaintext)
+	if err != nil {
+		return false, err
+	}
+	modified := secret.Time
+	secret.Time = time.Now()
+	secret.Data = data
+	return s.DB.UpdateSecretIfUnmodified(ctx, secret, modified)
+}
+
+// get reads and decrypts the secret with the given type and tag,
+// unmarshaling it into v.
+func (s *EncryptedStore) get(ctx context.Context, secretType, tag string, v interface{}) error {
+	secret := dbmodel.NewSecret(secretType, tag, nil)
+	if err := s.DB.GetSecret(ctx, &secret); err != nil {
+		return err
+	}
+	plaintext, err := s.decrypt(&secret, s.AllowPlaintext)
+	if err != nil {
+		zapctx.Error(ctx, "failed to decrypt secret", zap.String("type", secretType), zap.String("tag", tag), zaputil.Error(err))
+		return err
+	}
+	return json.Unmarshal(plaintext, v)
+}
+
+// put marshals, encrypts and stores v as the secret with the given type
+// and tag.
+func (s *EncryptedStore) put(ctx context.Context, secretType, tag string, v interface{}) error {
+	plaintext, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err := s.encrypt(secretType, tag, plaintext)
+	if err != nil {
+		return err
+	}
+	secret := dbmodel.NewSecret(secretType, tag, data)
+	return s.DB.UpsertSecret(ctx, &secret)
+}
+
+// encrypt encrypts the given plaintext for storage as the secret with
+// the given type and tag.
+func (s *EncryptedStore) encrypt(secretType, tag string, plaintext []byte) (dbmodel.JSON, error) {
+	kek := s.Keys.primary()
+	ad := additionalData(secretType, tag)
+	dek, err := newDataKey()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := seal(aead, plaintext, ad)
+	if err != nil {
+		return nil, err
+	}
+	sealedKey, err := seal(kek.aead, dek, ad)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope{
+		Algorithm:  algorithm,
+		KeyID:      kek.ID,
+		DataKey:    sealedKey,
+		Ciphertext: ciphertext,
+	})
+}
+
+// decrypt returns the plaintext of the given secret. Secrets stored in
+// plain text are returned unchanged if allowPlaintext is true, otherwise
+// they are rejected.
+func (s *EncryptedStore) decrypt(secret *dbmodel.Secret, allowPlaintext bool) ([]byte, error) {
+	env, ok := parseEnvelope(secret.Data)
+	if !ok {
+		if allowPlaintext {
+			return secret.Data, nil
+		}
+		return nil, errors.E(errors.CodeForbidden, "secret is not encrypted")
+	}
+	kek := s.Keys.key(env.KeyID)
+	if kek == nil {
+		return nil, errors.E(fmt.Sprintf("unknown encryption key %q", env.KeyID))
+	}
+	ad := additionalData(secret.Type, secret.Tag)
+	dek, err := open(kek.aead, env.DataKey, ad)
+	if err != nil {
+		return nil, errors.E(fmt.Sprintf("cannot decrypt data key: %s", err))
+	}
+	aead, err := newAEAD(dek)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(aead, env.Ciphertext, ad)
+	if err != nil {
+		return nil, errors.E(fmt.Sprintf("cannot decrypt secret: %s", err))
+	}
+	return plaintext, nil
+}
+
+// parseEnvelope parses the stored data of a secret, reporting whether it
+// is encrypted.
+func parseEnvelope(data []byte) (envelope, bool) {
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return envelope{}, false
+	}
+	return env, env.Algorithm == algorithm && env.KeyID != ""
+}
+
+func additionalData(secretType, tag string) []byte {
+	return []byte(secretType + "\x00" + tag)
+}
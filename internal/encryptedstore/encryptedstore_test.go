@@ -0,0 +1,270 @@
+// Copyright 2024 Canonical.
+
+package encryptedstore_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/names/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/encryptedstore"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+func newKeyring(c *qt.C, ids ...string) *encryptedstore.Keyring {
+	var keys []string
+	for _, id := range ids {
+		key, err := encryptedstore.NewKey()
+		c.Assert(err, qt.IsNil)
+		keys = append(keys, id+":"+key)
+	}
+	kr, err := encryptedstore.ParseKeyring(strings.Join(keys, ","))
+	c.Assert(err, qt.IsNil)
+	return kr
+}
+
+func TestParseKeyring(t *testing.T) {
+	c := qt.New(t)
+
+	key, err := encryptedstore.NewKey()
+	c.Assert(err, qt.IsNil)
+
+	tests := []struct {
+		keyring     string
+		expectError string
+	}{{
+		keyring: "k1:" + key,
+	}, {
+		keyring: "k2:" + key + ",\nk1:" + key + "\n",
+	}, {
+		keyring:     " \n",
+		expectError: `no keys specified`,
+	}, {
+		keyring:     key,
+		expectError: `invalid key, expected <id>:<base64-encoded-key>`,
+	}, {
+		keyring:     "k1:" + key + " k1:" + key,
+		expectError: `duplicate key "k1"`,
+	}, {
+		keyring:     "k1:AAAA",
+		expectError: `invalid key "k1": key must be 32 bytes`,
+	}, {
+		keyring:     "k1:!",
+		expectError: `invalid key "k1": .*`,
+	}}
+	for _, test := range tests {
+		_, err := encryptedstore.ParseKeyring(test.keyring)
+		if test.expectError == "" {
+			c.Check(err, qt.IsNil)
+			continue
+		}
+		c.Check(err, qt.ErrorMatches, test.expectError)
+		c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+	}
+}
+
+func TestEncryptedStore(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	database := &db.Database{
+		DB: jimmtest.PostgresDB(c, nil),
+	}
+	err := database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	store := &encryptedstore.EncryptedStore{
+		DB:   database,
+		Keys: newKeyring(c, "k1"),
+	}
+
+	tag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+	_, err = store.Get(ctx, tag)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	attrs := map[string]string{"username": "alice", "password": "secret"}
+	err = store.Put(ctx, tag, attrs)
+	c.Assert(err, qt.IsNil)
+	got, err := store.Get(ctx, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(got, qt.DeepEquals, attrs)
+
+	// The secret is not stored in plain text.
+	secret := dbmodel.Secret{Type: names.CloudCredentialTagKind, Tag: tag.String()}
+	err = database.GetSecret(ctx, &secret)
+	c.Assert(err, qt.IsNil)
+	c.Check(string(secret.Data), qt.Not(qt.Contains), "secret")
+
+	username, password, err := store.GetControllerCredentials(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(username, qt.Equals, "")
+	c.Check(password, qt.Equals, "")
+	err = store.PutControllerCredentials(ctx, "controller-1", "admin", "hunter2")
+	c.Assert(err, qt.IsNil)
+	username, password, err = store.GetControllerCredentials(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(username, qt.Equals, "admin")
+	c.Check(password, qt.Equals, "hunter2")
+
+	ks, err := jwk.ParseString(`{"keys":[{"kty":"oct","k":"AAAA","kid":"key-1"}]}`)
+	c.Assert(err, qt.IsNil)
+	err = store.PutJWKS(ctx, ks)
+	c.Assert(err, qt.IsNil)
+	gotKS, err := store.GetJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+	key, ok := gotKS.LookupKeyID("key-1")
+	c.Assert(ok, qt.IsTrue)
+	c.Check(key.KeyID(), qt.Equals, "key-1")
+
+	err = store.PutJWKSPrivateKey(ctx, []byte("private-key"))
+	c.Assert(err, qt.IsNil)
+	pem, err := store.GetJWKSPrivateKey(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(string(pem), qt.Equals, "private-key")
+
+	expiry := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	err = store.PutJWKSExpiry(ctx, expiry)
+	c.Assert(err, qt.IsNil)
+	gotExpiry, err := store.GetJWKSExpiry(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(gotExpiry.Equal(expiry), qt.IsTrue)
+
+	err = store.CleanupJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+	_, err = store.GetJWKS(ctx)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// An encrypted value cannot be moved to another secret.
+	tag2 := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-2")
+	moved := dbmodel.NewSecret(names.CloudCredentialTagKind, tag2.String(), secret.Data)
+	err = database.UpsertSecret(ctx, &moved)
+	c.Assert(err, qt.IsNil)
+	_, err = store.Get(ctx, tag2)
+	c.Check(err, qt.ErrorMatches, `cannot decrypt data key: .*`)
+}
+
+func TestEncryptedStoreReencrypt(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	database := &db.Database{
+		DB: jimmtest.PostgresDB(c, nil),
+	}
+	err := database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	// Store some secrets in plain text.
+	tag1 := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+	err = database.Put(ctx, tag1, map[string]string{"password": "plain"})
+	c.Assert(err, qt.IsNil)
+	err = database.PutControllerCredentials(ctx, "controller-1", "admin", "plain")
+	c.Assert(err, qt.IsNil)
+
+	k1, err := encryptedstore.NewKey()
+	c.Assert(err, qt.IsNil)
+	kr, err := encryptedstore.ParseKeyring("k1:" + k1)
+	c.Assert(err, qt.IsNil)
+	store := &encryptedstore.EncryptedStore{
+		DB:   database,
+		Keys: kr,
+	}
+
+	// Secrets stored in plain text are rejected unless the store is
+	// being migrated.
+	_, err = store.Get(ctx, tag1)
+	c.Check(err, qt.ErrorMatches, `secret is not encrypted`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeForbidden)
+	_, _, err = store.GetControllerCredentials(ctx, "controller-1")
+	c.Check(err, qt.ErrorMatches, `secret is not encrypted`)
+
+	store.AllowPlaintext = true
+	got, err := store.Get(ctx, tag1)
+	c.Assert(err, qt.IsNil)
+	c.Check(got, qt.DeepEquals, map[string]string{"password": "plain"})
+	store.AllowPlaintext = false
+
+	tag2 := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-2")
+	err = store.Put(ctx, tag2, map[string]string{"password": "k1"})
+	c.Assert(err, qt.IsNil)
+
+	// Re-encrypting encrypts the plain text secrets.
+	n, err := store.Reencrypt(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, 2)
+	c.Check(keyIDs(c, database), qt.DeepEquals, map[string]string{
+		tag1.String():  "k1",
+		tag2.String():  "k1",
+		"controller-1": "k1",
+	})
+
+	// Introduce a new primary key.
+	k2, err := encryptedstore.NewKey()
+	c.Assert(err, qt.IsNil)
+	kr, err = encryptedstore.ParseKeyring("k2:" + k2 + "," + "k1:" + k1)
+	c.Assert(err, qt.IsNil)
+	store.Keys = kr
+
+	got, err = store.Get(ctx, tag2)
+	c.Assert(err, qt.IsNil)
+	c.Check(got, qt.DeepEquals, map[string]string{"password": "k1"})
+
+	n, err = store.Reencrypt(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, 3)
+	c.Check(keyIDs(c, database), qt.DeepEquals, map[string]string{
+		tag1.String():  "k2",
+		tag2.String():  "k2",
+		"controller-1": "k2",
+	})
+
+	// The old key is no longer needed.
+	kr, err = encryptedstore.ParseKeyring("k2:" + k2)
+	c.Assert(err, qt.IsNil)
+	store.Keys = kr
+	got, err = store.Get(ctx, tag1)
+	c.Assert(err, qt.IsNil)
+	c.Check(got, qt.DeepEquals, map[string]string{"password": "plain"})
+	username, password, err := store.GetControllerCredentials(ctx, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(username, qt.Equals, "admin")
+	c.Check(password, qt.Equals, "plain")
+
+	// Nothing is left to re-encrypt.
+	n, err = store.Reencrypt(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(n, qt.Equals, 0)
+
+	// Secrets encrypted with an unknown key are reported.
+	store.Keys = newKeyring(c, "k3", "k1")
+	_, err = store.Get(ctx, tag1)
+	c.Check(err, qt.ErrorMatches, `unknown encryption key "k2"`)
+	_, err = store.Reencrypt(ctx)
+	c.Check(err, qt.ErrorMatches, `3 secrets could not be re-encrypted`)
+}
+
+// keyIDs returns the ID of the key used to encrypt each secret, indexed
+// by the secret's tag.
+func keyIDs(c *qt.C, database *db.Database) map[string]string {
+	ids := make(map[string]string)
+	err := database.ForEachSecret(context.Background(), func(secret *dbmodel.Secret) error {
+		var env struct {
+			KeyID string `json:"kid"`
+		}
+		if err := json.Unmarshal(secret.Data, &env); err != nil {
+			return err
+		}
+		ids[secret.Tag] = env.KeyID
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	return ids
+}
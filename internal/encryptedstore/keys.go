@@ -0,0 +1,158 @@
+// Copyright 2024 Canonical.
+
+package encryptedstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// keySize is the size, in bytes, of both key-encryption keys and the
+// data-encryption keys generated for each secret. Keys of this size
+// select AES-256.
+const keySize = 32
+
+// A Key is a named key-encryption key.
+type Key struct {
+	// ID identifies the key. The ID is stored alongside every secret
+	// encrypted with the key so that the key can be found when the
+	// secret is decrypted.
+	ID string
+
+	aead cipher.AEAD
+}
+
+// A Keyring holds the key-encryption keys used by an EncryptedStore.
+// The first key in the keyring is the primary key, which is used to
+// encrypt all new secrets. The remaining keys are only used to decrypt
+// secrets that were encrypted before the primary key was introduced.
+type Keyring struct {
+	keys []Key
+}
+
+// ParseKeyring parses a keyring from the given text. The text contains
+// one or more keys, separated by commas or white space, in the form
+// <id>:<base64-encoded-key>. Every key must be 32 bytes long. The first
+// key is the primary key. A new key can be introduced by adding it to the
+// start of the keyring, the old keys must be kept until every secret has
+// been re-encrypted with the new key.
+func ParseKeyring(s string) (*Keyring, error) {
+	const op = errors.Op("encryptedstore.ParseKeyring")
+
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	if len(fields) == 0 {
+		return nil, errors.E(op, errors.CodeBadRequest, "no keys specified")
+	}
+	var kr Keyring
+	seen := make(map[string]bool)
+	for _, f := range fields {
+		id, enc, ok := strings.Cut(f, ":")
+		if !ok || id == "" {
+			return nil, errors.E(op, errors.CodeBadRequest, "invalid key, expected <id>:<base64-encoded-key>")
+		}
+		if seen[id] {
+			return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("duplicate key %q", id))
+		}
+		seen[id] = true
+		key, err := base64.StdEncoding.DecodeString(enc)
+		if err != nil {
+			return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid key %q: %s", id, err))
+		}
+		if len(key) != keySize {
+			return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid key %q: key must be %d bytes", id, keySize))
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		kr.keys = append(kr.keys, Key{ID: id, aead: aead})
+	}
+	return &kr, nil
+}
+
+// LoadKeyring reads a keyring from the file at the given path. See
+// ParseKeyring for the format of the file.
+func LoadKeyring(path string) (*Keyring, error) {
+	const op = errors.Op("encryptedstore.LoadKeyring")
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	kr, err := ParseKeyring(string(buf))
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return kr, nil
+}
+
+// NewKey returns a new randomly generated key, encoded for use in a
+// keyring.
+func NewKey() (string, error) {
+	key, err := newDataKey()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// newDataKey returns a new randomly generated key.
+func newDataKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// primary returns the primary key.
+func (kr *Keyring) primary() *Key {
+	return &kr.keys[0]
+}
+
+// key returns the key with the given ID, or nil if there is no such key.
+func (kr *Keyring) key(id string) *Key {
+	for i := range kr.keys {
+		if kr.keys[i].ID == id {
+			return &kr.keys[i]
+		}
+	}
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the given plaintext with the given AEAD, returning the
+// nonce followed by the ciphertext.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts a value created by seal.
+func open(aead cipher.AEAD, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.E("invalid ciphertext")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
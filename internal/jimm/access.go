@@ -458,6 +458,10 @@ func (j *JIMM) ToJAASTag(ctx context.Context, tag *ofganames.Tag, resolveUUIDs b
 			return "", errors.E(err, fmt.Sprintf("failed to fetch cloud information: %s", cloud.Name))
 		}
 		return tagToString(names.CloudTagKind, cloud.Name), nil
+	case names.CloudCredentialTagKind:
+		return tagToString(names.CloudCredentialTagKind, tag.ID), nil
+	case jimmnames.CloudRegionTagKind:
+		return tagToString(jimmnames.CloudRegionTagKind, tag.ID), nil
 	default:
 		return "", errors.E(fmt.Sprintf("unexpected tag kind: %v", tag.Kind))
 	}
@@ -616,6 +620,51 @@ func (t *tagResolver) cloudTag(ctx context.Context, db *db.Database) (*ofga.Enti
 	return ofganames.ConvertTagWithRelation(cloud.ResourceTag(), t.relation), nil
 }
 
+func (t *tagResolver) cloudCredentialTag(ctx context.Context, db *db.Database) (*ofga.Entity, error) {
+	zapctx.Debug(
+		ctx,
+		"Resolving JIMM tags to Juju tags for tag kind: cloudcred",
+		zap.String("cloudcred-id", t.trailer),
+	)
+
+	if !names.IsValidCloudCredential(t.trailer) {
+		return nil, errors.E("cloud credential format incorrect, expected <cloud>/<owner>/<name>")
+	}
+	var credential dbmodel.CloudCredential
+	credential.SetTag(names.NewCloudCredentialTag(t.trailer))
+
+	err := db.GetCloudCredential(ctx, &credential)
+	if err != nil {
+		return nil, errors.E("cloud credential not found")
+	}
+
+	return ofganames.ConvertTagWithRelation(credential.ResourceTag(), t.relation), nil
+}
+
+func (t *tagResolver) cloudRegionTag(ctx context.Context, db *db.Database) (*ofga.Entity, error) {
+	zapctx.Debug(
+		ctx,
+		"Resolving JIMM tags to Juju tags for tag kind: cloudregion",
+		zap.String("cloudregion-id", t.trailer),
+	)
+
+	if !jimmnames.IsValidCloudRegion(t.trailer) {
+		return nil, errors.E("cloud region format incorrect, expected <cloud>/<region>")
+	}
+	rt := jimmnames.NewCloudRegionTag(t.trailer)
+	cloud := dbmodel.Cloud{Name: rt.Cloud().Id()}
+
+	err := db.GetCloud(ctx, &cloud)
+	if err != nil {
+		return nil, errors.E("cloud not found")
+	}
+	if cloud.Region(rt.Region()).ID == 0 {
+		return nil, errors.E("cloud region not found")
+	}
+
+	return ofganames.ConvertTagWithRelation(rt, t.relation), nil
+}
+
 func (t *tagResolver) serviceAccountTag(ctx context.Context) (*ofga.Entity, error) {
 	zapctx.Debug(
 		ctx,
@@ -656,6 +705,10 @@ func resolveTag(jimmUUID string, db *db.Database, tag string) (*ofganames.Tag, e
 		return resolver.applicationOfferTag(ctx, db)
 	case names.CloudTagKind:
 		return resolver.cloudTag(ctx, db)
+	case names.CloudCredentialTagKind:
+		return resolver.cloudCredentialTag(ctx, db)
+	case jimmnames.CloudRegionTagKind:
+		return resolver.cloudRegionTag(ctx, db)
 	case jimmnames.ServiceAccountTagKind:
 		return resolver.serviceAccountTag(ctx)
 	}
@@ -483,7 +483,7 @@ func TestResolveTags(t *testing.T) {
 	err := j.Database.Migrate(ctx, false)
 	c.Assert(err, qt.IsNil)
 
-	identity, group, controller, model, offer, cloud, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	identity, group, controller, model, offer, cloud, cred := createTestControllerEnvironment(ctx, c, j.Database)
 
 	testCases := []struct {
 		desc     string
@@ -537,6 +537,10 @@ func TestResolveTags(t *testing.T) {
 		desc:     "map cloud",
 		input:    "cloud-" + cloud.Name + "#administrator",
 		expected: ofganames.ConvertTagWithRelation(names.NewCloudTag(cloud.Name), ofganames.AdministratorRelation),
+	}, {
+		desc:     "map cloud credential",
+		input:    "cloudcred-" + cred.Path() + "#user",
+		expected: ofganames.ConvertTagWithRelation(cred.ResourceTag(), ofganames.UserRelation),
 	}}
 
 	for _, tC := range testCases {
@@ -601,6 +605,11 @@ func TestResolveTupleObjectHandlesErrors(t *testing.T) {
 			input: "applicationoffer-" + controller.Name + ":alex/" + model.Name + "." + offer.Name + "fluff",
 			want:  "application offer not found",
 		},
+		// Resolves bad cloud credentials where they do not exist
+		{
+			input: "cloudcred-test-cloud/alice@canonical.com/no-such-cred",
+			want:  "cloud credential not found",
+		},
 		{
 			input: "abc",
 			want:  "failed to setup tag resolver: tag is not properly formatted",
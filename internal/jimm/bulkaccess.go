@@ -0,0 +1,317 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+// bulkAccessBatchSize is the maximum number of tuples written to OpenFGA
+// in a single request. OpenFGA limits the number of writes per request
+// to 100.
+const bulkAccessBatchSize = 50
+
+// A ModelSelector selects models by their owner, controller, name and
+// labels. Empty fields match all models.
+type ModelSelector struct {
+	// Owner selects the models owned by the named identity.
+	Owner string
+
+	// Controller selects the models hosted on the named controller.
+	Controller string
+
+	// NameGlob selects the models whose name matches the glob pattern,
+	// using the syntax of path.Match.
+	NameGlob string
+
+	// LabelSelector selects the models whose labels match the selector.
+	LabelSelector LabelSelector
+}
+
+// empty reports whether the selector matches all models.
+func (s ModelSelector) empty() bool {
+	return s.Owner == "" && s.Controller == "" && s.NameGlob == "" && s.LabelSelector.Empty()
+}
+
+// administeredModels returns the models matching the selector that the
+// user administers. JIMM administrators administer every model, for
+// other users the candidate models are those OpenFGA reports the user
+// as administering.
+func (j *JIMM) administeredModels(ctx context.Context, user *openfga.User, sel ModelSelector) ([]dbmodel.Model, error) {
+	if sel.NameGlob != "" {
+		if _, err := path.Match(sel.NameGlob, ""); err != nil {
+			return nil, errors.E(errors.CodeBadRequest, "invalid name pattern")
+		}
+	}
+	filter := db.ModelFilter{
+		Owner:      sel.Owner,
+		Controller: sel.Controller,
+	}
+	var models []dbmodel.Model
+	if user.JimmAdmin {
+		var err error
+		models, err = j.Database.ListModels(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		uuids, err := user.ListModels(ctx, ofganames.AdministratorRelation)
+		if err != nil {
+			return nil, err
+		}
+		for len(uuids) > 0 {
+			n := min(len(uuids), modelListBatchSize)
+			filter.UUIDs = uuids[:n]
+			batch, err := j.Database.ListModels(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+			models = append(models, batch...)
+			uuids = uuids[n:]
+		}
+		slices.SortFunc(models, func(a, b dbmodel.Model) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+	}
+	var selected []dbmodel.Model
+	for _, m := range models {
+		if sel.NameGlob != "" {
+			if ok, _ := path.Match(sel.NameGlob, m.Name); !ok {
+				continue
+			}
+		}
+		if !sel.LabelSelector.Matches(m.Labels) {
+			continue
+		}
+		selected = append(selected, m)
+	}
+	return selected, nil
+}
+
+// BulkModelAccessParams holds the parameters for a bulk change to model
+// access.
+type BulkModelAccessParams struct {
+	// Revoke determines whether access is revoked, rather than granted.
+	Revoke bool
+
+	// Entity is the tag of the user or group whose access is changed,
+	// for example "user-bob@canonical.com" or "group-sre".
+	Entity string
+
+	// Access is the access level, one of "read", "write" or "admin".
+	Access string
+
+	// Selector selects the models to change.
+	Selector ModelSelector
+
+	// DryRun determines whether the affected models are only listed,
+	// without changing any access.
+	DryRun bool
+}
+
+// BulkModelAccessResult holds the result of a bulk change to model access.
+type BulkModelAccessResult struct {
+	// CorrelationID identifies the audit log entry recording the
+	// change. It is empty for a dry run.
+	CorrelationID string
+
+	// Models holds the models whose access was, or in a dry run would
+	// be, changed.
+	Models []dbmodel.Model
+}
+
+// BulkModelAccess grants or revokes a relation between a user or group and
+// every model matching the selector. Only the models the user administers
+// are changed. As with GrantModelAccess, a grant leaves alone any model
+// on which the entity already has the given access, or a higher level,
+// whether directly or through another relation. A revoke removes the given access level and any higher
+// level, in the same way as RevokeModelAccess. The tuple changes are
+// written to OpenFGA in batches and the whole change is recorded in a
+// single audit log entry. If writing a batch fails the earlier batches
+// remain applied; the audit log entry records the tuples that were
+// written and the error, and the returned result, along with the error,
+// holds only the models whose access was changed.
+func (j *JIMM) BulkModelAccess(ctx context.Context, user *openfga.User, p BulkModelAccessParams) (*BulkModelAccessResult, error) {
+	const op = errors.Op("jimm.BulkModelAccess")
+
+	if p.Selector.empty() {
+		return nil, errors.E(op, errors.CodeBadRequest, "a model selector must be specified")
+	}
+	relation, err := ToModelRelation(p.Access)
+	if err != nil {
+		return nil, errors.E(op, errors.CodeBadRequest, err)
+	}
+	entity, err := j.ParseTag(ctx, p.Entity)
+	if err != nil {
+		return nil, errors.E(op, errors.CodeBadRequest, err)
+	}
+	switch entity.Kind {
+	case openfga.UserType:
+	case openfga.GroupType:
+		entity.Relation = ofganames.MemberRelation
+	default:
+		return nil, errors.E(op, errors.CodeBadRequest, "invalid entity - not user or group")
+	}
+	relations := []openfga.Relation{relation}
+	if p.Revoke {
+		switch relation {
+		case ofganames.ReaderRelation:
+			relations = []openfga.Relation{ofganames.ReaderRelation, ofganames.WriterRelation, ofganames.AdministratorRelation}
+		case ofganames.WriterRelation:
+			relations = []openfga.Relation{ofganames.WriterRelation, ofganames.AdministratorRelation}
+		}
+	}
+
+	models, err := j.administeredModels(ctx, user, p.Selector)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	var res BulkModelAccessResult
+	var changes []bulkAccessChange
+	for _, m := range models {
+		target := ofganames.ConvertTag(m.ResourceTag())
+		changed := false
+		for _, r := range relations {
+			t := openfga.Tuple{
+				Object:   entity,
+				Relation: r,
+				Target:   target,
+			}
+			var needed bool
+			if p.Revoke {
+				// Only directly written tuples can be removed.
+				existing, _, err := j.OpenFGAClient.ReadRelatedObjects(ctx, t, 1, "")
+				if err != nil {
+					return nil, errors.E(op, err)
+				}
+				needed = len(existing) > 0
+			} else {
+				// As in GrantModelAccess, nothing is written if the
+				// entity already has the access, or a higher level,
+				// through any relation.
+				allowed, err := j.OpenFGAClient.CheckRelation(ctx, t, false)
+				if err != nil {
+					return nil, errors.E(op, err)
+				}
+				needed = !allowed
+			}
+			if needed {
+				changes = append(changes, bulkAccessChange{model: len(res.Models), tuple: t})
+				changed = true
+			}
+		}
+		if changed {
+			res.Models = append(res.Models, m)
+		}
+	}
+	if p.DryRun || len(res.Models) == 0 {
+		return &res, nil
+	}
+
+	res.CorrelationID = uuid.NewString()
+	ctx = zapctx.WithFields(ctx, zap.String("correlation-id", res.CorrelationID))
+	var applied []bulkAccessChange
+	for len(changes) > 0 {
+		n := min(len(changes), bulkAccessBatchSize)
+		batch := make([]openfga.Tuple, n)
+		for i, c := range changes[:n] {
+			batch[i] = c.tuple
+		}
+		if p.Revoke {
+			err = j.OpenFGAClient.RemoveRelation(ctx, batch...)
+		} else {
+			err = j.OpenFGAClient.AddRelation(ctx, batch...)
+		}
+		if err != nil {
+			break
+		}
+		applied = append(applied, changes[:n]...)
+		changes = changes[n:]
+	}
+	if err == nil {
+		j.addBulkModelAccessAuditLogEntry(user, p, &res, applied, nil)
+		return &res, nil
+	}
+
+	// Earlier batches have already been written, so record the partial
+	// change and report only the models whose access was changed.
+	zapctx.Error(ctx, "failed to write bulk model access tuples", zap.Int("applied", len(applied)), zaputil.Error(err))
+	var partial []dbmodel.Model
+	for i, c := range applied {
+		if i == 0 || applied[i-1].model != c.model {
+			partial = append(partial, res.Models[c.model])
+		}
+	}
+	res.Models = partial
+	j.addBulkModelAccessAuditLogEntry(user, p, &res, applied, err)
+	return &res, errors.E(op, errors.CodeOpenFGARequestFailed, fmt.Sprintf("access changed for %d models before failure (correlation ID %s): %s", len(partial), res.CorrelationID, err), err)
+}
+
+// A bulkAccessChange is a tuple written by BulkModelAccess, along with
+// the index of the model it applies to in the result.
+type bulkAccessChange struct {
+	model int
+	tuple openfga.Tuple
+}
+
+// addBulkModelAccessAuditLogEntry records a bulk change to model access in
+// the audit log, including the tuples that were written and any error
+// that stopped the change.
+func (j *JIMM) addBulkModelAccessAuditLogEntry(user *openfga.User, p BulkModelAccessParams, res *BulkModelAccessResult, applied []bulkAccessChange, changeErr error) {
+	action := "grant"
+	if p.Revoke {
+		action = "revoke"
+	}
+	uuids := make([]string, len(res.Models))
+	for i, m := range res.Models {
+		uuids[i] = m.UUID.String
+	}
+	tuples := make([]map[string]string, len(applied))
+	for i, c := range applied {
+		tuples[i] = map[string]string{
+			"relation": c.tuple.Relation.String(),
+			"target":   c.tuple.Target.String(),
+		}
+	}
+	params := map[string]interface{}{
+		"correlation-id": res.CorrelationID,
+		"action":         action,
+		"entity":         p.Entity,
+		"access":         p.Access,
+		"models":         uuids,
+		"tuples":         tuples,
+	}
+	if changeErr != nil {
+		params["error"] = changeErr.Error()
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		zapctx.Error(context.Background(), "failed to marshal bulk model access audit parameters", zaputil.Error(err))
+		return
+	}
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "JIMM",
+		FacadeMethod: "BulkModelAccess",
+		IdentityTag:  user.Tag().String(),
+		Params:       body,
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+func TestBulkModelAccess(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{},
+		},
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, forEachModelTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	group, err := j.Database.AddGroup(ctx, "sre")
+	c.Assert(err, qt.IsNil)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+
+	groupTuple := func(uuid string) openfga.Tuple {
+		return openfga.Tuple{
+			Object:   ofganames.ConvertTagWithRelation(jimmnames.NewGroupTag(group.UUID), ofganames.MemberRelation),
+			Relation: ofganames.WriterRelation,
+			Target:   ofganames.ConvertTag(names.NewModelTag(uuid)),
+		}
+	}
+	hasTuple := func(uuid string) bool {
+		tuples, _, err := client.ReadRelatedObjects(ctx, groupTuple(uuid), 1, "")
+		c.Assert(err, qt.IsNil)
+		return len(tuples) > 0
+	}
+
+	p := jimm.BulkModelAccessParams{
+		Entity: "group-sre",
+		Access: "write",
+		Selector: jimm.ModelSelector{
+			Owner:    "alice@canonical.com",
+			NameGlob: "model-[12]",
+		},
+		DryRun: true,
+	}
+	res, err := j.BulkModelAccess(ctx, alice, p)
+	c.Assert(err, qt.IsNil)
+	c.Check(res.CorrelationID, qt.Equals, "")
+	c.Assert(res.Models, qt.HasLen, 2)
+	c.Check(res.Models[0].Name, qt.Equals, "model-1")
+	c.Check(res.Models[1].Name, qt.Equals, "model-2")
+	c.Check(hasTuple(res.Models[0].UUID.String), qt.IsFalse)
+
+	p.DryRun = false
+	res, err = j.BulkModelAccess(ctx, alice, p)
+	c.Assert(err, qt.IsNil)
+	c.Check(res.CorrelationID, qt.Not(qt.Equals), "")
+	c.Assert(res.Models, qt.HasLen, 2)
+	c.Check(hasTuple(res.Models[0].UUID.String), qt.IsTrue)
+	c.Check(hasTuple(res.Models[1].UUID.String), qt.IsTrue)
+	c.Check(hasTuple("00000002-0000-0000-0000-000000000003"), qt.IsFalse)
+
+	p.Revoke = true
+	p.Access = "read"
+	p.Selector = jimm.ModelSelector{NameGlob: "model-2"}
+	res, err = j.BulkModelAccess(ctx, alice, p)
+	c.Assert(err, qt.IsNil)
+	c.Assert(res.Models, qt.HasLen, 1)
+	c.Check(hasTuple("00000002-0000-0000-0000-000000000001"), qt.IsTrue)
+	c.Check(hasTuple("00000002-0000-0000-0000-000000000002"), qt.IsFalse)
+
+	// bob only administers model-1, on which the group already has
+	// write access, so granting read access changes nothing.
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+	p = jimm.BulkModelAccessParams{
+		Entity:   "group-sre",
+		Access:   "read",
+		Selector: jimm.ModelSelector{NameGlob: "*"},
+	}
+	res, err = j.BulkModelAccess(ctx, bob, p)
+	c.Assert(err, qt.IsNil)
+	c.Check(res.Models, qt.HasLen, 0)
+	c.Check(res.CorrelationID, qt.Equals, "")
+
+	p.Access = "admin"
+	p.DryRun = true
+	res, err = j.BulkModelAccess(ctx, bob, p)
+	c.Assert(err, qt.IsNil)
+	c.Assert(res.Models, qt.HasLen, 1)
+	c.Check(res.Models[0].Name, qt.Equals, "model-1")
+
+	_, err = j.BulkModelAccess(ctx, alice, jimm.BulkModelAccessParams{Entity: "group-sre", Access: "write"})
+	c.Check(err, qt.ErrorMatches, `a model selector must be specified`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	_, err = j.BulkModelAccess(ctx, alice, jimm.BulkModelAccessParams{
+		Entity:   "controller-jimm",
+		Access:   "write",
+		Selector: jimm.ModelSelector{NameGlob: "*"},
+	})
+	c.Check(err, qt.ErrorMatches, `invalid entity - not user or group`)
+}
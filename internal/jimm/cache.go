@@ -6,6 +6,7 @@ import (
 	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/juju/names/v5"
 	"github.com/juju/zaputil/zapctx"
@@ -13,14 +14,47 @@ import (
 	"golang.org/x/sync/singleflight"
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
 )
 
+// CacheDialerParams holds the parameters used to configure the pool of
+// connections kept by a cache dialer.
+type CacheDialerParams struct {
+	// MaxConnections is the maximum number of connections that will be
+	// kept to each controller. When all connections to a controller are
+	// in use and the limit has been reached the connections are shared.
+	// If this is zero or less a single connection is kept.
+	MaxConnections int
+
+	// IdleTimeout is the length of time a connection that is not being
+	// used will be kept in the pool before it is closed. If this is
+	// zero unused connections are kept indefinitely.
+	IdleTimeout time.Duration
+
+	// MaxLifetime is the maximum length of time a connection will be
+	// handed out by the pool after it was established. Once a connection
+	// has expired it is closed when all current users have finished
+	// with it. If this is zero connections do not expire.
+	MaxLifetime time.Duration
+}
+
 // CacheDialer wraps the given Dialer in a cache that will share controller
 // connections between a number of operations.
 func CacheDialer(d Dialer) Dialer {
+	return NewCacheDialer(d, CacheDialerParams{})
+}
+
+// NewCacheDialer wraps the given Dialer in a cache that keeps a pool of
+// connections to each controller configured using the given parameters.
+func NewCacheDialer(d Dialer, p CacheDialerParams) Dialer {
+	if p.MaxConnections <= 0 {
+		p.MaxConnections = 1
+	}
 	return &cacheDialer{
 		dialer: d,
-		conns:  make(map[string]cachedAPI),
+		params: p,
+		pools:  make(map[string][]*pooledAPI),
 	}
 }
 
@@ -32,9 +66,26 @@ type cacheDialer struct {
 	// not in the cache.
 	dialer Dialer
 
+	// params configures the connection pool.
+	params CacheDialerParams
+
 	sfg   singleflight.Group
 	mu    sync.Mutex
-	conns map[string]cachedAPI
+	pools map[string][]*pooledAPI
+}
+
+// A pooledAPI is a connection held in a cacheDialer's pool.
+type pooledAPI struct {
+	cachedAPI
+
+	// created is the time the connection was established.
+	created time.Time
+}
+
+// inUse returns the number of operations currently using the connection.
+func (p *pooledAPI) inUse() int64 {
+	// The pool holds one reference itself.
+	return atomic.LoadInt64(p.refCount) - 1
 }
 
 // Dial implements Dialer.Dial.
@@ -43,6 +94,15 @@ func (d *cacheDialer) Dial(ctx context.Context, ctl *dbmodel.Controller, mt name
 		// connections to models are rare, so we don't cache them.
 		return d.dialer.Dial(ctx, ctl, mt, requiredPermissions)
 	}
+	durationObserver := servermon.DurationObserver(servermon.ConnectionPoolWaitDurationHistogram, ctl.Name)
+	defer durationObserver()
+
+	if api := d.get(ctx, ctl.Name); api != nil {
+		servermon.ConnectionPoolHitCount.WithLabelValues(ctl.Name).Inc()
+		return api, nil
+	}
+	servermon.ConnectionPoolMissCount.WithLabelValues(ctl.Name).Inc()
+
 	rc := d.sfg.DoChan(ctl.Name, func() (interface{}, error) {
 		return d.dial(ctx, ctl, requiredPermissions)
 	})
@@ -51,23 +111,47 @@ func (d *cacheDialer) Dial(ctx context.Context, ctl *dbmodel.Controller, mt name
 		if r.Err != nil {
 			return nil, r.Err
 		}
-		return r.Val.(cachedAPI).Clone(), nil
+		return r.Val.(*pooledAPI).Clone(), nil
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
 }
 
+// get returns a working connection to the named controller from the
+// pool. A connection is only returned if one is idle, or if the pool is
+// full in which case the least used connection is shared. If there is no
+// suitable connection nil is returned and the caller should dial a new
+// one.
+func (d *cacheDialer) get(ctx context.Context, name string) API {
+	for {
+		d.mu.Lock()
+		d.evict(time.Now())
+		papi := d.leastUsed(name)
+		if papi == nil || (papi.inUse() > 0 && len(d.pools[name]) < d.params.MaxConnections) {
+			d.mu.Unlock()
+			return nil
+		}
+		api := papi.Clone()
+		d.mu.Unlock()
+
+		err := api.Ping(ctx)
+		if err == nil {
+			return api
+		}
+		zapctx.Warn(ctx, "cached connection failed", zap.Error(err))
+		api.Close()
+		d.remove(name, papi)
+	}
+}
+
 func (d *cacheDialer) dial(ctx context.Context, ctl *dbmodel.Controller, requiredPermissions map[string]string) (interface{}, error) {
 	d.mu.Lock()
-	capi, ok := d.conns[ctl.Name]
-	if ok {
-		if err := capi.Ping(ctx); err == nil {
+	if len(d.pools[ctl.Name]) >= d.params.MaxConnections {
+		// Another connection was added to the pool whilst this
+		// request was waiting to dial, use that instead.
+		if papi := d.leastUsed(ctl.Name); papi != nil {
 			d.mu.Unlock()
-			return capi, nil
-		} else {
-			zapctx.Warn(ctx, "cached connection failed", zap.Error(err))
-			delete(d.conns, ctl.Name)
-			capi.Close()
+			return papi, nil
 		}
 	}
 	d.mu.Unlock()
@@ -77,16 +161,139 @@ func (d *cacheDialer) dial(ctx context.Context, ctl *dbmodel.Controller, require
 	if err != nil {
 		return nil, err
 	}
-	capi = cachedAPI{
-		API:      api,
-		refCount: new(int64),
-		closed:   new(uint32),
+	papi := &pooledAPI{
+		cachedAPI: cachedAPI{
+			API:      api,
+			refCount: new(int64),
+			closed:   new(uint32),
+			lastUsed: new(int64),
+		},
+		created: time.Now(),
+	}
+	atomic.StoreInt64(papi.refCount, 1)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pools[ctl.Name] = append(d.pools[ctl.Name], papi)
+	servermon.ConnectionPoolSize.WithLabelValues(ctl.Name).Set(float64(len(d.pools[ctl.Name])))
+	return papi, nil
+}
+
+// leastUsed returns the connection to the named controller with the
+// fewest current users, or nil if there are no connections. The caller
+// must hold d.mu.
+func (d *cacheDialer) leastUsed(name string) *pooledAPI {
+	var best *pooledAPI
+	for _, papi := range d.pools[name] {
+		if best == nil || papi.inUse() < best.inUse() {
+			best = papi
+		}
 	}
-	atomic.StoreInt64(capi.refCount, 1)
+	return best
+}
+
+// remove removes the given connection from the named controller's pool
+// and releases the pool's reference to it.
+func (d *cacheDialer) remove(name string, papi *pooledAPI) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	d.conns[ctl.Name] = capi
-	return capi, nil
+	d.removeLocked(name, papi)
+}
+
+// removeLocked removes the given connection from the named controller's
+// pool and releases the pool's reference to it. The caller must hold
+// d.mu.
+func (d *cacheDialer) removeLocked(name string, papi *pooledAPI) {
+	pool := d.pools[name]
+	for i, p := range pool {
+		if p != papi {
+			continue
+		}
+		pool = append(pool[:i], pool[i+1:]...)
+		papi.Close()
+		break
+	}
+	if len(pool) == 0 {
+		delete(d.pools, name)
+	} else {
+		d.pools[name] = pool
+	}
+	servermon.ConnectionPoolSize.WithLabelValues(name).Set(float64(len(pool)))
+}
+
+// evict removes connections that have passed their maximum lifetime, or
+// have been unused for longer than the idle timeout, from all pools. The
+// caller must hold d.mu.
+func (d *cacheDialer) evict(now time.Time) {
+	if d.params.IdleTimeout <= 0 && d.params.MaxLifetime <= 0 {
+		return
+	}
+	for name, pool := range d.pools {
+		var expired []*pooledAPI
+		for _, papi := range pool {
+			if d.params.MaxLifetime > 0 && now.Sub(papi.created) > d.params.MaxLifetime {
+				expired = append(expired, papi)
+				continue
+			}
+			if d.params.IdleTimeout > 0 && papi.inUse() == 0 && now.Sub(papi.idleSince()) > d.params.IdleTimeout {
+				expired = append(expired, papi)
+			}
+		}
+		for _, papi := range expired {
+			d.removeLocked(name, papi)
+		}
+	}
+}
+
+// EvictConnections implements ConnectionEvictor. Expired connections are
+// otherwise only evicted when a connection is requested, so without this
+// a pool that is no longer used would keep its connections open
+// indefinitely. The pools are checked at half the shorter of the idle
+// timeout and maximum lifetime. If neither limit is set
+// EvictConnections returns immediately.
+func (d *cacheDialer) EvictConnections(ctx context.Context) error {
+	interval := d.params.IdleTimeout
+	if interval <= 0 || (d.params.MaxLifetime > 0 && d.params.MaxLifetime < interval) {
+		interval = d.params.MaxLifetime
+	}
+	if interval <= 0 {
+		return nil
+	}
+	t := time.NewTicker(interval / 2)
+	defer t.Stop()
+	for {
+		select {
+		case now := <-t.C:
+			d.mu.Lock()
+			d.evict(now)
+			d.mu.Unlock()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// InvalidateControllerConnections implements
+// ControllerConnectionInvalidator. Any cached connections to the named
+// controller are removed from the cache, the connections will be closed
+// once all current users have finished with them.
+func (d *cacheDialer) InvalidateControllerConnections(controllerName string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, papi := range d.pools[controllerName] {
+		papi.Close()
+	}
+	delete(d.pools, controllerName)
+	servermon.ConnectionPoolSize.WithLabelValues(controllerName).Set(0)
+}
+
+// CheckPasswordLogin implements PasswordLoginChecker by delegating to the
+// wrapped Dialer. If the wrapped Dialer cannot check password logins an
+// error with a code of CodeNotImplemented is returned.
+func (d *cacheDialer) CheckPasswordLogin(ctx context.Context, ctl *dbmodel.Controller, username, password string) error {
+	if plc, ok := d.dialer.(PasswordLoginChecker); ok {
+		return plc.CheckPasswordLogin(ctx, ctl, username, password)
+	}
+	return errors.E(errors.CodeNotImplemented)
 }
 
 // Close implements io.Closer.
@@ -94,11 +301,14 @@ func (d *cacheDialer) Close() error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	var firstErr error
-	for k, v := range d.conns {
-		delete(d.conns, k)
-		if err := v.Close(); err != nil && firstErr == nil {
-			firstErr = err
+	for k, pool := range d.pools {
+		delete(d.pools, k)
+		for _, papi := range pool {
+			if err := papi.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
 		}
+		servermon.ConnectionPoolSize.WithLabelValues(k).Set(0)
 	}
 	return firstErr
 }
@@ -110,6 +320,10 @@ type cachedAPI struct {
 	// refCount reaches 0 the underlying connection is closed.
 	refCount *int64
 	closed   *uint32
+
+	// lastUsed holds the time, in nanoseconds since the unix epoch,
+	// that the connection was last released by a user.
+	lastUsed *int64
 }
 
 // Close implements API.Close()
@@ -118,6 +332,9 @@ func (a cachedAPI) Close() error {
 	if !atomic.CompareAndSwapUint32(a.closed, 0, 1) {
 		return nil
 	}
+	if a.lastUsed != nil {
+		atomic.StoreInt64(a.lastUsed, time.Now().UnixNano())
+	}
 	if atomic.AddInt64(a.refCount, -1) > 0 {
 		return nil
 	}
@@ -133,5 +350,15 @@ func (a cachedAPI) Clone() API {
 		API:      a.API,
 		refCount: a.refCount,
 		closed:   closed,
+		lastUsed: a.lastUsed,
+	}
+}
+
+// idleSince returns the time the connection was last released, or when
+// it was created if it has never been used.
+func (p *pooledAPI) idleSince() time.Time {
+	if t := atomic.LoadInt64(p.lastUsed); t != 0 {
+		return time.Unix(0, t)
 	}
+	return p.created
 }
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/juju/names/v5"
@@ -151,6 +152,212 @@ func TestCacheDialerCloseBrokenConnection(t *testing.T) {
 	c.Check(atomic.LoadInt64(&testAPI.count), qt.Equals, int64(1))
 }
 
+func TestCacheDialerInvalidateControllerConnections(t *testing.T) {
+	c := qt.New(t)
+
+	testAPI := closeCountingAPI{
+		API: &jimmtest.API{},
+	}
+	testDialer := &countingDialer{
+		dialer: &jimmtest.Dialer{
+			API: &testAPI,
+		},
+	}
+	dialer := jimm.CacheDialer(testDialer)
+	ctl := dbmodel.Controller{
+		Name: "test-controller",
+	}
+
+	api, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+
+	dialer.(jimm.ControllerConnectionInvalidator).InvalidateControllerConnections("test-controller")
+	// The connection is still in use so must not have been closed.
+	c.Check(atomic.LoadInt64(&testAPI.count), qt.Equals, int64(0))
+	err = api.Close()
+	c.Assert(err, qt.IsNil)
+	c.Check(atomic.LoadInt64(&testAPI.count), qt.Equals, int64(1))
+
+	api, err = dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	err = api.Close()
+	c.Assert(err, qt.IsNil)
+	c.Check(atomic.LoadInt64(&testDialer.count), qt.Equals, int64(2))
+}
+
+func TestCacheDialerCheckPasswordLogin(t *testing.T) {
+	c := qt.New(t)
+
+	dialer := jimm.CacheDialer(&jimmtest.Dialer{})
+	ctl := dbmodel.Controller{
+		Name: "test-controller",
+	}
+	err := dialer.(jimm.PasswordLoginChecker).CheckPasswordLogin(context.Background(), &ctl, "admin", "secret")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotImplemented)
+
+	dialer = jimm.CacheDialer(&jimmtest.Dialer{
+		CheckPasswordLogin_: func(_ context.Context, _ *dbmodel.Controller, username, password string) error {
+			if username != "admin" || password != "secret" {
+				return errors.E("authentication failed")
+			}
+			return nil
+		},
+	})
+	err = dialer.(jimm.PasswordLoginChecker).CheckPasswordLogin(context.Background(), &ctl, "admin", "secret")
+	c.Check(err, qt.IsNil)
+	err = dialer.(jimm.PasswordLoginChecker).CheckPasswordLogin(context.Background(), &ctl, "admin", "wrong")
+	c.Check(err, qt.ErrorMatches, "authentication failed")
+}
+
+func TestCacheDialerPoolMaxConnections(t *testing.T) {
+	c := qt.New(t)
+
+	testDialer := &countingDialer{
+		dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{},
+		},
+	}
+	dialer := jimm.NewCacheDialer(testDialer, jimm.CacheDialerParams{
+		MaxConnections: 2,
+	})
+	ctl := dbmodel.Controller{
+		Name: "test-controller",
+	}
+
+	// While connections are in use new ones are dialed up to the limit.
+	api1, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	api2, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(atomic.LoadInt64(&testDialer.count), qt.Equals, int64(2))
+
+	// Once the limit is reached connections are shared.
+	api3, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(atomic.LoadInt64(&testDialer.count), qt.Equals, int64(2))
+
+	// Idle connections are reused.
+	c.Assert(api1.Close(), qt.IsNil)
+	c.Assert(api2.Close(), qt.IsNil)
+	c.Assert(api3.Close(), qt.IsNil)
+	api4, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(api4.Close(), qt.IsNil)
+	c.Check(atomic.LoadInt64(&testDialer.count), qt.Equals, int64(2))
+
+	err = dialer.(io.Closer).Close()
+	c.Check(err, qt.IsNil)
+	c.Check(testDialer.dialer.(*jimmtest.Dialer).IsClosed(), qt.Equals, true)
+}
+
+func TestCacheDialerPoolIdleTimeout(t *testing.T) {
+	c := qt.New(t)
+
+	testAPI := closeCountingAPI{
+		API: &jimmtest.API{},
+	}
+	testDialer := &countingDialer{
+		dialer: &jimmtest.Dialer{
+			API: &testAPI,
+		},
+	}
+	dialer := jimm.NewCacheDialer(testDialer, jimm.CacheDialerParams{
+		IdleTimeout: time.Millisecond,
+	})
+	ctl := dbmodel.Controller{
+		Name: "test-controller",
+	}
+
+	api, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	// A connection in use is never idle.
+	time.Sleep(10 * time.Millisecond)
+	api2, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(atomic.LoadInt64(&testDialer.count), qt.Equals, int64(1))
+	c.Assert(api.Close(), qt.IsNil)
+	c.Assert(api2.Close(), qt.IsNil)
+
+	time.Sleep(10 * time.Millisecond)
+	api, err = dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(api.Close(), qt.IsNil)
+	c.Check(atomic.LoadInt64(&testDialer.count), qt.Equals, int64(2))
+	c.Check(atomic.LoadInt64(&testAPI.count), qt.Equals, int64(1))
+}
+
+func TestCacheDialerEvictConnections(t *testing.T) {
+	c := qt.New(t)
+
+	testAPI := closeCountingAPI{
+		API: &jimmtest.API{},
+	}
+	testDialer := &countingDialer{
+		dialer: &jimmtest.Dialer{
+			API: &testAPI,
+		},
+	}
+	dialer := jimm.NewCacheDialer(testDialer, jimm.CacheDialerParams{
+		IdleTimeout: 10 * time.Millisecond,
+	})
+	ctl := dbmodel.Controller{
+		Name: "test-controller",
+	}
+
+	api, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Assert(api.Close(), qt.IsNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- dialer.(jimm.ConnectionEvictor).EvictConnections(ctx)
+	}()
+
+	// The idle connection is closed without any further dials.
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt64(&testAPI.count) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	c.Check(atomic.LoadInt64(&testAPI.count), qt.Equals, int64(1))
+
+	cancel()
+	c.Check(<-done, qt.IsNil)
+}
+
+func TestCacheDialerPoolMaxLifetime(t *testing.T) {
+	c := qt.New(t)
+
+	testAPI := closeCountingAPI{
+		API: &jimmtest.API{},
+	}
+	testDialer := &countingDialer{
+		dialer: &jimmtest.Dialer{
+			API: &testAPI,
+		},
+	}
+	dialer := jimm.NewCacheDialer(testDialer, jimm.CacheDialerParams{
+		MaxLifetime: time.Millisecond,
+	})
+	ctl := dbmodel.Controller{
+		Name: "test-controller",
+	}
+
+	api, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	time.Sleep(10 * time.Millisecond)
+
+	// The expired connection is no longer handed out, but remains open
+	// until it is no longer in use.
+	api2, err := dialer.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(atomic.LoadInt64(&testDialer.count), qt.Equals, int64(2))
+	c.Check(atomic.LoadInt64(&testAPI.count), qt.Equals, int64(0))
+	c.Assert(api.Close(), qt.IsNil)
+	c.Check(atomic.LoadInt64(&testAPI.count), qt.Equals, int64(1))
+	c.Assert(api2.Close(), qt.IsNil)
+}
+
 type countingDialer struct {
 	dialer jimm.Dialer
 	count  int64
@@ -525,10 +525,31 @@ func (j *JIMM) RevokeCloudAccess(ctx context.Context, user *openfga.User, ct nam
 // then an error with the code CodeNotFound is returned. If the
 // authenticated user does not have admin access to the cloud then an error
 // with the code CodeUnauthorized is returned. If the RemoveClouds API call
-// returns an error the error code is not masked.
+// returns an error the error code is not masked. A cloud in the cloud
+// catalogue that is not hosted by any controller is removed from JAAS
+// directly.
 func (j *JIMM) RemoveCloud(ctx context.Context, user *openfga.User, ct names.CloudTag) error {
 	const op = errors.Op("jimm.RemoveCloud")
 
+	var c dbmodel.Cloud
+	c.SetTag(ct)
+	if err := j.Database.GetCloud(ctx, &c); err != nil {
+		return errors.E(op, err)
+	}
+	if len(cloudControllers(&c)) == 0 {
+		if err := j.checkCloudAdminAccess(ctx, user, &c); err != nil {
+			return errors.E(op, err)
+		}
+		if err := j.Database.DeleteCloud(ctx, &c); err != nil {
+			return errors.E(op, err)
+		}
+		if err := j.OpenFGAClient.RemoveCloud(ctx, ct); err != nil {
+			zapctx.Error(ctx, "failed to remove cloud from openfga", zap.String("cloud", ct.Id()), zap.Error(err))
+		}
+		j.removeCloudRegions(ctx, &c)
+		return nil
+	}
+
 	err := j.doCloudAdmin(ctx, user, ct, func(c *dbmodel.Cloud, api API) error {
 		// Note: JIMM doesn't attempt to determine if the cloud is
 		// used by any models before attempting to remove it. JIMM
@@ -545,6 +566,7 @@ func (j *JIMM) RemoveCloud(ctx context.Context, user *openfga.User, ct names.Clo
 		if err := j.OpenFGAClient.RemoveCloud(ctx, ct); err != nil {
 			zapctx.Error(ctx, "failed to remove cloud from openfga", zap.String("cloud", ct.Id()), zap.Error(err))
 		}
+		j.removeCloudRegions(ctx, c)
 		return nil
 	})
 	if err != nil {
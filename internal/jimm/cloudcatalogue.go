@@ -0,0 +1,328 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+// AddCatalogueCloud adds the given cloud definition to JIMM's cloud
+// catalogue without adding it to any controller. The cloud can later be
+// attached to controllers with AttachCloudToController. Only JIMM
+// administrators may add clouds to the catalogue, the user adding the
+// cloud is made an administrator of the cloud.
+func (j *JIMM) AddCatalogueCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud) error {
+	const op = errors.Op("jimm.AddCatalogueCloud")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := checkReservedCloudNames(tag, j.ReservedCloudNames); err != nil {
+		return errors.E(op, err)
+	}
+	if cloud.Type == "" {
+		return errors.E(op, errors.CodeBadRequest, "cloud type not specified")
+	}
+	if len(cloud.Regions) == 0 {
+		return errors.E(op, errors.CodeBadRequest, "cloud has no regions")
+	}
+
+	var dbCloud dbmodel.Cloud
+	dbCloud.FromJujuCloud(cloud)
+	dbCloud.Name = tag.Id()
+	if err := j.Database.AddCloud(ctx, &dbCloud); err != nil {
+		return errors.E(op, err)
+	}
+
+	if err := user.SetCloudAccess(ctx, dbCloud.ResourceTag(), ofganames.AdministratorRelation); err != nil {
+		zapctx.Error(
+			ctx,
+			"failed to add user as cloud admin",
+			zap.String("user", user.Name),
+			zap.String("cloud", dbCloud.ResourceTag().Id()),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+// AttachCloudToController adds the catalogue definition of the given
+// cloud to the given controller, after which models may be created on
+// the cloud using the controller. The user must be a JIMM administrator
+// or an administrator of both the cloud and the controller. If the cloud
+// is already hosted by the controller an error with the code
+// CodeAlreadyExists is returned.
+func (j *JIMM) AttachCloudToController(ctx context.Context, user *openfga.User, tag names.CloudTag, controllerName string, force bool) error {
+	const op = errors.Op("jimm.AttachCloudToController")
+
+	var cloud dbmodel.Cloud
+	cloud.SetTag(tag)
+	if err := j.Database.GetCloud(ctx, &cloud); err != nil {
+		return errors.E(op, err)
+	}
+	controller, err := j.getControllerByName(ctx, controllerName)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if !user.JimmAdmin {
+		if err := j.checkCloudAdminAccess(ctx, user, &cloud); err != nil {
+			return errors.E(op, err)
+		}
+		if err := j.checkControllerAdminAccess(ctx, user, controller); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	for _, ctl := range cloudControllers(&cloud) {
+		if ctl.ID == controller.ID {
+			return errors.E(op, errors.CodeAlreadyExists, "cloud already hosted by controller")
+		}
+	}
+
+	jujuCloud := cloud.ToJujuCloud()
+	if err := validateCloudRegion(ctx, &j.Database, user, jujuCloud, controllerName); err != nil {
+		return errors.E(op, err)
+	}
+	if _, err := j.addControllerCloud(ctx, controller, user.ResourceTag(), tag, jujuCloud, force); err != nil {
+		return errors.E(op, err)
+	}
+
+	for i := range cloud.Regions {
+		cloud.Regions[i].Controllers = append(cloud.Regions[i].Controllers, dbmodel.CloudRegionControllerPriority{
+			ControllerID: controller.ID,
+			Priority:     dbmodel.CloudRegionControllerPrioritySupported,
+		})
+	}
+	if err := j.Database.UpdateCloud(ctx, &cloud); err != nil {
+		// At this point the cloud has been created on the
+		// controller. Trying to undo that will probably make
+		// things worse.
+		return errors.E(op, err, "cannot update database after updating controller")
+	}
+	if err := j.Database.SetCloudControllerStatus(ctx, &dbmodel.CloudControllerStatus{
+		CloudName:    cloud.Name,
+		ControllerID: controller.ID,
+		Status:       dbmodel.CloudControllerSynced,
+	}); err != nil {
+		return errors.E(op, err)
+	}
+
+	if err := j.addCloudControllerRelation(ctx, cloud, *controller); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// UpdateCatalogueCloud replaces the catalogue definition of the given
+// cloud and then propagates the new definition to every controller
+// hosting the cloud. A failure to update a controller does not stop the
+// remaining controllers from being updated, instead the status of every
+// hosting controller is recorded and returned. Regions that are no
+// longer in the definition are deleted, along with any access granted to
+// them, the update is rejected if any model still uses such a region.
+// New regions are only made available
+// on the controllers that accepted the update. Only administrators of
+// the cloud may update its definition.
+func (j *JIMM) UpdateCatalogueCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud) ([]dbmodel.CloudControllerStatus, error) {
+	const op = errors.Op("jimm.UpdateCatalogueCloud")
+
+	var c dbmodel.Cloud
+	c.SetTag(tag)
+	if err := j.Database.GetCloud(ctx, &c); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := j.checkCloudAdminAccess(ctx, user, &c); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if cloud.Type != "" && cloud.Type != c.Type {
+		return nil, errors.E(op, errors.CodeBadRequest, "cannot change the type of a cloud")
+	}
+	cloud.Type = c.Type
+	if len(cloud.Regions) == 0 {
+		return nil, errors.E(op, errors.CodeBadRequest, "cloud has no regions")
+	}
+
+	keep := make(map[string]bool, len(cloud.Regions))
+	for _, r := range cloud.Regions {
+		keep[r.Name] = true
+	}
+	controllers := cloudControllers(&c)
+	var removed []dbmodel.CloudRegion
+	var unhosted []string
+	err := j.Database.Transaction(func(tx *db.Database) error {
+		var c dbmodel.Cloud
+		c.SetTag(tag)
+		if err := tx.GetCloud(ctx, &c); err != nil {
+			return err
+		}
+		// The regions in use are checked in the same transaction
+		// that deletes them, so that a model added in the meantime
+		// is not left without a region.
+		for _, r := range c.Regions {
+			if keep[r.Name] {
+				continue
+			}
+			models, err := tx.ListModels(ctx, db.ModelFilter{
+				Cloud:       tag.Id(),
+				CloudRegion: r.Name,
+			})
+			if err != nil {
+				return err
+			}
+			if len(models) > 0 {
+				return errors.E(errors.CodeBadRequest, fmt.Sprintf("cannot remove region %q, it is used by %d models", r.Name, len(models)))
+			}
+			removed = append(removed, r)
+		}
+		c.FromJujuCloud(cloud)
+		for _, r := range c.Regions {
+			if len(r.Controllers) == 0 {
+				unhosted = append(unhosted, r.Name)
+			}
+		}
+		if err := tx.UpdateCloud(ctx, &c); err != nil {
+			return err
+		}
+		for i := range removed {
+			if err := tx.DeleteCloudRegion(ctx, &removed[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	// Remove the relations of the deleted regions, so that access
+	// granted to them is not inherited by a region of the same name
+	// added later.
+	j.removeCloudRegions(ctx, &dbmodel.Cloud{Name: tag.Id(), Regions: removed})
+
+	var synced []dbmodel.Controller
+	for i := range controllers {
+		status := dbmodel.CloudControllerStatus{
+			CloudName:    tag.Id(),
+			ControllerID: controllers[i].ID,
+			Status:       dbmodel.CloudControllerSynced,
+		}
+		if err := j.updateControllerCloud(ctx, &controllers[i], tag, cloud); err != nil {
+			zapctx.Error(ctx, "failed to update cloud on controller", zap.String("cloud", tag.Id()), zap.String("controller", controllers[i].Name), zap.Error(err))
+			status.Status = dbmodel.CloudControllerError
+			status.Message = err.Error()
+		} else {
+			synced = append(synced, controllers[i])
+		}
+		if err := j.Database.SetCloudControllerStatus(ctx, &status); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+
+	if len(unhosted) > 0 && len(synced) > 0 {
+		// Only the controllers that accepted the new definition can
+		// host models in the new regions.
+		c = dbmodel.Cloud{}
+		c.SetTag(tag)
+		if err := j.Database.GetCloud(ctx, &c); err != nil {
+			return nil, errors.E(op, err)
+		}
+		for _, name := range unhosted {
+			for i := range c.Regions {
+				if c.Regions[i].Name != name {
+					continue
+				}
+				for _, ctl := range synced {
+					c.Regions[i].Controllers = append(c.Regions[i].Controllers, dbmodel.CloudRegionControllerPriority{
+						ControllerID: ctl.ID,
+						Priority:     dbmodel.CloudRegionControllerPrioritySupported,
+					})
+				}
+			}
+		}
+		if err := j.Database.UpdateCloud(ctx, &c); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+
+	statuses, err := j.Database.GetCloudControllerStatuses(ctx, tag.Id())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return statuses, nil
+}
+
+// GetCloudControllerStatuses returns the status of the given cloud on
+// each of the controllers hosting it. Only administrators of the cloud
+// may see its statuses.
+func (j *JIMM) GetCloudControllerStatuses(ctx context.Context, user *openfga.User, tag names.CloudTag) ([]dbmodel.CloudControllerStatus, error) {
+	const op = errors.Op("jimm.GetCloudControllerStatuses")
+
+	var c dbmodel.Cloud
+	c.SetTag(tag)
+	if err := j.Database.GetCloud(ctx, &c); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := j.checkCloudAdminAccess(ctx, user, &c); err != nil {
+		return nil, errors.E(op, err)
+	}
+	statuses, err := j.Database.GetCloudControllerStatuses(ctx, tag.Id())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return statuses, nil
+}
+
+// updateControllerCloud updates the definition of the given cloud on
+// the given controller.
+func (j *JIMM) updateControllerCloud(ctx context.Context, ctl *dbmodel.Controller, tag names.CloudTag, cloud jujuparams.Cloud) error {
+	api, err := j.dial(ctx, ctl, names.ModelTag{})
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	return api.UpdateCloud(ctx, tag, cloud)
+}
+
+// checkCloudAdminAccess checks that the given user is a JIMM
+// administrator or an administrator of the given cloud.
+func (j *JIMM) checkCloudAdminAccess(ctx context.Context, user *openfga.User, cloud *dbmodel.Cloud) error {
+	if user.JimmAdmin {
+		return nil
+	}
+	isAdministrator, err := openfga.IsAdministrator(ctx, user, cloud.ResourceTag())
+	if err != nil {
+		return err
+	}
+	if !isAdministrator {
+		return errors.E(errors.CodeUnauthorized, "unauthorized")
+	}
+	return nil
+}
+
+// cloudControllers returns the controllers hosting any region of the
+// given cloud. The cloud must have its regions and their controllers
+// filled out.
+func cloudControllers(cloud *dbmodel.Cloud) []dbmodel.Controller {
+	var controllers []dbmodel.Controller
+	seen := make(map[uint]bool)
+	for _, r := range cloud.Regions {
+		for _, ctl := range r.Controllers {
+			if seen[ctl.ControllerID] {
+				continue
+			}
+			seen[ctl.ControllerID] = true
+			controllers = append(controllers, ctl.Controller)
+		}
+	}
+	return controllers
+}
@@ -0,0 +1,233 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+const cloudCatalogueEnv = `clouds:
+- name: controller-cloud
+  type: test-provider
+  regions:
+  - name: default
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: controller-cloud
+  region: default
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: controller-cloud
+  region: default
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+`
+
+func TestCloudCatalogue(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var updateCalls int64
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				AddCloud_: func(context.Context, names.CloudTag, jujuparams.Cloud, bool) error {
+					return nil
+				},
+				GrantCloudAccess_: func(context.Context, names.CloudTag, names.UserTag, string) error {
+					return nil
+				},
+				Cloud_: func(_ context.Context, _ names.CloudTag, cloud *jujuparams.Cloud) error {
+					cloud.Type = "openstack"
+					return nil
+				},
+				UpdateCloud_: func(context.Context, names.CloudTag, jujuparams.Cloud) error {
+					if atomic.AddInt64(&updateCalls, 1) == 1 {
+						return errors.E("test error")
+					}
+					return nil
+				},
+			},
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, cloudCatalogueEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&u, client)
+	alice.JimmAdmin = true
+	u2 := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&u2, client)
+
+	tag := names.NewCloudTag("test-cloud")
+	cloud := jujuparams.Cloud{
+		Type:      "openstack",
+		AuthTypes: []string{"userpass"},
+		Endpoint:  "https://example.com",
+		Regions: []jujuparams.CloudRegion{{
+			Name: "region-1",
+		}},
+	}
+
+	err = j.AddCatalogueCloud(ctx, bob, tag, cloud)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	err = j.AddCatalogueCloud(ctx, alice, tag, jujuparams.Cloud{Type: "openstack"})
+	c.Check(err, qt.ErrorMatches, `cloud has no regions`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	err = j.AddCatalogueCloud(ctx, alice, tag, cloud)
+	c.Assert(err, qt.IsNil)
+
+	// The cloud exists without being hosted by any controller.
+	dbCloud := dbmodel.Cloud{Name: "test-cloud"}
+	err = j.Database.GetCloud(ctx, &dbCloud)
+	c.Assert(err, qt.IsNil)
+	c.Check(dbCloud.Endpoint, qt.Equals, "https://example.com")
+	c.Assert(dbCloud.Regions, qt.HasLen, 1)
+	c.Check(dbCloud.Regions[0].Controllers, qt.HasLen, 0)
+
+	err = j.AttachCloudToController(ctx, bob, tag, "controller-1", false)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	err = j.AttachCloudToController(ctx, alice, tag, "controller-1", false)
+	c.Assert(err, qt.IsNil)
+	err = j.AttachCloudToController(ctx, alice, tag, "controller-2", false)
+	c.Assert(err, qt.IsNil)
+	err = j.AttachCloudToController(ctx, alice, tag, "controller-2", false)
+	c.Check(err, qt.ErrorMatches, `cloud already hosted by controller`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	statuses, err := j.GetCloudControllerStatuses(ctx, alice, tag)
+	c.Assert(err, qt.IsNil)
+	c.Assert(statuses, qt.HasLen, 2)
+	c.Check(statuses[0].Controller.Name, qt.Equals, "controller-1")
+	c.Check(statuses[0].Status, qt.Equals, dbmodel.CloudControllerSynced)
+	c.Check(statuses[1].Controller.Name, qt.Equals, "controller-2")
+	c.Check(statuses[1].Status, qt.Equals, dbmodel.CloudControllerSynced)
+
+	_, err = j.GetCloudControllerStatuses(ctx, bob, tag)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+
+	rt := jimmnames.NewCloudRegionTag(tag.Id() + "/region-1")
+	err = j.GrantCloudRegionAccess(ctx, alice, rt, bob.ResourceTag(), "add-model")
+	c.Assert(err, qt.IsNil)
+
+	// A failure to update one controller does not prevent the others
+	// from being updated. The removed region is deleted, along with the
+	// access granted to it, and the new region is only hosted by the
+	// controller that was updated.
+	cloud.Endpoint = "https://example.com/v2"
+	cloud.Regions = []jujuparams.CloudRegion{{
+		Name: "region-2",
+	}}
+	statuses, err = j.UpdateCatalogueCloud(ctx, alice, tag, cloud)
+	c.Assert(err, qt.IsNil)
+	c.Assert(statuses, qt.HasLen, 2)
+	var synced, failed int
+	var syncedID uint
+	for _, s := range statuses {
+		switch s.Status {
+		case dbmodel.CloudControllerSynced:
+			synced++
+			syncedID = s.ControllerID
+		case dbmodel.CloudControllerError:
+			failed++
+			c.Check(s.Message, qt.Equals, "test error")
+		}
+	}
+	c.Check(synced, qt.Equals, 1)
+	c.Check(failed, qt.Equals, 1)
+
+	dbCloud = dbmodel.Cloud{Name: "test-cloud"}
+	err = j.Database.GetCloud(ctx, &dbCloud)
+	c.Assert(err, qt.IsNil)
+	c.Check(dbCloud.Endpoint, qt.Equals, "https://example.com/v2")
+	c.Assert(dbCloud.Regions, qt.HasLen, 1)
+	c.Check(dbCloud.Regions[0].Name, qt.Equals, "region-2")
+	c.Assert(dbCloud.Regions[0].Controllers, qt.HasLen, 1)
+	c.Check(dbCloud.Regions[0].Controllers[0].ControllerID, qt.Equals, syncedID)
+	allowed, err := bob.IsAllowedAddModelInRegion(ctx, rt)
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsFalse)
+
+	// A region that is used by a model cannot be removed.
+	cred := dbmodel.CloudCredential{
+		Name:              "cred-1",
+		CloudName:         "test-cloud",
+		OwnerIdentityName: "alice@canonical.com",
+		AuthType:          "empty",
+	}
+	err = j.Database.SetCloudCredential(ctx, &cred)
+	c.Assert(err, qt.IsNil)
+	err = j.Database.AddModel(ctx, &dbmodel.Model{
+		Name: "model-1",
+		UUID: sql.NullString{
+			String: "00000002-0000-0000-0000-000000000001",
+			Valid:  true,
+		},
+		OwnerIdentityName: "alice@canonical.com",
+		ControllerID:      syncedID,
+		CloudRegionID:     dbCloud.Regions[0].ID,
+		CloudCredentialID: cred.ID,
+	})
+	c.Assert(err, qt.IsNil)
+	cloud.Regions = []jujuparams.CloudRegion{{
+		Name: "region-3",
+	}}
+	_, err = j.UpdateCatalogueCloud(ctx, alice, tag, cloud)
+	c.Check(err, qt.ErrorMatches, `cannot remove region "region-2", it is used by 1 models`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+	cloud.Regions = []jujuparams.CloudRegion{{
+		Name: "region-2",
+	}}
+
+	cloud.Type = "ec2"
+	_, err = j.UpdateCatalogueCloud(ctx, alice, tag, cloud)
+	c.Check(err, qt.ErrorMatches, `cannot change the type of a cloud`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	// A cloud that is not hosted by any controller can be removed.
+	tag2 := names.NewCloudTag("test-cloud-2")
+	err = j.AddCatalogueCloud(ctx, alice, tag2, cloud)
+	c.Assert(err, qt.IsNil)
+	err = j.RemoveCloud(ctx, bob, tag2)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	err = j.RemoveCloud(ctx, alice, tag2)
+	c.Assert(err, qt.IsNil)
+	err = j.Database.GetCloud(ctx, &dbmodel.Cloud{Name: "test-cloud-2"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
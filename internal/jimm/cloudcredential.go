@@ -6,6 +6,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"sort"
 	"sync"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 )
 
 // GetCloudCredential retrieves the given credential from the database. The
@@ -25,12 +27,13 @@ import (
 // GetCloudCredentialAttributes to retrieve those. If credentials
 // identified by the given tag cannot be found then an errror with a code
 // of CodeNotFound will be returned. If the given user is not a controller
-// superuser or the owner of the credentials then an error with a code of
-// CodeUnauthorized will be returned.
+// superuser, the owner of the credentials or a user the credentials have
+// been shared with then an error with a code of CodeUnauthorized will be
+// returned.
 func (j *JIMM) GetCloudCredential(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (*dbmodel.CloudCredential, error) {
 	const op = errors.Op("jimm.GetCloudCredential")
 
-	if !user.JimmAdmin && user.Name != tag.Owner().Id() {
+	if !user.JimmAdmin && user.Name != tag.Owner().Id() && user.GetCloudCredentialAccess(ctx, tag) == ofganames.NoRelation {
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
@@ -47,25 +50,34 @@ func (j *JIMM) GetCloudCredential(ctx context.Context, user *openfga.User, tag n
 }
 
 // RevokeCloudCredential checks that the credential with the given path
-// can be revoked  and revokes the credential.
+// can be revoked  and revokes the credential. A credential owned by a
+// group can only be revoked by an administrator of the credential, such
+// as a member of the group, not by the identity named in its tag.
 func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error {
 	const op = errors.Op("jimm.RevokeCloudCredential")
 
-	if user.Name != tag.Owner().Id() {
-		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
-	}
-
 	var credential dbmodel.CloudCredential
 	credential.SetTag(tag)
 
 	err := j.Database.GetCloudCredential(ctx, &credential)
 	if err != nil {
-		if errors.ErrorCode(err) == errors.CodeNotFound {
+		if errors.ErrorCode(err) == errors.CodeNotFound && user.Name == tag.Owner().Id() {
 			// It is not an error to revoke an non-existent credential
 			return nil
 		}
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
 		return errors.E(op, err)
 	}
+	if credential.OwnerGroupID.Valid {
+		u := openfga.NewUser(user, j.OpenFGAClient)
+		if u.GetCloudCredentialAccess(ctx, tag) != ofganames.AdministratorRelation {
+			return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+	} else if user.Name != tag.Owner().Id() {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
 
 	credential.Valid = sql.NullBool{
 		Bool:  false,
@@ -116,6 +128,10 @@ func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity
 	if err != nil {
 		return errors.E(op, err, "failed to revoke credential in local database")
 	}
+
+	if err := j.OpenFGAClient.RemoveCloudCredential(ctx, tag); err != nil {
+		zapctx.Error(ctx, "failed to remove cloud credential from openfga", zap.String("credential", tag.Id()), zap.Error(err))
+	}
 	return nil
 }
 
@@ -129,14 +145,18 @@ type UpdateCloudCredentialArgs struct {
 
 // UpdateCloudCredential checks that the credential can be updated
 // and updates it in the local database and all controllers
-// to which it is deployed.
+// to which it is deployed. Credentials may be updated by their owner,
+// by controller superusers and by users with administrator access to
+// the credential, such as the members of a group that administers a
+// shared credential. The credential's attributes are checked against the
+// credential schema of the cloud's provider before anything is updated.
 func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, args UpdateCloudCredentialArgs) ([]jujuparams.UpdateCredentialModelResult, error) {
 	const op = errors.Op("jimm.UpdateCloudCredential")
 
 	var resultMu sync.Mutex
 	var result []jujuparams.UpdateCredentialModelResult
 	if user.Tag() != args.CredentialTag.Owner() {
-		if !user.JimmAdmin {
+		if !user.JimmAdmin && user.GetCloudCredentialAccess(ctx, args.CredentialTag) != ofganames.AdministratorRelation {
 			return result, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 		}
 		// ensure the user we are adding the credential for exists.
@@ -154,6 +174,12 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, ar
 	if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
 		return result, errors.E(op, err)
 	}
+	// A credential owned by a group is administered by the group, the
+	// identity named in its tag can only update it while they remain an
+	// administrator of the credential.
+	if credential.OwnerGroupID.Valid && !user.JimmAdmin && user.GetCloudCredentialAccess(ctx, args.CredentialTag) != ofganames.AdministratorRelation {
+		return result, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
 
 	// Confirm the cloud exists.
 	var cloud dbmodel.Cloud
@@ -162,6 +188,12 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, user *openfga.User, ar
 		return result, errors.E(op, err)
 	}
 
+	// Reject attributes that no controller would accept before
+	// contacting any controller or storing anything.
+	if err := cloudcred.ValidateAttributes(cloud.Type, args.Credential.AuthType, args.Credential.Attributes); err != nil {
+		return result, errors.E(op, err)
+	}
+
 	models, err := j.Database.GetModelsUsingCredential(ctx, credential.ID)
 	if err != nil {
 		return result, errors.E(op, err)
@@ -285,6 +317,94 @@ func (j *JIMM) updateControllerCloudCredential(
 	return models, nil
 }
 
+// SetCloudCredentialGroup makes the named group the owner of the given
+// cloud credential. The members of the owning group administer the
+// credential: they may select it when adding models, update it and
+// revoke it, whether or not the identity named in the credential's tag
+// still has access to it. If group is empty the credential reverts to
+// being owned by the identity named in its tag. A personal credential
+// may be given to a group by its owner, if they are a member of the
+// group. A group-owned credential may be moved to another group, or
+// returned to its owner, by its administrators. JIMM administrators may
+// set the owning group of any credential.
+func (j *JIMM) SetCloudCredentialGroup(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, group string) error {
+	const op = errors.Op("jimm.SetCloudCredentialGroup")
+
+	var credential dbmodel.CloudCredential
+	credential.SetTag(tag)
+	if err := j.Database.GetCloudCredential(ctx, &credential); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound && !user.JimmAdmin {
+			return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+		return errors.E(op, err)
+	}
+	if !user.JimmAdmin {
+		if credential.OwnerGroupID.Valid {
+			if user.GetCloudCredentialAccess(ctx, tag) != ofganames.AdministratorRelation {
+				return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+			}
+		} else if user.Name != tag.Owner().Id() {
+			return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+	}
+
+	var oldGroup, newGroup *dbmodel.GroupEntry
+	if credential.OwnerGroupID.Valid {
+		oldGroup = &dbmodel.GroupEntry{}
+		oldGroup.ID = uint(credential.OwnerGroupID.Int32)
+		if err := j.Database.GetGroup(ctx, oldGroup); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	if group != "" {
+		newGroup = &dbmodel.GroupEntry{Name: group}
+		if err := j.Database.GetGroup(ctx, newGroup); err != nil {
+			return errors.E(op, err)
+		}
+		if !user.JimmAdmin {
+			// Users may only hand credentials to groups they are in,
+			// so that secrets cannot be shared with arbitrary users.
+			uuids, err := user.ListGroups(ctx)
+			if err != nil {
+				return errors.E(op, err)
+			}
+			if !slices.Contains(uuids, newGroup.UUID) {
+				return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+			}
+		}
+		credential.OwnerGroupID = sql.NullInt32{Int32: int32(newGroup.ID), Valid: true}
+	} else {
+		credential.OwnerGroupID = sql.NullInt32{}
+	}
+	if oldGroup != nil && newGroup != nil && oldGroup.ID == newGroup.ID {
+		return nil
+	}
+
+	// The new group is given access before the database is updated,
+	// and the old group's access is removed afterwards, so that a
+	// failure at any point leaves the group recorded in the database
+	// able to administer the credential.
+	if newGroup != nil {
+		if err := j.OpenFGAClient.AddCloudCredentialGroup(ctx, tag, newGroup.ResourceTag()); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	if err := j.Database.UpdateCloudCredentialOwnerGroup(ctx, &credential); err != nil {
+		if newGroup != nil {
+			if err := j.OpenFGAClient.RemoveCloudCredentialGroup(ctx, tag, newGroup.ResourceTag()); err != nil {
+				zapctx.Error(ctx, "failed to remove cloud credential group access", zap.String("credential", tag.Id()), zap.Error(err))
+			}
+		}
+		return errors.E(op, err)
+	}
+	if oldGroup != nil {
+		if err := j.OpenFGAClient.RemoveCloudCredentialGroup(ctx, tag, oldGroup.ResourceTag()); err != nil {
+			zapctx.Error(ctx, "failed to remove cloud credential group access", zap.String("credential", tag.Id()), zap.Error(err))
+		}
+	}
+	return nil
+}
+
 // ForEachUserCloudCredential iterates through every credential owned by
 // the given user and for the given cloud (if specified). The given
 // function is called for each credential found. The credential used when
@@ -861,6 +861,239 @@ users:
 	c.Assert(err, qt.IsNil)
 }
 
+func TestUpdateCloudCredentialInvalidAttributes(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `clouds:
+- name: aws
+  type: ec2
+  regions:
+  - name: us-east-1
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+`)
+	store := jimmtest.NewInMemoryCredentialStore()
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{},
+		},
+		CredentialStore: store,
+		OpenFGAClient:   client,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&u, client)
+
+	tag := names.NewCloudCredentialTag("aws/alice@canonical.com/cred")
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "access-key",
+			Attributes: map[string]string{
+				"access-key": "key",
+				"secret":     "secret",
+			},
+		},
+		SkipCheck: true,
+	})
+	c.Check(err, qt.ErrorMatches, `invalid "access-key" credential: missing required attribute "secret-key", unknown attribute "secret"`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	// Nothing was stored.
+	cred := dbmodel.CloudCredential{CloudName: "aws", OwnerIdentityName: "alice@canonical.com", Name: "cred"}
+	err = j.Database.GetCloudCredential(ctx, &cred)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	_, err = store.Get(ctx, tag)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	_, err = j.UpdateCloudCredential(ctx, user, jimm.UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "access-key",
+			Attributes: map[string]string{
+				"access-key": "key",
+				"secret-key": "secret",
+			},
+		},
+	})
+	c.Assert(err, qt.IsNil)
+}
+
+const groupCloudCredentialEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+cloud-credentials:
+- name: cred-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: cred-1
+  owner: bob@canonical.com
+  life: alive
+`
+
+func TestGroupCloudCredential(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var updated []jujuparams.TaggedCredential
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				SupportsCheckCredentialModels_: true,
+				CheckCredentialModels_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				UpdateCredential_: func(_ context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					updated = append(updated, cred)
+					return []jujuparams.UpdateCredentialModelResult{{
+						ModelUUID: "00000002-0000-0000-0000-000000000001",
+						ModelName: "model-1",
+					}}, nil
+				},
+				RevokeCredential_: func(context.Context, names.CloudCredentialTag) error {
+					return nil
+				},
+			},
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, groupCloudCredentialEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	newUser := func(name string) *openfga.User {
+		u := env.User(name).DBObject(c, j.Database)
+		return openfga.NewUser(&u, client)
+	}
+	alice := newUser("alice@canonical.com")
+	bob := newUser("bob@canonical.com")
+	charlie := newUser("charlie@canonical.com")
+	dave := newUser("dave@canonical.com")
+
+	// alice gives her credential to the team group, of which she and
+	// charlie are members, and bob is given access to use it.
+	group, err := j.Database.AddGroup(ctx, "team")
+	c.Assert(err, qt.IsNil)
+	_, err = j.Database.AddGroup(ctx, "other")
+	c.Assert(err, qt.IsNil)
+	credTag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+	aliceMember := openfga.Tuple{
+		Object:   ofganames.ConvertTag(alice.ResourceTag()),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group.ResourceTag()),
+	}
+	err = client.AddRelation(ctx, aliceMember, openfga.Tuple{
+		Object:   ofganames.ConvertTag(charlie.ResourceTag()),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group.ResourceTag()),
+	}, openfga.Tuple{
+		Object:   ofganames.ConvertTag(bob.ResourceTag()),
+		Relation: ofganames.UserRelation,
+		Target:   ofganames.ConvertTag(credTag),
+	})
+	c.Assert(err, qt.IsNil)
+
+	// Only the owner can give away a personal credential, and only to
+	// a group they are a member of.
+	err = j.SetCloudCredentialGroup(ctx, dave, credTag, "team")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	err = j.SetCloudCredentialGroup(ctx, alice, credTag, "other")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	err = j.SetCloudCredentialGroup(ctx, alice, credTag, "team")
+	c.Assert(err, qt.IsNil)
+	c.Check(charlie.GetCloudCredentialAccess(ctx, credTag), qt.Equals, ofganames.AdministratorRelation)
+
+	dbCred := dbmodel.CloudCredential{}
+	dbCred.SetTag(credTag)
+	err = j.Database.GetCloudCredential(ctx, &dbCred)
+	c.Assert(err, qt.IsNil)
+	c.Check(dbCred.OwnerGroupID, qt.Equals, sql.NullInt32{Int32: int32(group.ID), Valid: true})
+
+	// Once alice leaves the group she can no longer revoke the
+	// credential, it belongs to the group.
+	err = client.RemoveRelation(ctx, aliceMember)
+	c.Assert(err, qt.IsNil)
+	err = j.RevokeCloudCredential(ctx, alice.Identity, credTag, true)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	cred, err := j.GetCloudCredential(ctx, bob, credTag)
+	c.Assert(err, qt.IsNil)
+	c.Check(cred.Name, qt.Equals, "cred-1")
+	_, err = j.GetCloudCredential(ctx, dave, credTag)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	args := jimm.UpdateCloudCredentialArgs{
+		CredentialTag: credTag,
+		Credential: jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "rotated",
+				"password": "secret",
+			},
+		},
+	}
+	// Users of a shared credential cannot update it.
+	_, err = j.UpdateCloudCredential(ctx, bob, args)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// Administrators can rotate it, which updates every model using it.
+	result, err := j.UpdateCloudCredential(ctx, charlie, args)
+	c.Assert(err, qt.IsNil)
+	c.Check(result, qt.DeepEquals, []jujuparams.UpdateCredentialModelResult{{
+		ModelUUID: "00000002-0000-0000-0000-000000000001",
+		ModelName: "model-1",
+	}})
+	c.Assert(updated, qt.HasLen, 1)
+	c.Check(updated[0].Tag, qt.Equals, credTag.String())
+	c.Check(updated[0].Credential.Attributes["username"], qt.Equals, "rotated")
+
+	// alice can no longer update the credential either.
+	_, err = j.UpdateCloudCredential(ctx, alice, args)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	// Members of the group can revoke it, which removes the access
+	// granted to it.
+	err = j.RevokeCloudCredential(ctx, charlie.Identity, credTag, true)
+	c.Assert(err, qt.IsNil)
+	c.Check(charlie.GetCloudCredentialAccess(ctx, credTag), qt.Equals, ofganames.NoRelation)
+	c.Check(bob.GetCloudCredentialAccess(ctx, credTag), qt.Equals, ofganames.NoRelation)
+}
+
 func TestRevokeCloudCredential(t *testing.T) {
 	c := qt.New(t)
 
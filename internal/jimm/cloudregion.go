@@ -0,0 +1,131 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+// GrantCloudRegionAccess grants the given access level on the given cloud
+// region to the given user. The only access level supported on a cloud
+// region is "add-model". If the cloud or region is not found then an error
+// with the code CodeNotFound is returned. If the authenticated user does
+// not have admin access to the cloud then an error with the code
+// CodeUnauthorized is returned.
+func (j *JIMM) GrantCloudRegionAccess(ctx context.Context, user *openfga.User, rt jimmnames.CloudRegionTag, ut names.UserTag, access string) error {
+	const op = errors.Op("jimm.GrantCloudRegionAccess")
+
+	if err := toCloudRegionRelation(access); err != nil {
+		return errors.E(op, err)
+	}
+
+	targetUser, err := j.cloudRegionAccessTarget(ctx, user, rt, ut)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := j.OpenFGAClient.AddCloudRegion(ctx, rt); err != nil {
+		return errors.E(op, err, "failed to relate cloud region")
+	}
+	if err := targetUser.SetCloudRegionAccess(ctx, rt, ofganames.CanAddModelRelation); err != nil {
+		zapctx.Error(
+			ctx,
+			"failed to grant cloud region access",
+			zaputil.Error(err),
+			zap.String("targetUser", ut.Id()),
+			zap.String("cloudregion", rt.Id()),
+		)
+		return errors.E(op, err, "failed to set cloud region access")
+	}
+	return nil
+}
+
+// RevokeCloudRegionAccess revokes the given access level on the given
+// cloud region from the given user. Access the user has through the whole
+// cloud is not affected. If the cloud or region is not found then an error
+// with the code CodeNotFound is returned. If the authenticated user does
+// not have admin access to the cloud then an error with the code
+// CodeUnauthorized is returned.
+func (j *JIMM) RevokeCloudRegionAccess(ctx context.Context, user *openfga.User, rt jimmnames.CloudRegionTag, ut names.UserTag, access string) error {
+	const op = errors.Op("jimm.RevokeCloudRegionAccess")
+
+	if err := toCloudRegionRelation(access); err != nil {
+		return errors.E(op, err)
+	}
+
+	targetUser, err := j.cloudRegionAccessTarget(ctx, user, rt, ut)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := targetUser.UnsetCloudRegionAccess(ctx, rt, ofganames.CanAddModelRelation); err != nil {
+		zapctx.Error(
+			ctx,
+			"failed to revoke cloud region access",
+			zaputil.Error(err),
+			zap.String("targetUser", ut.Id()),
+			zap.String("cloudregion", rt.Id()),
+		)
+		return errors.E(op, err, "failed to unset cloud region access")
+	}
+	return nil
+}
+
+// toCloudRegionRelation checks that the given access level can be granted
+// on a cloud region.
+func toCloudRegionRelation(access string) error {
+	relation, err := ToCloudRelation(access)
+	if err != nil || relation != ofganames.CanAddModelRelation {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("failed to recognize given access: %q", access))
+	}
+	return nil
+}
+
+// cloudRegionAccessTarget checks that the given cloud region exists and
+// that the given user may manage access to it, then returns the user
+// having their access changed.
+func (j *JIMM) cloudRegionAccessTarget(ctx context.Context, user *openfga.User, rt jimmnames.CloudRegionTag, ut names.UserTag) (*openfga.User, error) {
+	var cloud dbmodel.Cloud
+	cloud.SetTag(rt.Cloud())
+	if err := j.Database.GetCloud(ctx, &cloud); err != nil {
+		return nil, err
+	}
+	if err := j.checkCloudAdminAccess(ctx, user, &cloud); err != nil {
+		return nil, err
+	}
+	if cloud.Region(rt.Region()).ID == 0 {
+		return nil, errors.E(errors.CodeNotFound, "cloudregion not found")
+	}
+
+	var targetUser dbmodel.Identity
+	targetUser.SetTag(ut)
+	if err := j.Database.GetIdentity(ctx, &targetUser); err != nil {
+		return nil, err
+	}
+	return openfga.NewUser(&targetUser, j.OpenFGAClient), nil
+}
+
+// removeCloudRegions removes the OpenFGA relations of all regions of the
+// given cloud.
+func (j *JIMM) removeCloudRegions(ctx context.Context, cloud *dbmodel.Cloud) {
+	for _, r := range cloud.Regions {
+		id := cloud.Name + "/" + r.Name
+		if !jimmnames.IsValidCloudRegion(id) {
+			continue
+		}
+		rt := jimmnames.NewCloudRegionTag(id)
+		if err := j.OpenFGAClient.RemoveCloudRegion(ctx, rt); err != nil {
+			zapctx.Error(ctx, "failed to remove cloud region from openfga", zap.String("cloudregion", rt.Id()), zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+const cloudRegionAccessEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+  - name: test-region-2
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+cloud-credentials:
+- name: test-credential-1
+  owner: bob@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000000-0000-0000-0000-0000-0000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 0
+- name: controller-2
+  uuid: 00000000-0000-0000-0000-0000-0000000000002
+  cloud: test-cloud
+  region: test-region-2
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-2
+    priority: 0
+`
+
+func TestCloudRegionAccess(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				GrantJIMMModelAdmin_: func(context.Context, names.ModelTag) error {
+					return nil
+				},
+				CreateModel_: createModel(`
+uuid: 00000001-0000-0000-0000-0000-000000000001
+status:
+  status: started
+life: alive
+`[1:]),
+			},
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, cloudRegionAccessEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&u, client)
+	alice.JimmAdmin = true
+	u2 := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&u2, client)
+
+	addModel := func(region string) error {
+		_, err := j.AddModel(ctx, bob, &jimm.ModelCreateArgs{
+			Name:        "test-model",
+			Owner:       bob.ResourceTag(),
+			Cloud:       names.NewCloudTag("test-cloud"),
+			CloudRegion: region,
+		})
+		return err
+	}
+
+	// bob cannot add models to any region of the cloud.
+	err = addModel("")
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	rt := jimmnames.NewCloudRegionTag("test-cloud/test-region-1")
+	err = j.GrantCloudRegionAccess(ctx, bob, rt, bob.ResourceTag(), "add-model")
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	err = j.GrantCloudRegionAccess(ctx, alice, rt, bob.ResourceTag(), "admin")
+	c.Check(err, qt.ErrorMatches, `failed to recognize given access: "admin"`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	err = j.GrantCloudRegionAccess(ctx, alice, jimmnames.NewCloudRegionTag("test-cloud/no-such-region"), bob.ResourceTag(), "add-model")
+	c.Check(err, qt.ErrorMatches, `cloudregion not found`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = j.GrantCloudRegionAccess(ctx, alice, rt, bob.ResourceTag(), "add-model")
+	c.Assert(err, qt.IsNil)
+
+	allowed, err := bob.IsAllowedAddModelInRegion(ctx, rt)
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsTrue)
+	allowed, err = bob.IsAllowedAddModel(ctx, names.NewCloudTag("test-cloud"))
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsFalse)
+
+	// bob can only add models to the region they have access to.
+	err = addModel("test-region-2")
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	err = addModel("")
+	c.Assert(err, qt.IsNil)
+	m := dbmodel.Model{Name: "test-model", OwnerIdentityName: bob.Name}
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.CloudRegion.Name, qt.Equals, "test-region-1")
+	c.Check(m.Controller.Name, qt.Equals, "controller-1")
+
+	err = j.RevokeCloudRegionAccess(ctx, alice, rt, bob.ResourceTag(), "add-model")
+	c.Assert(err, qt.IsNil)
+	allowed, err = bob.IsAllowedAddModelInRegion(ctx, rt)
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsFalse)
+
+	// Access to the cloud grants access to all of its regions.
+	err = j.GrantCloudAccess(ctx, alice, names.NewCloudTag("test-cloud"), bob.ResourceTag(), "add-model")
+	c.Assert(err, qt.IsNil)
+	allowed, err = bob.IsAllowedAddModelInRegion(ctx, rt)
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsTrue)
+}
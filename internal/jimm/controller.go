@@ -4,7 +4,9 @@ package jimm
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 
@@ -347,6 +349,97 @@ func (j *JIMM) EarliestControllerVersion(ctx context.Context) (version.Number, e
 	return *v, nil
 }
 
+// ControllerVersionReport holds the version information for a controller
+// and the models it hosts.
+type ControllerVersionReport struct {
+	// Controller is the controller being reported on.
+	Controller dbmodel.Controller
+
+	// Models contains the version information for each model hosted on
+	// the controller.
+	Models []ModelVersionReport
+
+	// Err contains any error encountered connecting to the controller
+	// to validate model upgrades.
+	Err error
+}
+
+// ModelVersionReport holds the version information for a single model.
+type ModelVersionReport struct {
+	// Model is the model being reported on. The model version is held in
+	// Model.Status.Version, as last reported by the controller's watcher.
+	Model dbmodel.Model
+
+	// UpgradeErr contains the error returned from ValidateModelUpgrade,
+	// if upgrades were validated and the model cannot be upgraded.
+	UpgradeErr error
+}
+
+// ControllerVersions returns a report of the agent versions of the named
+// controller and of each of the models it hosts. If controllerName is
+// empty all controllers are reported. If validateUpgrades is true then
+// ValidateModelUpgrade is called for every model and any failure is
+// recorded in the model's report. Only JIMM administrators can retrieve
+// the controller versions.
+func (j *JIMM) ControllerVersions(ctx context.Context, user *openfga.User, controllerName string, validateUpgrades bool) ([]ControllerVersionReport, error) {
+	const op = errors.Op("jimm.ControllerVersions")
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	var controllers []dbmodel.Controller
+	if controllerName != "" {
+		ctl, err := j.getControllerByName(ctx, controllerName)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		controllers = append(controllers, *ctl)
+	} else {
+		err := j.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+			controllers = append(controllers, *ctl)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+
+	reports := make([]ControllerVersionReport, len(controllers))
+	for i := range controllers {
+		reports[i].Controller = controllers[i]
+		models, err := j.Database.GetModelsByController(ctx, controllers[i])
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		for _, m := range models {
+			reports[i].Models = append(reports[i].Models, ModelVersionReport{Model: m})
+		}
+		if validateUpgrades {
+			reports[i].Err = j.validateControllerModelUpgrades(ctx, &reports[i])
+		}
+	}
+	return reports, nil
+}
+
+// validateControllerModelUpgrades calls ValidateModelUpgrade for each
+// model in the given report, recording any failures against the model.
+// An error is only returned if the controller cannot be contacted.
+func (j *JIMM) validateControllerModelUpgrades(ctx context.Context, report *ControllerVersionReport) error {
+	if len(report.Models) == 0 {
+		return nil
+	}
+	api, err := j.dialController(ctx, &report.Controller)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	for i := range report.Models {
+		report.Models[i].UpgradeErr = api.ValidateModelUpgrade(ctx, report.Models[i].Model.ResourceTag(), false)
+	}
+	return nil
+}
+
 // GetJimmControllerAccess returns the JIMM controller access level for the
 // requested user.
 func (j *JIMM) GetJimmControllerAccess(ctx context.Context, user *openfga.User, tag names.UserTag) (string, error) {
@@ -741,3 +834,133 @@ func (j *JIMM) InitiateMigration(ctx context.Context, user *openfga.User, spec j
 	}
 	return result, nil
 }
+
+// generateControllerPassword returns a new random password to be used by
+// JIMM when logging in to a controller.
+func generateControllerPassword() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// RotateControllerCredentials generates a new password for the identity
+// JIMM uses to administer the named controller. The new password is set
+// on the controller using the UserManager facade and then stored in the
+// credential store. Any cached connections to the controller are then
+// discarded and a fresh login is made to verify the new credentials. If
+// any step after the password has been changed on the controller fails
+// the previous password is restored both on the controller and in the
+// credential store. Only JIMM administrators can rotate controller
+// credentials.
+func (j *JIMM) RotateControllerCredentials(ctx context.Context, user *openfga.User, controllerName string) error {
+	const op = errors.Op("jimm.RotateControllerCredentials")
+
+	if err := j.checkJimmAdmin(user); err != nil {
+		return errors.E(op, err)
+	}
+	if j.CredentialStore == nil {
+		return errors.E(op, errors.CodeServerConfiguration, "credential store not configured")
+	}
+
+	ctl, err := j.getControllerByName(ctx, controllerName)
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	username, oldPassword := ctl.AdminIdentityName, ctl.AdminPassword
+	if oldPassword == "" {
+		username, oldPassword, err = j.CredentialStore.GetControllerCredentials(ctx, controllerName)
+		if err != nil {
+			return errors.E(op, err, "failed to get controller credentials")
+		}
+	}
+	if username == "" || oldPassword == "" {
+		return errors.E(op, errors.CodeNotFound, "missing controller credentials")
+	}
+
+	newPassword, err := generateControllerPassword()
+	if err != nil {
+		return errors.E(op, err, "failed to generate password")
+	}
+
+	api, err := j.dialController(ctx, ctl)
+	if err != nil {
+		return errors.E(op, "failed to dial the controller", err)
+	}
+	defer api.Close()
+
+	userTag := names.NewUserTag(username)
+	if err := api.SetPassword(ctx, userTag, newPassword); err != nil {
+		return errors.E(op, err, "failed to set controller password")
+	}
+
+	// rollback restores the previous password on the controller and in
+	// the credential store, it returns the error that caused the
+	// rotation to fail.
+	rollback := func(rotateErr error) error {
+		zapctx.Error(ctx, "controller credential rotation failed, rolling back", zap.String("controller", controllerName), zap.Error(rotateErr))
+		if err := api.SetPassword(ctx, userTag, oldPassword); err != nil {
+			zapctx.Error(ctx, "failed to restore controller password", zap.String("controller", controllerName), zap.Error(err))
+			return errors.E(op, rotateErr, "failed to restore previous controller password")
+		}
+		if err := j.CredentialStore.PutControllerCredentials(ctx, controllerName, username, oldPassword); err != nil {
+			zapctx.Error(ctx, "failed to restore controller credentials", zap.String("controller", controllerName), zap.Error(err))
+			return errors.E(op, rotateErr, "failed to restore previous controller credentials")
+		}
+		if cci, ok := j.Dialer.(ControllerConnectionInvalidator); ok {
+			cci.InvalidateControllerConnections(controllerName)
+		}
+		return errors.E(op, rotateErr)
+	}
+
+	if err := j.CredentialStore.PutControllerCredentials(ctx, controllerName, username, newPassword); err != nil {
+		return rollback(errors.E(err, "failed to store controller credentials"))
+	}
+
+	if cci, ok := j.Dialer.(ControllerConnectionInvalidator); ok {
+		cci.InvalidateControllerConnections(controllerName)
+	}
+	if err := j.verifyControllerLogin(ctx, ctl, username, newPassword); err != nil {
+		return rollback(err)
+	}
+
+	if ctl.AdminPassword != "" {
+		// The credentials were held in the database, now that they
+		// are in the credential store they no longer need to be.
+		ctl.AdminIdentityName = ""
+		ctl.AdminPassword = ""
+		if err := j.Database.UpdateController(ctx, ctl); err != nil {
+			return rollback(errors.E(err, "failed to update controller"))
+		}
+	}
+	return nil
+}
+
+// verifyControllerLogin checks that a new connection can be made to the
+// given controller and, if the Dialer supports it, that the given
+// username and password can be used to log in.
+func (j *JIMM) verifyControllerLogin(ctx context.Context, ctl *dbmodel.Controller, username, password string) error {
+	api, err := j.dialController(ctx, ctl)
+	if err != nil {
+		return errors.E(err, "failed to dial the controller")
+	}
+	defer api.Close()
+	if err := api.Ping(ctx); err != nil {
+		return errors.E(err, "failed to ping the controller")
+	}
+
+	plc, ok := j.Dialer.(PasswordLoginChecker)
+	if !ok {
+		return nil
+	}
+	if err := plc.CheckPasswordLogin(ctx, ctl, username, password); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotImplemented {
+			zapctx.Warn(ctx, "cannot verify controller password login", zap.String("controller", ctl.Name))
+			return nil
+		}
+		return errors.E(err, "failed to log in with new controller credentials")
+	}
+	return nil
+}
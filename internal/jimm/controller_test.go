@@ -1668,3 +1668,243 @@ func (c *testControllerClient) InitiateMigration(spec controller.MigrationSpec)
 func (c *testControllerClient) Close() error {
 	return nil
 }
+
+const testRotateControllerCredentialsEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+`
+
+func TestRotateControllerCredentials(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		about              string
+		user               string
+		jimmAdmin          bool
+		controller         string
+		checkPasswordLogin func(password string, current string) error
+		expectedError      string
+		expectRotated      bool
+	}{{
+		about:         "user without admin access cannot rotate credentials",
+		user:          "bob@canonical.com",
+		controller:    "controller-1",
+		expectedError: "unauthorized",
+	}, {
+		about:         "controller not found",
+		user:          "alice@canonical.com",
+		jimmAdmin:     true,
+		controller:    "controller-2",
+		expectedError: "controller not found",
+	}, {
+		about:      "credentials rotated",
+		user:       "alice@canonical.com",
+		jimmAdmin:  true,
+		controller: "controller-1",
+		checkPasswordLogin: func(password string, current string) error {
+			if password != current {
+				return errors.E("authentication failed")
+			}
+			return nil
+		},
+		expectRotated: true,
+	}, {
+		about:      "failed login rolls back",
+		user:       "alice@canonical.com",
+		jimmAdmin:  true,
+		controller: "controller-1",
+		checkPasswordLogin: func(string, string) error {
+			return errors.E("authentication failed")
+		},
+		expectedError: "failed to log in with new controller credentials",
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			var mu sync.Mutex
+			currentPassword := "old-password"
+			store := jimmtest.NewInMemoryCredentialStore()
+			j := &jimm.JIMM{
+				UUID: uuid.NewString(),
+				Database: db.Database{
+					DB: jimmtest.PostgresDB(c, nil),
+				},
+				CredentialStore: store,
+				Dialer: jimm.CacheDialer(&jimmtest.Dialer{
+					API: &jimmtest.API{
+						SetPassword_: func(_ context.Context, user names.UserTag, password string) error {
+							if user.Id() != "admin" {
+								return errors.E(errors.CodeNotFound)
+							}
+							mu.Lock()
+							defer mu.Unlock()
+							currentPassword = password
+							return nil
+						},
+					},
+					CheckPasswordLogin_: func(_ context.Context, _ *dbmodel.Controller, _, password string) error {
+						mu.Lock()
+						defer mu.Unlock()
+						return test.checkPasswordLogin(password, currentPassword)
+					},
+				}),
+			}
+			ctx := context.Background()
+			err := j.Database.Migrate(ctx, false)
+			c.Assert(err, qt.IsNil)
+
+			env := jimmtest.ParseEnvironment(c, testRotateControllerCredentialsEnv)
+			env.PopulateDB(c, j.Database)
+
+			err = store.PutControllerCredentials(ctx, "controller-1", "admin", "old-password")
+			c.Assert(err, qt.IsNil)
+
+			dbUser := env.User(test.user).DBObject(c, j.Database)
+			user := openfga.NewUser(&dbUser, nil)
+			user.JimmAdmin = test.jimmAdmin
+
+			err = j.RotateControllerCredentials(ctx, user, test.controller)
+			if test.expectedError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectedError)
+			} else {
+				c.Check(err, qt.IsNil)
+			}
+
+			username, password, err := store.GetControllerCredentials(ctx, "controller-1")
+			c.Assert(err, qt.IsNil)
+			c.Check(username, qt.Equals, "admin")
+			c.Check(password, qt.Equals, currentPassword)
+			if test.expectRotated {
+				c.Check(password, qt.Not(qt.Equals), "old-password")
+			} else {
+				c.Check(password, qt.Equals, "old-password")
+			}
+		})
+	}
+}
+
+const testControllerVersionsEnv = `clouds:
+- name: test-cloud
+  type: test
+  regions:
+  - name: test-region
+cloud-credentials:
+- name: test-credential
+  cloud: test-cloud
+  owner: alice@canonical.com
+  type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region
+  agent-version: 3.2.1
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-region
+  agent-version: 3.1.0
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region
+  cloud-credential: test-credential
+  owner: alice@canonical.com
+  life: alive
+  agent-version: 3.2.1
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region
+  cloud-credential: test-credential
+  owner: alice@canonical.com
+  life: alive
+  agent-version: 2.9.44
+`
+
+func TestControllerVersions(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				ValidateModelUpgrade_: func(_ context.Context, mt names.ModelTag, _ bool) error {
+					if mt.Id() == "00000002-0000-0000-0000-000000000002" {
+						return errors.E("model is not ready to upgrade")
+					}
+					return nil
+				},
+			},
+		},
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testControllerVersionsEnv)
+	env.PopulateDB(c, j.Database)
+
+	dbUser := env.User("bob@canonical.com").DBObject(c, j.Database)
+	_, err = j.ControllerVersions(ctx, openfga.NewUser(&dbUser, nil), "", false)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+
+	dbUser = env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, nil)
+	user.JimmAdmin = true
+
+	_, err = j.ControllerVersions(ctx, user, "controller-3", false)
+	c.Check(err, qt.ErrorMatches, "controller not found")
+
+	reports, err := j.ControllerVersions(ctx, user, "", true)
+	c.Assert(err, qt.IsNil)
+	c.Assert(reports, qt.HasLen, 2)
+
+	c.Check(reports[0].Controller.Name, qt.Equals, "controller-1")
+	c.Check(reports[0].Controller.AgentVersion, qt.Equals, "3.2.1")
+	c.Check(reports[0].Err, qt.IsNil)
+	c.Assert(reports[0].Models, qt.HasLen, 2)
+	versions := make(map[string]string)
+	upgradeErrors := make(map[string]string)
+	for _, mr := range reports[0].Models {
+		versions[mr.Model.Name] = mr.Model.Status.Version
+		if mr.UpgradeErr != nil {
+			upgradeErrors[mr.Model.Name] = mr.UpgradeErr.Error()
+		}
+	}
+	c.Check(versions, qt.DeepEquals, map[string]string{
+		"model-1": "3.2.1",
+		"model-2": "2.9.44",
+	})
+	c.Check(upgradeErrors, qt.DeepEquals, map[string]string{
+		"model-2": "model is not ready to upgrade",
+	})
+
+	c.Check(reports[1].Controller.Name, qt.Equals, "controller-2")
+	c.Check(reports[1].Controller.AgentVersion, qt.Equals, "3.1.0")
+	c.Check(reports[1].Models, qt.HasLen, 0)
+
+	reports, err = j.ControllerVersions(ctx, user, "controller-2", false)
+	c.Assert(err, qt.IsNil)
+	c.Assert(reports, qt.HasLen, 1)
+	c.Check(reports[0].Controller.Name, qt.Equals, "controller-2")
+}
@@ -0,0 +1,331 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// ListCloudCredentialsParams holds the parameters for listing cloud
+// credentials.
+type ListCloudCredentialsParams struct {
+	// Owner restricts the credentials to those owned by the named
+	// identity. Only JIMM administrators may list the credentials of
+	// other identities. If this is empty JIMM administrators see the
+	// credentials of all identities and other users see their own.
+	Owner string
+
+	// Invalid restricts the credentials to those known to be invalid.
+	Invalid bool
+}
+
+// ListCloudCredentials returns the cloud credentials matching the given
+// parameters. The returned credentials never contain any attributes.
+func (j *JIMM) ListCloudCredentials(ctx context.Context, user *openfga.User, p ListCloudCredentialsParams) ([]dbmodel.CloudCredential, error) {
+	const op = errors.Op("jimm.ListCloudCredentials")
+
+	if !user.JimmAdmin && p.Owner == "" {
+		p.Owner = user.Name
+	}
+	if !user.JimmAdmin && p.Owner != user.Name {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	creds, err := j.Database.ListCloudCredentials(ctx, db.CloudCredentialFilter{
+		Owner:   p.Owner,
+		Invalid: p.Invalid,
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	for i := range creds {
+		creds[i].Attributes = nil
+	}
+	return creds, nil
+}
+
+// An InvalidCloudCredentialNotifier is notified when a cloud credential
+// that was previously valid is found to be invalid.
+type InvalidCloudCredentialNotifier interface {
+	// CloudCredentialInvalid is called with the credential that has
+	// become invalid and the reason it was rejected.
+	CloudCredentialInvalid(ctx context.Context, cred *dbmodel.CloudCredential, reason string)
+}
+
+// A WebhookCloudCredentialNotifier is an InvalidCloudCredentialNotifier
+// that posts a JSON description of each credential that has become
+// invalid to a URL, so that an external service can notify the
+// credential's owner.
+type WebhookCloudCredentialNotifier struct {
+	// URL is the URL notifications are posted to.
+	URL string
+
+	// Client is the HTTP client used to post notifications. If this is
+	// nil http.DefaultClient is used.
+	Client *http.Client
+
+	// Timeout is how long a notification may take to be delivered. If
+	// this is zero notifications time out after 30 seconds.
+	Timeout time.Duration
+}
+
+// An InvalidCloudCredentialNotification is the body posted by a
+// WebhookCloudCredentialNotifier.
+type InvalidCloudCredentialNotification struct {
+	// Credential is the path of the credential, cloud/owner/name.
+	Credential string `json:"credential"`
+
+	// Cloud is the name of the credential's cloud.
+	Cloud string `json:"cloud"`
+
+	// Owner is the name of the identity that owns the credential.
+	Owner string `json:"owner"`
+
+	// Reason is the reason the credential was rejected.
+	Reason string `json:"reason"`
+}
+
+// CloudCredentialInvalid implements InvalidCloudCredentialNotifier. Any
+// failure to deliver the notification is logged.
+func (n *WebhookCloudCredentialNotifier) CloudCredentialInvalid(ctx context.Context, cred *dbmodel.CloudCredential, reason string) {
+	body, err := json.Marshal(InvalidCloudCredentialNotification{
+		Credential: cred.Path(),
+		Cloud:      cred.CloudName,
+		Owner:      cred.OwnerIdentityName,
+		Reason:     reason,
+	})
+	if err != nil {
+		zapctx.Error(ctx, "failed to marshal cloud credential notification", zaputil.Error(err))
+		return
+	}
+	timeout := n.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		zapctx.Error(ctx, "failed to create cloud credential notification", zaputil.Error(err))
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		zapctx.Error(ctx, "failed to send cloud credential notification", zaputil.Error(err))
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		zapctx.Error(ctx, "cloud credential notification rejected", zap.Int("status", resp.StatusCode))
+	}
+}
+
+// A CloudCredentialValidator periodically checks every cloud credential
+// against the controllers hosting the models that use it, so that
+// expired or revoked credentials are found before they are used. The
+// result is stored in the credential's Valid and LastChecked fields. A
+// credential is only considered invalid if a controller rejects it for
+// a model using it and only considered valid if every controller hosting
+// a model using it accepts it. If the check cannot be made, or no model
+// uses the credential, the credential is left unchanged. When a
+// credential becomes invalid an audit log entry is recorded for its
+// owner and the Notifier, if any, is called.
+type CloudCredentialValidator struct {
+	// JIMM is the JIMM instance whose credentials are checked.
+	JIMM *JIMM
+
+	// Notifier, if configured, is notified of credentials that become
+	// invalid.
+	Notifier InvalidCloudCredentialNotifier
+}
+
+// Run checks all cloud credentials at the given interval until the
+// given context is canceled.
+func (v *CloudCredentialValidator) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := v.run(ctx, time.Now()); err != nil {
+			zapctx.Error(ctx, "failed to check cloud credentials", zaputil.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// run checks every cloud credential and updates the count of invalid
+// credentials.
+func (v *CloudCredentialValidator) run(ctx context.Context, now time.Time) error {
+	const op = errors.Op("jimm.CloudCredentialValidator.run")
+
+	creds, err := v.JIMM.Database.ListCloudCredentials(ctx, db.CloudCredentialFilter{})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	var invalid int
+	for i := range creds {
+		cred := &creds[i]
+		ctx := zapctx.WithFields(ctx, zap.String("credential", cred.Path()))
+		if err := v.check(ctx, cred, now); err != nil {
+			zapctx.Warn(ctx, "failed to check cloud credential", zaputil.Error(err))
+		}
+		if cred.Valid.Valid && !cred.Valid.Bool {
+			invalid++
+		}
+	}
+	servermon.InvalidCloudCredentialCount.Set(float64(invalid))
+	return nil
+}
+
+// check checks a single credential against every controller hosting a
+// model that uses it.
+func (v *CloudCredentialValidator) check(ctx context.Context, cred *dbmodel.CloudCredential, now time.Time) error {
+	controllers := credentialControllers(cred)
+	if len(controllers) == 0 {
+		zapctx.Debug(ctx, "no model uses cloud credential")
+		return nil
+	}
+
+	attrs, err := v.JIMM.getCloudCredentialAttributes(ctx, cred)
+	if err != nil {
+		return err
+	}
+	var results []jujuparams.UpdateCredentialModelResult
+	var checkErr error
+	for _, ctl := range controllers {
+		r, err := v.checkController(ctx, ctl, cred, attrs)
+		if err != nil {
+			// The check could not be made, the credential is not
+			// marked as valid so that a controller failure cannot
+			// hide a rejection from that controller.
+			zapctx.Warn(ctx, "cannot check cloud credential", zap.String("controller", ctl.Name), zaputil.Error(err))
+			checkErr = err
+			continue
+		}
+		results = append(results, r...)
+	}
+	reason := credentialCheckFailure(results)
+	if reason == "" && (checkErr != nil || len(results) == 0) {
+		// No controller rejected the credential, but not every
+		// model using it was checked, the credential's validity is
+		// left unchanged.
+		return checkErr
+	}
+
+	wasValid := !cred.Valid.Valid || cred.Valid.Bool
+	cred.Valid = sql.NullBool{Bool: reason == "", Valid: true}
+	cred.LastChecked = sql.NullTime{Time: now, Valid: true}
+	if err := v.JIMM.Database.UpdateCloudCredentialValidity(ctx, cred); err != nil {
+		return err
+	}
+	if reason != "" && wasValid {
+		zapctx.Warn(ctx, "cloud credential is invalid", zap.String("owner", cred.OwnerIdentityName), zap.String("reason", reason))
+		v.JIMM.addCloudCredentialInvalidAuditLogEntry(cred, reason)
+		if v.Notifier != nil {
+			v.Notifier.CloudCredentialInvalid(ctx, cred, reason)
+		}
+	}
+	return nil
+}
+
+// checkController checks the given credential against the models using
+// it on the given controller.
+func (v *CloudCredentialValidator) checkController(ctx context.Context, ctl *dbmodel.Controller, cred *dbmodel.CloudCredential, attrs map[string]string) ([]jujuparams.UpdateCredentialModelResult, error) {
+	api, err := v.JIMM.dial(ctx, ctl, names.ModelTag{})
+	if err != nil {
+		return nil, err
+	}
+	defer api.Close()
+	if !api.SupportsCheckCredentialModels() {
+		return nil, errors.E(errors.CodeNotSupported, "controller does not support checking credentials")
+	}
+	results, err := api.CheckCredentialModels(ctx, jujuparams.TaggedCredential{
+		Tag: cred.Tag().String(),
+		Credential: jujuparams.CloudCredential{
+			AuthType:   cred.AuthType,
+			Attributes: attrs,
+		},
+	})
+	if err != nil {
+		return nil, errors.E(err, "failed to check credential")
+	}
+	return results, nil
+}
+
+// credentialControllers returns the controllers hosting the models that
+// use the given credential, ordered by ID.
+func credentialControllers(cred *dbmodel.CloudCredential) []*dbmodel.Controller {
+	seen := make(map[uint]bool)
+	var controllers []*dbmodel.Controller
+	for i := range cred.Models {
+		m := &cred.Models[i]
+		if seen[m.ControllerID] {
+			continue
+		}
+		seen[m.ControllerID] = true
+		controllers = append(controllers, &m.Controller)
+	}
+	sort.Slice(controllers, func(i, j int) bool {
+		return controllers[i].ID < controllers[j].ID
+	})
+	return controllers
+}
+
+// credentialCheckFailure returns the reason a credential was rejected by
+// the models using it, or an empty string if the credential was accepted.
+func credentialCheckFailure(results []jujuparams.UpdateCredentialModelResult) string {
+	for _, r := range results {
+		for _, e := range r.Errors {
+			if e.Error != nil {
+				return fmt.Sprintf("model %q: %s", r.ModelName, e.Error.Message)
+			}
+		}
+	}
+	return ""
+}
+
+// addCloudCredentialInvalidAuditLogEntry records that a cloud credential
+// has become invalid in the audit log of the credential's owner.
+func (j *JIMM) addCloudCredentialInvalidAuditLogEntry(cred *dbmodel.CloudCredential, reason string) {
+	body, err := json.Marshal(map[string]interface{}{
+		"credential": cred.Path(),
+		"reason":     reason,
+	})
+	if err != nil {
+		zapctx.Error(context.Background(), "failed to marshal cloud credential audit parameters", zaputil.Error(err))
+		return
+	}
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		FacadeName:   "JIMM",
+		FacadeMethod: "CloudCredentialInvalid",
+		IdentityTag:  names.NewUserTag(cred.OwnerIdentityName).String(),
+		Params:       body,
+	})
+}
@@ -0,0 +1,250 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const credentialCheckEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+cloud-credentials:
+- name: cred-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+- name: cred-2
+  owner: bob@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+- name: cred-3
+  owner: bob@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: cred-2
+  owner: bob@canonical.com
+  life: alive
+`
+
+type credentialNotifier []string
+
+func (n *credentialNotifier) CloudCredentialInvalid(_ context.Context, cred *dbmodel.CloudCredential, reason string) {
+	*n = append(*n, cred.Path()+": "+reason)
+}
+
+func TestCloudCredentialValidator(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	bobValid := true
+	var checkErr error
+	var noResults bool
+	api := &jimmtest.API{
+		SupportsCheckCredentialModels_: true,
+		CheckCredentialModels_: func(_ context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+			if checkErr != nil {
+				return nil, checkErr
+			}
+			if noResults {
+				return nil, nil
+			}
+			switch cred.Tag {
+			case "cloudcred-test-cloud_alice@canonical.com_cred-1":
+				return []jujuparams.UpdateCredentialModelResult{{ModelName: "model-1"}}, nil
+			case "cloudcred-test-cloud_bob@canonical.com_cred-2":
+				if !bobValid {
+					return []jujuparams.UpdateCredentialModelResult{{
+						ModelName: "model-2",
+						Errors: []jujuparams.ErrorResult{{
+							Error: &jujuparams.Error{Message: "credential expired"},
+						}},
+					}}, nil
+				}
+				return []jujuparams.UpdateCredentialModelResult{{ModelName: "model-2"}}, nil
+			}
+			c.Errorf("unexpected check of unused credential %s", cred.Tag)
+			return nil, nil
+		},
+	}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer:        &jimmtest.Dialer{API: api},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, credentialCheckEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+
+	var notified credentialNotifier
+	v := &jimm.CloudCredentialValidator{
+		JIMM:     j,
+		Notifier: &notified,
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	err = jimm.CheckCloudCredentials(v, ctx, now)
+	c.Assert(err, qt.IsNil)
+	c.Check(notified, qt.HasLen, 0)
+
+	// Credentials that are not used by any model are not checked.
+	creds, err := j.ListCloudCredentials(ctx, alice, jimm.ListCloudCredentialsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 3)
+	for _, cred := range creds {
+		if cred.Name == "cred-3" {
+			c.Check(cred.Valid.Valid, qt.IsFalse)
+			c.Check(cred.LastChecked.Valid, qt.IsFalse)
+			continue
+		}
+		c.Check(cred.Valid.Bool, qt.IsTrue)
+		c.Check(cred.LastChecked.Time.Equal(now), qt.IsTrue)
+	}
+
+	// Bob's credential expires.
+	bobValid = false
+	err = jimm.CheckCloudCredentials(v, ctx, now.Add(time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(notified, qt.DeepEquals, credentialNotifier{`test-cloud/bob@canonical.com/cred-2: model "model-2": credential expired`})
+
+	creds, err = j.ListCloudCredentials(ctx, alice, jimm.ListCloudCredentialsParams{Invalid: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 1)
+	c.Check(creds[0].Name, qt.Equals, "cred-2")
+	c.Check(creds[0].LastChecked.Time.Equal(now.Add(time.Hour)), qt.IsTrue)
+
+	var entries []dbmodel.AuditLogEntry
+	err = j.Database.ForEachAuditLogEntry(ctx, db.AuditLogFilter{Method: "CloudCredentialInvalid"}, func(ale *dbmodel.AuditLogEntry) error {
+		entries = append(entries, *ale)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.HasLen, 1)
+	c.Check(entries[0].IdentityTag, qt.Equals, "user-bob@canonical.com")
+
+	// The owner is only notified when the credential becomes invalid.
+	err = jimm.CheckCloudCredentials(v, ctx, now.Add(2*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(notified, qt.HasLen, 1)
+
+	// A failure to make the check leaves the credentials unchanged.
+	checkErr = errors.E("connection refused")
+	err = jimm.CheckCloudCredentials(v, ctx, now.Add(3*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(notified, qt.HasLen, 1)
+	creds, err = j.ListCloudCredentials(ctx, alice, jimm.ListCloudCredentialsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 3)
+	for _, cred := range creds[:2] {
+		c.Check(cred.Valid.Bool, qt.Equals, cred.Name == "cred-1")
+		c.Check(cred.LastChecked.Time.Equal(now.Add(2*time.Hour)), qt.IsTrue)
+	}
+	checkErr = nil
+
+	// A check that returns no results does not make an invalid
+	// credential valid.
+	bobValid = true
+	noResults = true
+	err = jimm.CheckCloudCredentials(v, ctx, now.Add(4*time.Hour))
+	c.Assert(err, qt.IsNil)
+	creds, err = j.ListCloudCredentials(ctx, alice, jimm.ListCloudCredentialsParams{Invalid: true})
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 1)
+	c.Check(creds[0].Name, qt.Equals, "cred-2")
+	c.Check(creds[0].LastChecked.Time.Equal(now.Add(2*time.Hour)), qt.IsTrue)
+	noResults = false
+
+	// Users may only list their own credentials.
+	creds, err = j.ListCloudCredentials(ctx, bob, jimm.ListCloudCredentialsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(creds, qt.HasLen, 2)
+	c.Check(creds[0].OwnerIdentityName, qt.Equals, "bob@canonical.com")
+	c.Check(creds[0].Valid.Bool, qt.IsFalse)
+
+	_, err = j.ListCloudCredentials(ctx, bob, jimm.ListCloudCredentialsParams{Owner: "alice@canonical.com"})
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+}
+
+func TestWebhookCloudCredentialNotifier(t *testing.T) {
+	c := qt.New(t)
+
+	var notifications []jimm.InvalidCloudCredentialNotification
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, qt.Equals, http.MethodPost)
+		c.Check(req.Header.Get("Content-Type"), qt.Equals, "application/json")
+		var n jimm.InvalidCloudCredentialNotification
+		err := json.NewDecoder(req.Body).Decode(&n)
+		c.Check(err, qt.IsNil)
+		notifications = append(notifications, n)
+	}))
+	defer srv.Close()
+
+	n := &jimm.WebhookCloudCredentialNotifier{URL: srv.URL}
+	n.CloudCredentialInvalid(context.Background(), &dbmodel.CloudCredential{
+		Name:              "cred-1",
+		CloudName:         "test-cloud",
+		OwnerIdentityName: "bob@canonical.com",
+	}, "credential expired")
+	c.Check(notifications, qt.DeepEquals, []jimm.InvalidCloudCredentialNotification{{
+		Credential: "test-cloud/bob@canonical.com/cred-1",
+		Cloud:      "test-cloud",
+		Owner:      "bob@canonical.com",
+		Reason:     "credential expired",
+	}})
+}
@@ -0,0 +1,416 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/cloudcred"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+// credentialRotationTimeout is how long a rotation may go without being
+// updated before it is considered abandoned, for example because the
+// JIMM instance performing it was restarted, and is rolled back by a
+// CloudCredentialRotationRecoverer.
+const credentialRotationTimeout = time.Hour
+
+// RotateCloudCredentialArgs holds the arguments for a staged cloud
+// credential rotation.
+type RotateCloudCredentialArgs struct {
+	// CredentialTag is the tag of the credential to rotate.
+	CredentialTag names.CloudCredentialTag
+
+	// Credential holds the new auth-type and attributes of the
+	// credential.
+	Credential jujuparams.CloudCredential
+}
+
+// RotateCloudCredential replaces the attributes of an existing cloud
+// credential in stages, so that a wrong secret does not break every model
+// using the credential at the same time. The new attributes are stored as
+// pending and checked against every controller hosting models that use
+// the credential. They are then applied to a single canary controller,
+// the one hosting the fewest of those models, and finally to the
+// remaining controllers one at a time. If applying the new attributes
+// fails on any controller, the previous attributes are restored on every
+// controller that was updated and the rotation is marked as rolled back.
+// JIMM's stored copy of the credential is only replaced once every
+// controller has been updated.
+//
+// Only one rotation of a credential may be in progress at a time.
+// Rotations abandoned part way through are rolled back by a
+// CloudCredentialRotationRecoverer.
+//
+// The returned rotation describes the outcome, a rotation that failed or
+// was rolled back is not reported as an error. Credentials may be rotated
+// by the same users that may update them.
+func (j *JIMM) RotateCloudCredential(ctx context.Context, user *openfga.User, args RotateCloudCredentialArgs) (*dbmodel.CloudCredentialRotation, error) {
+	const op = errors.Op("jimm.RotateCloudCredential")
+
+	if user.Tag() != args.CredentialTag.Owner() {
+		if !user.JimmAdmin && user.GetCloudCredentialAccess(ctx, args.CredentialTag) != ofganames.AdministratorRelation {
+			return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+		}
+	}
+
+	var cred dbmodel.CloudCredential
+	cred.SetTag(args.CredentialTag)
+	if err := j.Database.GetCloudCredential(ctx, &cred); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := cloudcred.ValidateAttributes(cred.Cloud.Type, args.Credential.AuthType, args.Credential.Attributes); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	previous, err := j.getCloudCredentialAttributes(ctx, &cred)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	// The database only allows one rotation of the credential to be in
+	// progress, so the rotation is stored before any attributes are
+	// written to the credential store, where they would replace those
+	// of a rotation already in progress.
+	r := dbmodel.CloudCredentialRotation{
+		CloudCredentialID: cred.ID,
+		CreatedBy:         user.Name,
+		Status:            dbmodel.CredentialRotationValidating,
+		AuthType:          args.Credential.AuthType,
+		PreviousAuthType:  cred.AuthType,
+		AttributesInVault: j.CredentialStore != nil,
+	}
+	if !r.AttributesInVault {
+		r.Attributes = args.Credential.Attributes
+		r.PreviousAttributes = previous
+	}
+	if err := j.Database.AddCloudCredentialRotation(ctx, &r); err != nil {
+		if errors.ErrorCode(err) == errors.CodeAlreadyExists {
+			return nil, errors.E(op, errors.CodeBadRequest, "credential rotation already in progress")
+		}
+		return nil, errors.E(op, err)
+	}
+
+	rot := credentialRotation{
+		j:        j,
+		r:        &r,
+		previous: cred,
+		next:     cred,
+	}
+	rot.previous.Attributes = previous
+	rot.next.AuthType = args.Credential.AuthType
+	rot.next.Attributes = args.Credential.Attributes
+	if r.AttributesInVault {
+		err := j.CredentialStore.Put(ctx, rotationCredentialTag(args.CredentialTag, "pending"), args.Credential.Attributes)
+		if err == nil {
+			err = j.CredentialStore.Put(ctx, rotationCredentialTag(args.CredentialTag, "previous"), previous)
+		}
+		if err != nil {
+			if ferr := rot.finish(ctx, dbmodel.CredentialRotationFailed, fmt.Sprintf("cannot store attributes: %s", err)); ferr != nil {
+				zapctx.Error(ctx, "failed to record cloud credential rotation failure", zaputil.Error(ferr))
+			}
+			return nil, errors.E(op, err)
+		}
+	}
+	if err := rot.run(ctx); err != nil {
+		return nil, errors.E(op, err)
+	}
+	r.Attributes = nil
+	r.PreviousAttributes = nil
+	r.CloudCredential = cred
+	r.CloudCredential.Attributes = nil
+	return &r, nil
+}
+
+// GetCloudCredentialRotation returns the most recent rotation of the
+// given cloud credential. The returned rotation never contains any
+// attributes. If the credential has never been rotated an error with a
+// code of CodeNotFound is returned.
+func (j *JIMM) GetCloudCredentialRotation(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (*dbmodel.CloudCredentialRotation, error) {
+	const op = errors.Op("jimm.GetCloudCredentialRotation")
+
+	cred, err := j.GetCloudCredential(ctx, user, tag)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	r := dbmodel.CloudCredentialRotation{CloudCredentialID: cred.ID}
+	if err := j.Database.GetLatestCloudCredentialRotation(ctx, &r); err != nil {
+		return nil, errors.E(op, err)
+	}
+	r.Attributes = nil
+	r.PreviousAttributes = nil
+	r.CloudCredential.Attributes = nil
+	return &r, nil
+}
+
+// rotationCredentialTag returns the tag under which the pending or
+// previous attributes of a credential being rotated are held in the
+// credential store.
+func rotationCredentialTag(tag names.CloudCredentialTag, version string) names.CloudCredentialTag {
+	return names.NewCloudCredentialTag(fmt.Sprintf("%s/%s/%s+rotation-%s", tag.Cloud().Id(), tag.Owner().Id(), tag.Name(), version))
+}
+
+// A credentialRotation performs the stages of a single credential
+// rotation.
+type credentialRotation struct {
+	j *JIMM
+	r *dbmodel.CloudCredentialRotation
+
+	// previous and next hold the credential with the attributes from
+	// before and after the rotation.
+	previous dbmodel.CloudCredential
+	next     dbmodel.CloudCredential
+}
+
+// run performs the rotation. Errors applying the new attributes are
+// recorded in the rotation, the returned error is only non-nil if the
+// rotation state cannot be stored.
+func (rot *credentialRotation) run(ctx context.Context) error {
+	models, err := rot.j.Database.GetModelsUsingCredential(ctx, rot.next.ID)
+	if err != nil {
+		return err
+	}
+	controllers := rotationControllers(models)
+
+	// Validate the new attributes against every model using the
+	// credential.
+	err = rot.j.forEachController(ctx, controllers, func(ctl *dbmodel.Controller, api API) error {
+		results, err := rot.j.updateControllerCloudCredential(ctx, &rot.next, api.CheckCredentialModels)
+		return controllerCredentialError(ctl, results, err)
+	})
+	if err != nil {
+		return rot.finish(ctx, dbmodel.CredentialRotationFailed, err.Error())
+	}
+
+	var updated []dbmodel.Controller
+	for i, ctl := range controllers {
+		if i == 0 {
+			rot.r.Status = dbmodel.CredentialRotationCanary
+			rot.r.CanaryController = ctl.Name
+		} else {
+			rot.r.Status = dbmodel.CredentialRotationRollingOut
+		}
+		if err := rot.j.Database.UpdateCloudCredentialRotation(ctx, rot.r); err != nil {
+			return err
+		}
+
+		// A controller that fails part way through an update may
+		// still have applied the new attributes, so it is always
+		// rolled back.
+		updated = append(updated, ctl)
+		if err := rot.apply(ctx, &controllers[i], &rot.next); err != nil {
+			msg := fmt.Sprintf("rolled back: %s", err)
+			if rbErr := rot.rollback(ctx, updated); rbErr != nil {
+				msg += "; " + rbErr.Error()
+			}
+			return rot.finish(ctx, dbmodel.CredentialRotationRolledBack, msg)
+		}
+		rot.r.UpdatedControllers = append(rot.r.UpdatedControllers, ctl.Name)
+	}
+
+	if err := rot.j.updateCredential(ctx, &rot.next); err != nil {
+		msg := fmt.Sprintf("rolled back: cannot store credential: %s", err)
+		if rbErr := rot.rollback(ctx, updated); rbErr != nil {
+			msg += "; " + rbErr.Error()
+		}
+		return rot.finish(ctx, dbmodel.CredentialRotationRolledBack, msg)
+	}
+	return rot.finish(ctx, dbmodel.CredentialRotationCompleted, "")
+}
+
+// apply updates the credential on a single controller.
+func (rot *credentialRotation) apply(ctx context.Context, ctl *dbmodel.Controller, cred *dbmodel.CloudCredential) error {
+	api, err := rot.j.dial(ctx, ctl, names.ModelTag{})
+	if err != nil {
+		return errors.E(fmt.Sprintf("controller %q: %s", ctl.Name, err))
+	}
+	defer api.Close()
+	results, err := rot.j.updateControllerCloudCredential(ctx, cred, api.UpdateCredential)
+	return controllerCredentialError(ctl, results, err)
+}
+
+// rollback restores the previous attributes on the given controllers.
+// Every controller is attempted, the returned error describes the
+// controllers that could not be restored.
+func (rot *credentialRotation) rollback(ctx context.Context, controllers []dbmodel.Controller) error {
+	var failed []string
+	for i := range controllers {
+		if err := rot.apply(ctx, &controllers[i], &rot.previous); err != nil {
+			zapctx.Error(ctx, "failed to roll back cloud credential", zap.String("credential", rot.next.Path()), zaputil.Error(err))
+			failed = append(failed, "rollback failed: "+err.Error())
+		}
+	}
+	if len(failed) > 0 {
+		return errors.E(strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// finish records the final status of the rotation and discards the
+// pending and previous attributes.
+func (rot *credentialRotation) finish(ctx context.Context, status, msg string) error {
+	rot.r.Status = status
+	rot.r.StatusMessage = msg
+	rot.r.Attributes = nil
+	rot.r.PreviousAttributes = nil
+	if rot.r.AttributesInVault {
+		tag := rot.next.ResourceTag()
+		for _, version := range []string{"pending", "previous"} {
+			if err := rot.j.CredentialStore.Put(ctx, rotationCredentialTag(tag, version), nil); err != nil {
+				zapctx.Error(ctx, "failed to remove rotation credential attributes", zap.String("credential", rot.next.Path()), zaputil.Error(err))
+			}
+		}
+	}
+	return rot.j.Database.UpdateCloudCredentialRotation(ctx, rot.r)
+}
+
+// rotationControllers returns the controllers hosting the given models,
+// in the order in which they are updated. The controller hosting the
+// fewest models is updated first so that a bad credential affects as few
+// models as possible.
+func rotationControllers(models []dbmodel.Model) []dbmodel.Controller {
+	counts := make(map[uint]int)
+	var controllers []dbmodel.Controller
+	for _, m := range models {
+		if counts[m.ControllerID] == 0 {
+			controllers = append(controllers, m.Controller)
+		}
+		counts[m.ControllerID]++
+	}
+	sort.SliceStable(controllers, func(i, j int) bool {
+		if counts[controllers[i].ID] != counts[controllers[j].ID] {
+			return counts[controllers[i].ID] < counts[controllers[j].ID]
+		}
+		return controllers[i].Name < controllers[j].Name
+	})
+	return controllers
+}
+
+// controllerCredentialError returns an error describing the failure of a
+// credential call on the given controller, or nil if both the call and
+// every model succeeded.
+func controllerCredentialError(ctl *dbmodel.Controller, results []jujuparams.UpdateCredentialModelResult, err error) error {
+	if err != nil {
+		return errors.E(fmt.Sprintf("controller %q: %s", ctl.Name, err))
+	}
+	if reason := credentialCheckFailure(results); reason != "" {
+		return errors.E(fmt.Sprintf("controller %q: %s", ctl.Name, reason))
+	}
+	return nil
+}
+
+// A CloudCredentialRotationRecoverer rolls back cloud credential rotations
+// that were abandoned part way through, for example because the JIMM
+// instance performing them was restarted. A rotation is abandoned once
+// it has gone without being updated for credentialRotationTimeout.
+type CloudCredentialRotationRecoverer struct {
+	// JIMM is the JIMM whose rotations are recovered.
+	JIMM *JIMM
+}
+
+// Run recovers abandoned rotations at the given interval until the given
+// context is canceled.
+func (r *CloudCredentialRotationRecoverer) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.recover(ctx, time.Now()); err != nil {
+			zapctx.Error(ctx, "failed to recover cloud credential rotations", zaputil.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// recover rolls back every rotation abandoned by the given time.
+func (r *CloudCredentialRotationRecoverer) recover(ctx context.Context, now time.Time) error {
+	const op = errors.Op("jimm.CloudCredentialRotationRecoverer.recover")
+
+	before := now.Add(-credentialRotationTimeout)
+	rotations, err := r.JIMM.Database.ListStaleCloudCredentialRotations(ctx, before)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	for i := range rotations {
+		rt := &rotations[i]
+		ctx := zapctx.WithFields(ctx, zap.String("credential", rt.CloudCredential.Path()), zap.Uint("rotation", rt.ID))
+		claimed, err := r.JIMM.Database.ClaimStaleCloudCredentialRotation(ctx, rt, before, now)
+		if err != nil {
+			zapctx.Error(ctx, "failed to claim cloud credential rotation", zaputil.Error(err))
+			continue
+		}
+		if !claimed {
+			// Another JIMM instance is recovering the rotation.
+			continue
+		}
+		if err := r.rollback(ctx, rt); err != nil {
+			zapctx.Error(ctx, "failed to recover cloud credential rotation", zaputil.Error(err))
+		}
+	}
+	return nil
+}
+
+// rollback restores the previous attributes of the credential in the
+// given abandoned rotation, both on every controller hosting models that
+// use the credential and in JIMM's stored copy. A rotation abandoned
+// while validating has not changed anything and is marked as failed.
+func (r *CloudCredentialRotationRecoverer) rollback(ctx context.Context, rt *dbmodel.CloudCredentialRotation) error {
+	j := r.JIMM
+	rot := credentialRotation{
+		j:        j,
+		r:        rt,
+		previous: rt.CloudCredential,
+		next:     rt.CloudCredential,
+	}
+	if rt.Status == dbmodel.CredentialRotationValidating {
+		return rot.finish(ctx, dbmodel.CredentialRotationFailed, "abandoned while validating")
+	}
+
+	previous := map[string]string(rt.PreviousAttributes)
+	if rt.AttributesInVault {
+		if j.CredentialStore == nil {
+			return rot.finish(ctx, dbmodel.CredentialRotationFailed, "abandoned: cannot roll back: vault not configured")
+		}
+		var err error
+		previous, err = j.CredentialStore.Get(ctx, rotationCredentialTag(rt.CloudCredential.ResourceTag(), "previous"))
+		if err != nil {
+			return rot.finish(ctx, dbmodel.CredentialRotationFailed, fmt.Sprintf("abandoned: cannot roll back: %s", err))
+		}
+	}
+	rot.previous.AuthType = rt.PreviousAuthType
+	rot.previous.Attributes = previous
+
+	// The controller being updated when the rotation was abandoned is
+	// not recorded, so the previous attributes are restored on every
+	// controller, which leaves those not yet updated unchanged.
+	models, err := j.Database.GetModelsUsingCredential(ctx, rt.CloudCredentialID)
+	if err != nil {
+		return err
+	}
+	msg := "rolled back: rotation abandoned"
+	if err := rot.rollback(ctx, rotationControllers(models)); err != nil {
+		msg += "; " + err.Error()
+	}
+	// The rotation may have been abandoned after JIMM's copy of the
+	// credential was replaced.
+	if err := j.updateCredential(ctx, &rot.previous); err != nil {
+		msg += "; cannot store credential: " + err.Error()
+	}
+	return rot.finish(ctx, dbmodel.CredentialRotationRolledBack, msg)
+}
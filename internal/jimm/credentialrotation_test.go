@@ -0,0 +1,281 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const credentialRotationEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+cloud-credentials:
+- name: cred-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: userpass
+  attributes:
+    username: alice
+    password: old
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-region-1
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-2
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-2
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+`
+
+// credentialController is a fake controller that records the password
+// of the credential it holds.
+type credentialController struct {
+	password string
+	// reject holds a password that the controller's models reject.
+	reject string
+}
+
+func (cc *credentialController) api() *jimmtest.API {
+	check := func(_ context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+		if cred.Credential.Attributes["password"] == cc.reject {
+			return []jujuparams.UpdateCredentialModelResult{{
+				ModelName: "model",
+				Errors:    []jujuparams.ErrorResult{{Error: &jujuparams.Error{Message: "invalid password"}}},
+			}}, nil
+		}
+		return nil, nil
+	}
+	return &jimmtest.API{
+		CheckCredentialModels_: check,
+		UpdateCredential_: func(ctx context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+			results, err := check(ctx, cred)
+			cc.password = cred.Credential.Attributes["password"]
+			return results, err
+		},
+	}
+}
+
+func TestRotateCloudCredential(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	ctl1 := &credentialController{password: "old"}
+	ctl2 := &credentialController{password: "old"}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: jimmtest.DialerMap{
+			"controller-1": &jimmtest.Dialer{API: ctl1.api()},
+			"controller-2": &jimmtest.Dialer{API: ctl2.api()},
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, credentialRotationEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+
+	tag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+	rotate := func(password string) *dbmodel.CloudCredentialRotation {
+		r, err := j.RotateCloudCredential(ctx, alice, jimm.RotateCloudCredentialArgs{
+			CredentialTag: tag,
+			Credential: jujuparams.CloudCredential{
+				AuthType:   "userpass",
+				Attributes: map[string]string{"username": "alice", "password": password},
+			},
+		})
+		c.Assert(err, qt.IsNil)
+		c.Check(r.Attributes, qt.IsNil)
+		c.Check(r.PreviousAttributes, qt.IsNil)
+		return r
+	}
+	storedPassword := func() string {
+		cred, err := j.GetCloudCredential(ctx, alice, tag)
+		c.Assert(err, qt.IsNil)
+		attrs, _, err := j.GetCloudCredentialAttributes(ctx, alice, cred, true)
+		c.Assert(err, qt.IsNil)
+		return attrs["password"]
+	}
+
+	// A password rejected by any model fails validation and is not
+	// applied anywhere.
+	ctl2.reject = "wrong"
+	r := rotate("wrong")
+	c.Check(r.Status, qt.Equals, dbmodel.CredentialRotationFailed)
+	c.Check(r.StatusMessage, qt.Equals, `controller "controller-2": model "model": invalid password`)
+	c.Check(ctl1.password, qt.Equals, "old")
+	c.Check(ctl2.password, qt.Equals, "old")
+	c.Check(storedPassword(), qt.Equals, "old")
+
+	// A failure on the canary controller, which hosts the fewest models,
+	// is rolled back.
+	ctl2.reject = ""
+	j.Dialer.(jimmtest.DialerMap)["controller-1"] = &jimmtest.Dialer{API: rejectOnUpdate(ctl1, "canary")}
+	r = rotate("canary")
+	c.Check(r.Status, qt.Equals, dbmodel.CredentialRotationRolledBack)
+	c.Check(r.CanaryController, qt.Equals, "controller-1")
+	c.Check(r.StatusMessage, qt.Equals, `rolled back: controller "controller-1": model "model": invalid password`)
+	c.Check(r.UpdatedControllers, qt.HasLen, 0)
+	c.Check(ctl1.password, qt.Equals, "old")
+	c.Check(ctl2.password, qt.Equals, "old")
+	c.Check(storedPassword(), qt.Equals, "old")
+
+	// A failure after the canary rolls back every updated controller.
+	j.Dialer.(jimmtest.DialerMap)["controller-1"] = &jimmtest.Dialer{API: ctl1.api()}
+	j.Dialer.(jimmtest.DialerMap)["controller-2"] = &jimmtest.Dialer{API: rejectOnUpdate(ctl2, "rollout")}
+	r = rotate("rollout")
+	c.Check(r.Status, qt.Equals, dbmodel.CredentialRotationRolledBack)
+	c.Check(r.StatusMessage, qt.Equals, `rolled back: controller "controller-2": model "model": invalid password`)
+	c.Check(r.UpdatedControllers, qt.DeepEquals, dbmodel.Strings{"controller-1"})
+	c.Check(ctl1.password, qt.Equals, "old")
+	c.Check(ctl2.password, qt.Equals, "old")
+	c.Check(storedPassword(), qt.Equals, "old")
+
+	// A good password is applied everywhere.
+	j.Dialer.(jimmtest.DialerMap)["controller-2"] = &jimmtest.Dialer{API: ctl2.api()}
+	r = rotate("new")
+	c.Check(r.Status, qt.Equals, dbmodel.CredentialRotationCompleted)
+	c.Check(r.StatusMessage, qt.Equals, "")
+	c.Check(r.UpdatedControllers, qt.DeepEquals, dbmodel.Strings{"controller-1", "controller-2"})
+	c.Check(ctl1.password, qt.Equals, "new")
+	c.Check(ctl2.password, qt.Equals, "new")
+	c.Check(storedPassword(), qt.Equals, "new")
+
+	r2, err := j.GetCloudCredentialRotation(ctx, alice, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(r2.ID, qt.Equals, r.ID)
+	c.Check(r2.Status, qt.Equals, dbmodel.CredentialRotationCompleted)
+	c.Check(r2.Attributes, qt.IsNil)
+
+	// A rotation abandoned part way through, here after the canary
+	// controller was updated, blocks other rotations until it is rolled
+	// back.
+	cred, err := j.GetCloudCredential(ctx, alice, tag)
+	c.Assert(err, qt.IsNil)
+	abandoned := dbmodel.CloudCredentialRotation{
+		CloudCredentialID:  cred.ID,
+		CreatedBy:          "alice@canonical.com",
+		Status:             dbmodel.CredentialRotationRollingOut,
+		AuthType:           "userpass",
+		PreviousAuthType:   "userpass",
+		Attributes:         dbmodel.StringMap{"username": "alice", "password": "abandoned"},
+		PreviousAttributes: dbmodel.StringMap{"username": "alice", "password": "new"},
+		CanaryController:   "controller-1",
+		UpdatedControllers: dbmodel.Strings{"controller-1"},
+	}
+	err = j.Database.AddCloudCredentialRotation(ctx, &abandoned)
+	c.Assert(err, qt.IsNil)
+	ctl1.password = "abandoned"
+
+	_, err = j.RotateCloudCredential(ctx, alice, jimm.RotateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType:   "userpass",
+			Attributes: map[string]string{"username": "alice", "password": "blocked"},
+		},
+	})
+	c.Check(err, qt.ErrorMatches, "credential rotation already in progress")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	recoverer := jimm.CloudCredentialRotationRecoverer{JIMM: j}
+	err = jimm.RecoverCloudCredentialRotations(&recoverer, ctx, time.Now())
+	c.Assert(err, qt.IsNil)
+	r2, err = j.GetCloudCredentialRotation(ctx, alice, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(r2.Status, qt.Equals, dbmodel.CredentialRotationRollingOut)
+
+	err = jimm.RecoverCloudCredentialRotations(&recoverer, ctx, time.Now().Add(2*time.Hour))
+	c.Assert(err, qt.IsNil)
+	r2, err = j.GetCloudCredentialRotation(ctx, alice, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(r2.ID, qt.Equals, abandoned.ID)
+	c.Check(r2.Status, qt.Equals, dbmodel.CredentialRotationRolledBack)
+	c.Check(r2.StatusMessage, qt.Equals, "rolled back: rotation abandoned")
+	c.Check(ctl1.password, qt.Equals, "new")
+	c.Check(ctl2.password, qt.Equals, "new")
+	c.Check(storedPassword(), qt.Equals, "new")
+
+	// Other users cannot rotate or see the rotation.
+	_, err = j.RotateCloudCredential(ctx, bob, jimm.RotateCloudCredentialArgs{CredentialTag: tag})
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	_, err = j.GetCloudCredentialRotation(ctx, bob, tag)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
+
+// rejectOnUpdate returns an API for the given controller that accepts the
+// given password when it is checked but whose models reject it once it
+// has been applied.
+func rejectOnUpdate(cc *credentialController, password string) *jimmtest.API {
+	api := cc.api()
+	update := api.UpdateCredential_
+	api.UpdateCredential_ = func(ctx context.Context, cred jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+		results, err := update(ctx, cred)
+		if err == nil && cred.Credential.Attributes["password"] == password {
+			results = append(results, jujuparams.UpdateCredentialModelResult{
+				ModelName: "model",
+				Errors:    []jujuparams.ErrorResult{{Error: &jujuparams.Error{Message: "invalid password"}}},
+			})
+		}
+		return results, err
+	}
+	return api
+}
@@ -55,3 +55,31 @@ type CredentialStore interface {
 	// PutJWKSExpiry sets the expiry time for the current JWKS within the store.
 	PutJWKSExpiry(ctx context.Context, expiry time.Time) error
 }
+
+// A CredentialVersion describes a version of the attributes of a cloud
+// credential held by a VersionedCredentialStore.
+type CredentialVersion struct {
+	// Version is the version number, versions are numbered from 1.
+	Version int
+
+	// CreatedTime is the time the version was stored.
+	CreatedTime time.Time
+
+	// Deleted is true if the version has been deleted, in which case its
+	// attributes cannot be retrieved.
+	Deleted bool
+}
+
+// A VersionedCredentialStore is a CredentialStore that keeps the previous
+// versions of the attributes of cloud credentials.
+type VersionedCredentialStore interface {
+	CredentialStore
+
+	// ListCloudCredentialVersions returns the stored versions of the
+	// attributes of a cloud credential, oldest first.
+	ListCloudCredentialVersions(context.Context, names.CloudCredentialTag) ([]CredentialVersion, error)
+
+	// GetCloudCredentialVersion retrieves the given version of the
+	// attributes of a cloud credential.
+	GetCloudCredentialVersion(context.Context, names.CloudCredentialTag, int) (map[string]string, error)
+}
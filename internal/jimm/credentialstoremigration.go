@@ -0,0 +1,346 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm/credentials"
+)
+
+// Kinds of secret copied by MigrateCredentialStore.
+const (
+	SecretKindCloudCredential      = "cloud-credential"
+	SecretKindControllerCredential = "controller-credential"
+	SecretKindJWKS                 = "jwks"
+	SecretKindJWKSPrivateKey       = "jwks-private-key"
+	SecretKindJWKSExpiry           = "jwks-expiry"
+)
+
+// Statuses of secrets copied by MigrateCredentialStore.
+const (
+	// SecretCopied is the status of a secret that has been copied to
+	// the destination store and verified.
+	SecretCopied = "copied"
+
+	// SecretUpToDate is the status of a secret that the destination
+	// store already holds, for example because an earlier migration
+	// was interrupted after copying it.
+	SecretUpToDate = "up-to-date"
+
+	// SecretWouldCopy is the status of a secret that would be copied if
+	// the migration was not a dry run.
+	SecretWouldCopy = "would-copy"
+
+	// SecretMissing is the status of a secret that the source store
+	// does not hold, so there is nothing to copy.
+	SecretMissing = "missing"
+
+	// SecretFailed is the status of a secret that could not be copied.
+	SecretFailed = "failed"
+)
+
+// CredentialStoreMigrationParams holds the parameters for
+// MigrateCredentialStore.
+type CredentialStoreMigrationParams struct {
+	// Source is the credential store the secrets are copied from.
+	Source credentials.CredentialStore
+
+	// Destination is the credential store the secrets are copied to.
+	Destination credentials.CredentialStore
+
+	// DryRun reports what would be copied without writing anything.
+	DryRun bool
+}
+
+// A MigratedSecret reports the outcome of copying a single secret.
+type MigratedSecret struct {
+	// Kind is the kind of secret, one of the SecretKind constants.
+	Kind string
+
+	// Name identifies the secret within its kind, for example the path
+	// of a cloud credential or the name of a controller.
+	Name string
+
+	// Status is the outcome, one of the Secret status constants.
+	Status string
+
+	// Err holds the error if the secret could not be copied.
+	Err error
+}
+
+// MigrateCredentialStore copies the cloud credential attributes,
+// controller credentials and JWKS material held in one credential store
+// to another, for example when moving from the insecure database storage
+// to Vault. Every secret written is read back from the destination and
+// compared with the source. Secrets that the destination already holds
+// with the same value are not written again, so an
+// interrupted migration can be resumed by running it again. Cloud
+// credentials whose attributes are held in the cloud_credentials table
+// are not in any credential store and are left in place. The source
+// store is left unchanged so that JIMM can be switched back to it if
+// needed.
+//
+// OAuth session secrets are supplied through JIMM's configuration rather
+// than the credential store, so they are not migrated.
+//
+// The returned slice reports the outcome for every secret. A secret that
+// cannot be copied does not stop the migration, the returned error is
+// only non-nil if the secrets to copy cannot be listed.
+func MigrateCredentialStore(ctx context.Context, database *db.Database, p CredentialStoreMigrationParams) ([]MigratedSecret, error) {
+	const op = errors.Op("jimm.MigrateCredentialStore")
+
+	if p.Source == nil || p.Destination == nil {
+		return nil, errors.E(op, errors.CodeBadRequest, "source and destination credential stores must be specified")
+	}
+	if p.Source == p.Destination {
+		return nil, errors.E(op, errors.CodeBadRequest, "source and destination credential stores must be different")
+	}
+
+	m := credentialStoreMigration{
+		db:     database,
+		params: p,
+	}
+	if err := m.cloudCredentials(ctx); err != nil {
+		return m.results, errors.E(op, err)
+	}
+	if err := m.controllerCredentials(ctx); err != nil {
+		return m.results, errors.E(op, err)
+	}
+	m.jwks(ctx)
+	return m.results, nil
+}
+
+// A credentialStoreMigration holds the state of a single
+// MigrateCredentialStore call.
+type credentialStoreMigration struct {
+	db      *db.Database
+	params  CredentialStoreMigrationParams
+	results []MigratedSecret
+}
+
+// A secretCopier reads a single secret from a store and writes it to
+// another.
+type secretCopier struct {
+	kind string
+	name string
+
+	// get reads the secret from the given store, returning nil if the
+	// store does not hold the secret.
+	get func(context.Context, credentials.CredentialStore) ([]byte, error)
+
+	// put writes the secret read from the source to the given store.
+	put func(context.Context, credentials.CredentialStore) error
+}
+
+func (m *credentialStoreMigration) cloudCredentials(ctx context.Context) error {
+	creds, err := m.db.ListCloudCredentials(ctx, db.CloudCredentialFilter{})
+	if err != nil {
+		return err
+	}
+	for i := range creds {
+		cred := &creds[i]
+		if !cred.AttributesInVault {
+			// The attributes are held in the cloud_credentials
+			// table, which is used whichever store is configured.
+			continue
+		}
+		tag := cred.ResourceTag()
+		var attrs map[string]string
+		c := secretCopier{
+			kind: SecretKindCloudCredential,
+			name: cred.Path(),
+			get: func(ctx context.Context, s credentials.CredentialStore) ([]byte, error) {
+				a, err := s.Get(ctx, tag)
+				if errors.ErrorCode(err) == errors.CodeNotFound || (err == nil && len(a) == 0) {
+					return nil, nil
+				}
+				if err != nil {
+					return nil, err
+				}
+				if s == m.params.Source {
+					attrs = a
+				}
+				return json.Marshal(a)
+			},
+			put: func(ctx context.Context, s credentials.CredentialStore) error {
+				return s.Put(ctx, tag, attrs)
+			},
+		}
+		m.copy(ctx, c)
+	}
+	return nil
+}
+
+func (m *credentialStoreMigration) controllerCredentials(ctx context.Context) error {
+	var controllers []string
+	err := m.db.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+		controllers = append(controllers, ctl.Name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range controllers {
+		name := name
+		var username, password string
+		m.copy(ctx, secretCopier{
+			kind: SecretKindControllerCredential,
+			name: name,
+			get: func(ctx context.Context, s credentials.CredentialStore) ([]byte, error) {
+				u, p, err := s.GetControllerCredentials(ctx, name)
+				if errors.ErrorCode(err) == errors.CodeNotFound || (err == nil && u == "" && p == "") {
+					return nil, nil
+				}
+				if err != nil {
+					return nil, err
+				}
+				if s == m.params.Source {
+					username, password = u, p
+				}
+				return json.Marshal([]string{u, p})
+			},
+			put: func(ctx context.Context, s credentials.CredentialStore) error {
+				return s.PutControllerCredentials(ctx, name, username, password)
+			},
+		})
+	}
+	return nil
+}
+
+func (m *credentialStoreMigration) jwks(ctx context.Context) {
+	var set jwk.Set
+	var key []byte
+	var expiry time.Time
+	m.copy(ctx, secretCopier{
+		kind: SecretKindJWKS,
+		name: "jwks",
+		get: func(ctx context.Context, s credentials.CredentialStore) ([]byte, error) {
+			ks, err := s.GetJWKS(ctx)
+			if errors.ErrorCode(err) == errors.CodeNotFound {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if s == m.params.Source {
+				set = ks
+			}
+			return json.Marshal(ks)
+		},
+		put: func(ctx context.Context, s credentials.CredentialStore) error {
+			return s.PutJWKS(ctx, set)
+		},
+	})
+	m.copy(ctx, secretCopier{
+		kind: SecretKindJWKSPrivateKey,
+		name: "jwks",
+		get: func(ctx context.Context, s credentials.CredentialStore) ([]byte, error) {
+			k, err := s.GetJWKSPrivateKey(ctx)
+			if errors.ErrorCode(err) == errors.CodeNotFound || (err == nil && len(k) == 0) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if s == m.params.Source {
+				key = k
+			}
+			return k, nil
+		},
+		put: func(ctx context.Context, s credentials.CredentialStore) error {
+			return s.PutJWKSPrivateKey(ctx, key)
+		},
+	})
+	m.copy(ctx, secretCopier{
+		kind: SecretKindJWKSExpiry,
+		name: "jwks",
+		get: func(ctx context.Context, s credentials.CredentialStore) ([]byte, error) {
+			t, err := s.GetJWKSExpiry(ctx)
+			if errors.ErrorCode(err) == errors.CodeNotFound {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if s == m.params.Source {
+				expiry = t
+			}
+			// Vault stores the expiry with a precision of one
+			// second.
+			return []byte(t.UTC().Truncate(time.Second).Format(time.RFC3339)), nil
+		},
+		put: func(ctx context.Context, s credentials.CredentialStore) error {
+			return s.PutJWKSExpiry(ctx, expiry)
+		},
+	})
+}
+
+// copy copies a single secret, recording the outcome.
+func (m *credentialStoreMigration) copy(ctx context.Context, c secretCopier) {
+	res := MigratedSecret{
+		Kind: c.kind,
+		Name: c.name,
+	}
+	defer func() {
+		if res.Err != nil {
+			zapctx.Error(ctx, "failed to migrate secret", zap.String("kind", c.kind), zap.String("name", c.name), zaputil.Error(res.Err))
+		}
+		m.results = append(m.results, res)
+	}()
+
+	src, err := c.get(ctx, m.params.Source)
+	if err != nil {
+		res.Status = SecretFailed
+		res.Err = errors.E(fmt.Sprintf("cannot read source: %s", err))
+		return
+	}
+	if src == nil {
+		res.Status = SecretMissing
+		return
+	}
+
+	dst, err := c.get(ctx, m.params.Destination)
+	upToDate := err == nil && dst != nil && bytes.Equal(dst, src)
+	if m.params.DryRun {
+		res.Status = SecretWouldCopy
+		if upToDate {
+			res.Status = SecretUpToDate
+		}
+		return
+	}
+	if upToDate {
+		res.Status = SecretUpToDate
+		return
+	}
+
+	if err := c.put(ctx, m.params.Destination); err != nil {
+		res.Status = SecretFailed
+		res.Err = errors.E(fmt.Sprintf("cannot write destination: %s", err))
+		return
+	}
+	dst, err = c.get(ctx, m.params.Destination)
+	if err != nil {
+		res.Status = SecretFailed
+		res.Err = errors.E(fmt.Sprintf("cannot verify destination: %s", err))
+		return
+	}
+	if dst == nil || !bytes.Equal(dst, src) {
+		res.Status = SecretFailed
+		res.Err = errors.E("destination does not match source after copy")
+		return
+	}
+	res.Status = SecretCopied
+}
@@ -0,0 +1,165 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+)
+
+const credentialStoreMigrationEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+cloud-credentials:
+- name: cred-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: userpass
+- name: cred-2
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: userpass
+  attributes:
+    username: alice
+    password: inline
+- name: cred-3
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-region-1
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+`
+
+func TestMigrateCredentialStore(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	database := db.Database{
+		DB: jimmtest.PostgresDB(c, nil),
+	}
+	err := database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, credentialStoreMigrationEnv)
+	env.PopulateDB(c, database)
+
+	cred1 := dbmodel.CloudCredential{CloudName: "test-cloud", OwnerIdentityName: "alice@canonical.com", Name: "cred-1"}
+	err = database.GetCloudCredential(ctx, &cred1)
+	c.Assert(err, qt.IsNil)
+	cred1.AttributesInVault = true
+	err = database.SetCloudCredential(ctx, &cred1)
+	c.Assert(err, qt.IsNil)
+
+	src := jimmtest.NewInMemoryCredentialStore()
+	err = src.Put(ctx, cred1.ResourceTag(), map[string]string{"username": "alice", "password": "stored"})
+	c.Assert(err, qt.IsNil)
+	err = src.PutControllerCredentials(ctx, "controller-1", "admin", "secret")
+	c.Assert(err, qt.IsNil)
+	err = src.PutJWKS(ctx, jwk.NewSet())
+	c.Assert(err, qt.IsNil)
+	err = src.PutJWKSPrivateKey(ctx, []byte("private key"))
+	c.Assert(err, qt.IsNil)
+	err = src.PutJWKSExpiry(ctx, time.Now().Add(time.Hour))
+	c.Assert(err, qt.IsNil)
+
+	dst := jimmtest.NewInMemoryCredentialStore()
+	statuses := func(secrets []jimm.MigratedSecret) map[string]string {
+		m := make(map[string]string)
+		for _, s := range secrets {
+			c.Check(s.Err, qt.IsNil, qt.Commentf("%s %s", s.Kind, s.Name))
+			m[s.Kind+" "+s.Name] = s.Status
+		}
+		return m
+	}
+
+	// A dry run does not write anything.
+	secrets, err := jimm.MigrateCredentialStore(ctx, &database, jimm.CredentialStoreMigrationParams{
+		Source:      src,
+		Destination: dst,
+		DryRun:      true,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(statuses(secrets), qt.DeepEquals, map[string]string{
+		"cloud-credential test-cloud/alice@canonical.com/cred-1": jimm.SecretWouldCopy,
+		"cloud-credential test-cloud/alice@canonical.com/cred-3": jimm.SecretMissing,
+		"controller-credential controller-1":                     jimm.SecretWouldCopy,
+		"controller-credential controller-2":                     jimm.SecretMissing,
+		"jwks jwks":                                              jimm.SecretWouldCopy,
+		"jwks-private-key jwks":                                  jimm.SecretWouldCopy,
+		"jwks-expiry jwks":                                       jimm.SecretWouldCopy,
+	})
+	_, err = dst.Get(ctx, cred1.ResourceTag())
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// An interrupted migration leaves some secrets already copied.
+	err = dst.PutControllerCredentials(ctx, "controller-1", "admin", "secret")
+	c.Assert(err, qt.IsNil)
+
+	secrets, err = jimm.MigrateCredentialStore(ctx, &database, jimm.CredentialStoreMigrationParams{
+		Source:      src,
+		Destination: dst,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(statuses(secrets), qt.DeepEquals, map[string]string{
+		"cloud-credential test-cloud/alice@canonical.com/cred-1": jimm.SecretCopied,
+		"cloud-credential test-cloud/alice@canonical.com/cred-3": jimm.SecretMissing,
+		"controller-credential controller-1":                     jimm.SecretUpToDate,
+		"controller-credential controller-2":                     jimm.SecretMissing,
+		"jwks jwks":                                              jimm.SecretCopied,
+		"jwks-private-key jwks":                                  jimm.SecretCopied,
+		"jwks-expiry jwks":                                       jimm.SecretCopied,
+	})
+
+	attrs, err := dst.Get(ctx, cred1.ResourceTag())
+	c.Assert(err, qt.IsNil)
+	c.Check(attrs, qt.DeepEquals, map[string]string{"username": "alice", "password": "stored"})
+
+	// Inline attributes are left in place.
+	cred2 := dbmodel.CloudCredential{CloudName: "test-cloud", OwnerIdentityName: "alice@canonical.com", Name: "cred-2"}
+	err = database.GetCloudCredential(ctx, &cred2)
+	c.Assert(err, qt.IsNil)
+	c.Check(cred2.AttributesInVault, qt.IsFalse)
+	c.Check(cred2.Attributes, qt.DeepEquals, dbmodel.StringMap{"username": "alice", "password": "inline"})
+	_, err = dst.Get(ctx, cred2.ResourceTag())
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// Running the migration again finds everything up to date.
+	secrets, err = jimm.MigrateCredentialStore(ctx, &database, jimm.CredentialStoreMigrationParams{
+		Source:      src,
+		Destination: dst,
+	})
+	c.Assert(err, qt.IsNil)
+	for _, s := range secrets {
+		if s.Status != jimm.SecretMissing {
+			c.Check(s.Status, qt.Equals, jimm.SecretUpToDate, qt.Commentf("%s %s", s.Kind, s.Name))
+		}
+	}
+
+	_, err = jimm.MigrateCredentialStore(ctx, &database, jimm.CredentialStoreMigrationParams{
+		Source:      src,
+		Destination: src,
+	})
+	c.Check(err, qt.ErrorMatches, "source and destination credential stores must be different")
+}
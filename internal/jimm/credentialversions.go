@@ -0,0 +1,90 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm/credentials"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// ListCloudCredentialVersions returns the versions of the attributes of
+// the given cloud credential kept by JIMM's credential store. Only JIMM
+// administrators may list credential versions, and the credential store
+// must keep previous versions, as Vault's KV v2 engine does.
+func (j *JIMM) ListCloudCredentialVersions(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) ([]credentials.CredentialVersion, error) {
+	const op = errors.Op("jimm.ListCloudCredentialVersions")
+
+	store, err := j.versionedCredentialStore(ctx, user, tag)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	versions, err := store.ListCloudCredentialVersions(ctx, tag)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return versions, nil
+}
+
+// RestoreCloudCredentialVersion replaces the attributes of the given cloud
+// credential with the given previous version. The restored attributes are
+// checked against, and applied to, every controller hosting models that
+// use the credential in the same way as UpdateCloudCredential. The
+// credential keeps its current auth type. Only JIMM administrators may
+// restore credential versions.
+func (j *JIMM) RestoreCloudCredentialVersion(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, version int) ([]jujuparams.UpdateCredentialModelResult, error) {
+	const op = errors.Op("jimm.RestoreCloudCredentialVersion")
+
+	store, err := j.versionedCredentialStore(ctx, user, tag)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	attrs, err := store.GetCloudCredentialVersion(ctx, tag, version)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	var cred dbmodel.CloudCredential
+	cred.SetTag(tag)
+	if err := j.Database.GetCloudCredential(ctx, &cred); err != nil {
+		return nil, errors.E(op, err)
+	}
+	results, err := j.UpdateCloudCredential(ctx, user, UpdateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential: jujuparams.CloudCredential{
+			AuthType:   cred.AuthType,
+			Attributes: attrs,
+		},
+	})
+	if err != nil {
+		return results, errors.E(op, err)
+	}
+	return results, nil
+}
+
+// versionedCredentialStore checks that the given user may access the
+// versions of the given credential and returns the credential store
+// holding them.
+func (j *JIMM) versionedCredentialStore(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (credentials.VersionedCredentialStore, error) {
+	if !user.JimmAdmin {
+		return nil, errors.E(errors.CodeUnauthorized, "unauthorized")
+	}
+	store, ok := j.CredentialStore.(credentials.VersionedCredentialStore)
+	if !ok {
+		return nil, errors.E(errors.CodeNotSupported, "credential store does not keep previous versions")
+	}
+	var cred dbmodel.CloudCredential
+	cred.SetTag(tag)
+	if err := j.Database.GetCloudCredential(ctx, &cred); err != nil {
+		return nil, err
+	}
+	if !cred.AttributesInVault {
+		return nil, errors.E(errors.CodeNotSupported, "credential attributes are not held in the credential store")
+	}
+	return store, nil
+}
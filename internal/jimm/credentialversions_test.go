@@ -0,0 +1,119 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimm/credentials"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const credentialVersionsEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+cloud-credentials:
+- name: cred-2
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: userpass
+  attributes:
+    username: alice
+    password: inline
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+`
+
+func TestCloudCredentialVersions(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	store := jimmtest.NewInMemoryCredentialStore()
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		CredentialStore: store,
+		OpenFGAClient:   client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, credentialVersionsEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	u := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&u, client)
+	alice.JimmAdmin = true
+	u2 := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&u2, client)
+
+	tag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-1")
+	for _, password := range []string{"one", "two"} {
+		_, err = j.UpdateCloudCredential(ctx, alice, jimm.UpdateCloudCredentialArgs{
+			CredentialTag: tag,
+			Credential: jujuparams.CloudCredential{
+				AuthType:   "userpass",
+				Attributes: map[string]string{"username": "alice", "password": password},
+			},
+		})
+		c.Assert(err, qt.IsNil)
+	}
+
+	versions, err := j.ListCloudCredentialVersions(ctx, alice, tag)
+	c.Assert(err, qt.IsNil)
+	c.Assert(versions, qt.HasLen, 2)
+	c.Check(versions[0].Version, qt.Equals, 1)
+	c.Check(versions[1].Version, qt.Equals, 2)
+
+	_, err = j.RestoreCloudCredentialVersion(ctx, alice, tag, 1)
+	c.Assert(err, qt.IsNil)
+	attrs, err := store.Get(ctx, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(attrs, qt.DeepEquals, map[string]string{"username": "alice", "password": "one"})
+
+	versions, err = j.ListCloudCredentialVersions(ctx, alice, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(versions, qt.HasLen, 3)
+
+	_, err = j.RestoreCloudCredentialVersion(ctx, alice, tag, 10)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	_, err = j.ListCloudCredentialVersions(ctx, bob, tag)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+	_, err = j.RestoreCloudCredentialVersion(ctx, bob, tag, 1)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	_, err = j.ListCloudCredentialVersions(ctx, alice, names.NewCloudCredentialTag("test-cloud/alice@canonical.com/no-such-cred"))
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// Credentials held in the database have no versions.
+	_, err = j.ListCloudCredentialVersions(ctx, alice, names.NewCloudCredentialTag("test-cloud/alice@canonical.com/cred-2"))
+	c.Check(err, qt.ErrorMatches, `credential attributes are not held in the credential store`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotSupported)
+
+	// Not every credential store keeps versions.
+	j.CredentialStore = struct{ credentials.CredentialStore }{store}
+	_, err = j.ListCloudCredentialVersions(ctx, alice, tag)
+	c.Check(err, qt.ErrorMatches, `credential store does not keep previous versions`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotSupported)
+}
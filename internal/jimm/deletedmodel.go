@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+// readModelTuples reads all the OpenFGA tuples that have the given model
+// as their target.
+func readModelTuples(ctx context.Context, client *openfga.OFGAClient, mt names.ModelTag) (dbmodel.DeletedModelTuples, error) {
+	var tuples dbmodel.DeletedModelTuples
+	key := openfga.Tuple{
+		Target: ofganames.ConvertTag(mt),
+	}
+	var ct string
+	for {
+		page, next, err := client.ReadRelatedObjects(ctx, key, 50, ct)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range page {
+			tuples = append(tuples, dbmodel.DeletedModelTuple{
+				Object:   t.Object.String(),
+				Relation: string(t.Relation),
+				Target:   t.Target.String(),
+			})
+		}
+		if next == "" {
+			return tuples, nil
+		}
+		ct = next
+	}
+}
+
+// ListDeletedModels returns the tombstones of the models that have been
+// removed from JIMM and not yet purged, most recently deleted first. If
+// uuid is not empty only the tombstones for the model with that UUID are
+// returned. Only JIMM administrators can list deleted models.
+func (j *JIMM) ListDeletedModels(ctx context.Context, user *openfga.User, uuid string) ([]dbmodel.DeletedModel, error) {
+	const op = errors.Op("jimm.ListDeletedModels")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	models, err := j.Database.ListDeletedModels(ctx, uuid)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return models, nil
+}
+
+// A DeletedModelPurger removes the tombstones of deleted models once
+// they are older than the retention period.
+type DeletedModelPurger struct {
+	// Database is the database holding the tombstones.
+	Database db.Database
+
+	// RetentionPeriod is how long tombstones are kept for.
+	RetentionPeriod time.Duration
+}
+
+// Run purges expired tombstones at the given interval until the given
+// context is canceled.
+func (p *DeletedModelPurger) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := p.purge(ctx, time.Now()); err != nil {
+			zapctx.Error(ctx, "failed to purge deleted models", zaputil.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge removes the tombstones of models deleted more than the retention
+// period before now.
+func (p *DeletedModelPurger) purge(ctx context.Context, now time.Time) error {
+	const op = errors.Op("jimm.DeletedModelPurger.purge")
+
+	n, err := p.Database.DeleteDeletedModelsBefore(ctx, now.Add(-p.RetentionPeriod))
+	if err != nil {
+		return errors.E(op, err)
+	}
+	zapctx.Debug(ctx, "purged deleted models", zap.Int64("count", n))
+	return nil
+}
@@ -4,6 +4,7 @@ package jimm
 
 import (
 	"context"
+	"time"
 
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
@@ -20,6 +21,9 @@ var (
 	NewControllerClient            = &newControllerClient
 	FillMigrationTarget            = fillMigrationTarget
 	InitiateMigration              = &initiateMigration
+	InitiateInternalMigration      = &initiateInternalMigration
+	UpdateMigratedModel            = &updateMigratedModel
+	SourceMigrationStatus          = &sourceMigrationStatus
 	ResolveTag                     = resolveTag
 )
 
@@ -60,3 +64,19 @@ func (j *JIMM) UpdateUserLastLogin(ctx context.Context, identifier string) error
 func (j *JIMM) EveryoneUser() *openfga.User {
 	return j.everyoneUser()
 }
+
+func RunMigrationPlans(s *MigrationScheduler, ctx context.Context, now time.Time) error {
+	return s.run(ctx, now)
+}
+
+func ReapModels(r *ModelReaper, ctx context.Context, now time.Time) error {
+	return r.reap(ctx, now)
+}
+
+func CheckCloudCredentials(v *CloudCredentialValidator, ctx context.Context, now time.Time) error {
+	return v.run(ctx, now)
+}
+
+func RecoverCloudCredentialRotations(r *CloudCredentialRotationRecoverer, ctx context.Context, now time.Time) error {
+	return r.recover(ctx, now)
+}
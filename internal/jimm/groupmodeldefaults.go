@@ -0,0 +1,143 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// SetGroupModelDefaults writes new default model setting values for the
+// named group. Existing values for other keys are kept. If priority is
+// not nil the priority of the group's defaults is also updated. Only
+// JIMM administrators can set group model defaults.
+func (j *JIMM) SetGroupModelDefaults(ctx context.Context, user *openfga.User, groupName string, priority *int, configs map[string]interface{}) error {
+	const op = errors.Op("jimm.SetGroupModelDefaults")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	for k := range configs {
+		if k == agentVersionKey {
+			return errors.E(op, errors.CodeBadRequest, `agent-version cannot have a default value`)
+		}
+	}
+
+	err := j.updateGroupModelDefaults(ctx, groupName, func(defaults *dbmodel.GroupModelDefaults) {
+		if defaults.Defaults == nil {
+			defaults.Defaults = make(dbmodel.Map, len(configs))
+		}
+		for k, v := range configs {
+			defaults.Defaults[k] = v
+		}
+		if priority != nil {
+			defaults.Priority = *priority
+		}
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// UnsetGroupModelDefaults removes the given keys from the model defaults
+// of the named group. Only JIMM administrators can unset group model
+// defaults.
+func (j *JIMM) UnsetGroupModelDefaults(ctx context.Context, user *openfga.User, groupName string, keys []string) error {
+	const op = errors.Op("jimm.UnsetGroupModelDefaults")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	err := j.updateGroupModelDefaults(ctx, groupName, func(defaults *dbmodel.GroupModelDefaults) {
+		for _, k := range keys {
+			delete(defaults.Defaults, k)
+		}
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// GroupModelDefaults returns the model defaults of the named group. If
+// the group has no defaults an error with a code of CodeNotFound is
+// returned. Only JIMM administrators can view group model defaults.
+func (j *JIMM) GroupModelDefaults(ctx context.Context, user *openfga.User, groupName string) (dbmodel.GroupModelDefaults, error) {
+	const op = errors.Op("jimm.GroupModelDefaults")
+
+	if !user.JimmAdmin {
+		return dbmodel.GroupModelDefaults{}, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	group := dbmodel.GroupEntry{Name: groupName}
+	if err := j.Database.GetGroup(ctx, &group); err != nil {
+		return dbmodel.GroupModelDefaults{}, errors.E(op, err)
+	}
+	defaults := dbmodel.GroupModelDefaults{GroupUUID: group.UUID}
+	if err := j.Database.GetGroupModelDefaults(ctx, &defaults); err != nil {
+		return dbmodel.GroupModelDefaults{}, errors.E(op, err)
+	}
+	return defaults, nil
+}
+
+// EffectiveModelConfig returns the config a model added by the given
+// user with the given arguments would be created with, along with the
+// source of each value. The config is layered in the same order as when
+// the model is added. Defaults for a cloud region are only included if
+// the region is given, either in the arguments or by a model template.
+func (j *JIMM) EffectiveModelConfig(ctx context.Context, user *openfga.User, args *ModelCreateArgs) (map[string]ModelDefault, error) {
+	const op = errors.Op("jimm.EffectiveModelConfig")
+
+	args, template, err := j.applyModelTemplate(ctx, args)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	config, err := j.ModelDefaults(ctx, user.Name, args.Cloud.Id(), args.CloudRegion)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if template != nil {
+		for k, v := range template.Config {
+			config[k] = ModelDefault{Value: v, Source: TemplateConfigSource}
+		}
+	}
+	for k, v := range args.Config {
+		config[k] = ModelDefault{Value: v, Source: ExplicitConfigSource}
+	}
+	return config, nil
+}
+
+// updateGroupModelDefaults calls the given function to update the model
+// defaults of the named group and stores the result.
+func (j *JIMM) updateGroupModelDefaults(ctx context.Context, groupName string, f func(*dbmodel.GroupModelDefaults)) error {
+	group := dbmodel.GroupEntry{Name: groupName}
+	if err := j.Database.GetGroup(ctx, &group); err != nil {
+		return err
+	}
+	return j.Database.Transaction(func(d *db.Database) error {
+		defaults := dbmodel.GroupModelDefaults{GroupUUID: group.UUID}
+		if err := d.GetGroupModelDefaults(ctx, &defaults); err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+			return err
+		}
+		f(&defaults)
+		return d.SetGroupModelDefaults(ctx, &defaults)
+	})
+}
+
+// identityGroupModelDefaults returns the model defaults of the groups the
+// given identity is a member of, in order of increasing precedence.
+func (j *JIMM) identityGroupModelDefaults(ctx context.Context, identity *dbmodel.Identity) ([]dbmodel.GroupModelDefaults, error) {
+	if j.OpenFGAClient == nil {
+		return nil, nil
+	}
+	groups, err := openfga.NewUser(identity, j.OpenFGAClient).ListGroups(ctx)
+	if err != nil {
+		return nil, errors.E(err, "failed to list groups")
+	}
+	return j.Database.ListGroupModelDefaults(ctx, groups)
+}
@@ -0,0 +1,176 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+func TestGroupModelDefaults(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var createArgs jujuparams.ModelCreateArgs
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				GrantJIMMModelAdmin_: func(context.Context, names.ModelTag) error {
+					return nil
+				},
+				CreateModel_: func(ctx context.Context, args *jujuparams.ModelCreateArgs, mi *jujuparams.ModelInfo) error {
+					createArgs = *args
+					return createModel(`
+uuid: 00000001-0000-0000-0000-0000-000000000001
+status:
+  status: started
+life: alive
+`[1:])(ctx, args, mi)
+				},
+			},
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testModelTemplateEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+
+	for _, name := range []string{"ops", "dev", "qa", "other"} {
+		group, err := j.Database.AddGroup(ctx, name)
+		c.Assert(err, qt.IsNil)
+		if name == "other" {
+			continue
+		}
+		err = client.AddRelation(ctx, openfga.Tuple{
+			Object:   ofganames.ConvertTag(alice.ResourceTag()),
+			Relation: ofganames.MemberRelation,
+			Target:   ofganames.ConvertTag(jimmnames.NewGroupTag(group.UUID)),
+		})
+		c.Assert(err, qt.IsNil)
+	}
+
+	err = j.SetGroupModelDefaults(ctx, bob, "ops", nil, map[string]interface{}{"key1": "ops"})
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	err = j.SetGroupModelDefaults(ctx, alice, "ops", nil, map[string]interface{}{"agent-version": "3.5.0"})
+	c.Check(err, qt.ErrorMatches, `agent-version cannot have a default value`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	err = j.SetGroupModelDefaults(ctx, alice, "no-such-group", nil, map[string]interface{}{"key1": "value"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	_, err = j.GroupModelDefaults(ctx, alice, "ops")
+	c.Check(err, qt.ErrorMatches, `groupmodeldefaults not found`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = j.SetGroupModelDefaults(ctx, alice, "ops", nil, map[string]interface{}{
+		"key1": "ops",
+		"key2": "ops",
+		"key3": "ops",
+		"key4": "ops",
+	})
+	c.Assert(err, qt.IsNil)
+	five := 5
+	err = j.SetGroupModelDefaults(ctx, alice, "dev", &five, map[string]interface{}{"key2": "dev"})
+	c.Assert(err, qt.IsNil)
+	err = j.SetGroupModelDefaults(ctx, alice, "qa", &five, map[string]interface{}{"key2": "qa", "key6": "qa"})
+	c.Assert(err, qt.IsNil)
+	err = j.SetGroupModelDefaults(ctx, alice, "other", nil, map[string]interface{}{"key1": "other"})
+	c.Assert(err, qt.IsNil)
+
+	// Setting more values keeps the priority and the existing values.
+	err = j.SetGroupModelDefaults(ctx, alice, "qa", nil, map[string]interface{}{"key7": "qa"})
+	c.Assert(err, qt.IsNil)
+	err = j.UnsetGroupModelDefaults(ctx, alice, "qa", []string{"key6"})
+	c.Assert(err, qt.IsNil)
+	err = j.UnsetGroupModelDefaults(ctx, bob, "qa", []string{"key7"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	defaults, err := j.GroupModelDefaults(ctx, alice, "qa")
+	c.Assert(err, qt.IsNil)
+	c.Check(defaults.Group.Name, qt.Equals, "qa")
+	c.Check(defaults.Priority, qt.Equals, 5)
+	c.Check(defaults.Defaults, qt.DeepEquals, dbmodel.Map{"key2": "qa", "key7": "qa"})
+
+	err = j.SetIdentityModelDefaults(ctx, &dbAlice, map[string]interface{}{"key3": "identity"})
+	c.Assert(err, qt.IsNil)
+	err = j.SetModelDefaults(ctx, &dbAlice, names.NewCloudTag("test-cloud"), "test-region-1", map[string]interface{}{"key4": "region"})
+	c.Assert(err, qt.IsNil)
+
+	args := jimm.ModelCreateArgs{
+		Name:        "model-1",
+		Owner:       alice.ResourceTag(),
+		Cloud:       names.NewCloudTag("test-cloud"),
+		CloudRegion: "test-region-1",
+		Config:      map[string]interface{}{"key5": "explicit"},
+	}
+	config, err := j.EffectiveModelConfig(ctx, alice, &args)
+	c.Assert(err, qt.IsNil)
+	c.Check(config, qt.DeepEquals, map[string]jimm.ModelDefault{
+		"key1": {Value: "ops", Source: jimm.GroupDefaultsSource, Group: "ops"},
+		"key2": {Value: "qa", Source: jimm.GroupDefaultsSource, Group: "qa"},
+		"key3": {Value: "identity", Source: jimm.IdentityDefaultsSource},
+		"key4": {Value: "region", Source: jimm.RegionDefaultsSource},
+		"key5": {Value: "explicit", Source: jimm.ExplicitConfigSource},
+		"key7": {Value: "qa", Source: jimm.GroupDefaultsSource, Group: "qa"},
+	})
+
+	_, err = j.AddModel(ctx, alice, &args)
+	c.Assert(err, qt.IsNil)
+	c.Check(createArgs.Config, qt.DeepEquals, map[string]interface{}{
+		"key1": "ops",
+		"key2": "qa",
+		"key3": "identity",
+		"key4": "region",
+		"key5": "explicit",
+		"key7": "qa",
+	})
+
+	// Defaults of removed groups no longer apply.
+	qa := dbmodel.GroupEntry{Name: "qa"}
+	err = j.Database.GetGroup(ctx, &qa)
+	c.Assert(err, qt.IsNil)
+	err = j.Database.RemoveGroup(ctx, &qa)
+	c.Assert(err, qt.IsNil)
+	config, err = j.EffectiveModelConfig(ctx, alice, &jimm.ModelCreateArgs{})
+	c.Assert(err, qt.IsNil)
+	c.Check(config, qt.DeepEquals, map[string]jimm.ModelDefault{
+		"key1": {Value: "ops", Source: jimm.GroupDefaultsSource, Group: "ops"},
+		"key2": {Value: "dev", Source: jimm.GroupDefaultsSource, Group: "dev"},
+		"key3": {Value: "identity", Source: jimm.IdentityDefaultsSource},
+		"key4": {Value: "ops", Source: jimm.GroupDefaultsSource, Group: "ops"},
+	})
+}
@@ -37,6 +37,15 @@ var (
 	initiateMigration = func(ctx context.Context, j *JIMM, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error) {
 		return j.InitiateMigration(ctx, user, spec)
 	}
+	initiateInternalMigration = func(ctx context.Context, j *JIMM, user *openfga.User, modelTag names.ModelTag, targetController string) (jujuparams.InitiateMigrationResult, error) {
+		return j.InitiateInternalMigration(ctx, user, modelTag, targetController)
+	}
+	updateMigratedModel = func(ctx context.Context, j *JIMM, user *openfga.User, modelTag names.ModelTag, targetController string) error {
+		return j.UpdateMigratedModel(ctx, user, modelTag, targetController)
+	}
+	sourceMigrationStatus = func(ctx context.Context, j *JIMM, modelTag names.ModelTag) (*jujuparams.ModelMigrationStatus, error) {
+		return j.sourceMigrationStatus(ctx, modelTag)
+	}
 )
 
 // A JIMM provides the business logic for managing resources in the JAAS
@@ -202,6 +211,34 @@ type Dialer interface {
 	Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag, requiredPermissions map[string]string) (API, error)
 }
 
+// A PasswordLoginChecker is implemented by a Dialer that is able to
+// verify that a username and password can be used to log in to a
+// controller.
+type PasswordLoginChecker interface {
+	// CheckPasswordLogin makes a new connection to the given controller
+	// and attempts to log in with the given username and password.
+	CheckPasswordLogin(ctx context.Context, ctl *dbmodel.Controller, username, password string) error
+}
+
+// A ControllerConnectionInvalidator is implemented by a Dialer that
+// holds on to controller connections and can be asked to discard them.
+type ControllerConnectionInvalidator interface {
+	// InvalidateControllerConnections discards any connections held
+	// for the named controller, subsequent dials will create new
+	// connections.
+	InvalidateControllerConnections(controllerName string)
+}
+
+// A ConnectionEvictor is implemented by a Dialer that holds on to
+// controller connections that should be closed once they have been idle,
+// or open, for too long.
+type ConnectionEvictor interface {
+	// EvictConnections periodically closes the held connections that
+	// have expired. EvictConnections finishes when the given context
+	// is canceled.
+	EvictConnections(ctx context.Context) error
+}
+
 // An API is the interface JIMM uses to access the API on a controller.
 type API interface {
 	// API implements the base.APICallCloser so that we can
@@ -289,9 +326,17 @@ type API interface {
 	// filter.
 	ListApplicationOffers(context.Context, []jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
 
+	// ModelGet returns the config of the model the connection is
+	// logged in to.
+	ModelGet(context.Context) (map[string]jujuparams.ConfigValue, error)
+
 	// ModelInfo fetches a model's ModelInfo.
 	ModelInfo(context.Context, *jujuparams.ModelInfo) error
 
+	// ModelSet sets values in the config of the model the connection is
+	// logged in to.
+	ModelSet(context.Context, map[string]interface{}) error
+
 	// ModelStatus fetches a model's ModelStatus.
 	ModelStatus(context.Context, *jujuparams.ModelStatus) error
 
@@ -331,6 +376,9 @@ type API interface {
 	// RevokeModelAccess revokes model access from a user.
 	RevokeModelAccess(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error
 
+	// SetPassword sets the password of a local user on the controller.
+	SetPassword(context.Context, names.UserTag, string) error
+
 	// SupportsCheckCredentialModels returns true if the
 	// CheckCredentialModels method can be used.
 	SupportsCheckCredentialModels() bool
@@ -411,7 +459,8 @@ var sensitiveMethods = map[string]struct{}{
 	"getdevicesessiontoken": {},
 	"loginwithsessiontoken": {},
 	"addcredentials":        {},
-	"updatecredentials":     {}}
+	"updatecredentials":     {},
+	"rotatecloudcredential": {}}
 var redactJSON = dbmodel.JSON(`{"params":"redacted"}`)
 
 func redactSensitiveParams(ale *dbmodel.AuditLogEntry) {
@@ -0,0 +1,493 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// MigrationPlanParams holds the parameters for a new migration plan.
+type MigrationPlanParams struct {
+	// Name is the unique name of the plan.
+	Name string
+
+	// TargetController is the name of the controller the models are
+	// migrated to.
+	TargetController string
+
+	// StartAt is the earliest time at which migrations are started. If
+	// this is zero migrations start immediately.
+	StartAt time.Time
+
+	// MaxConcurrency is the maximum number of models migrated at the
+	// same time. If this is zero models are migrated one at a time.
+	MaxConcurrency int
+
+	// MaxFailures is the number of consecutive failed migrations after
+	// which the plan is paused. If this is zero the plan is never paused
+	// automatically.
+	MaxFailures int
+
+	// ModelUUIDs holds the UUIDs of the models to migrate in the order
+	// they are migrated.
+	ModelUUIDs []string
+}
+
+// AddMigrationPlan adds a migration plan that migrates the given models
+// to the target controller once the start time has been reached. The
+// migrations are performed by a MigrationScheduler on behalf of the user
+// creating the plan. Only JIMM administrators can add migration plans.
+func (j *JIMM) AddMigrationPlan(ctx context.Context, user *openfga.User, params MigrationPlanParams) (*dbmodel.MigrationPlan, error) {
+	const op = errors.Op("jimm.AddMigrationPlan")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if params.Name == "" {
+		return nil, errors.E(op, errors.CodeBadRequest, "migration plan name not specified")
+	}
+	if len(params.ModelUUIDs) == 0 {
+		return nil, errors.E(op, errors.CodeBadRequest, "no models specified")
+	}
+	if params.MaxConcurrency < 0 {
+		return nil, errors.E(op, errors.CodeBadRequest, "invalid maximum concurrency")
+	}
+	if params.MaxFailures < 0 {
+		return nil, errors.E(op, errors.CodeBadRequest, "invalid maximum failures")
+	}
+
+	target := dbmodel.Controller{Name: params.TargetController}
+	if err := j.Database.GetController(ctx, &target); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(op, errors.CodeNotFound, fmt.Sprintf("controller %q not found", params.TargetController))
+		}
+		return nil, errors.E(op, err)
+	}
+
+	plan := dbmodel.MigrationPlan{
+		Name:                 params.Name,
+		TargetControllerName: target.Name,
+		StartAt:              params.StartAt,
+		MaxConcurrency:       params.MaxConcurrency,
+		MaxFailures:          params.MaxFailures,
+		Status:               dbmodel.MigrationPlanPending,
+		CreatedBy:            user.Name,
+	}
+	if plan.StartAt.IsZero() {
+		plan.StartAt = j.Database.DB.Config.NowFunc()
+	}
+	if plan.MaxConcurrency == 0 {
+		plan.MaxConcurrency = 1
+	}
+	seen := make(map[string]bool, len(params.ModelUUIDs))
+	for i, uuid := range params.ModelUUIDs {
+		if seen[uuid] {
+			return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("model %q specified more than once", uuid))
+		}
+		seen[uuid] = true
+		if !names.IsValidModel(uuid) {
+			return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid model uuid %q", uuid))
+		}
+		m := dbmodel.Model{UUID: sql.NullString{String: uuid, Valid: true}}
+		if err := j.Database.GetModel(ctx, &m); err != nil {
+			if errors.ErrorCode(err) == errors.CodeNotFound {
+				return nil, errors.E(op, errors.CodeModelNotFound, fmt.Sprintf("model %q not found", uuid))
+			}
+			return nil, errors.E(op, err)
+		}
+		if m.ControllerID == target.ID {
+			return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("model %q is already hosted on controller %q", uuid, target.Name))
+		}
+		plan.Models = append(plan.Models, dbmodel.MigrationPlanModel{
+			Position:  i,
+			ModelUUID: uuid,
+			Status:    dbmodel.MigrationPending,
+		})
+	}
+
+	if err := j.Database.AddMigrationPlan(ctx, &plan); err != nil {
+		if errors.ErrorCode(err) == errors.CodeAlreadyExists {
+			return nil, errors.E(op, err, fmt.Sprintf("migration plan %q already exists", plan.Name))
+		}
+		return nil, errors.E(op, err)
+	}
+	return &plan, nil
+}
+
+// GetMigrationPlan returns the named migration plan. Only JIMM
+// administrators can view migration plans.
+func (j *JIMM) GetMigrationPlan(ctx context.Context, user *openfga.User, name string) (*dbmodel.MigrationPlan, error) {
+	const op = errors.Op("jimm.GetMigrationPlan")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	plan := dbmodel.MigrationPlan{Name: name}
+	if err := j.Database.GetMigrationPlan(ctx, &plan); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &plan, nil
+}
+
+// ListMigrationPlans returns all the migration plans ordered by start
+// time. Only JIMM administrators can list migration plans.
+func (j *JIMM) ListMigrationPlans(ctx context.Context, user *openfga.User) ([]dbmodel.MigrationPlan, error) {
+	const op = errors.Op("jimm.ListMigrationPlans")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	plans, err := j.Database.ListMigrationPlans(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return plans, nil
+}
+
+// PauseMigrationPlan pauses the named migration plan. Migrations that
+// are already in progress are allowed to finish, but no further
+// migrations are started until the plan is resumed. Only JIMM
+// administrators can pause migration plans.
+func (j *JIMM) PauseMigrationPlan(ctx context.Context, user *openfga.User, name string) error {
+	const op = errors.Op("jimm.PauseMigrationPlan")
+
+	err := j.updateMigrationPlan(ctx, user, name, func(p *dbmodel.MigrationPlan) error {
+		if p.Status != dbmodel.MigrationPlanPending && p.Status != dbmodel.MigrationPlanRunning {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("migration plan %q is %s", p.Name, p.Status))
+		}
+		p.Status = dbmodel.MigrationPlanPaused
+		p.StatusMessage = fmt.Sprintf("paused by %s", user.Name)
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ResumeMigrationPlan resumes the named paused migration plan. The count
+// of consecutive failures is reset. Only JIMM administrators can resume
+// migration plans.
+func (j *JIMM) ResumeMigrationPlan(ctx context.Context, user *openfga.User, name string) error {
+	const op = errors.Op("jimm.ResumeMigrationPlan")
+
+	err := j.updateMigrationPlan(ctx, user, name, func(p *dbmodel.MigrationPlan) error {
+		if p.Status != dbmodel.MigrationPlanPaused {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("migration plan %q is %s", p.Name, p.Status))
+		}
+		// The scheduler moves the plan back to running once the start
+		// time has been reached.
+		p.Status = dbmodel.MigrationPlanPending
+		p.StatusMessage = ""
+		p.ConsecutiveFailures = 0
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// CancelMigrationPlan cancels the named migration plan. Migrations that
+// are already in progress are allowed to finish, but the remaining
+// models are not migrated. Only JIMM administrators can cancel migration
+// plans.
+func (j *JIMM) CancelMigrationPlan(ctx context.Context, user *openfga.User, name string) error {
+	const op = errors.Op("jimm.CancelMigrationPlan")
+
+	err := j.updateMigrationPlan(ctx, user, name, func(p *dbmodel.MigrationPlan) error {
+		if p.Status == dbmodel.MigrationPlanCompleted || p.Status == dbmodel.MigrationPlanCancelled {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("migration plan %q is %s", p.Name, p.Status))
+		}
+		p.Status = dbmodel.MigrationPlanCancelled
+		p.StatusMessage = fmt.Sprintf("cancelled by %s", user.Name)
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// updateMigrationPlan applies the given update to the named migration
+// plan and stores the result.
+func (j *JIMM) updateMigrationPlan(ctx context.Context, user *openfga.User, name string, update func(*dbmodel.MigrationPlan) error) error {
+	if !user.JimmAdmin {
+		return errors.E(errors.CodeUnauthorized, "unauthorized")
+	}
+	plan := dbmodel.MigrationPlan{Name: name}
+	if err := j.Database.GetMigrationPlan(ctx, &plan); err != nil {
+		return err
+	}
+	if err := update(&plan); err != nil {
+		return err
+	}
+	return j.Database.UpdateMigrationPlan(ctx, &plan)
+}
+
+// A MigrationScheduler performs the migrations in migration plans. Each
+// run starts plans whose start time has passed, checks whether models
+// being migrated have arrived on the target controller and starts the
+// next models in each running plan, up to the plan's concurrency limit.
+// Plans are paused when too many consecutive migrations fail.
+type MigrationScheduler struct {
+	// JIMM is the JIMM instance used to migrate models.
+	JIMM *JIMM
+
+	// MigrationTimeout is how long a migration may be in progress
+	// before it is considered to have failed. Migrations that the
+	// source controller reports as aborted fail straight away. If this
+	// is zero migrations never time out.
+	MigrationTimeout time.Duration
+}
+
+// Run processes the migration plans at the given interval until the
+// given context is canceled.
+func (s *MigrationScheduler) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.run(ctx, time.Now()); err != nil {
+			zapctx.Error(ctx, "failed to run migration plans", zaputil.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// run processes all the migration plans that are not yet finished.
+func (s *MigrationScheduler) run(ctx context.Context, now time.Time) error {
+	const op = errors.Op("jimm.MigrationScheduler.run")
+
+	// Paused and cancelled plans may still have migrations in progress
+	// that need to be tracked.
+	plans, err := s.JIMM.Database.ListMigrationPlans(ctx,
+		dbmodel.MigrationPlanPending,
+		dbmodel.MigrationPlanRunning,
+		dbmodel.MigrationPlanPaused,
+		dbmodel.MigrationPlanCancelled,
+	)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	for i := range plans {
+		p := &plans[i]
+		ctx := zapctx.WithFields(ctx, zap.String("migration-plan", p.Name))
+		if err := s.runPlan(ctx, p, now); err != nil {
+			zapctx.Error(ctx, "failed to run migration plan", zaputil.Error(err))
+		}
+	}
+	return nil
+}
+
+// runPlan performs a single step of the given migration plan.
+func (s *MigrationScheduler) runPlan(ctx context.Context, p *dbmodel.MigrationPlan, now time.Time) error {
+	inProgress := countMigrationPlanModels(p, dbmodel.MigrationInProgress)
+	switch p.Status {
+	case dbmodel.MigrationPlanPending:
+		if p.StartAt.After(now) {
+			return nil
+		}
+	case dbmodel.MigrationPlanPaused, dbmodel.MigrationPlanCancelled:
+		if inProgress == 0 {
+			return nil
+		}
+	}
+
+	user, err := s.JIMM.getUser(ctx, p.CreatedBy)
+	if err != nil {
+		return err
+	}
+
+	status, message, failures := p.Status, p.StatusMessage, p.ConsecutiveFailures
+	if p.Status == dbmodel.MigrationPlanPending {
+		zapctx.Info(ctx, "starting migration plan")
+		p.Status = dbmodel.MigrationPlanRunning
+	}
+
+	for i := range p.Models {
+		m := &p.Models[i]
+		if m.Status != dbmodel.MigrationInProgress {
+			continue
+		}
+		if err := s.checkMigration(ctx, user, p, m, now); err != nil {
+			return err
+		}
+	}
+
+	inProgress = countMigrationPlanModels(p, dbmodel.MigrationInProgress)
+	for i := range p.Models {
+		if p.Status != dbmodel.MigrationPlanRunning || inProgress >= p.MaxConcurrency {
+			break
+		}
+		m := &p.Models[i]
+		if m.Status != dbmodel.MigrationPending {
+			continue
+		}
+		if err := s.startMigration(ctx, user, p, m, now); err != nil {
+			return err
+		}
+		if m.Status == dbmodel.MigrationInProgress {
+			inProgress++
+		}
+	}
+
+	if p.Status == dbmodel.MigrationPlanRunning && inProgress == 0 && countMigrationPlanModels(p, dbmodel.MigrationPending) == 0 {
+		zapctx.Info(ctx, "migration plan completed")
+		p.Status = dbmodel.MigrationPlanCompleted
+		p.StatusMessage = ""
+	}
+
+	if p.Status == status && p.StatusMessage == message && p.ConsecutiveFailures == failures {
+		return nil
+	}
+	updated, err := s.JIMM.Database.UpdateMigrationPlanStatus(ctx, p, status)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		zapctx.Info(ctx, "migration plan status changed while the plan was being processed")
+	}
+	return nil
+}
+
+// checkMigration checks whether the given model, which is being
+// migrated, has arrived on the plan's target controller. A model that is
+// still on its source controller is marked as failed as soon as the
+// source controller reports that the migration was aborted, rather than
+// once the migration times out.
+func (s *MigrationScheduler) checkMigration(ctx context.Context, user *openfga.User, p *dbmodel.MigrationPlan, m *dbmodel.MigrationPlanModel, now time.Time) error {
+	ctx = zapctx.WithFields(ctx, zap.String("model", m.ModelUUID))
+	mt := names.NewModelTag(m.ModelUUID)
+	err := updateMigratedModel(ctx, s.JIMM, user, mt, p.TargetControllerName)
+	if err == nil {
+		zapctx.Info(ctx, "model migrated")
+		m.Status = dbmodel.MigrationDone
+		m.Error = ""
+		m.CompletedAt = sql.NullTime{Time: now, Valid: true}
+		p.ConsecutiveFailures = 0
+		return s.JIMM.Database.UpdateMigrationPlanModel(ctx, m)
+	}
+
+	status, serr := sourceMigrationStatus(ctx, s.JIMM, mt)
+	switch {
+	case serr != nil:
+		zapctx.Debug(ctx, "cannot get migration status from source controller", zaputil.Error(serr))
+	case migrationAborted(status, m.StartedAt.Time):
+		zapctx.Warn(ctx, "model migration aborted", zap.String("status", status.Status))
+		s.migrationFailed(p, m, fmt.Sprintf("migration aborted: %s", status.Status), now)
+		return s.JIMM.Database.UpdateMigrationPlanModel(ctx, m)
+	}
+	if s.MigrationTimeout > 0 && now.Sub(m.StartedAt.Time) > s.MigrationTimeout {
+		zapctx.Warn(ctx, "model migration timed out", zaputil.Error(err))
+		s.migrationFailed(p, m, fmt.Sprintf("migration did not complete within %s: %s", s.MigrationTimeout, err), now)
+		return s.JIMM.Database.UpdateMigrationPlanModel(ctx, m)
+	}
+	// The migration is still in progress.
+	return nil
+}
+
+// sourceMigrationStatus returns the status of the latest migration of the
+// given model as reported by the controller JIMM records as hosting it,
+// which is the source controller until the migrated model is recorded on
+// its target. The returned status is nil if the model has never been
+// migrated.
+func (j *JIMM) sourceMigrationStatus(ctx context.Context, mt names.ModelTag) (*jujuparams.ModelMigrationStatus, error) {
+	const op = errors.Op("jimm.sourceMigrationStatus")
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return nil, errors.E(op, err)
+	}
+	api, err := j.dial(ctx, &m.Controller, names.ModelTag{})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	defer api.Close()
+
+	mi := jujuparams.ModelInfo{UUID: mt.Id()}
+	if err := api.ModelInfo(ctx, &mi); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return mi.Migration, nil
+}
+
+// migrationClockSkew is the allowed difference between the clocks of
+// JIMM and a controller when matching the migration reported by the
+// controller with the migration started by JIMM.
+const migrationClockSkew = 5 * time.Minute
+
+// migrationAborted reports whether the given migration status, as
+// reported by the source controller, is of a migration started at about
+// the given time that has been aborted. Juju reports the status of an
+// aborted migration with a message starting with "aborted".
+func migrationAborted(status *jujuparams.ModelMigrationStatus, started time.Time) bool {
+	if status == nil || status.Start == nil || status.Start.Before(started.Add(-migrationClockSkew)) {
+		return false
+	}
+	return strings.HasPrefix(status.Status, "aborted")
+}
+
+// startMigration initiates the migration of the given model to the
+// plan's target controller.
+func (s *MigrationScheduler) startMigration(ctx context.Context, user *openfga.User, p *dbmodel.MigrationPlan, m *dbmodel.MigrationPlanModel, now time.Time) error {
+	ctx = zapctx.WithFields(ctx, zap.String("model", m.ModelUUID))
+	result, err := initiateInternalMigration(ctx, s.JIMM, user, names.NewModelTag(m.ModelUUID), p.TargetControllerName)
+	if err == nil && result.Error != nil {
+		err = result.Error
+	}
+	if err != nil {
+		zapctx.Warn(ctx, "failed to initiate model migration", zaputil.Error(err))
+		s.migrationFailed(p, m, err.Error(), now)
+	} else {
+		zapctx.Info(ctx, "initiated model migration", zap.String("migration-id", result.MigrationId))
+		m.Status = dbmodel.MigrationInProgress
+		m.Error = ""
+		m.StartedAt = sql.NullTime{Time: now, Valid: true}
+	}
+	return s.JIMM.Database.UpdateMigrationPlanModel(ctx, m)
+}
+
+// migrationFailed records the failed migration of the given model and
+// pauses the plan if it has reached its maximum number of consecutive
+// failures.
+func (s *MigrationScheduler) migrationFailed(p *dbmodel.MigrationPlan, m *dbmodel.MigrationPlanModel, msg string, now time.Time) {
+	m.Status = dbmodel.MigrationFailed
+	m.Error = msg
+	m.CompletedAt = sql.NullTime{Time: now, Valid: true}
+	p.ConsecutiveFailures++
+	if p.Status == dbmodel.MigrationPlanRunning && p.MaxFailures > 0 && p.ConsecutiveFailures >= p.MaxFailures {
+		p.Status = dbmodel.MigrationPlanPaused
+		p.StatusMessage = fmt.Sprintf("paused after %d consecutive migration failures", p.ConsecutiveFailures)
+	}
+}
+
+// countMigrationPlanModels returns the number of models in the given plan
+// with the given status.
+func countMigrationPlanModels(p *dbmodel.MigrationPlan, status string) int {
+	var n int
+	for _, m := range p.Models {
+		if m.Status == status {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,409 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const testMigrationPlanEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+cloud-credentials:
+- name: test-credential-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-region-1
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-4
+  uuid: 00000002-0000-0000-0000-000000000004
+  controller: controller-2
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+`
+
+func TestAddMigrationPlan(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testMigrationPlanEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+
+	params := jimm.MigrationPlanParams{
+		Name:             "overnight",
+		TargetController: "controller-2",
+		ModelUUIDs:       []string{"00000002-0000-0000-0000-000000000001"},
+	}
+	_, err = j.AddMigrationPlan(ctx, bob, params)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	plan, err := j.AddMigrationPlan(ctx, alice, params)
+	c.Assert(err, qt.IsNil)
+	c.Check(plan.Status, qt.Equals, dbmodel.MigrationPlanPending)
+	c.Check(plan.MaxConcurrency, qt.Equals, 1)
+	c.Check(plan.CreatedBy, qt.Equals, "alice@canonical.com")
+	c.Check(plan.StartAt.IsZero(), qt.IsFalse)
+
+	_, err = j.AddMigrationPlan(ctx, alice, params)
+	c.Check(err, qt.ErrorMatches, `migration plan "overnight" already exists`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	params.Name = "other"
+	params.TargetController = "controller-3"
+	_, err = j.AddMigrationPlan(ctx, alice, params)
+	c.Check(err, qt.ErrorMatches, `controller "controller-3" not found`)
+
+	params.TargetController = "controller-2"
+	params.ModelUUIDs = []string{"00000002-0000-0000-0000-000000000004"}
+	_, err = j.AddMigrationPlan(ctx, alice, params)
+	c.Check(err, qt.ErrorMatches, `model "00000002-0000-0000-0000-000000000004" is already hosted on controller "controller-2"`)
+
+	params.ModelUUIDs = []string{"00000002-0000-0000-0000-000000000001", "00000002-0000-0000-0000-000000000001"}
+	_, err = j.AddMigrationPlan(ctx, alice, params)
+	c.Check(err, qt.ErrorMatches, `model "00000002-0000-0000-0000-000000000001" specified more than once`)
+
+	params.ModelUUIDs = []string{"00000002-0000-0000-0000-000000000009"}
+	_, err = j.AddMigrationPlan(ctx, alice, params)
+	c.Check(err, qt.ErrorMatches, `model "00000002-0000-0000-0000-000000000009" not found`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeModelNotFound)
+}
+
+func TestMigrationScheduler(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testMigrationPlanEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+
+	var initiated []string
+	migrated := make(map[string]bool)
+	c.Patch(jimm.InitiateInternalMigration, func(ctx context.Context, j *jimm.JIMM, user *openfga.User, mt names.ModelTag, target string) (jujuparams.InitiateMigrationResult, error) {
+		if user.Name != "alice@canonical.com" || target != "controller-2" {
+			return jujuparams.InitiateMigrationResult{}, errors.E("unexpected migration arguments")
+		}
+		initiated = append(initiated, mt.Id())
+		if mt.Id() == "00000002-0000-0000-0000-000000000003" {
+			return jujuparams.InitiateMigrationResult{
+				ModelTag: mt.String(),
+				Error:    &jujuparams.Error{Message: "prechecks failed"},
+			}, nil
+		}
+		return jujuparams.InitiateMigrationResult{ModelTag: mt.String(), MigrationId: mt.Id() + ":0"}, nil
+	})
+	c.Patch(jimm.UpdateMigratedModel, func(ctx context.Context, j *jimm.JIMM, user *openfga.User, mt names.ModelTag, target string) error {
+		if !migrated[mt.Id()] {
+			return errors.E(errors.CodeNotFound, "model not found")
+		}
+		return nil
+	})
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	_, err = j.AddMigrationPlan(ctx, alice, jimm.MigrationPlanParams{
+		Name:             "overnight",
+		TargetController: "controller-2",
+		StartAt:          now.Add(time.Hour),
+		MaxConcurrency:   2,
+		MaxFailures:      2,
+		ModelUUIDs: []string{
+			"00000002-0000-0000-0000-000000000001",
+			"00000002-0000-0000-0000-000000000002",
+			"00000002-0000-0000-0000-000000000003",
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	s := &jimm.MigrationScheduler{
+		JIMM:             j,
+		MigrationTimeout: time.Hour,
+	}
+	getPlan := func() *dbmodel.MigrationPlan {
+		plan, err := j.GetMigrationPlan(ctx, alice, "overnight")
+		c.Assert(err, qt.IsNil)
+		return plan
+	}
+
+	// Nothing happens before the start time.
+	err = jimm.RunMigrationPlans(s, ctx, now)
+	c.Assert(err, qt.IsNil)
+	c.Check(initiated, qt.HasLen, 0)
+	c.Check(getPlan().Status, qt.Equals, dbmodel.MigrationPlanPending)
+
+	// Once started only two models are migrated at the same time.
+	err = jimm.RunMigrationPlans(s, ctx, now.Add(time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(initiated, qt.DeepEquals, []string{
+		"00000002-0000-0000-0000-000000000001",
+		"00000002-0000-0000-0000-000000000002",
+	})
+	plan := getPlan()
+	c.Check(plan.Status, qt.Equals, dbmodel.MigrationPlanRunning)
+	c.Check(plan.Models[0].Status, qt.Equals, dbmodel.MigrationInProgress)
+	c.Check(plan.Models[1].Status, qt.Equals, dbmodel.MigrationInProgress)
+	c.Check(plan.Models[2].Status, qt.Equals, dbmodel.MigrationPending)
+
+	// When model-1 arrives on the target model-3 is started, which
+	// fails.
+	migrated["00000002-0000-0000-0000-000000000001"] = true
+	err = jimm.RunMigrationPlans(s, ctx, now.Add(90*time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Check(initiated, qt.HasLen, 3)
+	plan = getPlan()
+	c.Check(plan.Status, qt.Equals, dbmodel.MigrationPlanRunning)
+	c.Check(plan.ConsecutiveFailures, qt.Equals, 1)
+	c.Check(plan.Models[0].Status, qt.Equals, dbmodel.MigrationDone)
+	c.Check(plan.Models[2].Status, qt.Equals, dbmodel.MigrationFailed)
+	c.Check(plan.Models[2].Error, qt.Equals, "prechecks failed")
+
+	// model-2 times out, which pauses the plan.
+	err = jimm.RunMigrationPlans(s, ctx, now.Add(3*time.Hour))
+	c.Assert(err, qt.IsNil)
+	plan = getPlan()
+	c.Check(plan.Status, qt.Equals, dbmodel.MigrationPlanPaused)
+	c.Check(plan.StatusMessage, qt.Equals, "paused after 2 consecutive migration failures")
+	c.Check(plan.Models[1].Status, qt.Equals, dbmodel.MigrationFailed)
+	c.Check(plan.Models[1].Error, qt.Matches, `migration did not complete within 1h0m0s: .*`)
+
+	// Once resumed the plan completes as there are no models left to
+	// migrate.
+	err = j.ResumeMigrationPlan(ctx, alice, "overnight")
+	c.Assert(err, qt.IsNil)
+	c.Check(getPlan().ConsecutiveFailures, qt.Equals, 0)
+	err = jimm.RunMigrationPlans(s, ctx, now.Add(4*time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(getPlan().Status, qt.Equals, dbmodel.MigrationPlanCompleted)
+	c.Check(initiated, qt.HasLen, 3)
+
+	err = j.CancelMigrationPlan(ctx, alice, "overnight")
+	c.Check(err, qt.ErrorMatches, `migration plan "overnight" is completed`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+}
+
+func TestMigrationSchedulerAbortedMigration(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testMigrationPlanEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	c.Patch(jimm.InitiateInternalMigration, func(ctx context.Context, j *jimm.JIMM, user *openfga.User, mt names.ModelTag, target string) (jujuparams.InitiateMigrationResult, error) {
+		return jujuparams.InitiateMigrationResult{ModelTag: mt.String(), MigrationId: mt.Id() + ":1"}, nil
+	})
+	c.Patch(jimm.UpdateMigratedModel, func(ctx context.Context, j *jimm.JIMM, user *openfga.User, mt names.ModelTag, target string) error {
+		return errors.E(errors.CodeNotFound, "model not found")
+	})
+	// The source controller first reports an earlier migration that
+	// was aborted, then the migration started by the plan.
+	earlier := now.Add(-24 * time.Hour)
+	status := &jujuparams.ModelMigrationStatus{
+		Status: "aborted, removing model from target controller: earlier failure",
+		Start:  &earlier,
+		End:    &earlier,
+	}
+	c.Patch(jimm.SourceMigrationStatus, func(ctx context.Context, j *jimm.JIMM, mt names.ModelTag) (*jujuparams.ModelMigrationStatus, error) {
+		return status, nil
+	})
+
+	_, err = j.AddMigrationPlan(ctx, alice, jimm.MigrationPlanParams{
+		Name:             "overnight",
+		TargetController: "controller-2",
+		StartAt:          now,
+		MaxConcurrency:   1,
+		ModelUUIDs:       []string{"00000002-0000-0000-0000-000000000001"},
+	})
+	c.Assert(err, qt.IsNil)
+
+	s := &jimm.MigrationScheduler{
+		JIMM:             j,
+		MigrationTimeout: 6 * time.Hour,
+	}
+	getPlan := func() *dbmodel.MigrationPlan {
+		plan, err := j.GetMigrationPlan(ctx, alice, "overnight")
+		c.Assert(err, qt.IsNil)
+		return plan
+	}
+
+	err = jimm.RunMigrationPlans(s, ctx, now)
+	c.Assert(err, qt.IsNil)
+	err = jimm.RunMigrationPlans(s, ctx, now.Add(time.Minute))
+	c.Assert(err, qt.IsNil)
+	c.Check(getPlan().Models[0].Status, qt.Equals, dbmodel.MigrationInProgress)
+
+	// Once the migration is aborted the model fails without waiting
+	// for the migration timeout.
+	status = &jujuparams.ModelMigrationStatus{
+		Status: "aborted, removing model from target controller: machine sanity check failed, 1 error found",
+		Start:  &now,
+	}
+	err = jimm.RunMigrationPlans(s, ctx, now.Add(2*time.Minute))
+	c.Assert(err, qt.IsNil)
+	plan := getPlan()
+	c.Check(plan.Models[0].Status, qt.Equals, dbmodel.MigrationFailed)
+	c.Check(plan.Models[0].Error, qt.Equals, "migration aborted: aborted, removing model from target controller: machine sanity check failed, 1 error found")
+	c.Check(plan.Status, qt.Equals, dbmodel.MigrationPlanCompleted)
+}
+
+func TestMigrationSchedulerPlanCancelledDuringRun(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testMigrationPlanEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+
+	// The plan is cancelled while the scheduler is starting a
+	// migration.
+	c.Patch(jimm.InitiateInternalMigration, func(ctx context.Context, j *jimm.JIMM, user *openfga.User, mt names.ModelTag, target string) (jujuparams.InitiateMigrationResult, error) {
+		err := j.CancelMigrationPlan(ctx, alice, "overnight")
+		c.Check(err, qt.IsNil)
+		return jujuparams.InitiateMigrationResult{ModelTag: mt.String(), MigrationId: mt.Id() + ":0"}, nil
+	})
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	_, err = j.AddMigrationPlan(ctx, alice, jimm.MigrationPlanParams{
+		Name:             "overnight",
+		TargetController: "controller-2",
+		StartAt:          now,
+		MaxConcurrency:   1,
+		ModelUUIDs:       []string{"00000002-0000-0000-0000-000000000001"},
+	})
+	c.Assert(err, qt.IsNil)
+
+	s := &jimm.MigrationScheduler{
+		JIMM:             j,
+		MigrationTimeout: time.Hour,
+	}
+	err = jimm.RunMigrationPlans(s, ctx, now)
+	c.Assert(err, qt.IsNil)
+
+	plan, err := j.GetMigrationPlan(ctx, alice, "overnight")
+	c.Assert(err, qt.IsNil)
+	c.Check(plan.Status, qt.Equals, dbmodel.MigrationPlanCancelled)
+	c.Check(plan.Models[0].Status, qt.Equals, dbmodel.MigrationInProgress)
+}
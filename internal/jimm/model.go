@@ -4,8 +4,11 @@ package jimm
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"math/rand"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -22,6 +25,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
 )
 
 // shuffle is used to randomize the order in which possible controllers
@@ -109,6 +113,18 @@ type modelBuilder struct {
 	cloudRegionID uint
 	model         *dbmodel.Model
 	modelInfo     *jujuparams.ModelInfo
+	expiresAt     sql.NullTime
+	labels        dbmodel.StringMap
+	configSources dbmodel.StringMap
+
+	// canAddModelInCloud caches whether the owner may add models to
+	// every region of the cloud.
+	canAddModelInCloud *bool
+
+	// credentialNames, if set, restricts the automatically selected
+	// cloud credential to those with the given names, in order of
+	// preference.
+	credentialNames []string
 }
 
 // Error returns the error that occurred in the process
@@ -173,6 +189,73 @@ func (b *modelBuilder) WithConfig(cfg map[string]interface{}) *modelBuilder {
 	return b
 }
 
+// WithExpiry returns a builder with the model expiry time taken from the
+// reserved ModelTTLConfigKey in the model config. The reserved key is
+// removed from the config passed to the controller.
+func (b *modelBuilder) WithExpiry(now time.Time) *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	v, ok := b.config[ModelTTLConfigKey]
+	if !ok {
+		return b
+	}
+	delete(b.config, ModelTTLConfigKey)
+	s, ok := v.(string)
+	if !ok {
+		b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid %s value: expected a duration", ModelTTLConfigKey))
+		return b
+	}
+	ttl, err := time.ParseDuration(s)
+	if err != nil || ttl <= 0 {
+		b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid %s value %q: expected a positive duration", ModelTTLConfigKey, s))
+		return b
+	}
+	b.expiresAt = sql.NullTime{Time: now.Add(ttl), Valid: true}
+	return b
+}
+
+// WithConfigSource returns a builder that records the given source for
+// each of the given config keys, so that the values are not reported as
+// drift from the model defaults.
+func (b *modelBuilder) WithConfigSource(config map[string]interface{}, source string) *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	if len(config) > 0 && b.configSources == nil {
+		b.configSources = make(dbmodel.StringMap, len(config))
+	}
+	for k := range config {
+		b.configSources[k] = source
+	}
+	return b
+}
+
+// WithLabels returns a builder with the specified model labels.
+func (b *modelBuilder) WithLabels(labels map[string]string) *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	if len(labels) > 0 {
+		b.labels = make(dbmodel.StringMap, len(labels))
+		for k, v := range labels {
+			b.labels[k] = v
+		}
+	}
+	return b
+}
+
+// WithCloudCredentialNames returns a builder that will only select a
+// cloud credential with one of the given names, in order of preference,
+// if no cloud credential is specified.
+func (b *modelBuilder) WithCloudCredentialNames(credentialNames []string) *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+	b.credentialNames = credentialNames
+	return b
+}
+
 // WithCloud returns a builder with the specified cloud.
 func (b *modelBuilder) WithCloud(user *openfga.User, cloud names.CloudTag) *modelBuilder {
 	if b.err != nil {
@@ -226,7 +309,9 @@ func (b *modelBuilder) withImplicitCloud(user *openfga.User) *modelBuilder {
 	return b
 }
 
-// WithCloudRegion returns a builder with the specified cloud region.
+// WithCloudRegion returns a builder with the specified cloud region. The
+// model owner must be allowed to add models to the region, either through
+// access to the whole cloud or to the region itself.
 func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 	if b.err != nil {
 		return b
@@ -236,15 +321,29 @@ func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 		return b
 	}
 	// if the region is not specified, we pick the first cloud region
-	// with any associated controllers
+	// with any associated controllers that the owner may add models to
 	if region == "" {
+		var hasControllers bool
 		for _, r := range b.cloud.Regions {
 			regionControllers := r.Controllers
 			if len(regionControllers) == 0 {
 				continue
 			}
+			hasControllers = true
+			allowed, err := b.canAddModelInRegion(r.Name)
+			if err != nil {
+				b.err = err
+				return b
+			}
+			if !allowed {
+				continue
+			}
 			region = r.Name
 		}
+		if region == "" && hasControllers {
+			b.err = errors.E(errors.CodeUnauthorized, "unauthorized")
+			return b
+		}
 	}
 	// loop through all cloud regions
 	for _, r := range b.cloud.Regions {
@@ -258,6 +357,15 @@ func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 			b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("unsupported cloud region %s/%s", b.cloud.Name, region))
 			return b
 		}
+		allowed, err := b.canAddModelInRegion(region)
+		if err != nil {
+			b.err = err
+			return b
+		}
+		if !allowed {
+			b.err = errors.E(errors.CodeUnauthorized, "unauthorized")
+			return b
+		}
 		// shuffle controllers
 		shuffleRegionControllers(regionControllers)
 
@@ -275,6 +383,37 @@ func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 	return b
 }
 
+// canAddModelInRegion returns whether the model owner may add models to
+// the given region of the builder's cloud. Owners allowed to add models
+// to the cloud may add models to all of its regions.
+func (b *modelBuilder) canAddModelInRegion(region string) (bool, error) {
+	if b.owner == nil {
+		return false, errors.E("owner not specified")
+	}
+	user := openfga.NewUser(b.owner, b.jimm.OpenFGAClient)
+	if b.canAddModelInCloud == nil {
+		allowed, err := user.IsAllowedAddModel(b.ctx, b.cloud.ResourceTag())
+		if err != nil {
+			zapctx.Error(b.ctx, "failed to check cloud access", zap.Error(err))
+			return false, errors.E("permission check failed")
+		}
+		b.canAddModelInCloud = &allowed
+	}
+	if *b.canAddModelInCloud {
+		return true, nil
+	}
+	id := b.cloud.Name + "/" + region
+	if !jimmnames.IsValidCloudRegion(id) {
+		return false, nil
+	}
+	allowed, err := user.IsAllowedAddModelInRegion(b.ctx, jimmnames.NewCloudRegionTag(id))
+	if err != nil {
+		zapctx.Error(b.ctx, "failed to check cloud region access", zap.Error(err))
+		return false, errors.E("permission check failed")
+	}
+	return allowed, nil
+}
+
 // WithCloudCredential returns a builder with the specified cloud credentials.
 func (b *modelBuilder) WithCloudCredential(credentialTag names.CloudCredentialTag) *modelBuilder {
 	if b.err != nil {
@@ -338,12 +477,29 @@ func (b *modelBuilder) CreateDatabaseModel() *modelBuilder {
 		}
 	}
 
+	// An empty set of sources is still recorded, so that the model
+	// can be told apart from models added before sources were
+	// recorded.
+	if b.configSources == nil {
+		b.configSources = make(dbmodel.StringMap)
+	}
+	// Only record the sources of values that are passed to the
+	// controller, JIMM specific keys have been removed from the config.
+	for k := range b.configSources {
+		if _, ok := b.config[k]; !ok {
+			delete(b.configSources, k)
+		}
+	}
+
 	b.model = &dbmodel.Model{
 		Name:              b.name,
 		ControllerID:      b.controller.ID,
 		Owner:             *b.owner,
 		CloudCredentialID: b.credential.ID,
 		CloudRegionID:     b.cloudRegionID,
+		ExpiresAt:         b.expiresAt,
+		Labels:            b.labels,
+		ConfigSources:     b.configSources,
 	}
 
 	err := b.jimm.Database.AddModel(b.ctx, b.model)
@@ -412,14 +568,29 @@ func (b *modelBuilder) selectCloudRegion() error {
 	}
 
 	var regionControllers []dbmodel.CloudRegionControllerPriority
+	var hasControllers bool
 	for _, r := range b.cloud.Regions {
-		regionControllers = append(regionControllers, r.Controllers...)
+		if len(r.Controllers) == 0 {
+			continue
+		}
+		hasControllers = true
+		// only consider regions the owner may add models to
+		allowed, err := b.canAddModelInRegion(r.Name)
+		if err != nil {
+			return err
+		}
+		if allowed {
+			regionControllers = append(regionControllers, r.Controllers...)
+		}
 	}
 
 	// if no controllers are found, we return an error
-	if len(regionControllers) == 0 {
+	if !hasControllers {
 		return errors.E(fmt.Sprintf("unsupported cloud %s", b.cloud.Name))
 	}
+	if len(regionControllers) == 0 {
+		return errors.E(errors.CodeUnauthorized, "unauthorized")
+	}
 
 	// shuffle controllers according to their priority
 	shuffleRegionControllers(regionControllers)
@@ -441,6 +612,26 @@ func (b *modelBuilder) selectCloudCredentials() error {
 	if err != nil {
 		return errors.E(err, "failed to fetch user cloud credentials")
 	}
+	if b.jimm.OpenFGAClient != nil {
+		// A credential that has been given to a group is only
+		// available to the identity named in its tag while they can
+		// still use it through the group.
+		owner := openfga.NewUser(b.owner, b.jimm.OpenFGAClient)
+		credentials = slices.DeleteFunc(credentials, func(c dbmodel.CloudCredential) bool {
+			return c.OwnerGroupID.Valid && owner.GetCloudCredentialAccess(b.ctx, c.ResourceTag()) == ofganames.NoRelation
+		})
+	}
+	// Credentials owned by one of the owner's groups, or otherwise
+	// shared with them, are considered after the owner's own
+	// credentials.
+	shared, err := b.sharedCloudCredentials()
+	if err != nil {
+		return errors.E(err, "failed to fetch shared cloud credentials")
+	}
+	credentials = append(credentials, shared...)
+	if len(b.credentialNames) > 0 {
+		return b.selectNamedCloudCredentials(credentials)
+	}
 	for _, credential := range credentials {
 		// skip any credentials known to be invalid.
 		if credential.Valid.Valid && !credential.Valid.Bool {
@@ -452,6 +643,82 @@ func (b *modelBuilder) selectCloudCredentials() error {
 	return errors.E("valid cloud credentials not found")
 }
 
+// sharedCloudCredentials returns the credentials for the builder's cloud
+// that are not tagged with the model owner's name but that the model
+// owner has been given access to, such as the credentials owned by the
+// owner's groups, ordered by path.
+func (b *modelBuilder) sharedCloudCredentials() ([]dbmodel.CloudCredential, error) {
+	if b.jimm.OpenFGAClient == nil {
+		return nil, nil
+	}
+	owner := openfga.NewUser(b.owner, b.jimm.OpenFGAClient)
+	ids, err := owner.ListCloudCredentials(b.ctx, ofganames.UserRelation)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(ids)
+	var credentials []dbmodel.CloudCredential
+	for _, id := range ids {
+		if !names.IsValidCloudCredential(id) {
+			continue
+		}
+		tag := names.NewCloudCredentialTag(id)
+		if tag.Cloud().Id() != b.cloud.Name || tag.Owner().Id() == b.owner.Name {
+			continue
+		}
+		var credential dbmodel.CloudCredential
+		credential.SetTag(tag)
+		if err := b.jimm.Database.GetCloudCredential(b.ctx, &credential); err != nil {
+			if errors.ErrorCode(err) == errors.CodeNotFound {
+				continue
+			}
+			return nil, err
+		}
+		credentials = append(credentials, credential)
+	}
+	return credentials, nil
+}
+
+// selectNamedCloudCredentials selects the first valid credential from
+// the given credentials whose name is in the builder's credential names,
+// in order of preference.
+func (b *modelBuilder) selectNamedCloudCredentials(credentials []dbmodel.CloudCredential) error {
+	for _, name := range b.credentialNames {
+		for i := range credentials {
+			if credentials[i].Name != name {
+				continue
+			}
+			// skip any credentials known to be invalid.
+			if credentials[i].Valid.Valid && !credentials[i].Valid.Bool {
+				continue
+			}
+			b.credential = &credentials[i]
+			return nil
+		}
+	}
+	return errors.E(fmt.Sprintf("valid cloud credentials not found, expected one of %s", strings.Join(b.credentialNames, ", ")))
+}
+
+// CheckModelQuotas checks that the new model does not take the owner
+// beyond the number of models allowed by the model quotas that apply to
+// them. It must be called after CreateDatabaseModel so that the new
+// model is included in the owner's usage.
+func (b *modelBuilder) CheckModelQuotas() *modelBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	if b.model == nil {
+		b.err = errors.E("model not specified")
+		return b
+	}
+
+	if err := b.jimm.checkModelQuotas(b.ctx, b.owner, b.cloud.Name, b.model); err != nil {
+		b.err = errors.E(err)
+	}
+	return b
+}
+
 // CreateControllerModel uses provided information to create a new
 // model on the selected controller.
 func (b *modelBuilder) CreateControllerModel() *modelBuilder {
@@ -568,6 +835,11 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
+	args, template, err := j.applyModelTemplate(ctx, args)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
 	builder := newModelBuilder(ctx, j)
 	builder = builder.WithOwner(owner)
 	builder = builder.WithName(args.Name)
@@ -575,28 +847,6 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 		return nil, errors.E(op, err)
 	}
 
-	// fetch user model defaults
-	userConfig, err := j.IdentityModelDefaults(ctx, user.Identity)
-	if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
-		return nil, errors.E(op, "failed to fetch cloud defaults")
-	}
-	builder = builder.WithConfig(userConfig)
-
-	// fetch cloud defaults
-	if args.Cloud != (names.CloudTag{}) {
-		cloudDefaults := dbmodel.CloudDefaults{
-			IdentityName: user.Name,
-			Cloud: dbmodel.Cloud{
-				Name: args.Cloud.Id(),
-			},
-		}
-		err = j.Database.CloudDefaults(ctx, &cloudDefaults)
-		if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
-			return nil, errors.E(op, "failed to fetch cloud defaults")
-		}
-		builder = builder.WithConfig(cloudDefaults.Defaults)
-	}
-
 	builder = builder.WithCloud(user, args.Cloud)
 	if err := builder.Error(); err != nil {
 		return nil, errors.E(op, err)
@@ -607,41 +857,42 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 		return nil, errors.E(op, err)
 	}
 
-	// at this point we know which cloud will host the model and
-	// we must check the user has add-model permission on the cloud
-	canAddModel, err := openfga.NewUser(owner, j.OpenFGAClient).IsAllowedAddModel(ctx, builder.cloud.ResourceTag())
+	// the model defaults are those of the model owner, so that they
+	// are the same defaults the model is later checked against by
+	// ModelDrift, even when the model is added on the owner's behalf
+	defaults, err := j.ModelDefaults(ctx, owner.Name, builder.cloud.Name, builder.cloudRegion)
 	if err != nil {
-		return nil, errors.E(op, "permission check failed")
+		return nil, errors.E(op, "failed to fetch model defaults", err)
 	}
-	if !canAddModel {
-		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	defaultConfig := make(map[string]interface{}, len(defaults))
+	for k, d := range defaults {
+		defaultConfig[k] = d.Value
 	}
+	builder = builder.WithConfig(defaultConfig)
 
-	// fetch cloud region defaults
-	if args.Cloud != (names.CloudTag{}) && builder.cloudRegion != "" {
-		cloudRegionDefaults := dbmodel.CloudDefaults{
-			IdentityName: user.Name,
-			Cloud: dbmodel.Cloud{
-				Name: args.Cloud.Id(),
-			},
-			Region: builder.cloudRegion,
-		}
-		err = j.Database.CloudDefaults(ctx, &cloudRegionDefaults)
-		if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
-			return nil, errors.E(op, "failed to fetch cloud defaults")
-		}
-		builder = builder.WithConfig(cloudRegionDefaults.Defaults)
+	// template config overrides the defaults
+	if template != nil {
+		builder = builder.WithConfig(template.Config)
+		builder = builder.WithConfigSource(template.Config, TemplateConfigSource)
+		builder = builder.WithLabels(template.Labels)
 	}
 
 	// last but not least, use the provided config values
 	// overriding all defaults
 	builder = builder.WithConfig(args.Config)
+	builder = builder.WithConfigSource(args.Config, ExplicitConfigSource)
+	builder = builder.WithExpiry(time.Now())
+	if err := builder.Error(); err != nil {
+		return nil, errors.E(op, err)
+	}
 
 	if args.CloudCredential != (names.CloudCredentialTag{}) {
 		builder = builder.WithCloudCredential(args.CloudCredential)
 		if err := builder.Error(); err != nil {
 			return nil, errors.E(op, err)
 		}
+	} else if template != nil {
+		builder = builder.WithCloudCredentialNames(template.CloudCredentials)
 	}
 	builder = builder.CreateDatabaseModel()
 	if err := builder.Error(); err != nil {
@@ -649,6 +900,11 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 	}
 	defer builder.Cleanup()
 
+	builder = builder.CheckModelQuotas()
+	if err := builder.Error(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
 	builder = builder.CreateControllerModel()
 	if err := builder.Error(); err != nil {
 		return nil, errors.E(op, err)
@@ -682,6 +938,9 @@ func (j *JIMM) AddModel(ctx context.Context, user *openfga.User, args *ModelCrea
 			zap.String("model", builder.model.UUID.String),
 		)
 	}
+	if template != nil {
+		j.grantModelTemplateAccess(ctx, template, names.NewModelTag(mi.UUID))
+	}
 
 	return mi, nil
 }
@@ -1025,6 +1284,123 @@ func (j *JIMM) RevokeModelAccess(ctx context.Context, user *openfga.User, mt nam
 	return nil
 }
 
+// TransferModelOwnership transfers ownership of the given model to the
+// given user. Only the current owner of the model or a JIMM administrator
+// can transfer ownership. The new owner is granted administrator access
+// to the model. If keepAccess is false all of the previous owner's access
+// to the model is revoked, otherwise the previous owner retains their
+// existing access. If the new owner already owns a model with the same
+// name an error with a code of CodeAlreadyExists is returned. If the
+// access changes cannot be written to OpenFGA the model is returned to
+// its previous owner, so a failed transfer may be retried.
+func (j *JIMM) TransferModelOwnership(ctx context.Context, user *openfga.User, mt names.ModelTag, newOwner names.UserTag, keepAccess bool) error {
+	const op = errors.Op("jimm.TransferModelOwnership")
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return errors.E(op, err)
+	}
+	if !user.JimmAdmin && user.Name != m.OwnerIdentityName {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if newOwner.Id() == m.OwnerIdentityName {
+		return errors.E(op, errors.CodeBadRequest, "model is already owned by "+newOwner.Id())
+	}
+
+	owner := dbmodel.Identity{}
+	owner.SetTag(newOwner)
+	if err := j.Database.FetchIdentity(ctx, &owner); err != nil {
+		return errors.E(op, err)
+	}
+
+	existing := dbmodel.Model{
+		Name:              m.Name,
+		OwnerIdentityName: owner.Name,
+	}
+	err := j.Database.GetModel(ctx, &existing)
+	if err == nil {
+		return errors.E(op, errors.CodeAlreadyExists, fmt.Sprintf("%s already owns a model called %q", owner.Name, m.Name))
+	}
+	if errors.ErrorCode(err) != errors.CodeNotFound {
+		return errors.E(op, err)
+	}
+
+	// Both OpenFGA writes are idempotent and are made after the owner
+	// is changed in the database. If either fails the database change
+	// is undone, along with the new owner's administrator relation if
+	// it was added, so that the transfer can simply be retried.
+	newOwnerUser := openfga.NewUser(&owner, j.OpenFGAClient)
+	adminTuple := openfga.Tuple{
+		Object:   ofganames.ConvertTag(newOwner),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(mt),
+	}
+	existingAdmin, _, err := j.OpenFGAClient.ReadRelatedObjects(ctx, adminTuple, 1, "")
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	previousOwner := m.Owner
+	m.SwitchOwner(&owner)
+	if err := j.Database.UpdateModel(ctx, &m); err != nil {
+		if errors.ErrorCode(err) == errors.CodeAlreadyExists {
+			return errors.E(op, err, fmt.Sprintf("%s already owns a model called %q", owner.Name, m.Name))
+		}
+		return errors.E(op, err)
+	}
+	undo := func() {
+		if len(existingAdmin) == 0 {
+			if err := newOwnerUser.UnsetModelAccess(ctx, mt, ofganames.AdministratorRelation); err != nil {
+				zapctx.Error(ctx, "failed to revoke model access from the new owner", zap.String("model", mt.Id()), zaputil.Error(err))
+			}
+		}
+		m.SwitchOwner(&previousOwner)
+		if err := j.Database.UpdateModel(ctx, &m); err != nil {
+			zapctx.Error(ctx, "failed to restore model owner", zap.String("model", mt.Id()), zaputil.Error(err))
+		}
+	}
+
+	if err := newOwnerUser.SetModelAccess(ctx, mt, ofganames.AdministratorRelation); err != nil {
+		undo()
+		return errors.E(op, err, "failed to grant model access to the new owner")
+	}
+	if !keepAccess {
+		previousOfgaUser := openfga.NewUser(&previousOwner, j.OpenFGAClient)
+		err := previousOfgaUser.UnsetModelAccess(ctx, mt, ofganames.ReaderRelation, ofganames.WriterRelation, ofganames.AdministratorRelation)
+		if err != nil {
+			undo()
+			return errors.E(op, err, "failed to revoke model access from the previous owner")
+		}
+	}
+
+	j.addModelOwnershipAuditLogEntry(user, &m, previousOwner.Name, keepAccess)
+	return nil
+}
+
+// addModelOwnershipAuditLogEntry records a model ownership transfer in
+// the audit log.
+func (j *JIMM) addModelOwnershipAuditLogEntry(user *openfga.User, m *dbmodel.Model, previousOwner string, keepAccess bool) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":          m.Name,
+		"previous-owner": previousOwner,
+		"new-owner":      m.OwnerIdentityName,
+		"keep-access":    keepAccess,
+	})
+	if err != nil {
+		zapctx.Error(context.Background(), "failed to marshal model ownership audit parameters", zaputil.Error(err))
+		return
+	}
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		Model:        m.UUID.String,
+		FacadeName:   "JIMM",
+		FacadeMethod: "TransferModelOwnership",
+		IdentityTag:  user.Tag().String(),
+		Params:       body,
+	})
+}
+
 // DestroyModel starts the process of destroying the given model. If the
 // given user is not a controller superuser or a model admin an error
 // with a code of CodeUnauthorized is returned. Any error returned from
@@ -1184,7 +1560,7 @@ var allowedModelAccess = map[string]map[string]bool{
 func (j *JIMM) ChangeModelCredential(ctx context.Context, user *openfga.User, modelTag names.ModelTag, cloudCredentialTag names.CloudCredentialTag) error {
 	const op = errors.Op("jimm.ChangeModelCredential")
 
-	if !user.JimmAdmin && user.Tag() != cloudCredentialTag.Owner() {
+	if !user.JimmAdmin && user.Tag() != cloudCredentialTag.Owner() && user.GetCloudCredentialAccess(ctx, cloudCredentialTag) == ofganames.NoRelation {
 		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
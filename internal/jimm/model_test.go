@@ -934,6 +934,9 @@ controllers:
 		return nil
 	},
 	createModel: assertConfig(map[string]interface{}{
+		"key1": "value1",
+		"key2": "value2",
+		"key3": "value3",
 		"key4": "value4",
 	}, createModel(`
 uuid: 00000001-0000-0000-0000-0000-000000000001
@@ -1147,6 +1150,112 @@ func TestAddModel(t *testing.T) {
 	}
 }
 
+func TestAddModelWithSharedCloudCredential(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var credentialTag string
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				GrantJIMMModelAdmin_: func(context.Context, names.ModelTag) error {
+					return nil
+				},
+				CreateModel_: func(ctx context.Context, args *jujuparams.ModelCreateArgs, mi *jujuparams.ModelInfo) error {
+					credentialTag = args.CloudCredentialTag
+					return createModel(`
+uuid: 00000001-0000-0000-0000-0000-000000000001
+status:
+  status: started
+life: alive
+`[1:])(ctx, args, mi)
+				},
+			},
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, `
+clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+  users:
+  - user: bob@canonical.com
+    access: add-model
+cloud-credentials:
+- name: shared-credential
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000000-0000-0000-0000-0000-0000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 0
+`[1:])
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+
+	args := jimm.ModelCreateArgs{
+		Name:  "test-model",
+		Owner: names.NewUserTag("bob@canonical.com"),
+		Cloud: names.NewCloudTag("test-cloud"),
+	}
+	_, err = j.AddModel(ctx, bob, &args)
+	c.Check(err, qt.ErrorMatches, `could not select cloud credentials: valid cloud credentials not found`)
+
+	// Once bob's group is given access to alice's credential it is
+	// selected for bob's model.
+	group, err := j.Database.AddGroup(ctx, "team")
+	c.Assert(err, qt.IsNil)
+	credTag := names.NewCloudCredentialTag("test-cloud/alice@canonical.com/shared-credential")
+	err = client.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(bob.ResourceTag()),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group.ResourceTag()),
+	}, openfga.Tuple{
+		Object:   ofganames.ConvertTagWithRelation(group.ResourceTag(), ofganames.MemberRelation),
+		Relation: ofganames.UserRelation,
+		Target:   ofganames.ConvertTag(credTag),
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = j.AddModel(ctx, bob, &args)
+	c.Assert(err, qt.IsNil)
+	c.Check(credentialTag, qt.Equals, credTag.String())
+
+	m := dbmodel.Model{
+		UUID: sql.NullString{
+			String: "00000001-0000-0000-0000-0000-000000000001",
+			Valid:  true,
+		},
+	}
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.CloudCredential.OwnerIdentityName, qt.Equals, "alice@canonical.com")
+	c.Check(m.CloudCredential.Name, qt.Equals, "shared-credential")
+}
+
 func createModel(template string) func(context.Context, *jujuparams.ModelCreateArgs, *jujuparams.ModelInfo) error {
 	var tmi jujuparams.ModelInfo
 	err := yaml.Unmarshal([]byte(template), &tmi)
@@ -3861,3 +3970,183 @@ func newVersion(s string) *version.Number {
 	n := version.MustParse(s)
 	return &n
 }
+
+const transferModelOwnershipTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+- username: charlie@canonical.com
+  controller-access: login
+cloud-credentials:
+- name: test-credential-1
+  owner: bob@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+- name: test-credential-2
+  owner: charlie@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000000-0000-0000-0000-0000-0000000000001
+  cloud: test-cloud
+  region: test-region-1
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: bob@canonical.com
+  life: alive
+  users:
+  - user: bob@canonical.com
+    access: admin
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-2
+  owner: charlie@canonical.com
+  life: alive
+  users:
+  - user: charlie@canonical.com
+    access: admin
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-2
+  owner: charlie@canonical.com
+  life: alive
+  users:
+  - user: charlie@canonical.com
+    access: admin
+`
+
+var transferModelOwnershipTests = []struct {
+	name                 string
+	username             string
+	uuid                 string
+	newOwner             string
+	keepAccess           bool
+	expectPreviousOwner  string
+	expectPreviousAccess openfga.Relation
+	expectError          string
+	expectErrorCode      errors.Code
+}{{
+	name:                 "OwnerTransfersModel",
+	username:             "bob@canonical.com",
+	uuid:                 "00000002-0000-0000-0000-000000000001",
+	newOwner:             "alice@canonical.com",
+	expectPreviousOwner:  "bob@canonical.com",
+	expectPreviousAccess: ofganames.NoRelation,
+}, {
+	name:                 "AdminTransfersModelKeepingAccess",
+	username:             "alice@canonical.com",
+	uuid:                 "00000002-0000-0000-0000-000000000002",
+	newOwner:             "bob@canonical.com",
+	keepAccess:           true,
+	expectPreviousOwner:  "charlie@canonical.com",
+	expectPreviousAccess: ofganames.AdministratorRelation,
+}, {
+	name:            "UnauthorizedUser",
+	username:        "bob@canonical.com",
+	uuid:            "00000002-0000-0000-0000-000000000002",
+	newOwner:        "bob@canonical.com",
+	expectError:     `unauthorized`,
+	expectErrorCode: errors.CodeUnauthorized,
+}, {
+	name:            "ModelNameClash",
+	username:        "charlie@canonical.com",
+	uuid:            "00000002-0000-0000-0000-000000000003",
+	newOwner:        "bob@canonical.com",
+	expectError:     `bob@canonical.com already owns a model called "model-1"`,
+	expectErrorCode: errors.CodeAlreadyExists,
+}, {
+	name:            "UnknownNewOwner",
+	username:        "bob@canonical.com",
+	uuid:            "00000002-0000-0000-0000-000000000001",
+	newOwner:        "dave@canonical.com",
+	expectErrorCode: errors.CodeNotFound,
+	expectError:     `.*not found.*`,
+}, {
+	name:            "SameOwner",
+	username:        "bob@canonical.com",
+	uuid:            "00000002-0000-0000-0000-000000000001",
+	newOwner:        "bob@canonical.com",
+	expectError:     `model is already owned by bob@canonical.com`,
+	expectErrorCode: errors.CodeBadRequest,
+}}
+
+func TestTransferModelOwnership(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range transferModelOwnershipTests {
+		c.Run(test.name, func(c *qt.C) {
+			ctx := context.Background()
+
+			client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name(), test.name)
+			c.Assert(err, qt.IsNil)
+
+			j := &jimm.JIMM{
+				UUID:          uuid.NewString(),
+				OpenFGAClient: client,
+				Database: db.Database{
+					DB: jimmtest.PostgresDB(c, nil),
+				},
+			}
+			err = j.Database.Migrate(ctx, false)
+			c.Assert(err, qt.IsNil)
+
+			env := jimmtest.ParseEnvironment(c, transferModelOwnershipTestEnv)
+			env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+			dbUser := env.User(test.username).DBObject(c, j.Database)
+			user := openfga.NewUser(&dbUser, client)
+			user.JimmAdmin = dbUser.Name == "alice@canonical.com"
+
+			mt := names.NewModelTag(test.uuid)
+			err = j.TransferModelOwnership(ctx, user, mt, names.NewUserTag(test.newOwner), test.keepAccess)
+			if test.expectError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectError)
+				c.Check(errors.ErrorCode(err), qt.Equals, test.expectErrorCode)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+
+			m := dbmodel.Model{
+				UUID: sql.NullString{
+					String: test.uuid,
+					Valid:  true,
+				},
+			}
+			err = j.Database.GetModel(ctx, &m)
+			c.Assert(err, qt.IsNil)
+			c.Check(m.OwnerIdentityName, qt.Equals, test.newOwner)
+
+			newOwner := env.User(test.newOwner).DBObject(c, j.Database)
+			c.Check(openfga.NewUser(&newOwner, client).GetModelAccess(ctx, mt), qt.Equals, ofganames.AdministratorRelation)
+			previousOwner := env.User(test.expectPreviousOwner).DBObject(c, j.Database)
+			c.Check(openfga.NewUser(&previousOwner, client).GetModelAccess(ctx, mt), qt.Equals, test.expectPreviousAccess)
+
+			var entries []dbmodel.AuditLogEntry
+			err = j.Database.ForEachAuditLogEntry(ctx, db.AuditLogFilter{Method: "TransferModelOwnership"}, func(ale *dbmodel.AuditLogEntry) error {
+				entries = append(entries, *ale)
+				return nil
+			})
+			c.Assert(err, qt.IsNil)
+			c.Assert(entries, qt.HasLen, 1)
+			c.Check(entries[0].Model, qt.Equals, test.uuid)
+		})
+	}
+}
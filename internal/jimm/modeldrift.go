@@ -0,0 +1,290 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// Model default sources, in the order the defaults are applied. Values
+// from later sources take precedence, so the effective config of a new
+// model is built from group defaults, overridden by identity defaults,
+// then cloud defaults, then cloud region defaults, then the config of
+// any model template and finally the config given explicitly when the
+// model is added.
+const (
+	// GroupDefaultsSource is the source of defaults set for a group the
+	// identity is a member of with SetGroupModelDefaults.
+	GroupDefaultsSource = "group"
+
+	// IdentityDefaultsSource is the source of defaults set with
+	// SetIdentityModelDefaults.
+	IdentityDefaultsSource = "identity"
+
+	// CloudDefaultsSource is the source of defaults set for a cloud
+	// with SetModelDefaults.
+	CloudDefaultsSource = "cloud"
+
+	// RegionDefaultsSource is the source of defaults set for a cloud
+	// region with SetModelDefaults.
+	RegionDefaultsSource = "region"
+
+	// TemplateConfigSource is the source of config taken from a model
+	// template.
+	TemplateConfigSource = "template"
+
+	// ExplicitConfigSource is the source of config given when the model
+	// is added.
+	ExplicitConfigSource = "explicit"
+)
+
+// A ModelDefault is a model config value taken from a set of model
+// defaults.
+type ModelDefault struct {
+	// Value is the default value.
+	Value interface{}
+
+	// Source is where the default comes from, one of the model default
+	// sources above.
+	Source string
+
+	// Group is the name of the group the default comes from when Source
+	// is GroupDefaultsSource.
+	Group string
+}
+
+// ModelDriftParams holds the parameters for a model drift report.
+type ModelDriftParams struct {
+	// Selector selects the models to check. Only models the user
+	// administers are checked.
+	Selector ModelSelector
+
+	// Apply determines whether the defaults are re-applied to models
+	// whose config has drifted.
+	Apply bool
+
+	// Force determines whether the defaults are re-applied to models
+	// that were added before the sources of their config were recorded.
+	// Such models may have been added with template or explicit config
+	// values that would be overwritten by the defaults.
+	Force bool
+}
+
+// A ConfigDrift is a model config value that differs from the value in
+// the model defaults.
+type ConfigDrift struct {
+	// Key is the config key.
+	Key string
+
+	// Expected is the value from the model defaults.
+	Expected interface{}
+
+	// Actual is the value in the model config, it is nil if the key is
+	// not set in the model.
+	Actual interface{}
+
+	// Source is where the expected value comes from.
+	Source string
+
+	// Group is the name of the group the expected value comes from when
+	// Source is GroupDefaultsSource.
+	Group string
+}
+
+// ModelDrift holds the config drift of a single model.
+type ModelDrift struct {
+	// Model is the model that was checked.
+	Model dbmodel.Model
+
+	// Drift holds the config values that differ from the model
+	// defaults, ordered by key.
+	Drift []ConfigDrift
+
+	// Applied reports whether the defaults were re-applied to the
+	// model.
+	Applied bool
+
+	// Err holds any error encountered checking or updating the model.
+	Err error
+}
+
+// ModelDrift compares the live config of each selected model with the
+// model defaults that apply to it. The defaults are layered in the same
+// order as when a model is added: the defaults of the groups the model
+// owner is a member of, then the owner's defaults, then the owner's
+// defaults for the model's cloud and finally the owner's defaults for
+// the model's cloud region. Config values the model was added with from
+// a model template or explicitly take precedence over the defaults, so
+// they are never reported as drift. If p.Apply is true the defaults are
+// set on any model whose config has drifted. Models that have no recorded
+// config sources, because they were added before sources were recorded,
+// are only updated if p.Force is also true. Errors with individual
+// models are reported in the results rather than failing the whole
+// report.
+func (j *JIMM) ModelDrift(ctx context.Context, user *openfga.User, p ModelDriftParams) ([]ModelDrift, error) {
+	const op = errors.Op("jimm.ModelDrift")
+
+	models, err := j.administeredModels(ctx, user, p.Selector)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	results := make([]ModelDrift, len(models))
+	for i := range models {
+		results[i].Model = models[i]
+		ctx := zapctx.WithFields(ctx, zap.String("model", models[i].UUID.String))
+		if err := j.modelDrift(ctx, &results[i], p.Apply, p.Force); err != nil {
+			zapctx.Warn(ctx, "failed to check model config drift", zaputil.Error(err))
+			results[i].Err = err
+		}
+	}
+	return results, nil
+}
+
+// modelDrift fills in the config drift of the model in the given result
+// and, if apply is true, re-applies the model defaults. Models without
+// recorded config sources are only updated if force is true.
+func (j *JIMM) modelDrift(ctx context.Context, res *ModelDrift, apply, force bool) error {
+	m := &res.Model
+	defaults, err := j.ModelDefaults(ctx, m.OwnerIdentityName, m.CloudRegion.Cloud.Name, m.CloudRegion.Name)
+	if err != nil {
+		return err
+	}
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	api, err := j.dial(ctx, &m.Controller, m.ResourceTag())
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	config, err := api.ModelGet(ctx)
+	if err != nil {
+		return err
+	}
+	for k, d := range defaults {
+		if _, ok := m.ConfigSources[k]; ok {
+			// The value was set from a model template or explicitly
+			// when the model was added, both of which take
+			// precedence over the defaults.
+			continue
+		}
+		var actual interface{}
+		if v, ok := config[k]; ok {
+			actual = v.Value
+			// Config values may be decoded to a different type than the
+			// stored default, for example a number set as a string, so
+			// the values are compared by their string representation.
+			if fmt.Sprint(actual) == fmt.Sprint(d.Value) {
+				continue
+			}
+		}
+		res.Drift = append(res.Drift, ConfigDrift{
+			Key:      k,
+			Expected: d.Value,
+			Actual:   actual,
+			Source:   d.Source,
+			Group:    d.Group,
+		})
+	}
+	sort.Slice(res.Drift, func(i, j int) bool {
+		return res.Drift[i].Key < res.Drift[j].Key
+	})
+	if !apply || len(res.Drift) == 0 {
+		return nil
+	}
+	if m.ConfigSources == nil && !force {
+		return errors.E(errors.CodeBadRequest, "model config sources not recorded, the model may have been added with values the defaults would overwrite; use force to apply")
+	}
+
+	update := make(map[string]interface{}, len(res.Drift))
+	for _, d := range res.Drift {
+		update[d.Key] = d.Expected
+	}
+	if err := api.ModelSet(ctx, update); err != nil {
+		return err
+	}
+	res.Applied = true
+	return nil
+}
+
+// ModelDefaults returns the model defaults that apply to a model owned by
+// the named identity in the given cloud region, along with the source of
+// each default. Later sources override earlier ones.
+func (j *JIMM) ModelDefaults(ctx context.Context, identityName, cloud, region string) (map[string]ModelDefault, error) {
+	const op = errors.Op("jimm.ModelDefaults")
+
+	defaults := make(map[string]ModelDefault)
+	add := func(values map[string]interface{}, source string) {
+		for k, v := range values {
+			defaults[k] = ModelDefault{Value: v, Source: source}
+		}
+	}
+
+	identity, err := dbmodel.NewIdentity(identityName)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	groupDefaults, err := j.identityGroupModelDefaults(ctx, identity)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	for _, gd := range groupDefaults {
+		for k, v := range gd.Defaults {
+			defaults[k] = ModelDefault{Value: v, Source: GroupDefaultsSource, Group: gd.Group.Name}
+		}
+	}
+
+	identityDefaults := dbmodel.IdentityModelDefaults{
+		IdentityName: identityName,
+	}
+	err = j.Database.IdentityModelDefaults(ctx, &identityDefaults)
+	if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+		return nil, errors.E(op, err)
+	}
+	add(identityDefaults.Defaults, IdentityDefaultsSource)
+
+	if cloud == "" {
+		return defaults, nil
+	}
+	cloudDefaults := dbmodel.CloudDefaults{
+		IdentityName: identityName,
+		Cloud: dbmodel.Cloud{
+			Name: cloud,
+		},
+	}
+	err = j.Database.CloudDefaults(ctx, &cloudDefaults)
+	if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+		return nil, errors.E(op, err)
+	}
+	add(cloudDefaults.Defaults, CloudDefaultsSource)
+
+	if region == "" {
+		return defaults, nil
+	}
+	regionDefaults := dbmodel.CloudDefaults{
+		IdentityName: identityName,
+		Cloud: dbmodel.Cloud{
+			Name: cloud,
+		},
+		Region: region,
+	}
+	err = j.Database.CloudDefaults(ctx, &regionDefaults)
+	if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+		return nil, errors.E(op, err)
+	}
+	add(regionDefaults.Defaults, RegionDefaultsSource)
+
+	return defaults, nil
+}
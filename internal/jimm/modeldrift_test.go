@@ -0,0 +1,228 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/version"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const testModelDriftEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+  users:
+  - user: alice@canonical.com
+    access: add-model
+cloud-credentials:
+- name: test-credential-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-region-1
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+  users:
+  - user: alice@canonical.com
+    access: admin
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+  users:
+  - user: alice@canonical.com
+    access: admin
+  - user: bob@canonical.com
+    access: read
+`
+
+func TestModelDrift(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	config := map[string]map[string]jujuparams.ConfigValue{
+		"00000002-0000-0000-0000-000000000001": {
+			"logging-config":              {Value: "<root>=WARNING", Source: "model"},
+			"update-status-hook-interval": {Value: "5m", Source: "default"},
+			"automatically-retry-hooks":   {Value: true, Source: "default"},
+		},
+		"00000002-0000-0000-0000-000000000002": {
+			"logging-config":              {Value: "<root>=INFO", Source: "model"},
+			"update-status-hook-interval": {Value: "10m", Source: "model"},
+			"automatically-retry-hooks":   {Value: false, Source: "model"},
+		},
+	}
+	set := make(map[string]map[string]interface{})
+	dialer := modelDialer(func(mt names.ModelTag) jimm.API {
+		return &jimmtest.API{
+			ModelGet_: func(context.Context) (map[string]jujuparams.ConfigValue, error) {
+				return config[mt.Id()], nil
+			},
+			ModelSet_: func(_ context.Context, cfg map[string]interface{}) error {
+				set[mt.Id()] = cfg
+				return nil
+			},
+		}
+	})
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer:        dialer,
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testModelDriftEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+
+	err = j.SetIdentityModelDefaults(ctx, &dbAlice, map[string]interface{}{
+		"logging-config":              "<root>=INFO",
+		"update-status-hook-interval": "10m",
+	})
+	c.Assert(err, qt.IsNil)
+	err = j.SetModelDefaults(ctx, &dbAlice, names.NewCloudTag("test-cloud"), "", map[string]interface{}{
+		"automatically-retry-hooks": "false",
+	})
+	c.Assert(err, qt.IsNil)
+	err = j.SetModelDefaults(ctx, &dbAlice, names.NewCloudTag("test-cloud"), "test-region-1", map[string]interface{}{
+		"update-status-hook-interval": "10m",
+	})
+	c.Assert(err, qt.IsNil)
+
+	// model-1 was added with an explicit value for
+	// update-status-hook-interval, which is never reported as drift.
+	m1 := dbmodel.Model{UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000001", Valid: true}}
+	err = j.Database.GetModel(ctx, &m1)
+	c.Assert(err, qt.IsNil)
+	m1.ConfigSources = dbmodel.StringMap{"update-status-hook-interval": jimm.ExplicitConfigSource}
+	err = j.Database.UpdateModel(ctx, &m1)
+	c.Assert(err, qt.IsNil)
+
+	defaults, err := j.ModelDefaults(ctx, "alice@canonical.com", "test-cloud", "test-region-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(defaults, qt.DeepEquals, map[string]jimm.ModelDefault{
+		"logging-config":              {Value: "<root>=INFO", Source: jimm.IdentityDefaultsSource},
+		"automatically-retry-hooks":   {Value: "false", Source: jimm.CloudDefaultsSource},
+		"update-status-hook-interval": {Value: "10m", Source: jimm.RegionDefaultsSource},
+	})
+
+	results, err := j.ModelDrift(ctx, alice, jimm.ModelDriftParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(results, qt.HasLen, 2)
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Model.Name < results[j].Model.Name
+	})
+	c.Check(results[0].Model.Name, qt.Equals, "model-1")
+	c.Check(results[0].Err, qt.IsNil)
+	c.Check(results[0].Drift, qt.DeepEquals, []jimm.ConfigDrift{{
+		Key:      "automatically-retry-hooks",
+		Expected: "false",
+		Actual:   true,
+		Source:   jimm.CloudDefaultsSource,
+	}, {
+		Key:      "logging-config",
+		Expected: "<root>=INFO",
+		Actual:   "<root>=WARNING",
+		Source:   jimm.IdentityDefaultsSource,
+	}})
+	c.Check(results[0].Applied, qt.IsFalse)
+	c.Check(results[1].Model.Name, qt.Equals, "model-2")
+	c.Check(results[1].Drift, qt.HasLen, 0)
+	c.Check(set, qt.HasLen, 0)
+
+	results, err = j.ModelDrift(ctx, alice, jimm.ModelDriftParams{
+		Selector: jimm.ModelSelector{NameGlob: "model-1"},
+		Apply:    true,
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(results, qt.HasLen, 1)
+	c.Check(results[0].Applied, qt.IsTrue)
+	c.Check(set, qt.DeepEquals, map[string]map[string]interface{}{
+		"00000002-0000-0000-0000-000000000001": {
+			"automatically-retry-hooks": "false",
+			"logging-config":            "<root>=INFO",
+		},
+	})
+
+	// model-2 has no recorded config sources, so the defaults are only
+	// applied when forced.
+	config["00000002-0000-0000-0000-000000000002"]["logging-config"] = jujuparams.ConfigValue{Value: "<root>=DEBUG", Source: "model"}
+	p := jimm.ModelDriftParams{
+		Selector: jimm.ModelSelector{NameGlob: "model-2"},
+		Apply:    true,
+	}
+	results, err = j.ModelDrift(ctx, alice, p)
+	c.Assert(err, qt.IsNil)
+	c.Assert(results, qt.HasLen, 1)
+	c.Check(errors.ErrorCode(results[0].Err), qt.Equals, errors.CodeBadRequest)
+	c.Check(results[0].Drift, qt.HasLen, 1)
+	c.Check(results[0].Applied, qt.IsFalse)
+	c.Check(set["00000002-0000-0000-0000-000000000002"], qt.IsNil)
+
+	p.Force = true
+	results, err = j.ModelDrift(ctx, alice, p)
+	c.Assert(err, qt.IsNil)
+	c.Assert(results, qt.HasLen, 1)
+	c.Check(results[0].Err, qt.IsNil)
+	c.Check(results[0].Applied, qt.IsTrue)
+	c.Check(set["00000002-0000-0000-0000-000000000002"], qt.DeepEquals, map[string]interface{}{
+		"logging-config": "<root>=INFO",
+	})
+
+	// bob only has read access to model-2, so no models are checked.
+	results, err = j.ModelDrift(ctx, bob, jimm.ModelDriftParams{})
+	c.Assert(err, qt.IsNil)
+	c.Check(results, qt.HasLen, 0)
+}
+
+// modelDialer is a jimm.Dialer that returns the API for the dialed model.
+type modelDialer func(names.ModelTag) jimm.API
+
+// Dial implements jimm.Dialer.
+func (d modelDialer) Dial(_ context.Context, ctl *dbmodel.Controller, mt names.ModelTag, _ map[string]string) (jimm.API, error) {
+	ctl.UUID = jimmtest.DefaultControllerUUID
+	ctl.AgentVersion = version.Current.String()
+	return d(mt), nil
+}
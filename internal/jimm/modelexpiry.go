@@ -0,0 +1,174 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+// ModelTTLConfigKey is the reserved model config key used to create an
+// ephemeral model. The value is a duration after which the model will be
+// destroyed by JIMM, for example "24h". The key is never passed to the
+// juju controller.
+const ModelTTLConfigKey = "jimm-model-ttl"
+
+// ExtendModel extends the expiry time of the given ephemeral model by the
+// given duration. If the model has already expired the new expiry time is
+// calculated from the current time. The new expiry time is returned. Only
+// model administrators can extend a model. If the model does not expire an
+// error with the code CodeBadRequest is returned.
+func (j *JIMM) ExtendModel(ctx context.Context, user *openfga.User, mt names.ModelTag, d time.Duration) (time.Time, error) {
+	const op = errors.Op("jimm.ExtendModel")
+
+	if d <= 0 {
+		return time.Time{}, errors.E(op, errors.CodeBadRequest, "duration must be positive")
+	}
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return time.Time{}, errors.E(op, err)
+	}
+	if user.GetModelAccess(ctx, mt) != ofganames.AdministratorRelation {
+		return time.Time{}, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if !m.ExpiresAt.Valid {
+		return time.Time{}, errors.E(op, errors.CodeBadRequest, "model does not expire")
+	}
+
+	expiresAt := m.ExpiresAt.Time
+	if now := time.Now(); expiresAt.Before(now) {
+		expiresAt = now
+	}
+	m.ExpiresAt = sql.NullTime{Time: expiresAt.Add(d), Valid: true}
+	m.ExpiryWarnedAt = sql.NullTime{}
+	if err := j.Database.UpdateModel(ctx, &m); err != nil {
+		return time.Time{}, errors.E(op, err)
+	}
+	return m.ExpiresAt.Time, nil
+}
+
+// A ModelReaper destroys ephemeral models once their expiry time has
+// passed. The owners of models that are about to expire are warned
+// through the audit log.
+type ModelReaper struct {
+	// JIMM is the JIMM instance used to destroy models.
+	JIMM *JIMM
+
+	// WarningPeriod is how long before a model expires its owner is
+	// warned.
+	WarningPeriod time.Duration
+
+	// DestroyStorage determines whether storage in expired models is
+	// destroyed along with the model.
+	DestroyStorage bool
+
+	// Force determines whether expired models are forcibly destroyed.
+	Force bool
+
+	// MaxWait is the maximum time to wait for each step of a forced
+	// model destruction. If this is zero the controller default is
+	// used.
+	MaxWait time.Duration
+}
+
+// Run checks for expired models at the given interval until the given
+// context is canceled.
+func (r *ModelReaper) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.reap(ctx, time.Now()); err != nil {
+			zapctx.Error(ctx, "failed to reap expired models", zaputil.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// reap warns the owners of models that will expire within the warning
+// period and destroys models that expired before now.
+func (r *ModelReaper) reap(ctx context.Context, now time.Time) error {
+	const op = errors.Op("jimm.ModelReaper.reap")
+
+	models, err := r.JIMM.Database.GetExpiringModels(ctx, now.Add(r.WarningPeriod))
+	if err != nil {
+		return errors.E(op, err)
+	}
+	for i := range models {
+		m := &models[i]
+		ctx := zapctx.WithFields(ctx, zap.String("model", m.UUID.String))
+		if m.ExpiresAt.Time.After(now) {
+			if !m.ExpiryWarnedAt.Valid {
+				r.warn(ctx, m, now)
+			}
+			continue
+		}
+		r.destroy(ctx, m)
+	}
+	return nil
+}
+
+// warn records that the given model is about to expire.
+func (r *ModelReaper) warn(ctx context.Context, m *dbmodel.Model, now time.Time) {
+	zapctx.Info(ctx, "model is about to expire", zap.String("owner", m.OwnerIdentityName), zap.Time("expires-at", m.ExpiresAt.Time))
+	m.ExpiryWarnedAt = sql.NullTime{Time: now, Valid: true}
+	if err := r.JIMM.Database.UpdateModel(ctx, m); err != nil {
+		zapctx.Error(ctx, "failed to store model expiry warning", zaputil.Error(err))
+		return
+	}
+	r.JIMM.addModelExpiryAuditLogEntry(m, "ModelExpiryWarning")
+}
+
+// destroy destroys the given expired model on behalf of its owner.
+func (r *ModelReaper) destroy(ctx context.Context, m *dbmodel.Model) {
+	var maxWait *time.Duration
+	if r.MaxWait > 0 {
+		maxWait = &r.MaxWait
+	}
+	user := openfga.NewUser(&m.Owner, r.JIMM.OpenFGAClient)
+	err := r.JIMM.DestroyModel(ctx, user, m.ResourceTag(), &r.DestroyStorage, &r.Force, maxWait, nil)
+	if err != nil {
+		zapctx.Error(ctx, "failed to destroy expired model", zaputil.Error(err))
+		return
+	}
+	zapctx.Info(ctx, "destroyed expired model", zap.String("owner", m.OwnerIdentityName))
+	r.JIMM.addModelExpiryAuditLogEntry(m, "ModelExpired")
+}
+
+// addModelExpiryAuditLogEntry records a model expiry event in the audit
+// log.
+func (j *JIMM) addModelExpiryAuditLogEntry(m *dbmodel.Model, method string) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model":      m.Name,
+		"expires-at": m.ExpiresAt.Time.UTC(),
+	})
+	if err != nil {
+		zapctx.Error(context.Background(), "failed to marshal model expiry audit parameters", zaputil.Error(err))
+		return
+	}
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		Model:        m.UUID.String,
+		FacadeName:   "JIMM",
+		FacadeMethod: method,
+		IdentityTag:  m.Owner.Tag().String(),
+		Params:       body,
+	})
+}
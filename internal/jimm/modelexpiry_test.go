@@ -0,0 +1,303 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const testModelExpiryEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+  users:
+  - user: alice@canonical.com
+    access: add-model
+cloud-credentials:
+- name: test-credential-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000000-0000-0000-0000-0000-0000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 1
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+  users:
+  - user: alice@canonical.com
+    access: admin
+  - user: bob@canonical.com
+    access: write
+- name: model-2
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+  users:
+  - user: alice@canonical.com
+    access: admin
+- name: model-3
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+  users:
+  - user: alice@canonical.com
+    access: admin
+- name: model-4
+  uuid: 00000002-0000-0000-0000-000000000004
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+  users:
+  - user: alice@canonical.com
+    access: admin
+`
+
+func setModelExpiry(c *qt.C, j *jimm.JIMM, uuid string, expiresAt time.Time) {
+	m := dbmodel.Model{UUID: sql.NullString{String: uuid, Valid: true}}
+	err := j.Database.GetModel(context.Background(), &m)
+	c.Assert(err, qt.IsNil)
+	m.ExpiresAt = sql.NullTime{Time: expiresAt, Valid: true}
+	err = j.Database.UpdateModel(context.Background(), &m)
+	c.Assert(err, qt.IsNil)
+}
+
+func TestAddModelWithTTL(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				GrantJIMMModelAdmin_: func(context.Context, names.ModelTag) error {
+					return nil
+				},
+				CreateModel_: assertConfig(map[string]interface{}{
+					"key1": "value1",
+				}, createModel(`
+uuid: 00000001-0000-0000-0000-0000-000000000001
+status:
+  status: started
+life: alive
+`[1:])),
+			},
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testModelExpiryEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, client)
+
+	args := jimm.ModelCreateArgs{
+		Name:            "ephemeral",
+		Owner:           names.NewUserTag("alice@canonical.com"),
+		Cloud:           names.NewCloudTag("test-cloud"),
+		CloudRegion:     "test-region-1",
+		CloudCredential: names.NewCloudCredentialTag("test-cloud/alice@canonical.com/test-credential-1"),
+		Config: map[string]interface{}{
+			"key1":                 "value1",
+			jimm.ModelTTLConfigKey: "not-a-duration",
+		},
+	}
+	_, err = j.AddModel(ctx, user, &args)
+	c.Check(err, qt.ErrorMatches, `invalid jimm-model-ttl value "not-a-duration": expected a positive duration`)
+
+	args.Config[jimm.ModelTTLConfigKey] = "2h"
+	before := time.Now()
+	_, err = j.AddModel(ctx, user, &args)
+	c.Assert(err, qt.IsNil)
+
+	m := dbmodel.Model{UUID: sql.NullString{String: "00000001-0000-0000-0000-0000-000000000001", Valid: true}}
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Assert(m.ExpiresAt.Valid, qt.IsTrue)
+	c.Check(m.ExpiresAt.Time.Before(before.Add(2*time.Hour)), qt.IsFalse)
+	c.Check(m.ExpiresAt.Time.After(time.Now().Add(2*time.Hour)), qt.IsFalse)
+}
+
+func TestExtendModel(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testModelExpiryEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+
+	mt1 := names.NewModelTag("00000002-0000-0000-0000-000000000001")
+	mt2 := names.NewModelTag("00000002-0000-0000-0000-000000000002")
+
+	_, err = j.ExtendModel(ctx, alice, mt1, time.Hour)
+	c.Check(err, qt.ErrorMatches, "model does not expire")
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	setModelExpiry(c, j, mt1.Id(), expiresAt)
+
+	_, err = j.ExtendModel(ctx, bob, mt1, time.Hour)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+
+	_, err = j.ExtendModel(ctx, alice, mt1, -time.Hour)
+	c.Check(err, qt.ErrorMatches, "duration must be positive")
+
+	newExpiry, err := j.ExtendModel(ctx, alice, mt1, time.Hour)
+	c.Assert(err, qt.IsNil)
+	c.Check(newExpiry.Equal(expiresAt.Add(time.Hour)), qt.IsTrue)
+
+	// An expired model is extended from the current time.
+	setModelExpiry(c, j, mt2.Id(), time.Now().Add(-time.Hour))
+	before := time.Now()
+	newExpiry, err = j.ExtendModel(ctx, alice, mt2, time.Hour)
+	c.Assert(err, qt.IsNil)
+	c.Check(newExpiry.Before(before.Add(time.Hour)), qt.IsFalse)
+}
+
+func TestModelReaper(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var mu sync.Mutex
+	var destroyed []string
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				DestroyModel_: func(_ context.Context, mt names.ModelTag, destroyStorage, force *bool, maxWait, _ *time.Duration) error {
+					if destroyStorage == nil || !*destroyStorage || force == nil || !*force || maxWait == nil || *maxWait != time.Minute {
+						return errors.E("unexpected destroy arguments")
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					destroyed = append(destroyed, mt.Id())
+					return nil
+				},
+			},
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testModelExpiryEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	now := time.Now()
+	// model-1 has expired, model-2 expires within the warning period,
+	// model-3 expires after the warning period and model-4 does not
+	// expire.
+	setModelExpiry(c, j, "00000002-0000-0000-0000-000000000001", now.Add(-time.Minute))
+	setModelExpiry(c, j, "00000002-0000-0000-0000-000000000002", now.Add(30*time.Minute))
+	setModelExpiry(c, j, "00000002-0000-0000-0000-000000000003", now.Add(2*time.Hour))
+
+	r := &jimm.ModelReaper{
+		JIMM:           j,
+		WarningPeriod:  time.Hour,
+		DestroyStorage: true,
+		Force:          true,
+		MaxWait:        time.Minute,
+	}
+	err = jimm.ReapModels(r, ctx, now)
+	c.Assert(err, qt.IsNil)
+	c.Check(destroyed, qt.DeepEquals, []string{"00000002-0000-0000-0000-000000000001"})
+
+	m1 := dbmodel.Model{UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000001", Valid: true}}
+	err = j.Database.GetModel(ctx, &m1)
+	c.Assert(err, qt.IsNil)
+	c.Check(m1.Life, qt.Equals, state.Dying.String())
+
+	m2 := dbmodel.Model{UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000002", Valid: true}}
+	err = j.Database.GetModel(ctx, &m2)
+	c.Assert(err, qt.IsNil)
+	c.Check(m2.ExpiryWarnedAt.Valid, qt.IsTrue)
+
+	m3 := dbmodel.Model{UUID: sql.NullString{String: "00000002-0000-0000-0000-000000000003", Valid: true}}
+	err = j.Database.GetModel(ctx, &m3)
+	c.Assert(err, qt.IsNil)
+	c.Check(m3.ExpiryWarnedAt.Valid, qt.IsFalse)
+
+	// Once model-2 expires it is destroyed, the dying model-1 is not
+	// destroyed again.
+	err = jimm.ReapModels(r, ctx, now.Add(time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(destroyed, qt.DeepEquals, []string{
+		"00000002-0000-0000-0000-000000000001",
+		"00000002-0000-0000-0000-000000000002",
+	})
+}
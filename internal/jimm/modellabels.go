@@ -0,0 +1,179 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+// maxModelLabels is the maximum number of labels that can be attached to
+// a model.
+const maxModelLabels = 64
+
+var (
+	labelKeyRegexp   = regexp.MustCompile(`^[a-z0-9]([a-z0-9._/-]{0,61}[a-z0-9])?$`)
+	labelValueRegexp = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]{0,61}[A-Za-z0-9])?)?$`)
+)
+
+// validateLabel checks that the given label key and value are valid.
+func validateLabel(key, value string) error {
+	if !labelKeyRegexp.MatchString(key) {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid label key %q", key))
+	}
+	if !labelValueRegexp.MatchString(value) {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid value %q for label %q", value, key))
+	}
+	return nil
+}
+
+// SetModelLabels sets the given labels on the given model and removes the
+// labels with the given keys. The resulting set of labels is returned.
+// Only model administrators can change the labels of a model.
+func (j *JIMM) SetModelLabels(ctx context.Context, user *openfga.User, mt names.ModelTag, labels map[string]string, remove []string) (map[string]string, error) {
+	const op = errors.Op("jimm.SetModelLabels")
+
+	for k, v := range labels {
+		if err := validateLabel(k, v); err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if user.GetModelAccess(ctx, mt) != ofganames.AdministratorRelation {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	if m.Labels == nil {
+		m.Labels = make(dbmodel.StringMap)
+	}
+	for _, k := range remove {
+		delete(m.Labels, k)
+	}
+	for k, v := range labels {
+		m.Labels[k] = v
+	}
+	if len(m.Labels) > maxModelLabels {
+		return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("a model cannot have more than %d labels", maxModelLabels))
+	}
+	if err := j.Database.UpdateModel(ctx, &m); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return m.Labels, nil
+}
+
+// ModelLabels returns the labels attached to the given model. The user
+// must have at least read access to the model.
+func (j *JIMM) ModelLabels(ctx context.Context, user *openfga.User, mt names.ModelTag) (map[string]string, error) {
+	const op = errors.Op("jimm.ModelLabels")
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if user.GetModelAccess(ctx, mt) == ofganames.NoRelation {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	return m.Labels, nil
+}
+
+// A LabelSelector selects models by their labels. The zero value selects
+// all models.
+type LabelSelector struct {
+	requirements []labelRequirement
+}
+
+// labelRequirement is a single requirement in a label selector.
+type labelRequirement struct {
+	key      string
+	value    string
+	operator string
+}
+
+// matches reports whether the given labels satisfy the requirement.
+func (r labelRequirement) matches(labels map[string]string) bool {
+	v, ok := labels[r.key]
+	switch r.operator {
+	case "exists":
+		return ok
+	case "!exists":
+		return !ok
+	case "=":
+		return ok && v == r.value
+	case "!=":
+		return !ok || v != r.value
+	}
+	return false
+}
+
+// ParseLabelSelector parses a label selector. A selector is a comma
+// separated list of requirements, all of which must be satisfied. Each
+// requirement takes one of the following forms:
+//
+//	key=value   the label is set to value
+//	key==value  the label is set to value
+//	key!=value  the label is not set to value, or is not set
+//	key         the label is set
+//	!key        the label is not set
+//
+// An empty selector matches all models.
+func ParseLabelSelector(s string) (LabelSelector, error) {
+	var sel LabelSelector
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		var r labelRequirement
+		switch {
+		case strings.HasPrefix(part, "!") && !strings.Contains(part, "="):
+			r.key, r.operator = strings.TrimSpace(part[1:]), "!exists"
+		case strings.Contains(part, "!="):
+			k, v, _ := strings.Cut(part, "!=")
+			r.key, r.value, r.operator = strings.TrimSpace(k), strings.TrimSpace(v), "!="
+		case strings.Contains(part, "=="):
+			k, v, _ := strings.Cut(part, "==")
+			r.key, r.value, r.operator = strings.TrimSpace(k), strings.TrimSpace(v), "="
+		case strings.Contains(part, "="):
+			k, v, _ := strings.Cut(part, "=")
+			r.key, r.value, r.operator = strings.TrimSpace(k), strings.TrimSpace(v), "="
+		default:
+			r.key, r.operator = part, "exists"
+		}
+		if err := validateLabel(r.key, r.value); err != nil {
+			return LabelSelector{}, err
+		}
+		sel.requirements = append(sel.requirements, r)
+	}
+	return sel, nil
+}
+
+// Empty reports whether the selector matches all models.
+func (s LabelSelector) Empty() bool {
+	return len(s.requirements) == 0
+}
+
+// Matches reports whether the given labels satisfy all the requirements
+// of the selector.
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, r := range s.requirements {
+		if !r.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
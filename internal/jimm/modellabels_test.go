@@ -0,0 +1,154 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const testModelLabelsEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+cloud-credentials:
+- name: test-credential-1
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000000-0000-0000-0000-0000-0000000000001
+  cloud: test-cloud
+  region: test-region-1
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: alice@canonical.com
+  life: alive
+  users:
+  - user: alice@canonical.com
+    access: admin
+  - user: bob@canonical.com
+    access: read
+`
+
+func TestSetModelLabels(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testModelLabelsEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+	mt := names.NewModelTag("00000002-0000-0000-0000-000000000001")
+
+	labels, err := j.SetModelLabels(ctx, alice, mt, map[string]string{"team": "observability", "env": "prod"}, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(labels, qt.DeepEquals, map[string]string{"team": "observability", "env": "prod"})
+
+	labels, err = j.SetModelLabels(ctx, alice, mt, map[string]string{"cost-centre": "1234"}, []string{"env"})
+	c.Assert(err, qt.IsNil)
+	c.Check(labels, qt.DeepEquals, map[string]string{"team": "observability", "cost-centre": "1234"})
+
+	labels, err = j.ModelLabels(ctx, bob, mt)
+	c.Assert(err, qt.IsNil)
+	c.Check(labels, qt.DeepEquals, map[string]string{"team": "observability", "cost-centre": "1234"})
+
+	_, err = j.SetModelLabels(ctx, bob, mt, map[string]string{"team": "security"}, nil)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	_, err = j.SetModelLabels(ctx, alice, mt, map[string]string{"Team": "security"}, nil)
+	c.Check(err, qt.ErrorMatches, `invalid label key "Team"`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	c := qt.New(t)
+
+	labels := map[string]string{"team": "observability", "env": "prod"}
+	tests := []struct {
+		selector    string
+		expectMatch bool
+		expectError string
+	}{{
+		selector:    "",
+		expectMatch: true,
+	}, {
+		selector:    "team=observability",
+		expectMatch: true,
+	}, {
+		selector:    "team==observability, env=prod",
+		expectMatch: true,
+	}, {
+		selector:    "team=observability,env=dev",
+		expectMatch: false,
+	}, {
+		selector:    "env!=dev",
+		expectMatch: true,
+	}, {
+		selector:    "cost-centre!=1234",
+		expectMatch: true,
+	}, {
+		selector:    "team",
+		expectMatch: true,
+	}, {
+		selector:    "!team",
+		expectMatch: false,
+	}, {
+		selector:    "!cost-centre",
+		expectMatch: true,
+	}, {
+		selector:    "Team=observability",
+		expectError: `invalid label key "Team"`,
+	}, {
+		selector:    "team=obs ervability",
+		expectError: `invalid value "obs ervability" for label "team"`,
+	}}
+	for _, test := range tests {
+		c.Run(test.selector, func(c *qt.C) {
+			sel, err := jimm.ParseLabelSelector(test.selector)
+			if test.expectError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectError)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Check(sel.Matches(labels), qt.Equals, test.expectMatch)
+		})
+	}
+	sel, err := jimm.ParseLabelSelector("")
+	c.Assert(err, qt.IsNil)
+	c.Check(sel.Empty(), qt.IsTrue)
+}
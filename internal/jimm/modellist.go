@@ -0,0 +1,179 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+// A ModelListFilter restricts the models returned by ListUserModels.
+// Empty fields match all models.
+type ModelListFilter struct {
+	// Controller restricts the models to those hosted on the named
+	// controller.
+	Controller string
+
+	// Cloud restricts the models to those hosted on the named cloud.
+	Cloud string
+
+	// CloudRegion restricts the models to those hosted in the named
+	// cloud region.
+	CloudRegion string
+
+	// Owner restricts the models to those owned by the named identity.
+	Owner string
+
+	// Life restricts the models to those with the given life status.
+	Life string
+
+	// Status restricts the models to those with the given status.
+	Status string
+
+	// NamePrefix restricts the models to those whose name starts with
+	// the given prefix.
+	NamePrefix string
+
+	// LabelSelector restricts the models to those whose labels match
+	// the selector.
+	LabelSelector LabelSelector
+}
+
+// A UserModel is a model and the access a user has to it.
+type UserModel struct {
+	// Model is the model.
+	Model *dbmodel.Model
+
+	// Access is the user's access level on the model.
+	Access jujuparams.UserAccessPermission
+}
+
+// modelListBatchSize is the number of models read from the database at
+// a time when listing models.
+const modelListBatchSize = 500
+
+// ListUserModels returns a page of the models that the given user has
+// access to and that match the given filter. At most pageSize models are
+// returned, if pageSize is zero all matching models are returned. The
+// continuation token returned from a previous call can be used to
+// retrieve the next page, an empty token is returned with the final page.
+func (j *JIMM) ListUserModels(ctx context.Context, user *openfga.User, filter ModelListFilter, pageSize int, continuationToken string) ([]UserModel, string, error) {
+	const op = errors.Op("jimm.ListUserModels")
+
+	if pageSize < 0 {
+		return nil, "", errors.E(op, errors.CodeBadRequest, "invalid page size")
+	}
+	afterID, err := parseModelContinuationToken(continuationToken)
+	if err != nil {
+		return nil, "", errors.E(op, err)
+	}
+
+	var access map[string]jujuparams.UserAccessPermission
+	if !user.JimmAdmin {
+		access, err = userModelAccess(ctx, user)
+		if err != nil {
+			return nil, "", errors.E(op, err)
+		}
+		if len(access) == 0 {
+			return nil, "", nil
+		}
+	}
+
+	// The models are read from the database in batches ordered by ID,
+	// so that neither the matching models nor the models the user can
+	// access need to be sent to the database in one query.
+	dbFilter := db.ModelFilter{
+		Controller:  filter.Controller,
+		Cloud:       filter.Cloud,
+		CloudRegion: filter.CloudRegion,
+		Owner:       filter.Owner,
+		Life:        filter.Life,
+		Status:      filter.Status,
+		NamePrefix:  filter.NamePrefix,
+		Limit:       modelListBatchSize,
+	}
+	var models []UserModel
+	for {
+		dbFilter.AfterID = afterID
+		batch, err := j.Database.ListModels(ctx, dbFilter)
+		if err != nil {
+			return nil, "", errors.E(op, err)
+		}
+		for i := range batch {
+			m := &batch[i]
+			afterID = m.ID
+			if !filter.LabelSelector.Matches(m.Labels) {
+				continue
+			}
+			a := jujuparams.UserAccessPermission("admin")
+			if !user.JimmAdmin {
+				var ok bool
+				if a, ok = access[m.UUID.String]; !ok {
+					continue
+				}
+			}
+			models = append(models, UserModel{
+				Model:  m,
+				Access: a,
+			})
+			if pageSize > 0 && len(models) == pageSize {
+				return models, modelContinuationToken(afterID), nil
+			}
+		}
+		if len(batch) < modelListBatchSize {
+			return models, "", nil
+		}
+	}
+}
+
+// userModelAccess returns the access level the given user has on each
+// of the models they can read, keyed by model UUID. The levels are
+// derived from one OpenFGA ListObjects call per relation, rather than a
+// check per model.
+func userModelAccess(ctx context.Context, user *openfga.User) (map[string]jujuparams.UserAccessPermission, error) {
+	access := make(map[string]jujuparams.UserAccessPermission)
+	// Each relation implies the ones before it, so later relations
+	// replace the access levels set by earlier ones.
+	for _, r := range []openfga.Relation{ofganames.ReaderRelation, ofganames.WriterRelation, ofganames.AdministratorRelation} {
+		uuids, err := user.ListModels(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		for _, uuid := range uuids {
+			access[uuid] = jujuparams.UserAccessPermission(ToModelAccessString(r))
+		}
+	}
+	return access, nil
+}
+
+// modelContinuationToken returns the continuation token for a page of
+// models that ended with the model with the given ID.
+func modelContinuationToken(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+// parseModelContinuationToken returns the ID of the last model of the
+// previous page encoded in the given continuation token.
+func parseModelContinuationToken(token string) (uint, error) {
+	if token == "" {
+		return 0, nil
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.E(errors.CodeBadRequest, "invalid continuation token")
+	}
+	id, err := strconv.ParseUint(string(buf), 10, 0)
+	if err != nil {
+		return 0, errors.E(errors.CodeBadRequest, "invalid continuation token")
+	}
+	return uint(id), nil
+}
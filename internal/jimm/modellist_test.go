@@ -0,0 +1,107 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+func TestListUserModels(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID:          uuid.NewString(),
+		OpenFGAClient: client,
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{},
+		},
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, forEachModelTestEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbUser := env.User("bob@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, client)
+
+	type result struct {
+		Name   string
+		Access jujuparams.UserAccessPermission
+	}
+	results := func(models []jimm.UserModel) []result {
+		var res []result
+		for _, um := range models {
+			res = append(res, result{Name: um.Model.Name, Access: um.Access})
+		}
+		return res
+	}
+
+	models, ct, err := j.ListUserModels(ctx, user, jimm.ModelListFilter{}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Check(ct, qt.Equals, "")
+	c.Check(results(models), qt.DeepEquals, []result{
+		{Name: "model-1", Access: "admin"},
+		{Name: "model-2", Access: "write"},
+		{Name: "model-4", Access: "read"},
+	})
+
+	models, ct, err = j.ListUserModels(ctx, user, jimm.ModelListFilter{}, 2, "")
+	c.Assert(err, qt.IsNil)
+	c.Check(ct, qt.Not(qt.Equals), "")
+	c.Check(results(models), qt.DeepEquals, []result{
+		{Name: "model-1", Access: "admin"},
+		{Name: "model-2", Access: "write"},
+	})
+
+	models, ct, err = j.ListUserModels(ctx, user, jimm.ModelListFilter{}, 2, ct)
+	c.Assert(err, qt.IsNil)
+	c.Check(ct, qt.Equals, "")
+	c.Check(results(models), qt.DeepEquals, []result{
+		{Name: "model-4", Access: "read"},
+	})
+
+	models, _, err = j.ListUserModels(ctx, user, jimm.ModelListFilter{
+		Controller: "controller-1",
+		Owner:      "alice@canonical.com",
+		NamePrefix: "model-",
+		Life:       "alive",
+		Status:     "available",
+	}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Check(results(models), qt.HasLen, 3)
+
+	models, _, err = j.ListUserModels(ctx, user, jimm.ModelListFilter{NamePrefix: "model-3"}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Check(models, qt.HasLen, 0)
+
+	_, _, err = j.ListUserModels(ctx, user, jimm.ModelListFilter{}, 2, "not a token")
+	c.Check(err, qt.ErrorMatches, `invalid continuation token`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	dbAdmin := env.User("alice@canonical.com").DBObject(c, j.Database)
+	admin := openfga.NewUser(&dbAdmin, client)
+	admin.JimmAdmin = true
+	models, _, err = j.ListUserModels(ctx, admin, jimm.ModelListFilter{}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Check(models, qt.HasLen, 4)
+}
@@ -0,0 +1,347 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+const (
+	// quotaLimitModels is the name of the limit on the number of models.
+	quotaLimitModels = "models"
+	// quotaLimitMachines is the name of the soft limit on the number of
+	// machines.
+	quotaLimitMachines = "machines"
+	// quotaLimitCores is the name of the soft limit on the number of
+	// cores.
+	quotaLimitCores = "cores"
+	// quotaLimitUnits is the name of the soft limit on the number of
+	// units.
+	quotaLimitUnits = "units"
+)
+
+// A ModelQuotaUsage holds a model quota along with the usage of the
+// identity it applies to measured against the quota.
+type ModelQuotaUsage struct {
+	// Quota is the model quota.
+	Quota dbmodel.ModelQuota
+
+	// Group is the group the quota is assigned to. It is nil if the
+	// quota is assigned directly to an identity.
+	Group *dbmodel.GroupEntry
+
+	// Usage is the usage of the identity's models on the clouds the
+	// quota applies to. It is nil when reporting the quotas assigned to
+	// a group.
+	Usage *dbmodel.ModelUsage
+}
+
+// SetModelQuota sets the model quota for the given entity, which must be
+// a user, service account or group tag. Any existing quota for the
+// entity on the same cloud is replaced. Only JIMM administrators can set
+// model quotas.
+func (j *JIMM) SetModelQuota(ctx context.Context, user *openfga.User, entity string, quota *dbmodel.ModelQuota) error {
+	const op = errors.Op("jimm.SetModelQuota")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := j.resolveModelQuotaEntity(ctx, entity, quota); err != nil {
+		return errors.E(op, err)
+	}
+	for _, limit := range []sql.NullInt64{quota.MaxModels, quota.MaxMachines, quota.MaxCores, quota.MaxUnits} {
+		if limit.Valid && limit.Int64 < 0 {
+			return errors.E(op, errors.CodeBadRequest, "quota limits cannot be negative")
+		}
+	}
+	if quota.CloudName.Valid {
+		cloud := dbmodel.Cloud{Name: quota.CloudName.String}
+		if err := j.Database.GetCloud(ctx, &cloud); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	if err := j.Database.SetModelQuota(ctx, quota); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RemoveModelQuota removes the model quota for the given entity on the
+// named cloud. If cloudName is empty the quota that applies to all
+// clouds is removed. Only JIMM administrators can remove model quotas.
+func (j *JIMM) RemoveModelQuota(ctx context.Context, user *openfga.User, entity, cloudName string) error {
+	const op = errors.Op("jimm.RemoveModelQuota")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	var quota dbmodel.ModelQuota
+	if err := j.resolveModelQuotaEntity(ctx, entity, &quota); err != nil {
+		return errors.E(op, err)
+	}
+	if cloudName != "" {
+		quota.CloudName = sql.NullString{String: cloudName, Valid: true}
+	}
+	if err := j.Database.RemoveModelQuota(ctx, &quota); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ModelQuotas returns the model quotas for the given entity. If the
+// entity is an identity all the quotas that apply to the identity,
+// including those assigned to groups it is a member of, are returned
+// along with the identity's current usage. If the entity is a group
+// the quotas assigned to the group are returned. If entity is empty the
+// quotas for the authenticated user are returned. Only JIMM
+// administrators can view the quotas of other entities.
+func (j *JIMM) ModelQuotas(ctx context.Context, user *openfga.User, entity string) ([]ModelQuotaUsage, error) {
+	const op = errors.Op("jimm.ModelQuotas")
+
+	if entity == "" {
+		entity = user.ResourceTag().String()
+	}
+	var subject dbmodel.ModelQuota
+	if err := j.resolveModelQuotaEntity(ctx, entity, &subject); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if !user.JimmAdmin && subject.IdentityName.String != user.Name {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	if subject.GroupUUID.Valid {
+		group := dbmodel.GroupEntry{UUID: subject.GroupUUID.String}
+		if err := j.Database.GetGroup(ctx, &group); err != nil {
+			return nil, errors.E(op, err)
+		}
+		quotas, err := j.Database.GetModelQuotas(ctx, "", []string{group.UUID})
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		usages := make([]ModelQuotaUsage, len(quotas))
+		for i, q := range quotas {
+			usages[i] = ModelQuotaUsage{Quota: q, Group: &group}
+		}
+		return usages, nil
+	}
+
+	identity, err := dbmodel.NewIdentity(subject.IdentityName.String)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	usages, err := j.identityModelQuotaUsage(ctx, identity)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return usages, nil
+}
+
+// resolveModelQuotaEntity sets the identity or group of the given quota
+// from the given entity tag.
+func (j *JIMM) resolveModelQuotaEntity(ctx context.Context, entity string, quota *dbmodel.ModelQuota) error {
+	tag, err := j.ParseTag(ctx, entity)
+	if err != nil {
+		return errors.E(err, errors.CodeBadRequest)
+	}
+	if tag.IsPublicAccess() {
+		return errors.E(errors.CodeBadRequest, "cannot assign a model quota to all users")
+	}
+	switch tag.Kind {
+	case openfga.UserType, openfga.ServiceAccountType:
+		quota.IdentityName = sql.NullString{String: tag.ID, Valid: true}
+	case openfga.GroupType:
+		quota.GroupUUID = sql.NullString{String: tag.ID, Valid: true}
+	default:
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("cannot assign a model quota to a %s", tag.Kind))
+	}
+	return nil
+}
+
+// identityModelQuotas returns all the model quotas that apply to the
+// given identity, including those assigned to groups of which the
+// identity is a member.
+func (j *JIMM) identityModelQuotas(ctx context.Context, identity *dbmodel.Identity) ([]dbmodel.ModelQuota, error) {
+	groups, err := openfga.NewUser(identity, j.OpenFGAClient).ListGroups(ctx)
+	if err != nil {
+		return nil, errors.E(err, "failed to list groups")
+	}
+	return j.Database.GetModelQuotas(ctx, identity.Name, groups)
+}
+
+// identityModelQuotaUsage returns the model quotas that apply to the
+// given identity along with the identity's usage for each quota.
+func (j *JIMM) identityModelQuotaUsage(ctx context.Context, identity *dbmodel.Identity) ([]ModelQuotaUsage, error) {
+	quotas, err := j.identityModelQuotas(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string]*dbmodel.GroupEntry)
+	usage := make(map[string]dbmodel.ModelUsage)
+	usages := make([]ModelQuotaUsage, len(quotas))
+	for i, q := range quotas {
+		usages[i].Quota = q
+		if q.GroupUUID.Valid {
+			group, ok := groups[q.GroupUUID.String]
+			if !ok {
+				group = &dbmodel.GroupEntry{UUID: q.GroupUUID.String}
+				if err := j.Database.GetGroup(ctx, group); err != nil {
+					return nil, err
+				}
+				groups[q.GroupUUID.String] = group
+			}
+			usages[i].Group = group
+		}
+		u, ok := usage[q.CloudName.String]
+		if !ok {
+			u, err = j.Database.GetModelUsage(ctx, identity.Name, q.CloudName.String)
+			if err != nil {
+				return nil, err
+			}
+			usage[q.CloudName.String] = u
+		}
+		usages[i].Usage = &u
+	}
+	return usages, nil
+}
+
+// checkModelQuotas checks that the models owned by the given identity on
+// the named cloud, which must include the model being created, do not
+// exceed any model quota that applies to the identity. If a quota has
+// been exceeded an error with the code CodeQuotaLimitExceeded is
+// returned.
+func (j *JIMM) checkModelQuotas(ctx context.Context, owner *dbmodel.Identity, cloudName string, m *dbmodel.Model) error {
+	quotas, err := j.identityModelQuotas(ctx, owner)
+	if err != nil {
+		return err
+	}
+	var usage, cloudUsage *dbmodel.ModelUsage
+	for _, q := range quotas {
+		if !q.MaxModels.Valid || !q.AppliesToCloud(cloudName) {
+			continue
+		}
+		u := &usage
+		if q.CloudName.Valid {
+			u = &cloudUsage
+		}
+		if *u == nil {
+			mu, err := j.Database.GetModelUsage(ctx, owner.Name, q.CloudName.String)
+			if err != nil {
+				return err
+			}
+			*u = &mu
+		}
+		if (*u).Models <= q.MaxModels.Int64 {
+			continue
+		}
+		servermon.ModelQuotaExceededCount.WithLabelValues(quotaLimitModels).Inc()
+		j.addQuotaAuditLogEntry(owner, m, "ModelQuotaExceeded", q, quotaLimitModels, (*u).Models)
+		if q.CloudName.Valid {
+			return errors.E(errors.CodeQuotaLimitExceeded, fmt.Sprintf("model quota exceeded: %s may own at most %d models on cloud %q", owner.Name, q.MaxModels.Int64, q.CloudName.String))
+		}
+		return errors.E(errors.CodeQuotaLimitExceeded, fmt.Sprintf("model quota exceeded: %s may own at most %d models", owner.Name, q.MaxModels.Int64))
+	}
+	return nil
+}
+
+// CheckSoftQuotas implements SoftQuotaChecker. It checks whether the
+// change in the resources used by the given model has taken the owner's
+// usage beyond any soft limit in the model quotas that apply to the
+// owner. Breaches are recorded in the audit log and metrics, but are not
+// otherwise acted upon.
+func (j *JIMM) CheckSoftQuotas(ctx context.Context, m *dbmodel.Model, previous dbmodel.ModelUsage) {
+	if m.Machines <= previous.Machines && m.Cores <= previous.Cores && m.Units <= previous.Units {
+		// Usage cannot have crossed a limit.
+		return
+	}
+	quotas, err := j.identityModelQuotas(ctx, &m.Owner)
+	if err != nil {
+		zapctx.Error(ctx, "cannot get model quotas", zaputil.Error(err))
+		return
+	}
+	for _, q := range quotas {
+		if !q.AppliesToCloud(m.CloudRegion.CloudName) {
+			continue
+		}
+		if !q.MaxMachines.Valid && !q.MaxCores.Valid && !q.MaxUnits.Valid {
+			continue
+		}
+		usage, err := j.Database.GetModelUsage(ctx, m.OwnerIdentityName, q.CloudName.String)
+		if err != nil {
+			zapctx.Error(ctx, "cannot get model usage", zaputil.Error(err))
+			return
+		}
+		limits := []struct {
+			name          string
+			limit         sql.NullInt64
+			current, prev int64
+		}{
+			{quotaLimitMachines, q.MaxMachines, usage.Machines, usage.Machines - m.Machines + previous.Machines},
+			{quotaLimitCores, q.MaxCores, usage.Cores, usage.Cores - m.Cores + previous.Cores},
+			{quotaLimitUnits, q.MaxUnits, usage.Units, usage.Units - m.Units + previous.Units},
+		}
+		for _, l := range limits {
+			// Only report the change that takes the usage over
+			// the limit, not every subsequent change.
+			if !l.limit.Valid || l.current <= l.limit.Int64 || l.prev > l.limit.Int64 {
+				continue
+			}
+			zapctx.Warn(ctx, "model soft quota exceeded", zap.String("owner", m.OwnerIdentityName), zap.String("limit", l.name), zap.Int64("max", l.limit.Int64), zap.Int64("usage", l.current))
+			servermon.ModelQuotaSoftLimitBreachCount.WithLabelValues(l.name).Inc()
+			j.addQuotaAuditLogEntry(&m.Owner, m, "ModelSoftQuotaExceeded", q, l.name, l.current)
+		}
+	}
+}
+
+// addQuotaAuditLogEntry records a model quota event in the audit log.
+func (j *JIMM) addQuotaAuditLogEntry(owner *dbmodel.Identity, m *dbmodel.Model, method string, q dbmodel.ModelQuota, limit string, usage int64) {
+	params := map[string]interface{}{
+		"model": m.Name,
+		"limit": limit,
+		"usage": usage,
+	}
+	if q.IdentityName.Valid {
+		params["identity"] = q.IdentityName.String
+	}
+	if q.GroupUUID.Valid {
+		params["group"] = q.GroupUUID.String
+	}
+	if q.CloudName.Valid {
+		params["cloud"] = q.CloudName.String
+	}
+	switch limit {
+	case quotaLimitModels:
+		params["max"] = q.MaxModels.Int64
+	case quotaLimitMachines:
+		params["max"] = q.MaxMachines.Int64
+	case quotaLimitCores:
+		params["max"] = q.MaxCores.Int64
+	case quotaLimitUnits:
+		params["max"] = q.MaxUnits.Int64
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		zapctx.Error(context.Background(), "failed to marshal quota audit parameters", zaputil.Error(err))
+		return
+	}
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		Time:         time.Now().UTC().Round(time.Millisecond),
+		Model:        m.UUID.String,
+		FacadeName:   "JIMM",
+		FacadeMethod: method,
+		IdentityTag:  owner.Tag().String(),
+		Params:       body,
+	})
+}
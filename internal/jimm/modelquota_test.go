@@ -0,0 +1,168 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+const testModelQuotasEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+  users:
+  - user: bob@canonical.com
+    access: add-model
+cloud-credentials:
+- name: test-credential-1
+  owner: bob@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000000-0000-0000-0000-0000-0000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 1
+models:
+- name: model-1
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-region-1
+  cloud-credential: test-credential-1
+  owner: bob@canonical.com
+  life: alive
+  machines: 2
+  cores: 4
+  units: 3
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+`
+
+func TestModelQuotas(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testModelQuotasEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+
+	group, err := j.AddGroup(ctx, alice, "test-group")
+	c.Assert(err, qt.IsNil)
+	err = client.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(bob.ResourceTag()),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group.ResourceTag()),
+	})
+	c.Assert(err, qt.IsNil)
+
+	err = j.SetModelQuota(ctx, bob, "user-bob@canonical.com", &dbmodel.ModelQuota{
+		MaxModels: sql.NullInt64{Int64: 100, Valid: true},
+	})
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+
+	err = j.SetModelQuota(ctx, alice, "user-bob@canonical.com", &dbmodel.ModelQuota{
+		MaxModels: sql.NullInt64{Int64: -1, Valid: true},
+	})
+	c.Check(err, qt.ErrorMatches, `quota limits cannot be negative`)
+
+	err = j.SetModelQuota(ctx, alice, "user-bob@canonical.com", &dbmodel.ModelQuota{
+		CloudName: sql.NullString{String: "no-such-cloud", Valid: true},
+		MaxModels: sql.NullInt64{Int64: 1, Valid: true},
+	})
+	c.Check(err, qt.ErrorMatches, `cloud "no-such-cloud" not found`)
+
+	err = j.SetModelQuota(ctx, alice, "user-bob@canonical.com", &dbmodel.ModelQuota{
+		MaxModels:   sql.NullInt64{Int64: 5, Valid: true},
+		MaxMachines: sql.NullInt64{Int64: 10, Valid: true},
+	})
+	c.Assert(err, qt.IsNil)
+	err = j.SetModelQuota(ctx, alice, "group-test-group", &dbmodel.ModelQuota{
+		CloudName: sql.NullString{String: "test-cloud", Valid: true},
+		MaxModels: sql.NullInt64{Int64: 1, Valid: true},
+	})
+	c.Assert(err, qt.IsNil)
+
+	usages, err := j.ModelQuotas(ctx, bob, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(usages, qt.HasLen, 2)
+	c.Check(usages[0].Quota.IdentityName.String, qt.Equals, "bob@canonical.com")
+	c.Check(usages[0].Group, qt.IsNil)
+	c.Check(*usages[0].Usage, qt.DeepEquals, dbmodel.ModelUsage{Models: 1, Machines: 2, Cores: 4, Units: 3})
+	c.Check(usages[1].Quota.GroupUUID.String, qt.Equals, group.UUID)
+	c.Check(usages[1].Group.Name, qt.Equals, "test-group")
+	c.Check(*usages[1].Usage, qt.DeepEquals, dbmodel.ModelUsage{Models: 1, Machines: 2, Cores: 4, Units: 3})
+
+	_, err = j.ModelQuotas(ctx, bob, "user-alice@canonical.com")
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+
+	usages, err = j.ModelQuotas(ctx, alice, "group-test-group")
+	c.Assert(err, qt.IsNil)
+	c.Assert(usages, qt.HasLen, 1)
+	c.Check(usages[0].Quota.MaxModels.Int64, qt.Equals, int64(1))
+	c.Check(usages[0].Usage, qt.IsNil)
+
+	// bob already owns a model on test-cloud, so the group quota
+	// prevents another model being added.
+	args := jimm.ModelCreateArgs{}
+	err = args.FromJujuModelCreateArgs(&jujuparams.ModelCreateArgs{
+		Name:               "model-2",
+		OwnerTag:           names.NewUserTag("bob@canonical.com").String(),
+		CloudTag:           names.NewCloudTag("test-cloud").String(),
+		CloudRegion:        "test-region-1",
+		CloudCredentialTag: names.NewCloudCredentialTag("test-cloud/bob@canonical.com/test-credential-1").String(),
+	})
+	c.Assert(err, qt.IsNil)
+	_, err = j.AddModel(ctx, bob, &args)
+	c.Check(err, qt.ErrorMatches, `model quota exceeded: bob@canonical.com may own at most 1 models on cloud "test-cloud"`)
+
+	m := dbmodel.Model{
+		Name:              "model-2",
+		OwnerIdentityName: "bob@canonical.com",
+	}
+	err = j.Database.GetModel(ctx, &m)
+	c.Check(err, qt.ErrorMatches, "model not found")
+
+	err = j.RemoveModelQuota(ctx, alice, "group-test-group", "test-cloud")
+	c.Assert(err, qt.IsNil)
+	err = j.RemoveModelQuota(ctx, alice, "group-test-group", "test-cloud")
+	c.Check(err, qt.ErrorMatches, "model quota not found")
+}
@@ -0,0 +1,211 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+// ModelTemplateConfigKey is the reserved model config key used to create
+// a model from a model template. The value is the name of the template.
+// The key is never passed to the juju controller.
+const ModelTemplateConfigKey = "jimm-model-template"
+
+// AddModelTemplate adds the given model template. Only JIMM
+// administrators can add model templates.
+func (j *JIMM) AddModelTemplate(ctx context.Context, user *openfga.User, t *dbmodel.ModelTemplate) error {
+	const op = errors.Op("jimm.AddModelTemplate")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := j.validateModelTemplate(ctx, t); err != nil {
+		return errors.E(op, err)
+	}
+	if err := j.Database.AddModelTemplate(ctx, t); err != nil {
+		if errors.ErrorCode(err) == errors.CodeAlreadyExists {
+			return errors.E(op, err, fmt.Sprintf("model template %q already exists", t.Name))
+		}
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// UpdateModelTemplate replaces the model template with the same name as
+// the given template. Only JIMM administrators can update model
+// templates. Models previously created from the template are not
+// changed.
+func (j *JIMM) UpdateModelTemplate(ctx context.Context, user *openfga.User, t *dbmodel.ModelTemplate) error {
+	const op = errors.Op("jimm.UpdateModelTemplate")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := j.validateModelTemplate(ctx, t); err != nil {
+		return errors.E(op, err)
+	}
+	existing := dbmodel.ModelTemplate{Name: t.Name}
+	if err := j.Database.GetModelTemplate(ctx, &existing); err != nil {
+		return errors.E(op, err)
+	}
+	t.ID = existing.ID
+	t.CreatedAt = existing.CreatedAt
+	if err := j.Database.UpdateModelTemplate(ctx, t); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RemoveModelTemplate removes the named model template. Only JIMM
+// administrators can remove model templates.
+func (j *JIMM) RemoveModelTemplate(ctx context.Context, user *openfga.User, name string) error {
+	const op = errors.Op("jimm.RemoveModelTemplate")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := j.Database.RemoveModelTemplate(ctx, &dbmodel.ModelTemplate{Name: name}); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListModelTemplates returns all the model templates. Any user may list
+// the model templates so that they can choose one when adding a model.
+func (j *JIMM) ListModelTemplates(ctx context.Context, user *openfga.User) ([]dbmodel.ModelTemplate, error) {
+	const op = errors.Op("jimm.ListModelTemplates")
+
+	templates, err := j.Database.ListModelTemplates(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return templates, nil
+}
+
+// validateModelTemplate checks that the given model template is valid
+// and that the cloud, region and groups it refers to exist.
+func (j *JIMM) validateModelTemplate(ctx context.Context, t *dbmodel.ModelTemplate) error {
+	if !names.IsValidModelName(t.Name) {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid model template name %q", t.Name))
+	}
+
+	if t.CloudName.Valid {
+		cloud := dbmodel.Cloud{Name: t.CloudName.String}
+		if err := j.Database.GetCloud(ctx, &cloud); err != nil {
+			return err
+		}
+		if t.CloudRegion != "" && cloud.Region(t.CloudRegion).Name != t.CloudRegion {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("cloud %q has no region %q", cloud.Name, t.CloudRegion))
+		}
+	} else if t.CloudRegion != "" {
+		return errors.E(errors.CodeBadRequest, "region specified without a cloud")
+	}
+
+	for _, name := range t.CloudCredentials {
+		if !names.IsValidCloudCredentialName(name) {
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid cloud credential name %q", name))
+		}
+	}
+
+	if _, ok := t.Config[ModelTemplateConfigKey]; ok {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("template config cannot contain %s", ModelTemplateConfigKey))
+	}
+
+	for group, relation := range t.Access {
+		switch relation {
+		case string(ofganames.ReaderRelation), string(ofganames.WriterRelation):
+		default:
+			return errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid access %q for group %q, expected reader or writer", relation, group))
+		}
+		if err := j.Database.GetGroup(ctx, &dbmodel.GroupEntry{Name: group}); err != nil {
+			if errors.ErrorCode(err) == errors.CodeNotFound {
+				return errors.E(err, fmt.Sprintf("group %q not found", group))
+			}
+			return err
+		}
+	}
+
+	if len(t.Labels) > maxModelLabels {
+		return errors.E(errors.CodeBadRequest, fmt.Sprintf("a model cannot have more than %d labels", maxModelLabels))
+	}
+	for k, v := range t.Labels {
+		if err := validateLabel(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyModelTemplate returns the model template referenced by the
+// reserved ModelTemplateConfigKey in the given model creation arguments,
+// along with a copy of the arguments with the template's cloud and
+// region filled in where they were not specified. The reserved key is
+// removed from the config in the returned arguments. If no template is
+// referenced the arguments are returned unchanged with a nil template.
+func (j *JIMM) applyModelTemplate(ctx context.Context, args *ModelCreateArgs) (*ModelCreateArgs, *dbmodel.ModelTemplate, error) {
+	v, ok := args.Config[ModelTemplateConfigKey]
+	if !ok {
+		return args, nil, nil
+	}
+	name, ok := v.(string)
+	if !ok {
+		return nil, nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid %s value: expected a template name", ModelTemplateConfigKey))
+	}
+	t := dbmodel.ModelTemplate{Name: name}
+	if err := j.Database.GetModelTemplate(ctx, &t); err != nil {
+		return nil, nil, err
+	}
+
+	newArgs := *args
+	newArgs.Config = make(map[string]interface{}, len(args.Config))
+	for k, v := range args.Config {
+		if k != ModelTemplateConfigKey {
+			newArgs.Config[k] = v
+		}
+	}
+	if newArgs.Cloud == (names.CloudTag{}) && t.CloudName.Valid {
+		newArgs.Cloud = names.NewCloudTag(t.CloudName.String)
+	}
+	if newArgs.CloudRegion == "" && newArgs.Cloud.Id() == t.CloudName.String {
+		newArgs.CloudRegion = t.CloudRegion
+	}
+	return &newArgs, &t, nil
+}
+
+// grantModelTemplateAccess grants the members of the groups in the given
+// template access to the given model. Failures are logged, but do not
+// prevent the model from being created.
+func (j *JIMM) grantModelTemplateAccess(ctx context.Context, t *dbmodel.ModelTemplate, mt names.ModelTag) {
+	for group, relation := range t.Access {
+		g := dbmodel.GroupEntry{Name: group}
+		err := j.Database.GetGroup(ctx, &g)
+		if err == nil {
+			err = j.OpenFGAClient.AddRelation(ctx, openfga.Tuple{
+				Object:   ofganames.ConvertTagWithRelation(jimmnames.NewGroupTag(g.UUID), ofganames.MemberRelation),
+				Relation: openfga.Relation(relation),
+				Target:   ofganames.ConvertTag(mt),
+			})
+		}
+		if err != nil {
+			zapctx.Error(
+				ctx,
+				"failed to grant model template access",
+				zaputil.Error(err),
+				zap.String("group", group),
+				zap.String("model", mt.Id()),
+			)
+		}
+	}
+}
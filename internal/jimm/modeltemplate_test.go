@@ -0,0 +1,242 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+const testModelTemplateEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-region-1
+  - name: test-region-2
+  users:
+  - user: alice@canonical.com
+    access: add-model
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+- username: bob@canonical.com
+  controller-access: login
+cloud-credentials:
+- name: default
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+- name: production
+  owner: alice@canonical.com
+  cloud: test-cloud
+  auth-type: empty
+controllers:
+- name: controller-1
+  uuid: 00000000-0000-0000-0000-0000-0000000000001
+  cloud: test-cloud
+  region: test-region-1
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-region-1
+    priority: 1
+  - cloud: test-cloud
+    region: test-region-2
+    priority: 1
+`
+
+func TestModelTemplates(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testModelTemplateEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	bob := openfga.NewUser(&dbBob, client)
+
+	_, err = j.Database.AddGroup(ctx, "sre")
+	c.Assert(err, qt.IsNil)
+
+	template := dbmodel.ModelTemplate{
+		Name:        "production",
+		CloudName:   sql.NullString{String: "test-cloud", Valid: true},
+		CloudRegion: "test-region-2",
+		Access:      dbmodel.StringMap{"sre": "writer"},
+	}
+	err = j.AddModelTemplate(ctx, bob, &template)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	err = j.AddModelTemplate(ctx, alice, &template)
+	c.Assert(err, qt.IsNil)
+
+	err = j.AddModelTemplate(ctx, alice, &template)
+	c.Check(err, qt.ErrorMatches, `model template "production" already exists`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	template.Labels = dbmodel.StringMap{"env": "prod"}
+	err = j.UpdateModelTemplate(ctx, alice, &template)
+	c.Assert(err, qt.IsNil)
+
+	templates, err := j.ListModelTemplates(ctx, bob)
+	c.Assert(err, qt.IsNil)
+	c.Assert(templates, qt.HasLen, 1)
+	c.Check(templates[0].Labels, qt.DeepEquals, dbmodel.StringMap{"env": "prod"})
+
+	invalid := []struct {
+		template    dbmodel.ModelTemplate
+		expectError string
+	}{{
+		template:    dbmodel.ModelTemplate{Name: "Bad Name"},
+		expectError: `invalid model template name "Bad Name"`,
+	}, {
+		template:    dbmodel.ModelTemplate{Name: "t", CloudName: sql.NullString{String: "test-cloud", Valid: true}, CloudRegion: "no-such-region"},
+		expectError: `cloud "test-cloud" has no region "no-such-region"`,
+	}, {
+		template:    dbmodel.ModelTemplate{Name: "t", Access: dbmodel.StringMap{"no-such-group": "reader"}},
+		expectError: `group "no-such-group" not found`,
+	}, {
+		template:    dbmodel.ModelTemplate{Name: "t", Config: dbmodel.Map{jimm.ModelTemplateConfigKey: "t"}},
+		expectError: `template config cannot contain jimm-model-template`,
+	}}
+	for _, test := range invalid {
+		err := j.AddModelTemplate(ctx, alice, &test.template)
+		c.Check(err, qt.ErrorMatches, test.expectError)
+	}
+
+	err = j.RemoveModelTemplate(ctx, alice, "production")
+	c.Assert(err, qt.IsNil)
+	err = j.RemoveModelTemplate(ctx, alice, "production")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
+
+func TestAddModelWithTemplate(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var createArgs jujuparams.ModelCreateArgs
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				UpdateCredential_: func(context.Context, jujuparams.TaggedCredential) ([]jujuparams.UpdateCredentialModelResult, error) {
+					return nil, nil
+				},
+				GrantJIMMModelAdmin_: func(context.Context, names.ModelTag) error {
+					return nil
+				},
+				CreateModel_: func(ctx context.Context, args *jujuparams.ModelCreateArgs, mi *jujuparams.ModelInfo) error {
+					createArgs = *args
+					return createModel(`
+uuid: 00000001-0000-0000-0000-0000-000000000001
+status:
+  status: started
+life: alive
+`[1:])(ctx, args, mi)
+				},
+			},
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, testModelTemplateEnv)
+	env.PopulateDBAndPermissions(c, j.ResourceTag(), j.Database, client)
+
+	dbAlice := env.User("alice@canonical.com").DBObject(c, j.Database)
+	alice := openfga.NewUser(&dbAlice, client)
+	alice.JimmAdmin = true
+
+	group, err := j.Database.AddGroup(ctx, "sre")
+	c.Assert(err, qt.IsNil)
+	err = client.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("bob@canonical.com")),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(jimmnames.NewGroupTag(group.UUID)),
+	})
+	c.Assert(err, qt.IsNil)
+
+	err = j.AddModelTemplate(ctx, alice, &dbmodel.ModelTemplate{
+		Name:             "production",
+		CloudName:        sql.NullString{String: "test-cloud", Valid: true},
+		CloudRegion:      "test-region-2",
+		CloudCredentials: dbmodel.Strings{"no-such-credential", "production"},
+		Config:           dbmodel.Map{"key1": "template", "key2": "template"},
+		Access:           dbmodel.StringMap{"sre": "writer"},
+		Labels:           dbmodel.StringMap{"env": "prod"},
+	})
+	c.Assert(err, qt.IsNil)
+
+	_, err = j.AddModel(ctx, alice, &jimm.ModelCreateArgs{
+		Name:  "model-1",
+		Owner: names.NewUserTag("alice@canonical.com"),
+		Config: map[string]interface{}{
+			jimm.ModelTemplateConfigKey: "no-such-template",
+		},
+	})
+	c.Check(err, qt.ErrorMatches, `model template not found`)
+
+	_, err = j.AddModel(ctx, alice, &jimm.ModelCreateArgs{
+		Name:  "model-1",
+		Owner: names.NewUserTag("alice@canonical.com"),
+		Config: map[string]interface{}{
+			jimm.ModelTemplateConfigKey: "production",
+			"key2":                      "user",
+		},
+	})
+	c.Assert(err, qt.IsNil)
+
+	c.Check(createArgs.CloudTag, qt.Equals, names.NewCloudTag("test-cloud").String())
+	c.Check(createArgs.CloudRegion, qt.Equals, "test-region-2")
+	c.Check(createArgs.CloudCredentialTag, qt.Equals, names.NewCloudCredentialTag("test-cloud/alice@canonical.com/production").String())
+	c.Check(createArgs.Config, qt.DeepEquals, map[string]interface{}{"key1": "template", "key2": "user"})
+
+	mt := names.NewModelTag("00000001-0000-0000-0000-0000-000000000001")
+	m := dbmodel.Model{}
+	m.SetTag(mt)
+	err = j.Database.GetModel(ctx, &m)
+	c.Assert(err, qt.IsNil)
+	c.Check(m.Labels, qt.DeepEquals, dbmodel.StringMap{"env": "prod"})
+	c.Check(m.ConfigSources, qt.DeepEquals, dbmodel.StringMap{"key1": jimm.TemplateConfigSource, "key2": jimm.ExplicitConfigSource})
+
+	dbBob := env.User("bob@canonical.com").DBObject(c, j.Database)
+	c.Check(openfga.NewUser(&dbBob, client).GetModelAccess(ctx, mt), qt.Equals, ofganames.WriterRelation)
+}
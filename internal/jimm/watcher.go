@@ -16,6 +16,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
 	"github.com/canonical/jimm/v3/internal/servermon"
 )
 
@@ -25,6 +26,14 @@ type Publisher interface {
 	Publish(model string, content interface{}) <-chan struct{}
 }
 
+// A SoftQuotaChecker checks the resources used by models against the
+// soft limits of model quotas.
+type SoftQuotaChecker interface {
+	// CheckSoftQuotas is called after the resources used by the given
+	// model have changed from those in previous.
+	CheckSoftQuotas(ctx context.Context, m *dbmodel.Model, previous dbmodel.ModelUsage)
+}
+
 // A Watcher watches juju controllers for changes to all models.
 type Watcher struct {
 	// Database is the database used by the Watcher.
@@ -38,6 +47,14 @@ type Watcher struct {
 	// model summaries.
 	Pubsub Publisher
 
+	// SoftQuotaChecker, if configured, is notified whenever the
+	// resources used by a model change.
+	SoftQuotaChecker SoftQuotaChecker
+
+	// OpenFGAClient, if configured, is used to record and remove the
+	// OpenFGA tuples that refer to models that are removed from JIMM.
+	OpenFGAClient *openfga.OFGAClient
+
 	controllerUnavailableChan chan error
 	deltaProcessedChan        chan bool
 }
@@ -255,12 +272,25 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 			}
 			if err := api.ModelInfo(ctx, &mi); err != nil {
 				// Some versions of juju return unauthorized for models that cannot be found.
-				if errors.ErrorCode(err) == errors.CodeNotFound || errors.ErrorCode(err) == errors.CodeUnauthorized {
-					if err := w.Database.DeleteModel(ctx, m); err != nil {
+				code := errors.ErrorCode(err)
+				if code == errors.CodeNotFound || code == errors.CodeUnauthorized {
+					tuples, err := w.readModelTuples(ctx, m)
+					if err != nil {
 						return errors.E(op, err)
-					} else {
-						return nil
 					}
+					err = w.Database.Transaction(func(tx *db.Database) error {
+						return w.removeModel(ctx, tx, m, tuples)
+					})
+					if err != nil {
+						return errors.E(op, err)
+					}
+					// Unauthorized may also be caused by a problem with
+					// JIMM's access to the controller, the model's tuples
+					// are only removed when the model is known to be gone.
+					if code == errors.CodeNotFound {
+						w.removeModelTuples(ctx, m)
+					}
+					return nil
 				} else {
 					return errors.E(op, err)
 				}
@@ -331,13 +361,19 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 			if v.changed {
 				v.changed = false
 				// Update changed model.
+				m := dbmodel.Model{
+					ID: v.id,
+				}
+				var previous dbmodel.ModelUsage
 				err := w.Database.Transaction(func(tx *db.Database) error {
-					m := dbmodel.Model{
-						ID: v.id,
-					}
 					if err := tx.GetModel(ctx, &m); err != nil {
 						return err
 					}
+					previous = dbmodel.ModelUsage{
+						Machines: m.Machines,
+						Cores:    m.Cores,
+						Units:    m.Units,
+					}
 					var machines, cores int64
 					for _, n := range v.machines {
 						machines++
@@ -355,6 +391,9 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 					zapctx.Error(ctx, "cannot get model for update", zap.Error(err))
 					continue
 				}
+				if w.SoftQuotaChecker != nil {
+					w.SoftQuotaChecker.CheckSoftQuotas(ctx, &m, previous)
+				}
 			}
 		}
 	}
@@ -505,7 +544,12 @@ func (w *Watcher) handleDelta(ctx context.Context, modelIDf func(string) *modelS
 func (w *Watcher) deleteModel(ctx context.Context, model *dbmodel.Model) error {
 	const op = errors.Op("watcher.deleteModel")
 
-	err := w.Database.Transaction(func(db *db.Database) error {
+	tuples, err := w.readModelTuples(ctx, model)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	removed := false
+	err = w.Database.Transaction(func(db *db.Database) error {
 		if err := db.GetModel(ctx, model); err != nil {
 			if errors.ErrorCode(err) != errors.CodeNotFound {
 				return err
@@ -515,14 +559,61 @@ func (w *Watcher) deleteModel(ctx context.Context, model *dbmodel.Model) error {
 			// If the model hasn't been marked as dying, don't remove it.
 			return nil
 		}
-		return db.DeleteModel(ctx, model)
+		removed = true
+		return w.removeModel(ctx, db, model, tuples)
 	})
 	if err != nil {
 		return errors.E(op, err)
 	}
+	if removed {
+		w.removeModelTuples(ctx, model)
+	}
 	return nil
 }
 
+// readModelTuples reads the OpenFGA tuples that refer to the given model
+// so that they can be recorded in the model's tombstone. The tuples are
+// read before the tombstone's transaction is started so that no OpenFGA
+// calls are made while it is open.
+func (w *Watcher) readModelTuples(ctx context.Context, m *dbmodel.Model) (dbmodel.DeletedModelTuples, error) {
+	if w.OpenFGAClient == nil {
+		return nil, nil
+	}
+	return readModelTuples(ctx, w.OpenFGAClient, m.ResourceTag())
+}
+
+// removeModel records a tombstone for the given model, including the
+// given OpenFGA tuples that refer to it, and removes the model from the
+// given database.
+func (w *Watcher) removeModel(ctx context.Context, tx *db.Database, m *dbmodel.Model, tuples dbmodel.DeletedModelTuples) error {
+	// Models listed from a controller are loaded without their
+	// associations, reload the model so that the tombstone is complete.
+	if err := tx.GetModel(ctx, m); err != nil {
+		return err
+	}
+	dm := dbmodel.DeletedModel{
+		DeletedAt: time.Now().UTC(),
+		Tuples:    tuples,
+	}
+	dm.FromModel(m)
+	if err := tx.AddDeletedModel(ctx, &dm); err != nil {
+		return err
+	}
+	return tx.DeleteModel(ctx, m)
+}
+
+// removeModelTuples removes the OpenFGA tuples that refer to the given
+// model. Failures are logged, the tuples remain recorded in the model's
+// tombstone.
+func (w *Watcher) removeModelTuples(ctx context.Context, m *dbmodel.Model) {
+	if w.OpenFGAClient == nil {
+		return
+	}
+	if err := w.OpenFGAClient.RemoveModel(ctx, m.ResourceTag()); err != nil {
+		zapctx.Error(ctx, "failed to remove model tuples", zap.String("model", m.UUID.String), zap.Error(err))
+	}
+}
+
 func (w *Watcher) updateModel(ctx context.Context, model *dbmodel.Model, info *jujuparams.ModelUpdate) error {
 	const op = errors.Op("watcher.updateModel")
 
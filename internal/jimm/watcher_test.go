@@ -22,6 +22,8 @@ import (
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 )
 
 const testWatcherEnv = `clouds:
@@ -362,12 +364,12 @@ var watcherTests = []struct {
 				Status: jujuparams.StatusInfo{
 					Current: "available",
 					Message: "updated status message",
-					Version: "1.2.3",
 				},
 				SLA: jujuparams.ModelSLAInfo{
 					Level: "1",
 					Owner: "me",
 				},
+				Version: "1.2.3",
 			},
 		}},
 		nil,
@@ -409,6 +411,51 @@ var watcherTests = []struct {
 			},
 		})
 	},
+}, {
+	name: "UpdateModelKeepsVersion",
+	deltas: [][]jujuparams.Delta{
+		{{
+			Entity: &jujuparams.ModelUpdate{
+				ModelUUID:      "00000002-0000-0000-0000-000000000001",
+				Name:           "model-1",
+				Owner:          "alice@canonical.com",
+				Life:           life.Value(state.Alive.String()),
+				ControllerUUID: "00000001-0000-0000-0000-000000000001",
+				Status: jujuparams.StatusInfo{
+					Current: "available",
+				},
+				Version: "3.5.1",
+			},
+		}},
+		{{
+			Entity: &jujuparams.ModelUpdate{
+				ModelUUID:      "00000002-0000-0000-0000-000000000001",
+				Name:           "model-1",
+				Owner:          "alice@canonical.com",
+				Life:           life.Value(state.Alive.String()),
+				ControllerUUID: "00000001-0000-0000-0000-000000000001",
+				Status: jujuparams.StatusInfo{
+					Current: "busy",
+					Message: "upgrading",
+				},
+			},
+		}},
+		nil,
+	},
+	checkDB: func(c *qt.C, db db.Database) {
+		ctx := context.Background()
+
+		model := dbmodel.Model{
+			UUID: sql.NullString{
+				String: "00000002-0000-0000-0000-000000000001",
+				Valid:  true,
+			},
+		}
+		err := db.GetModel(ctx, &model)
+		c.Assert(err, qt.IsNil)
+		c.Check(model.Status.Status, qt.Equals, "busy")
+		c.Check(model.Status.Version, qt.Equals, "3.5.1")
+	},
 }, {
 	name: "DeleteDyingModel",
 	deltas: [][]jujuparams.Delta{
@@ -834,6 +881,7 @@ func TestWatcherClearsControllerUnavailable(t *testing.T) {
 						c.Errorf("unexpected model uuid: %s", info.UUID)
 					case "00000002-0000-0000-0000-000000000002":
 					case "00000002-0000-0000-0000-000000000003":
+						return errors.E(errors.CodeUnauthorized)
 					}
 					return errors.E(errors.CodeNotFound)
 				},
@@ -888,6 +936,9 @@ func TestWatcherRemoveDyingModelsOnStartup(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
 	w := &jimm.Watcher{
 		Pubsub: &testPublisher{},
 		Database: db.Database{
@@ -906,6 +957,7 @@ func TestWatcherRemoveDyingModelsOnStartup(t *testing.T) {
 						c.Errorf("unexpected model uuid: %s", info.UUID)
 					case "00000002-0000-0000-0000-000000000002":
 					case "00000002-0000-0000-0000-000000000003":
+						return errors.E(errors.CodeUnauthorized)
 					}
 					return errors.E(errors.CodeNotFound)
 				},
@@ -914,12 +966,33 @@ func TestWatcherRemoveDyingModelsOnStartup(t *testing.T) {
 				},
 			},
 		},
+		OpenFGAClient: client,
 	}
 	env := jimmtest.ParseEnvironment(c, testWatcherEnv)
-	err := w.Database.Migrate(ctx, false)
+	err = w.Database.Migrate(ctx, false)
 	c.Assert(err, qt.IsNil)
 	env.PopulateDB(c, w.Database)
 
+	// The watcher removes the OpenFGA tuples of the models it removes.
+	mt := names.NewModelTag("00000002-0000-0000-0000-000000000002")
+	readerTuple := openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("bob@canonical.com")),
+		Relation: ofganames.ReaderRelation,
+		Target:   ofganames.ConvertTag(mt),
+	}
+	err = client.AddRelation(ctx, readerTuple)
+	c.Assert(err, qt.IsNil)
+
+	// Models that the controller reports as unauthorized are removed,
+	// but their tuples are kept.
+	unauthorizedTuple := openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("bob@canonical.com")),
+		Relation: ofganames.ReaderRelation,
+		Target:   ofganames.ConvertTag(names.NewModelTag("00000002-0000-0000-0000-000000000003")),
+	}
+	err = client.AddRelation(ctx, unauthorizedTuple)
+	c.Assert(err, qt.IsNil)
+
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -937,6 +1010,38 @@ func TestWatcherRemoveDyingModelsOnStartup(t *testing.T) {
 	}
 	err = w.Database.GetModel(context.Background(), &m)
 	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	deleted, err := w.Database.ListDeletedModels(context.Background(), "00000002-0000-0000-0000-000000000002")
+	c.Assert(err, qt.IsNil)
+	c.Assert(deleted, qt.HasLen, 1)
+	c.Check(deleted[0].Name, qt.Equals, "model-2")
+	c.Check(deleted[0].OwnerIdentityName, qt.Equals, "alice@canonical.com")
+	c.Check(deleted[0].ControllerName, qt.Equals, "controller-1")
+	c.Check(deleted[0].CloudName, qt.Equals, "test-cloud")
+	c.Check(deleted[0].CloudRegionName, qt.Equals, "test-cloud-region")
+	c.Check(deleted[0].CloudCredentialName, qt.Equals, "cred-1")
+	c.Check(deleted[0].Tuples, qt.DeepEquals, dbmodel.DeletedModelTuples{{
+		Object:   "user:bob@canonical.com",
+		Relation: "reader",
+		Target:   "model:" + mt.Id(),
+	}})
+
+	allowed, err := client.CheckRelation(context.Background(), readerTuple, false)
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsFalse)
+
+	m = dbmodel.Model{
+		UUID: sql.NullString{
+			String: "00000002-0000-0000-0000-000000000003",
+			Valid:  true,
+		},
+	}
+	err = w.Database.GetModel(context.Background(), &m)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	allowed, err = client.CheckRelation(context.Background(), unauthorizedTuple, false)
+	c.Assert(err, qt.IsNil)
+	c.Check(allowed, qt.IsTrue)
 }
 
 const testWatcherIgnoreDeltasForModelsFromIncorrectControllerEnv = `clouds:
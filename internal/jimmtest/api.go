@@ -46,6 +46,11 @@ type Dialer struct {
 	// Addresses contains the addresses to set on the controller.
 	Addresses [][]jujuparams.HostPort
 
+	// CheckPasswordLogin_ is called to implement
+	// jimm.PasswordLoginChecker. If this is nil CheckPasswordLogin
+	// returns a NotImplemented error.
+	CheckPasswordLogin_ func(ctx context.Context, ctl *dbmodel.Controller, username, password string) error
+
 	open int64
 }
 
@@ -70,6 +75,14 @@ func (d *Dialer) Dial(_ context.Context, ctl *dbmodel.Controller, _ names.ModelT
 	}, nil
 }
 
+// CheckPasswordLogin implements jimm.PasswordLoginChecker.
+func (d *Dialer) CheckPasswordLogin(ctx context.Context, ctl *dbmodel.Controller, username, password string) error {
+	if d.CheckPasswordLogin_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return d.CheckPasswordLogin_(ctx, ctl, username, password)
+}
+
 // IsClosed returns true if all opened connections have been closed.
 func (d *Dialer) IsClosed() bool {
 	return atomic.LoadInt64(&d.open) == 0
@@ -142,7 +155,9 @@ type API struct {
 	GrantModelAccess_                  func(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error
 	IsBroken_                          bool
 	ListApplicationOffers_             func(context.Context, []jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
+	ModelGet_                          func(context.Context) (map[string]jujuparams.ConfigValue, error)
 	ModelInfo_                         func(context.Context, *jujuparams.ModelInfo) error
+	ModelSet_                          func(context.Context, map[string]interface{}) error
 	ModelStatus_                       func(context.Context, *jujuparams.ModelStatus) error
 	ModelSummaryWatcherNext_           func(context.Context, string) ([]jujuparams.ModelAbstract, error)
 	ModelSummaryWatcherStop_           func(context.Context, string) error
@@ -155,6 +170,7 @@ type API struct {
 	RevokeCloudAccess_                 func(context.Context, names.CloudTag, names.UserTag, string) error
 	RevokeCredential_                  func(context.Context, names.CloudCredentialTag) error
 	RevokeModelAccess_                 func(context.Context, names.ModelTag, names.UserTag, jujuparams.UserAccessPermission) error
+	SetPassword_                       func(context.Context, names.UserTag, string) error
 	SupportsCheckCredentialModels_     bool
 	SupportsModelSummaryWatcher_       bool
 	Status_                            func(context.Context, []string) (*jujuparams.FullStatus, error)
@@ -327,6 +343,13 @@ func (a *API) ListApplicationOffers(ctx context.Context, f []jujuparams.OfferFil
 	return a.ListApplicationOffers_(ctx, f)
 }
 
+func (a *API) ModelGet(ctx context.Context) (map[string]jujuparams.ConfigValue, error) {
+	if a.ModelGet_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return a.ModelGet_(ctx)
+}
+
 func (a *API) ModelInfo(ctx context.Context, mi *jujuparams.ModelInfo) error {
 	if a.ModelInfo_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -334,6 +357,13 @@ func (a *API) ModelInfo(ctx context.Context, mi *jujuparams.ModelInfo) error {
 	return a.ModelInfo_(ctx, mi)
 }
 
+func (a *API) ModelSet(ctx context.Context, config map[string]interface{}) error {
+	if a.ModelSet_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return a.ModelSet_(ctx, config)
+}
+
 func (a *API) ModelStatus(ctx context.Context, ms *jujuparams.ModelStatus) error {
 	if a.ModelStatus_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -404,6 +434,13 @@ func (a *API) RevokeModelAccess(ctx context.Context, mt names.ModelTag, ut names
 	return a.RevokeModelAccess_(ctx, mt, ut, p)
 }
 
+func (a *API) SetPassword(ctx context.Context, user names.UserTag, password string) error {
+	if a.SetPassword_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return a.SetPassword_(ctx, user, password)
+}
+
 func (a *API) SupportsCheckCredentialModels() bool {
 	return a.SupportsCheckCredentialModels_
 }
@@ -32,18 +32,27 @@ type JIMM struct {
 	mocks.LoginService
 	mocks.ModelManager
 	AddAuditLogEntry_                  func(ale *dbmodel.AuditLogEntry)
+	AddCatalogueCloud_                 func(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud) error
 	AddCloudToController_              func(ctx context.Context, user *openfga.User, controllerName string, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error
 	AddController_                     func(ctx context.Context, u *openfga.User, ctl *dbmodel.Controller) error
 	AddGroup_                          func(ctx context.Context, user *openfga.User, name string) (*dbmodel.GroupEntry, error)
+	AddMigrationPlan_                  func(ctx context.Context, user *openfga.User, params jimm.MigrationPlanParams) (*dbmodel.MigrationPlan, error)
+	AddModelTemplate_                  func(ctx context.Context, user *openfga.User, t *dbmodel.ModelTemplate) error
 	AddHostedCloud_                    func(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error
 	AddServiceAccount_                 func(ctx context.Context, u *openfga.User, clientId string) error
 	Authenticate_                      func(ctx context.Context, req *jujuparams.LoginRequest) (*openfga.User, error)
+	AttachCloudToController_           func(ctx context.Context, user *openfga.User, tag names.CloudTag, controllerName string, force bool) error
 	AuthorizationClient_               func() *openfga.OFGAClient
+	BulkModelAccess_                   func(ctx context.Context, user *openfga.User, p jimm.BulkModelAccessParams) (*jimm.BulkModelAccessResult, error)
+	CancelMigrationPlan_               func(ctx context.Context, user *openfga.User, name string) error
 	CheckPermission_                   func(ctx context.Context, user *openfga.User, cachedPerms map[string]string, desiredPerms map[string]interface{}) (map[string]string, error)
+	ControllerVersions_                func(ctx context.Context, user *openfga.User, controllerName string, validateUpgrades bool) ([]jimm.ControllerVersionReport, error)
 	CopyServiceAccountCredential_      func(ctx context.Context, u *openfga.User, svcAcc *openfga.User, cloudCredentialTag names.CloudCredentialTag) (names.CloudCredentialTag, []jujuparams.UpdateCredentialModelResult, error)
 	DB_                                func() *db.Database
 	DestroyOffer_                      func(ctx context.Context, user *openfga.User, offerURL string, force bool) error
 	EarliestControllerVersion_         func(ctx context.Context) (version.Number, error)
+	EffectiveModelConfig_              func(ctx context.Context, user *openfga.User, args *jimm.ModelCreateArgs) (map[string]jimm.ModelDefault, error)
+	ExtendModel_                       func(ctx context.Context, user *openfga.User, mt names.ModelTag, d time.Duration) (time.Time, error)
 	FindApplicationOffers_             func(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
 	FindAuditEvents_                   func(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error)
 	ForEachCloud_                      func(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) error
@@ -52,26 +61,41 @@ type JIMM struct {
 	GetApplicationOffer_               func(ctx context.Context, user *openfga.User, offerURL string) (*jujuparams.ApplicationOfferAdminDetailsV5, error)
 	GetApplicationOfferConsumeDetails_ func(ctx context.Context, user *openfga.User, details *jujuparams.ConsumeOfferDetails, v bakery.Version) error
 	GetCloud_                          func(ctx context.Context, u *openfga.User, tag names.CloudTag) (dbmodel.Cloud, error)
+	GetCloudControllerStatuses_        func(ctx context.Context, user *openfga.User, tag names.CloudTag) ([]dbmodel.CloudControllerStatus, error)
 	GetCloudCredential_                func(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (*dbmodel.CloudCredential, error)
 	GetCloudCredentialAttributes_      func(ctx context.Context, u *openfga.User, cred *dbmodel.CloudCredential, hidden bool) (attrs map[string]string, redacted []string, err error)
+	GetCloudCredentialRotation_        func(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (*dbmodel.CloudCredentialRotation, error)
 	GetControllerConfig_               func(ctx context.Context, u *dbmodel.Identity) (*dbmodel.ControllerConfig, error)
 	GetCredentialStore_                func() jimmcreds.CredentialStore
 	GetJimmControllerAccess_           func(ctx context.Context, user *openfga.User, tag names.UserTag) (string, error)
+	GetMigrationPlan_                  func(ctx context.Context, user *openfga.User, name string) (*dbmodel.MigrationPlan, error)
 	GetUserCloudAccess_                func(ctx context.Context, user *openfga.User, cloud names.CloudTag) (string, error)
 	GetUserControllerAccess_           func(ctx context.Context, user *openfga.User, controller names.ControllerTag) (string, error)
 	GetUserModelAccess_                func(ctx context.Context, user *openfga.User, model names.ModelTag) (string, error)
 	GrantAuditLogAccess_               func(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error
 	GrantCloudAccess_                  func(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error
+	GrantCloudRegionAccess_            func(ctx context.Context, user *openfga.User, rt jimmnames.CloudRegionTag, ut names.UserTag, access string) error
 	GrantModelAccess_                  func(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error
 	GrantOfferAccess_                  func(ctx context.Context, u *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) error
 	GrantServiceAccountAccess_         func(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, entities []string) error
+	GroupModelDefaults_                func(ctx context.Context, user *openfga.User, groupName string) (dbmodel.GroupModelDefaults, error)
 	InitiateMigration_                 func(ctx context.Context, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error)
 	InitiateInternalMigration_         func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetController string) (jujuparams.InitiateMigrationResult, error)
 	ListApplicationOffers_             func(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
 	ListControllers_                   func(ctx context.Context, user *openfga.User) ([]dbmodel.Controller, error)
+	ListDeletedModels_                 func(ctx context.Context, user *openfga.User, uuid string) ([]dbmodel.DeletedModel, error)
 	ListGroups_                        func(ctx context.Context, user *openfga.User) ([]dbmodel.GroupEntry, error)
+	ListCloudCredentials_              func(ctx context.Context, user *openfga.User, p jimm.ListCloudCredentialsParams) ([]dbmodel.CloudCredential, error)
+	ListCloudCredentialVersions_       func(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) ([]jimmcreds.CredentialVersion, error)
+	ListMigrationPlans_                func(ctx context.Context, user *openfga.User) ([]dbmodel.MigrationPlan, error)
+	ListModelTemplates_                func(ctx context.Context, user *openfga.User) ([]dbmodel.ModelTemplate, error)
+	ListUserModels_                    func(ctx context.Context, user *openfga.User, filter jimm.ModelListFilter, pageSize int, continuationToken string) ([]jimm.UserModel, string, error)
+	ModelDrift_                        func(ctx context.Context, user *openfga.User, p jimm.ModelDriftParams) ([]jimm.ModelDrift, error)
+	ModelLabels_                       func(ctx context.Context, user *openfga.User, mt names.ModelTag) (map[string]string, error)
+	ModelQuotas_                       func(ctx context.Context, user *openfga.User, entity string) ([]jimm.ModelQuotaUsage, error)
 	Offer_                             func(ctx context.Context, user *openfga.User, offer jimm.AddApplicationOfferParams) error
 	OAuthAuthenticationService_        func() jimm.OAuthAuthenticator
+	PauseMigrationPlan_                func(ctx context.Context, user *openfga.User, name string) error
 	ParseTag_                          func(ctx context.Context, key string) (*ofganames.Tag, error)
 	PubSubHub_                         func() *pubsub.Hub
 	PurgeLogs_                         func(ctx context.Context, user *openfga.User, before time.Time) (int64, error)
@@ -79,20 +103,35 @@ type JIMM struct {
 	RemoveCloudFromController_         func(ctx context.Context, u *openfga.User, controllerName string, ct names.CloudTag) error
 	RemoveController_                  func(ctx context.Context, user *openfga.User, controllerName string, force bool) error
 	RemoveGroup_                       func(ctx context.Context, user *openfga.User, name string) error
+	RemoveModelQuota_                  func(ctx context.Context, user *openfga.User, entity, cloudName string) error
+	RemoveModelTemplate_               func(ctx context.Context, user *openfga.User, name string) error
 	RenameGroup_                       func(ctx context.Context, user *openfga.User, oldName, newName string) error
+	RestoreCloudCredentialVersion_     func(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, version int) ([]jujuparams.UpdateCredentialModelResult, error)
+	ResumeMigrationPlan_               func(ctx context.Context, user *openfga.User, name string) error
 	ResourceTag_                       func() names.ControllerTag
 	RevokeAuditLogAccess_              func(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error
 	RevokeCloudAccess_                 func(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error
 	RevokeCloudCredential_             func(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error
+	RevokeCloudRegionAccess_           func(ctx context.Context, user *openfga.User, rt jimmnames.CloudRegionTag, ut names.UserTag, access string) error
 	RevokeModelAccess_                 func(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error
 	RevokeOfferAccess_                 func(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) (err error)
+	RotateCloudCredential_             func(ctx context.Context, user *openfga.User, args jimm.RotateCloudCredentialArgs) (*dbmodel.CloudCredentialRotation, error)
+	RotateControllerCredentials_       func(ctx context.Context, user *openfga.User, controllerName string) error
+	SetCloudCredentialGroup_           func(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, group string) error
 	SetControllerConfig_               func(ctx context.Context, u *openfga.User, args jujuparams.ControllerConfigSet) error
 	SetControllerDeprecated_           func(ctx context.Context, user *openfga.User, controllerName string, deprecated bool) error
+	SetGroupModelDefaults_             func(ctx context.Context, user *openfga.User, groupName string, priority *int, configs map[string]interface{}) error
+	SetModelLabels_                    func(ctx context.Context, user *openfga.User, mt names.ModelTag, labels map[string]string, remove []string) (map[string]string, error)
+	SetModelQuota_                     func(ctx context.Context, user *openfga.User, entity string, quota *dbmodel.ModelQuota) error
 	SetIdentityModelDefaults_          func(ctx context.Context, user *dbmodel.Identity, configs map[string]interface{}) error
 	ToJAASTag_                         func(ctx context.Context, tag *ofganames.Tag, resolveUUIDs bool) (string, error)
+	TransferModelOwnership_            func(ctx context.Context, user *openfga.User, mt names.ModelTag, newOwner names.UserTag, keepAccess bool) error
+	UnsetGroupModelDefaults_           func(ctx context.Context, user *openfga.User, groupName string, keys []string) error
 	UpdateApplicationOffer_            func(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) error
+	UpdateCatalogueCloud_              func(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud) ([]dbmodel.CloudControllerStatus, error)
 	UpdateCloud_                       func(ctx context.Context, u *openfga.User, ct names.CloudTag, cloud jujuparams.Cloud) error
 	UpdateCloudCredential_             func(ctx context.Context, u *openfga.User, args jimm.UpdateCloudCredentialArgs) ([]jujuparams.UpdateCredentialModelResult, error)
+	UpdateModelTemplate_               func(ctx context.Context, user *openfga.User, t *dbmodel.ModelTemplate) error
 	UserLogin_                         func(ctx context.Context, identityName string) (*openfga.User, error)
 }
 
@@ -102,6 +141,12 @@ func (j *JIMM) AddAuditLogEntry(ale *dbmodel.AuditLogEntry) {
 	}
 	j.AddAuditLogEntry(ale)
 }
+func (j *JIMM) AddCatalogueCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud) error {
+	if j.AddCatalogueCloud_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.AddCatalogueCloud_(ctx, user, tag, cloud)
+}
 func (j *JIMM) AddCloudToController(ctx context.Context, user *openfga.User, controllerName string, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error {
 	if j.AddCloudToController_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -120,6 +165,18 @@ func (j *JIMM) AddGroup(ctx context.Context, u *openfga.User, name string) (*dbm
 	}
 	return j.AddGroup_(ctx, u, name)
 }
+func (j *JIMM) AddMigrationPlan(ctx context.Context, user *openfga.User, params jimm.MigrationPlanParams) (*dbmodel.MigrationPlan, error) {
+	if j.AddMigrationPlan_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.AddMigrationPlan_(ctx, user, params)
+}
+func (j *JIMM) AddModelTemplate(ctx context.Context, user *openfga.User, t *dbmodel.ModelTemplate) error {
+	if j.AddModelTemplate_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.AddModelTemplate_(ctx, user, t)
+}
 func (j *JIMM) AddHostedCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error {
 	if j.AddHostedCloud_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -134,6 +191,25 @@ func (j *JIMM) AddServiceAccount(ctx context.Context, u *openfga.User, clientId
 	return j.AddServiceAccount_(ctx, u, clientId)
 }
 
+func (j *JIMM) BulkModelAccess(ctx context.Context, user *openfga.User, p jimm.BulkModelAccessParams) (*jimm.BulkModelAccessResult, error) {
+	if j.BulkModelAccess_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.BulkModelAccess_(ctx, user, p)
+}
+func (j *JIMM) CancelMigrationPlan(ctx context.Context, user *openfga.User, name string) error {
+	if j.CancelMigrationPlan_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.CancelMigrationPlan_(ctx, user, name)
+}
+func (j *JIMM) ControllerVersions(ctx context.Context, user *openfga.User, controllerName string, validateUpgrades bool) ([]jimm.ControllerVersionReport, error) {
+	if j.ControllerVersions_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ControllerVersions_(ctx, user, controllerName, validateUpgrades)
+}
+
 func (j *JIMM) CopyServiceAccountCredential(ctx context.Context, u *openfga.User, svcAcc *openfga.User, cloudCredentialTag names.CloudCredentialTag) (names.CloudCredentialTag, []jujuparams.UpdateCredentialModelResult, error) {
 	if j.CopyServiceAccountCredential_ == nil {
 		return names.CloudCredentialTag{}, nil, errors.E(errors.CodeNotImplemented)
@@ -147,6 +223,12 @@ func (j *JIMM) Authenticate(ctx context.Context, req *jujuparams.LoginRequest) (
 	}
 	return j.Authenticate_(ctx, req)
 }
+func (j *JIMM) AttachCloudToController(ctx context.Context, user *openfga.User, tag names.CloudTag, controllerName string, force bool) error {
+	if j.AttachCloudToController_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.AttachCloudToController_(ctx, user, tag, controllerName, force)
+}
 func (j *JIMM) AuthorizationClient() *openfga.OFGAClient {
 	if j.AuthorizationClient_ == nil {
 		return nil
@@ -179,6 +261,18 @@ func (j *JIMM) EarliestControllerVersion(ctx context.Context) (version.Number, e
 	}
 	return j.EarliestControllerVersion_(ctx)
 }
+func (j *JIMM) EffectiveModelConfig(ctx context.Context, user *openfga.User, args *jimm.ModelCreateArgs) (map[string]jimm.ModelDefault, error) {
+	if j.EffectiveModelConfig_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.EffectiveModelConfig_(ctx, user, args)
+}
+func (j *JIMM) ExtendModel(ctx context.Context, user *openfga.User, mt names.ModelTag, d time.Duration) (time.Time, error) {
+	if j.ExtendModel_ == nil {
+		return time.Time{}, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ExtendModel_(ctx, user, mt, d)
+}
 func (j *JIMM) FindApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error) {
 	if j.FindApplicationOffers_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
@@ -229,6 +323,12 @@ func (j *JIMM) GetCloud(ctx context.Context, u *openfga.User, tag names.CloudTag
 	}
 	return j.GetCloud_(ctx, u, tag)
 }
+func (j *JIMM) GetCloudControllerStatuses(ctx context.Context, user *openfga.User, tag names.CloudTag) ([]dbmodel.CloudControllerStatus, error) {
+	if j.GetCloudControllerStatuses_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.GetCloudControllerStatuses_(ctx, user, tag)
+}
 func (j *JIMM) GetCloudCredential(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (*dbmodel.CloudCredential, error) {
 	if j.GetCloudCredential_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
@@ -241,6 +341,13 @@ func (j *JIMM) GetCloudCredentialAttributes(ctx context.Context, u *openfga.User
 	}
 	return j.GetCloudCredentialAttributes_(ctx, u, cred, hidden)
 }
+func (j *JIMM) GetCloudCredentialRotation(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (*dbmodel.CloudCredentialRotation, error) {
+	if j.GetCloudCredentialRotation_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.GetCloudCredentialRotation_(ctx, user, tag)
+}
+
 func (j *JIMM) GetControllerConfig(ctx context.Context, u *dbmodel.Identity) (*dbmodel.ControllerConfig, error) {
 	if j.GetControllerConfig_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
@@ -259,6 +366,12 @@ func (j *JIMM) GetJimmControllerAccess(ctx context.Context, user *openfga.User,
 	}
 	return j.GetJimmControllerAccess_(ctx, user, tag)
 }
+func (j *JIMM) GetMigrationPlan(ctx context.Context, user *openfga.User, name string) (*dbmodel.MigrationPlan, error) {
+	if j.GetMigrationPlan_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.GetMigrationPlan_(ctx, user, name)
+}
 func (j *JIMM) GetUserCloudAccess(ctx context.Context, user *openfga.User, cloud names.CloudTag) (string, error) {
 	if j.GetUserCloudAccess_ == nil {
 		return "", errors.E(errors.CodeNotImplemented)
@@ -289,6 +402,12 @@ func (j *JIMM) GrantCloudAccess(ctx context.Context, user *openfga.User, ct name
 	}
 	return j.GrantCloudAccess_(ctx, user, ct, ut, access)
 }
+func (j *JIMM) GrantCloudRegionAccess(ctx context.Context, user *openfga.User, rt jimmnames.CloudRegionTag, ut names.UserTag, access string) error {
+	if j.GrantCloudRegionAccess_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.GrantCloudRegionAccess_(ctx, user, rt, ut, access)
+}
 func (j *JIMM) GrantModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error {
 	if j.GrantModelAccess_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -309,6 +428,12 @@ func (j *JIMM) GrantServiceAccountAccess(ctx context.Context, u *openfga.User, s
 	return j.GrantServiceAccountAccess_(ctx, u, svcAccTag, entities)
 }
 
+func (j *JIMM) GroupModelDefaults(ctx context.Context, user *openfga.User, groupName string) (dbmodel.GroupModelDefaults, error) {
+	if j.GroupModelDefaults_ == nil {
+		return dbmodel.GroupModelDefaults{}, errors.E(errors.CodeNotImplemented)
+	}
+	return j.GroupModelDefaults_(ctx, user, groupName)
+}
 func (j *JIMM) InitiateMigration(ctx context.Context, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error) {
 	if j.InitiateMigration_ == nil {
 		return jujuparams.InitiateMigrationResult{}, errors.E(errors.CodeNotImplemented)
@@ -333,12 +458,71 @@ func (j *JIMM) ListControllers(ctx context.Context, user *openfga.User) ([]dbmod
 	}
 	return j.ListControllers_(ctx, user)
 }
+func (j *JIMM) ListDeletedModels(ctx context.Context, user *openfga.User, uuid string) ([]dbmodel.DeletedModel, error) {
+	if j.ListDeletedModels_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListDeletedModels_(ctx, user, uuid)
+}
 func (j *JIMM) ListGroups(ctx context.Context, user *openfga.User) ([]dbmodel.GroupEntry, error) {
 	if j.ListGroups_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
 	}
 	return j.ListGroups_(ctx, user)
 }
+func (j *JIMM) ListCloudCredentials(ctx context.Context, user *openfga.User, p jimm.ListCloudCredentialsParams) ([]dbmodel.CloudCredential, error) {
+	if j.ListCloudCredentials_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListCloudCredentials_(ctx, user, p)
+}
+
+func (j *JIMM) ListCloudCredentialVersions(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) ([]jimmcreds.CredentialVersion, error) {
+	if j.ListCloudCredentialVersions_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListCloudCredentialVersions_(ctx, user, tag)
+}
+
+func (j *JIMM) ListMigrationPlans(ctx context.Context, user *openfga.User) ([]dbmodel.MigrationPlan, error) {
+	if j.ListMigrationPlans_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListMigrationPlans_(ctx, user)
+}
+func (j *JIMM) ListModelTemplates(ctx context.Context, user *openfga.User) ([]dbmodel.ModelTemplate, error) {
+	if j.ListModelTemplates_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListModelTemplates_(ctx, user)
+}
+func (j *JIMM) ListUserModels(ctx context.Context, user *openfga.User, filter jimm.ModelListFilter, pageSize int, continuationToken string) ([]jimm.UserModel, string, error) {
+	if j.ListUserModels_ == nil {
+		return nil, "", errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListUserModels_(ctx, user, filter, pageSize, continuationToken)
+}
+
+func (j *JIMM) ModelDrift(ctx context.Context, user *openfga.User, p jimm.ModelDriftParams) ([]jimm.ModelDrift, error) {
+	if j.ModelDrift_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ModelDrift_(ctx, user, p)
+}
+
+func (j *JIMM) ModelLabels(ctx context.Context, user *openfga.User, mt names.ModelTag) (map[string]string, error) {
+	if j.ModelLabels_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ModelLabels_(ctx, user, mt)
+}
+
+func (j *JIMM) ModelQuotas(ctx context.Context, user *openfga.User, entity string) ([]jimm.ModelQuotaUsage, error) {
+	if j.ModelQuotas_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ModelQuotas_(ctx, user, entity)
+}
 
 func (j *JIMM) Offer(ctx context.Context, user *openfga.User, offer jimm.AddApplicationOfferParams) error {
 	if j.Offer_ == nil {
@@ -352,6 +536,12 @@ func (j *JIMM) OAuthAuthenticationService() jimm.OAuthAuthenticator {
 	}
 	return j.OAuthAuthenticationService_()
 }
+func (j *JIMM) PauseMigrationPlan(ctx context.Context, user *openfga.User, name string) error {
+	if j.PauseMigrationPlan_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.PauseMigrationPlan_(ctx, user, name)
+}
 func (j *JIMM) ParseTag(ctx context.Context, key string) (*ofganames.Tag, error) {
 	if j.ParseTag_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
@@ -395,12 +585,39 @@ func (j *JIMM) RemoveGroup(ctx context.Context, user *openfga.User, name string)
 	}
 	return j.RemoveGroup_(ctx, user, name)
 }
+
+func (j *JIMM) RemoveModelQuota(ctx context.Context, user *openfga.User, entity, cloudName string) error {
+	if j.RemoveModelQuota_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveModelQuota_(ctx, user, entity, cloudName)
+}
+
+func (j *JIMM) RemoveModelTemplate(ctx context.Context, user *openfga.User, name string) error {
+	if j.RemoveModelTemplate_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveModelTemplate_(ctx, user, name)
+}
 func (j *JIMM) RenameGroup(ctx context.Context, user *openfga.User, oldName, newName string) error {
 	if j.RenameGroup_ == nil {
 		return errors.E(errors.CodeNotImplemented)
 	}
 	return j.RenameGroup_(ctx, user, oldName, newName)
 }
+func (j *JIMM) RestoreCloudCredentialVersion(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, version int) ([]jujuparams.UpdateCredentialModelResult, error) {
+	if j.RestoreCloudCredentialVersion_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.RestoreCloudCredentialVersion_(ctx, user, tag, version)
+}
+
+func (j *JIMM) ResumeMigrationPlan(ctx context.Context, user *openfga.User, name string) error {
+	if j.ResumeMigrationPlan_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.ResumeMigrationPlan_(ctx, user, name)
+}
 func (j *JIMM) ResourceTag() names.ControllerTag {
 	if j.ResourceTag_ == nil {
 		return names.NewControllerTag(uuid.NewString())
@@ -425,6 +642,12 @@ func (j *JIMM) RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity
 	}
 	return j.RevokeCloudCredential_(ctx, user, tag, force)
 }
+func (j *JIMM) RevokeCloudRegionAccess(ctx context.Context, user *openfga.User, rt jimmnames.CloudRegionTag, ut names.UserTag, access string) error {
+	if j.RevokeCloudRegionAccess_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RevokeCloudRegionAccess_(ctx, user, rt, ut, access)
+}
 func (j *JIMM) RevokeModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error {
 	if j.RevokeModelAccess_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -437,6 +660,25 @@ func (j *JIMM) RevokeOfferAccess(ctx context.Context, user *openfga.User, offerU
 	}
 	return j.RevokeOfferAccess_(ctx, user, offerURL, ut, access)
 }
+func (j *JIMM) RotateCloudCredential(ctx context.Context, user *openfga.User, args jimm.RotateCloudCredentialArgs) (*dbmodel.CloudCredentialRotation, error) {
+	if j.RotateCloudCredential_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.RotateCloudCredential_(ctx, user, args)
+}
+
+func (j *JIMM) RotateControllerCredentials(ctx context.Context, user *openfga.User, controllerName string) error {
+	if j.RotateControllerCredentials_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RotateControllerCredentials_(ctx, user, controllerName)
+}
+func (j *JIMM) SetCloudCredentialGroup(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, group string) error {
+	if j.SetCloudCredentialGroup_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetCloudCredentialGroup_(ctx, user, tag, group)
+}
 func (j *JIMM) SetControllerConfig(ctx context.Context, u *openfga.User, args jujuparams.ControllerConfigSet) error {
 	if j.SetControllerConfig_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -450,6 +692,26 @@ func (j *JIMM) SetControllerDeprecated(ctx context.Context, user *openfga.User,
 	return j.SetControllerDeprecated_(ctx, user, controllerName, deprecated)
 }
 
+func (j *JIMM) SetGroupModelDefaults(ctx context.Context, user *openfga.User, groupName string, priority *int, configs map[string]interface{}) error {
+	if j.SetGroupModelDefaults_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetGroupModelDefaults_(ctx, user, groupName, priority, configs)
+}
+func (j *JIMM) SetModelLabels(ctx context.Context, user *openfga.User, mt names.ModelTag, labels map[string]string, remove []string) (map[string]string, error) {
+	if j.SetModelLabels_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetModelLabels_(ctx, user, mt, labels, remove)
+}
+
+func (j *JIMM) SetModelQuota(ctx context.Context, user *openfga.User, entity string, quota *dbmodel.ModelQuota) error {
+	if j.SetModelQuota_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetModelQuota_(ctx, user, entity, quota)
+}
+
 func (j *JIMM) SetIdentityModelDefaults(ctx context.Context, user *dbmodel.Identity, configs map[string]interface{}) error {
 	if j.SetIdentityModelDefaults_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -463,12 +725,31 @@ func (j *JIMM) ToJAASTag(ctx context.Context, tag *ofganames.Tag, resolveUUIDs b
 	return j.ToJAASTag_(ctx, tag, resolveUUIDs)
 }
 
+func (j *JIMM) TransferModelOwnership(ctx context.Context, user *openfga.User, mt names.ModelTag, newOwner names.UserTag, keepAccess bool) error {
+	if j.TransferModelOwnership_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.TransferModelOwnership_(ctx, user, mt, newOwner, keepAccess)
+}
+
+func (j *JIMM) UnsetGroupModelDefaults(ctx context.Context, user *openfga.User, groupName string, keys []string) error {
+	if j.UnsetGroupModelDefaults_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.UnsetGroupModelDefaults_(ctx, user, groupName, keys)
+}
 func (j *JIMM) UpdateApplicationOffer(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) error {
 	if j.UpdateApplicationOffer_ == nil {
 		return errors.E(errors.CodeNotImplemented)
 	}
 	return j.UpdateApplicationOffer_(ctx, controller, offerUUID, removed)
 }
+func (j *JIMM) UpdateCatalogueCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud) ([]dbmodel.CloudControllerStatus, error) {
+	if j.UpdateCatalogueCloud_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.UpdateCatalogueCloud_(ctx, user, tag, cloud)
+}
 func (j *JIMM) UpdateCloud(ctx context.Context, u *openfga.User, ct names.CloudTag, cloud jujuparams.Cloud) error {
 	if j.UpdateCloud_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -481,6 +762,12 @@ func (j *JIMM) UpdateCloudCredential(ctx context.Context, u *openfga.User, args
 	}
 	return j.UpdateCloudCredential_(ctx, u, args)
 }
+func (j *JIMM) UpdateModelTemplate(ctx context.Context, user *openfga.User, t *dbmodel.ModelTemplate) error {
+	if j.UpdateModelTemplate_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.UpdateModelTemplate_(ctx, user, t)
+}
 
 func (j *JIMM) UserLogin(ctx context.Context, identityName string) (*openfga.User, error) {
 	if j.UserLogin_ == nil {
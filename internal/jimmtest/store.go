@@ -10,6 +10,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwk"
 
 	"github.com/canonical/jimm/v3/internal/errors"
+	jimmcreds "github.com/canonical/jimm/v3/internal/jimm/credentials"
 )
 
 type controllerCredentials struct {
@@ -29,6 +30,14 @@ type InMemoryCredentialStore struct {
 	oauthSessionStoreSecret   []byte
 	controllerCredentials     map[string]controllerCredentials
 	cloudCredentialAttributes map[string]map[string]string
+	cloudCredentialVersions   map[string][]cloudCredentialVersion
+}
+
+// A cloudCredentialVersion holds a previous version of the attributes of
+// a cloud credential.
+type cloudCredentialVersion struct {
+	created time.Time
+	attrs   map[string]string
 }
 
 // NewInMemoryCredentialStore returns a new instance of `InMemoryCredentialStore`
@@ -70,9 +79,55 @@ func (s *InMemoryCredentialStore) Put(ctx context.Context, credTag names.CloudCr
 		attrsCopy[k] = v
 	}
 	s.cloudCredentialAttributes[credTag.String()] = attrsCopy
+	if s.cloudCredentialVersions == nil {
+		s.cloudCredentialVersions = make(map[string][]cloudCredentialVersion)
+	}
+	s.cloudCredentialVersions[credTag.String()] = append(s.cloudCredentialVersions[credTag.String()], cloudCredentialVersion{
+		created: time.Now(),
+		attrs:   attrsCopy,
+	})
 	return nil
 }
 
+// ListCloudCredentialVersions returns the stored versions of the
+// attributes of a cloud credential.
+func (s *InMemoryCredentialStore) ListCloudCredentialVersions(ctx context.Context, credTag names.CloudCredentialTag) ([]jimmcreds.CredentialVersion, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions, ok := s.cloudCredentialVersions[credTag.String()]
+	if !ok {
+		return nil, errors.E(errors.CodeNotFound)
+	}
+	result := make([]jimmcreds.CredentialVersion, len(versions))
+	for i, v := range versions {
+		result[i] = jimmcreds.CredentialVersion{
+			Version:     i + 1,
+			CreatedTime: v.created,
+			Deleted:     len(v.attrs) == 0,
+		}
+	}
+	return result, nil
+}
+
+// GetCloudCredentialVersion retrieves the given version of the attributes
+// of a cloud credential.
+func (s *InMemoryCredentialStore) GetCloudCredentialVersion(ctx context.Context, credTag names.CloudCredentialTag, version int) (map[string]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	versions := s.cloudCredentialVersions[credTag.String()]
+	if version < 1 || version > len(versions) || len(versions[version-1].attrs) == 0 {
+		return nil, errors.E(errors.CodeNotFound)
+	}
+	attrs := versions[version-1].attrs
+	attrsCopy := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		attrsCopy[k] = v
+	}
+	return attrsCopy, nil
+}
+
 // GetControllerCredentials retrieves the credentials for the given controller from a vault
 // service.
 func (s *InMemoryCredentialStore) GetControllerCredentials(ctx context.Context, controllerName string) (string, string, error) {
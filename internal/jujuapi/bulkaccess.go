@@ -0,0 +1,61 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// bulkaccess contains the RPC methods for changing access to many models
+// at once.
+
+// BulkModelAccess grants or revokes access to every model matching the
+// selector in the request.
+func (r *controllerRoot) BulkModelAccess(ctx context.Context, req apiparams.BulkModelAccessRequest) (apiparams.BulkModelAccessResponse, error) {
+	const op = errors.Op("jujuapi.BulkModelAccess")
+
+	sel, err := jimm.ParseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return apiparams.BulkModelAccessResponse{}, errors.E(op, err)
+	}
+	p := jimm.BulkModelAccessParams{
+		Revoke: req.Revoke,
+		Entity: req.Entity,
+		Access: req.Access,
+		Selector: jimm.ModelSelector{
+			Controller:    req.Controller,
+			NameGlob:      req.NameGlob,
+			LabelSelector: sel,
+		},
+		DryRun: req.DryRun,
+	}
+	if req.OwnerTag != "" {
+		ut, err := names.ParseUserTag(req.OwnerTag)
+		if err != nil {
+			return apiparams.BulkModelAccessResponse{}, errors.E(op, err, errors.CodeBadRequest)
+		}
+		p.Selector.Owner = ut.Id()
+	}
+	res, err := r.jimm.BulkModelAccess(ctx, r.user, p)
+	if err != nil {
+		return apiparams.BulkModelAccessResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.BulkModelAccessResponse{
+		CorrelationID: res.CorrelationID,
+		Models:        make([]apiparams.BulkModelAccessModel, len(res.Models)),
+	}
+	for i, m := range res.Models {
+		resp.Models[i] = apiparams.BulkModelAccessModel{
+			UUID:  m.UUID.String,
+			Name:  m.Name,
+			Owner: m.OwnerIdentityName,
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type bulkAccessSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&bulkAccessSuite{})
+
+func (s *bulkAccessSuite) TestBulkModelAccess(c *gc.C) {
+	ctx := context.Background()
+
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	expectModels := []apiparams.BulkModelAccessModel{{
+		UUID:  s.Model2.UUID.String,
+		Name:  "model-2",
+		Owner: "charlie@canonical.com",
+	}, {
+		UUID:  s.Model3.UUID.String,
+		Name:  "model-3",
+		Owner: "charlie@canonical.com",
+	}}
+
+	req := apiparams.BulkModelAccessRequest{
+		Entity:   "user-dave@canonical.com",
+		Access:   "read",
+		OwnerTag: "user-charlie@canonical.com",
+		DryRun:   true,
+	}
+	resp, err := client.BulkModelAccess(&req)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.CorrelationID, gc.Equals, "")
+	c.Check(resp.Models, jc.DeepEquals, expectModels)
+
+	dave := openfga.NewUser(&dbmodel.Identity{Name: "dave@canonical.com"}, s.OFGAClient)
+	c.Check(dave.GetModelAccess(ctx, names.NewModelTag(s.Model2.UUID.String)), gc.Equals, ofganames.NoRelation)
+
+	req.DryRun = false
+	resp, err = client.BulkModelAccess(&req)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.CorrelationID, gc.Not(gc.Equals), "")
+	c.Check(resp.Models, jc.DeepEquals, expectModels)
+	c.Check(dave.GetModelAccess(ctx, names.NewModelTag(s.Model2.UUID.String)), gc.Equals, ofganames.ReaderRelation)
+	c.Check(dave.GetModelAccess(ctx, names.NewModelTag(s.Model3.UUID.String)), gc.Equals, ofganames.ReaderRelation)
+
+	// Granting the same access again changes nothing.
+	resp, err = client.BulkModelAccess(&req)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Models, gc.HasLen, 0)
+
+	req.Revoke = true
+	req.NameGlob = "model-3"
+	resp, err = client.BulkModelAccess(&req)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Models, jc.DeepEquals, expectModels[1:])
+	c.Check(dave.GetModelAccess(ctx, names.NewModelTag(s.Model2.UUID.String)), gc.Equals, ofganames.ReaderRelation)
+	c.Check(dave.GetModelAccess(ctx, names.NewModelTag(s.Model3.UUID.String)), gc.Equals, ofganames.NoRelation)
+}
+
+func (s *bulkAccessSuite) TestBulkModelAccessNotAdministrator(c *gc.C) {
+	// bob can read model-3 but does not administer it.
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	resp, err := client.BulkModelAccess(&apiparams.BulkModelAccessRequest{
+		Entity:   "user-dave@canonical.com",
+		Access:   "read",
+		OwnerTag: "user-charlie@canonical.com",
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Models, gc.HasLen, 0)
+}
+
+func (s *bulkAccessSuite) TestBulkModelAccessInvalid(c *gc.C) {
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.BulkModelAccess(&apiparams.BulkModelAccessRequest{
+		Entity: "user-dave@canonical.com",
+		Access: "read",
+	})
+	c.Check(err, gc.ErrorMatches, `a model selector must be specified \(bad request\)`)
+
+	_, err = client.BulkModelAccess(&apiparams.BulkModelAccessRequest{
+		Entity:   "user-dave@canonical.com",
+		Access:   "read",
+		OwnerTag: "charlie",
+	})
+	c.Check(err, gc.ErrorMatches, `"charlie" is not a valid tag \(bad request\)`)
+}
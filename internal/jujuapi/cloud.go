@@ -5,6 +5,7 @@ package jujuapi
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	jujuerrors "github.com/juju/errors"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
 )
 
 func init() {
@@ -418,6 +420,9 @@ func (r *controllerRoot) modifyCloudAccess(ctx context.Context, change jujuparam
 	if err != nil {
 		return errors.E(op, err)
 	}
+	if strings.HasPrefix(change.CloudTag, jimmnames.CloudRegionTagKind+"-") {
+		return r.modifyCloudRegionAccess(ctx, change, ut)
+	}
 	ct, err := names.ParseCloudTag(change.CloudTag)
 	if err != nil {
 		return errors.E(op, errors.CodeBadRequest, err)
@@ -438,6 +443,31 @@ func (r *controllerRoot) modifyCloudAccess(ctx context.Context, change jujuparam
 	return nil
 }
 
+// modifyCloudRegionAccess modifies access to the single cloud region
+// referenced by the change, rather than to the whole cloud.
+func (r *controllerRoot) modifyCloudRegionAccess(ctx context.Context, change jujuparams.ModifyCloudAccess, ut names.UserTag) error {
+	const op = errors.Op("jujuapi.ModifyCloudAccess")
+
+	rt, err := jimmnames.ParseCloudRegionTag(change.CloudTag)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+
+	var modifyf func(context.Context, *openfga.User, jimmnames.CloudRegionTag, names.UserTag, string) error
+	switch change.Action {
+	case jujuparams.GrantCloudAccess:
+		modifyf = r.jimm.GrantCloudRegionAccess
+	case jujuparams.RevokeCloudAccess:
+		modifyf = r.jimm.RevokeCloudRegionAccess
+	default:
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("unsupported modify cloud action %q", change.Action))
+	}
+	if err := modifyf(ctx, r.user, rt, ut, change.Access); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 // UpdateCredentialsCheckModels updates a set of cloud credentials' content.
 // If there are any models that are using a credential and these models
 // are not going to be visible with updated credential content,
@@ -88,10 +88,10 @@ func (s *cloudSuite) TestUserCredentials(c *gc.C) {
 func (s *cloudSuite) TestUserCredentialsWithDomain(c *gc.C) {
 	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/test@domain/cred1")
 	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{
-		AuthType: "credtype",
+		AuthType: "userpass",
 		Attributes: map[string]string{
-			"attr1": "val1",
-			"attr2": "val2",
+			"username": "user",
+			"password": "pass",
 		},
 	})
 	conn := s.open(c, nil, "test@domain")
@@ -126,9 +126,9 @@ func (s *cloudSuite) TestUpdateCloudCredentials(c *gc.C) {
 	client := cloudapi.NewClient(conn)
 	credentialTag := names.NewCloudCredentialTag(fmt.Sprintf(jimmtest.TestCloudName + "/test@canonical.com/cred3"))
 	reqCreds := map[string]cloud.Credential{
-		credentialTag.String(): cloud.NewCredential("credtype", map[string]string{
-			"attr1": "val31",
-			"attr2": "val32",
+		credentialTag.String(): cloud.NewCredential("userpass", map[string]string{
+			"username": "user31",
+			"password": "pass32",
 		}),
 	}
 	res, err := client.UpdateCloudsCredentials(reqCreds, false)
@@ -139,7 +139,7 @@ func (s *cloudSuite) TestUpdateCloudCredentials(c *gc.C) {
 	creds, err := client.UserCredentials(names.NewUserTag("test@canonical.com"), names.NewCloudTag(jimmtest.TestCloudName))
 	c.Assert(err, gc.Equals, nil)
 	c.Assert(creds, jc.DeepEquals, []names.CloudCredentialTag{credentialTag})
-	_, err = client.UpdateCredentialsCheckModels(credentialTag, cloud.NewCredential("credtype", map[string]string{"attr1": "val33", "attr2": "val34"}))
+	_, err = client.UpdateCredentialsCheckModels(credentialTag, cloud.NewCredential("userpass", map[string]string{"username": "user33", "password": "pass34"}))
 	c.Assert(err, gc.Equals, nil)
 	creds, err = client.UserCredentials(names.NewUserTag("test@canonical.com"), names.NewCloudTag(jimmtest.TestCloudName))
 	c.Assert(err, gc.Equals, nil)
@@ -153,25 +153,28 @@ func (s *cloudSuite) TestUpdateCloudCredentialsErrors(c *gc.C) {
 		Credentials: []jujuparams.TaggedCredential{{
 			Tag: "not-a-cloud-credentials-tag",
 			Credential: jujuparams.CloudCredential{
-				AuthType: "credtype",
+				AuthType: "userpass",
 				Attributes: map[string]string{
-					"attr1": "val1",
+					"username": "user",
+					"password": "pass",
 				},
 			},
 		}, {
 			Tag: names.NewCloudCredentialTag(jimmtest.TestCloudName + "/test2@canonical.com/cred1").String(),
 			Credential: jujuparams.CloudCredential{
-				AuthType: "credtype",
+				AuthType: "userpass",
 				Attributes: map[string]string{
-					"attr1": "val1",
+					"username": "user",
+					"password": "pass",
 				},
 			},
 		}, {
 			Tag: names.NewCloudCredentialTag(jimmtest.TestCloudName + "/test@canonical.com/bad-name-").String(),
 			Credential: jujuparams.CloudCredential{
-				AuthType: "credtype",
+				AuthType: "userpass",
 				Attributes: map[string]string{
-					"attr1": "val1",
+					"username": "user",
+					"password": "pass",
 				},
 			},
 		}},
@@ -193,18 +196,23 @@ func (s *cloudSuite) TestUpdateCloudCredentialsForce(c *gc.C) {
 	_, err := client.UpdateCredentialsCheckModels(credentialTag, cloud.NewCredential("userpass", map[string]string{"username": "a", "password": "b"}))
 	c.Assert(err, gc.Equals, nil)
 
+	// The dummy provider fails to list the instances of a model with
+	// this configuration, so the controller rejects any credential used
+	// by the model.
 	mmclient := modelmanager.NewClient(conn)
-	_, err = mmclient.CreateModel("model1", "test@canonical.com", jimmtest.TestCloudName, "", credentialTag, nil)
+	_, err = mmclient.CreateModel("model1", "test@canonical.com", jimmtest.TestCloudName, "", credentialTag, map[string]interface{}{
+		"broken": "AllInstances",
+	})
 	c.Assert(err, gc.Equals, nil)
 
 	args := jujuparams.UpdateCredentialArgs{
 		Credentials: []jujuparams.TaggedCredential{{
 			Tag: credentialTag.String(),
 			Credential: jujuparams.CloudCredential{
-				AuthType: "badauthtype",
+				AuthType: "userpass",
 				Attributes: map[string]string{
-					"bad1attr": "cloud-user2",
-					"bad2attr": "cloud-pass2",
+					"username": "cloud-user2",
+					"password": "cloud-pass2",
 				},
 			},
 		}},
@@ -231,19 +239,78 @@ func (s *cloudSuite) TestUpdateCloudCredentialsForce(c *gc.C) {
 	}})
 
 	args.Force = true
+	resp = jujuparams.UpdateCredentialResults{}
 	err = conn.APICall("Cloud", 7, "", "UpdateCredentialsCheckModels", args, &resp)
 	c.Assert(err, gc.Equals, nil)
-	c.Check(resp.Results[0].Error, gc.ErrorMatches, `updating cloud credentials: validating credential "`+jimmtest.TestCloudName+`/test@canonical.com/cred3" for cloud "`+jimmtest.TestCloudName+`": supported auth-types \["empty" "userpass"\], "badauthtype" not supported`)
+	c.Check(resp.Results[0].Error, gc.IsNil)
+	c.Assert(resp.Results[0].Models, gc.HasLen, 1)
+	c.Assert(resp.Results[0].Models[0].Errors, gc.HasLen, 1)
+	c.Check(resp.Results[0].Models[0].Errors[0].Error, gc.ErrorMatches, `receiving instances from provider: dummy.AllInstances is broken`)
 
 	// Check that the credentials have been updated even though
-	// we got an error.
+	// some models reported errors.
 	creds, err = client.Credentials(credentialTag)
 	c.Assert(err, gc.Equals, nil)
-	sort.Strings(creds[0].Result.Redacted)
 	c.Assert(creds, jc.DeepEquals, []jujuparams.CloudCredentialResult{{
 		Result: &jujuparams.CloudCredential{
-			AuthType: "badauthtype",
-			Redacted: []string{"bad1attr", "bad2attr"},
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "cloud-user2",
+			},
+			Redacted: []string{
+				"password",
+			},
+		},
+	}})
+}
+
+func (s *cloudSuite) TestUpdateCloudCredentialsInvalidSchema(c *gc.C) {
+	conn := s.open(c, nil, "test")
+	defer conn.Close()
+	client := cloudapi.NewClient(conn)
+	credentialTag := names.NewCloudCredentialTag(fmt.Sprintf(jimmtest.TestCloudName + "/test@canonical.com/cred3"))
+	_, err := client.UpdateCredentialsCheckModels(credentialTag, cloud.NewCredential("userpass", map[string]string{"username": "a", "password": "b"}))
+	c.Assert(err, gc.Equals, nil)
+
+	mmclient := modelmanager.NewClient(conn)
+	_, err = mmclient.CreateModel("model1", "test@canonical.com", jimmtest.TestCloudName, "", credentialTag, nil)
+	c.Assert(err, gc.Equals, nil)
+
+	args := jujuparams.UpdateCredentialArgs{
+		Credentials: []jujuparams.TaggedCredential{{
+			Tag: credentialTag.String(),
+			Credential: jujuparams.CloudCredential{
+				AuthType: "badauthtype",
+				Attributes: map[string]string{
+					"bad1attr": "cloud-user2",
+					"bad2attr": "cloud-pass2",
+				},
+			},
+		}},
+	}
+	// Credentials that do not match the cloud's credential schema are
+	// rejected, even when forced, before any controller is updated.
+	for _, force := range []bool{false, true} {
+		args.Force = force
+		var resp jujuparams.UpdateCredentialResults
+		err = conn.APICall("Cloud", 7, "", "UpdateCredentialsCheckModels", args, &resp)
+		c.Assert(err, gc.Equals, nil)
+		c.Check(resp.Results[0].Error, gc.ErrorMatches, `auth type "badauthtype" not supported by "dummy" clouds, supported auth types are "empty", "userpass"`)
+		c.Check(resp.Results[0].Models, gc.HasLen, 0)
+	}
+
+	// Check that the credentials have not been updated.
+	creds, err := client.Credentials(credentialTag)
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(creds, jc.DeepEquals, []jujuparams.CloudCredentialResult{{
+		Result: &jujuparams.CloudCredential{
+			AuthType: "userpass",
+			Attributes: map[string]string{
+				"username": "a",
+			},
+			Redacted: []string{
+				"password",
+			},
 		},
 	}})
 }
@@ -315,8 +382,13 @@ func (s *cloudSuite) TestCheckCredentialsModelsInvalidCreds(c *gc.C) {
 	_, err := client.UpdateCredentialsCheckModels(credTag, cred1)
 	c.Assert(err, gc.Equals, nil)
 
+	// The dummy provider fails to list the instances of a model with
+	// this configuration, so the controller rejects any credential used
+	// by the model.
 	mmclient := modelmanager.NewClient(conn)
-	model1, err := mmclient.CreateModel("model1", "test@canonical.com", jimmtest.TestCloudName, "", credTag, nil)
+	model1, err := mmclient.CreateModel("model1", "test@canonical.com", jimmtest.TestCloudName, "", credTag, map[string]interface{}{
+		"broken": "AllInstances",
+	})
 	c.Assert(err, gc.Equals, nil)
 
 	var resp jujuparams.UpdateCredentialResults
@@ -324,9 +396,10 @@ func (s *cloudSuite) TestCheckCredentialsModelsInvalidCreds(c *gc.C) {
 		Credentials: []jujuparams.TaggedCredential{{
 			Tag: credTag.String(),
 			Credential: jujuparams.CloudCredential{
-				AuthType: "unknowntype",
+				AuthType: "userpass",
 				Attributes: map[string]string{
-					"x": "y",
+					"username": "cloud-user2",
+					"password": "cloud-pass2",
 				},
 			},
 		}},
@@ -341,8 +414,7 @@ func (s *cloudSuite) TestCheckCredentialsModelsInvalidCreds(c *gc.C) {
 				ModelName: "model1",
 				Errors: []jujuparams.ErrorResult{{
 					Error: &jujuparams.Error{
-						Message: `validating credential "` + jimmtest.TestCloudName + `/test@canonical.com/cred" for cloud "` + jimmtest.TestCloudName + `": supported auth-types ["empty" "userpass"], "unknowntype" not supported`,
-						Code:    "not supported",
+						Message: "receiving instances from provider: dummy.AllInstances is broken",
 					},
 				}},
 			}},
@@ -350,6 +422,48 @@ func (s *cloudSuite) TestCheckCredentialsModelsInvalidCreds(c *gc.C) {
 	})
 }
 
+func (s *cloudSuite) TestCheckCredentialsModelsInvalidSchema(c *gc.C) {
+	conn := s.open(c, nil, "test")
+	defer conn.Close()
+
+	credTag := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/test@canonical.com/cred")
+	cred1 := cloud.NewCredential("userpass", map[string]string{
+		"username": "cloud-user",
+		"password": "cloud-pass",
+	})
+
+	client := cloudapi.NewClient(conn)
+	_, err := client.UpdateCredentialsCheckModels(credTag, cred1)
+	c.Assert(err, gc.Equals, nil)
+
+	mmclient := modelmanager.NewClient(conn)
+	_, err = mmclient.CreateModel("model1", "test@canonical.com", jimmtest.TestCloudName, "", credTag, nil)
+	c.Assert(err, gc.Equals, nil)
+
+	var resp jujuparams.UpdateCredentialResults
+	err = conn.APICall("Cloud", 7, "", "CheckCredentialsModels", jujuparams.TaggedCredentials{
+		Credentials: []jujuparams.TaggedCredential{{
+			Tag: credTag.String(),
+			Credential: jujuparams.CloudCredential{
+				AuthType: "unknowntype",
+				Attributes: map[string]string{
+					"x": "y",
+				},
+			},
+		}},
+	}, &resp)
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp, jc.DeepEquals, jujuparams.UpdateCredentialResults{
+		Results: []jujuparams.UpdateCredentialResult{{
+			CredentialTag: "cloudcred-" + jimmtest.TestCloudName + "_test@canonical.com_cred",
+			Error: &jujuparams.Error{
+				Message: `auth type "unknowntype" not supported by "dummy" clouds, supported auth types are "empty", "userpass"`,
+				Code:    "bad request",
+			},
+		}},
+	})
+}
+
 func (s *cloudSuite) TestCredential(c *gc.C) {
 	conn := s.open(c, nil, "test")
 	defer conn.Close()
@@ -737,7 +851,7 @@ func (s *cloudSuite) TestCredentialContentsWithEmptyAttributes(c *gc.C) {
 	err := client.AddCredential(
 		credentialTag.String(),
 		cloud.NewCredential(
-			"certificate",
+			"empty",
 			nil,
 		),
 	)
@@ -749,7 +863,7 @@ func (s *cloudSuite) TestCredentialContentsWithEmptyAttributes(c *gc.C) {
 			Content: jujuparams.CredentialContent{
 				Name:       "cred3",
 				Cloud:      jimmtest.TestCloudName,
-				AuthType:   "certificate",
+				AuthType:   "empty",
 				Attributes: nil,
 			},
 		},
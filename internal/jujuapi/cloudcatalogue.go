@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// cloudcatalogue contains the RPC methods for managing the clouds held in
+// JIMM's cloud catalogue independently of the controllers hosting them.
+
+// AddCatalogueCloud adds the cloud in the request to the cloud catalogue.
+func (r *controllerRoot) AddCatalogueCloud(ctx context.Context, req apiparams.AddCatalogueCloudRequest) error {
+	const op = errors.Op("jujuapi.AddCatalogueCloud")
+
+	tag, err := parseCloudName(req.Name)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.jimm.AddCatalogueCloud(ctx, r.user, tag, req.Cloud); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// AttachCloudToController adds the catalogue cloud in the request to the
+// controller in the request.
+func (r *controllerRoot) AttachCloudToController(ctx context.Context, req apiparams.AttachCloudToControllerRequest) error {
+	const op = errors.Op("jujuapi.AttachCloudToController")
+
+	tag, err := parseCloudName(req.CloudName)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := r.jimm.AttachCloudToController(ctx, r.user, tag, req.ControllerName, req.Force); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// UpdateCatalogueCloud updates the catalogue definition of the cloud in
+// the request and returns the status of the update on each controller
+// hosting the cloud.
+func (r *controllerRoot) UpdateCatalogueCloud(ctx context.Context, req apiparams.UpdateCatalogueCloudRequest) (apiparams.CloudControllerStatusResponse, error) {
+	const op = errors.Op("jujuapi.UpdateCatalogueCloud")
+
+	tag, err := parseCloudName(req.Name)
+	if err != nil {
+		return apiparams.CloudControllerStatusResponse{}, errors.E(op, err)
+	}
+	statuses, err := r.jimm.UpdateCatalogueCloud(ctx, r.user, tag, req.Cloud)
+	if err != nil {
+		return apiparams.CloudControllerStatusResponse{}, errors.E(op, err)
+	}
+	return cloudControllerStatusResponse(statuses), nil
+}
+
+// CloudControllerStatus returns the status of the cloud in the request on
+// each controller hosting it.
+func (r *controllerRoot) CloudControllerStatus(ctx context.Context, req apiparams.CloudControllerStatusRequest) (apiparams.CloudControllerStatusResponse, error) {
+	const op = errors.Op("jujuapi.CloudControllerStatus")
+
+	tag, err := parseCloudName(req.Name)
+	if err != nil {
+		return apiparams.CloudControllerStatusResponse{}, errors.E(op, err)
+	}
+	statuses, err := r.jimm.GetCloudControllerStatuses(ctx, r.user, tag)
+	if err != nil {
+		return apiparams.CloudControllerStatusResponse{}, errors.E(op, err)
+	}
+	return cloudControllerStatusResponse(statuses), nil
+}
+
+// parseCloudName returns the tag of the cloud with the given name.
+func parseCloudName(name string) (names.CloudTag, error) {
+	if !names.IsValidCloud(name) {
+		return names.CloudTag{}, errors.E(errors.CodeBadRequest, "invalid cloud name "+name)
+	}
+	return names.NewCloudTag(name), nil
+}
+
+// cloudControllerStatusResponse converts the given statuses to their API
+// representation.
+func cloudControllerStatusResponse(statuses []dbmodel.CloudControllerStatus) apiparams.CloudControllerStatusResponse {
+	resp := apiparams.CloudControllerStatusResponse{
+		Controllers: make([]apiparams.CloudControllerStatus, len(statuses)),
+	}
+	for i, s := range statuses {
+		resp.Controllers[i] = apiparams.CloudControllerStatus{
+			Controller:  s.Controller.Name,
+			Status:      s.Status,
+			Message:     s.Message,
+			UpdatedTime: s.UpdatedAt,
+		}
+	}
+	return resp
+}
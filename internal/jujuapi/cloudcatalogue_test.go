@@ -0,0 +1,165 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"context"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type cloudCatalogueSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&cloudCatalogueSuite{})
+
+func (s *cloudCatalogueSuite) TestCloudCatalogue(c *gc.C) {
+	ctx := context.Background()
+
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	cloud := jujuparams.Cloud{
+		Type:      "maas",
+		AuthTypes: []string{"oauth1"},
+		Endpoint:  "https://maas.example.com/MAAS",
+		Regions:   []jujuparams.CloudRegion{{Name: "default"}},
+	}
+	err := client.AddCatalogueCloud(&apiparams.AddCatalogueCloudRequest{
+		Name:  "test-maas-cloud",
+		Cloud: cloud,
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	dbCloud := dbmodel.Cloud{Name: "test-maas-cloud"}
+	err = s.JIMM.Database.GetCloud(ctx, &dbCloud)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(dbCloud.Endpoint, gc.Equals, "https://maas.example.com/MAAS")
+
+	resp, err := client.CloudControllerStatus(&apiparams.CloudControllerStatusRequest{
+		Name: "test-maas-cloud",
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Controllers, gc.HasLen, 0)
+
+	err = client.AttachCloudToController(&apiparams.AttachCloudToControllerRequest{
+		CloudName:      "test-maas-cloud",
+		ControllerName: "controller-1",
+		Force:          true,
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	err = client.AttachCloudToController(&apiparams.AttachCloudToControllerRequest{
+		CloudName:      "test-maas-cloud",
+		ControllerName: "controller-1",
+		Force:          true,
+	})
+	c.Check(err, gc.ErrorMatches, `cloud already hosted by controller \(already exists\)`)
+
+	resp, err = client.CloudControllerStatus(&apiparams.CloudControllerStatusRequest{
+		Name: "test-maas-cloud",
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp.Controllers, gc.HasLen, 1)
+	c.Check(resp.Controllers[0].Controller, gc.Equals, "controller-1")
+	c.Check(resp.Controllers[0].Status, gc.Equals, "synced")
+
+	cloud.Endpoint = "https://maas2.example.com/MAAS"
+	resp, err = client.UpdateCatalogueCloud(&apiparams.UpdateCatalogueCloudRequest{
+		Name:  "test-maas-cloud",
+		Cloud: cloud,
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp.Controllers, gc.HasLen, 1)
+	c.Check(resp.Controllers[0].Controller, gc.Equals, "controller-1")
+	c.Check(resp.Controllers[0].Status, gc.Equals, "synced")
+
+	dbCloud = dbmodel.Cloud{Name: "test-maas-cloud"}
+	err = s.JIMM.Database.GetCloud(ctx, &dbCloud)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(dbCloud.Endpoint, gc.Equals, "https://maas2.example.com/MAAS")
+
+	cloud.Type = "openstack"
+	_, err = client.UpdateCatalogueCloud(&apiparams.UpdateCatalogueCloudRequest{
+		Name:  "test-maas-cloud",
+		Cloud: cloud,
+	})
+	c.Check(err, gc.ErrorMatches, `cannot change the type of a cloud \(bad request\)`)
+}
+
+func (s *cloudCatalogueSuite) TestAddCatalogueCloudInvalid(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	err := client.AddCatalogueCloud(&apiparams.AddCatalogueCloudRequest{
+		Name: "test_maas_cloud!",
+		Cloud: jujuparams.Cloud{
+			Type:    "maas",
+			Regions: []jujuparams.CloudRegion{{Name: "default"}},
+		},
+	})
+	c.Check(err, gc.ErrorMatches, `invalid cloud name test_maas_cloud! \(bad request\)`)
+
+	err = client.AddCatalogueCloud(&apiparams.AddCatalogueCloudRequest{
+		Name: "test-maas-cloud",
+		Cloud: jujuparams.Cloud{
+			Type: "maas",
+		},
+	})
+	c.Check(err, gc.ErrorMatches, `cloud has no regions \(bad request\)`)
+}
+
+func (s *cloudCatalogueSuite) TestCloudCatalogueUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	cloud := jujuparams.Cloud{
+		Type:      "maas",
+		AuthTypes: []string{"oauth1"},
+		Endpoint:  "https://maas.example.com/MAAS",
+		Regions:   []jujuparams.CloudRegion{{Name: "default"}},
+	}
+	err := client.AddCatalogueCloud(&apiparams.AddCatalogueCloudRequest{
+		Name:  "test-maas-cloud",
+		Cloud: cloud,
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	// bob is not a JIMM administrator and does not administer the
+	// cloud or the controller.
+	conn2 := s.open(c, nil, "bob")
+	defer conn2.Close()
+	client2 := api.NewClient(conn2)
+
+	err = client2.AddCatalogueCloud(&apiparams.AddCatalogueCloudRequest{
+		Name:  "test-maas-cloud-2",
+		Cloud: cloud,
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	err = client2.AttachCloudToController(&apiparams.AttachCloudToControllerRequest{
+		CloudName:      "test-maas-cloud",
+		ControllerName: "controller-1",
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = client2.UpdateCatalogueCloud(&apiparams.UpdateCatalogueCloudRequest{
+		Name:  "test-maas-cloud",
+		Cloud: cloud,
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = client2.CloudControllerStatus(&apiparams.CloudControllerStatusRequest{
+		Name: "test-maas-cloud",
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
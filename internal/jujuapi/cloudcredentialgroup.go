@@ -0,0 +1,29 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// cloudcredentialgroup contains the RPC methods for managing cloud
+// credentials owned by groups.
+
+// SetCloudCredentialGroup sets the group that owns a cloud credential.
+func (r *controllerRoot) SetCloudCredentialGroup(ctx context.Context, req apiparams.SetCloudCredentialGroupRequest) error {
+	const op = errors.Op("jujuapi.SetCloudCredentialGroup")
+
+	tag, err := names.ParseCloudCredentialTag(req.CloudCredentialTag)
+	if err != nil {
+		return errors.E(op, err, errors.CodeBadRequest)
+	}
+	if err := r.jimm.SetCloudCredentialGroup(ctx, r.user, tag, req.Group); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
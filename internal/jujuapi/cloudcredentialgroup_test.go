@@ -0,0 +1,123 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type cloudCredentialGroupSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&cloudCredentialGroupSuite{})
+
+func (s *cloudCredentialGroupSuite) TestSetCloudCredentialGroup(c *gc.C) {
+	ctx := context.Background()
+
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.AddGroup(&apiparams.AddGroupRequest{Name: "ops"})
+	c.Assert(err, gc.Equals, nil)
+	_, err = client.AddGroup(&apiparams.AddGroupRequest{Name: "other"})
+	c.Assert(err, gc.Equals, nil)
+	err = client.AddRelation(&apiparams.AddRelationRequest{
+		Tuples: []apiparams.RelationshipTuple{{
+			Object:       "user-charlie@canonical.com",
+			Relation:     "member",
+			TargetObject: "group-ops",
+		}, {
+			Object:       "user-bob@canonical.com",
+			Relation:     "member",
+			TargetObject: "group-ops",
+		}},
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	bob := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, s.OFGAClient)
+	c.Check(bob.GetCloudCredentialAccess(ctx, cct), gc.Equals, ofganames.NoRelation)
+
+	conn2 := s.open(c, nil, "charlie")
+	defer conn2.Close()
+	client2 := api.NewClient(conn2)
+
+	// charlie may not give the credential to a group they are not in.
+	err = client2.SetCloudCredentialGroup(&apiparams.SetCloudCredentialGroupRequest{
+		CloudCredentialTag: cct.String(),
+		Group:              "other",
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	err = client2.SetCloudCredentialGroup(&apiparams.SetCloudCredentialGroupRequest{
+		CloudCredentialTag: cct.String(),
+		Group:              "ops",
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	cred := dbmodel.CloudCredential{}
+	cred.SetTag(cct)
+	err = s.JIMM.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(cred.OwnerGroupID.Valid, gc.Equals, true)
+	c.Check(bob.GetCloudCredentialAccess(ctx, cct), gc.Equals, ofganames.AdministratorRelation)
+
+	// The credential reverts to being owned by charlie.
+	err = client2.SetCloudCredentialGroup(&apiparams.SetCloudCredentialGroupRequest{
+		CloudCredentialTag: cct.String(),
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	cred = dbmodel.CloudCredential{}
+	cred.SetTag(cct)
+	err = s.JIMM.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(cred.OwnerGroupID.Valid, gc.Equals, false)
+	c.Check(bob.GetCloudCredentialAccess(ctx, cct), gc.Equals, ofganames.NoRelation)
+}
+
+func (s *cloudCredentialGroupSuite) TestSetCloudCredentialGroupUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.AddGroup(&apiparams.AddGroupRequest{Name: "ops"})
+	c.Assert(err, gc.Equals, nil)
+	err = client.AddRelation(&apiparams.AddRelationRequest{
+		Tuples: []apiparams.RelationshipTuple{{
+			Object:       "user-bob@canonical.com",
+			Relation:     "member",
+			TargetObject: "group-ops",
+		}},
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	// bob may not give away charlie's credential.
+	conn2 := s.open(c, nil, "bob")
+	defer conn2.Close()
+	client2 := api.NewClient(conn2)
+
+	err = client2.SetCloudCredentialGroup(&apiparams.SetCloudCredentialGroupRequest{
+		CloudCredentialTag: s.Credential2.ResourceTag().String(),
+		Group:              "ops",
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	err = client2.SetCloudCredentialGroup(&apiparams.SetCloudCredentialGroupRequest{
+		CloudCredentialTag: "invalid",
+		Group:              "ops",
+	})
+	c.Check(err, gc.ErrorMatches, `"invalid" is not a valid tag \(bad request\)`)
+}
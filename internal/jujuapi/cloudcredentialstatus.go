@@ -0,0 +1,57 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// cloudcredentialstatus contains the RPC methods for reporting the
+// validity of cloud credentials.
+
+// ListCloudCredentials lists the cloud credentials selected by the
+// request, along with the result of their most recent validity check.
+func (r *controllerRoot) ListCloudCredentials(ctx context.Context, req apiparams.ListCloudCredentialsRequest) (apiparams.ListCloudCredentialsResponse, error) {
+	const op = errors.Op("jujuapi.ListCloudCredentials")
+
+	p := jimm.ListCloudCredentialsParams{
+		Invalid: req.Invalid,
+	}
+	if req.OwnerTag != "" {
+		ut, err := names.ParseUserTag(req.OwnerTag)
+		if err != nil {
+			return apiparams.ListCloudCredentialsResponse{}, errors.E(op, err, errors.CodeBadRequest)
+		}
+		p.Owner = ut.Id()
+	}
+	creds, err := r.jimm.ListCloudCredentials(ctx, r.user, p)
+	if err != nil {
+		return apiparams.ListCloudCredentialsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListCloudCredentialsResponse{
+		Credentials: make([]apiparams.CloudCredentialStatus, len(creds)),
+	}
+	for i, cred := range creds {
+		cs := apiparams.CloudCredentialStatus{
+			Tag:      cred.ResourceTag().String(),
+			AuthType: cred.AuthType,
+			Models:   len(cred.Models),
+		}
+		if cred.Valid.Valid {
+			valid := cred.Valid.Bool
+			cs.Valid = &valid
+		}
+		if cred.LastChecked.Valid {
+			t := cred.LastChecked.Time
+			cs.LastChecked = &t
+		}
+		resp.Credentials[i] = cs
+	}
+	return resp, nil
+}
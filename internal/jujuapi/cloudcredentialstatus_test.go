@@ -0,0 +1,86 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type cloudCredentialStatusSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&cloudCredentialStatusSuite{})
+
+func (s *cloudCredentialStatusSuite) TestListCloudCredentials(c *gc.C) {
+	ctx := context.Background()
+
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	resp, err := client.ListCloudCredentials(&apiparams.ListCloudCredentialsRequest{
+		OwnerTag: "user-charlie@canonical.com",
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Credentials, jc.DeepEquals, []apiparams.CloudCredentialStatus{{
+		Tag:      "cloudcred-" + jimmtest.TestCloudName + "_charlie@canonical.com_cred",
+		AuthType: "empty",
+		Models:   2,
+	}})
+
+	resp, err = client.ListCloudCredentials(&apiparams.ListCloudCredentialsRequest{
+		Invalid: true,
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Credentials, gc.HasLen, 0)
+
+	lastChecked := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.Credential2.Valid = sql.NullBool{Bool: false, Valid: true}
+	s.Credential2.LastChecked = sql.NullTime{Time: lastChecked, Valid: true}
+	err = s.JIMM.Database.UpdateCloudCredentialValidity(ctx, s.Credential2)
+	c.Assert(err, gc.Equals, nil)
+
+	resp, err = client.ListCloudCredentials(&apiparams.ListCloudCredentialsRequest{
+		Invalid: true,
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp.Credentials, gc.HasLen, 1)
+	cs := resp.Credentials[0]
+	c.Check(cs.Tag, gc.Equals, "cloudcred-"+jimmtest.TestCloudName+"_charlie@canonical.com_cred")
+	c.Check(cs.Models, gc.Equals, 2)
+	c.Assert(cs.Valid, gc.Not(gc.IsNil))
+	c.Check(*cs.Valid, gc.Equals, false)
+	c.Assert(cs.LastChecked, gc.Not(gc.IsNil))
+	c.Check(cs.LastChecked.Equal(lastChecked), gc.Equals, true)
+}
+
+func (s *cloudCredentialStatusSuite) TestListCloudCredentialsOwnCredentials(c *gc.C) {
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	resp, err := client.ListCloudCredentials(&apiparams.ListCloudCredentialsRequest{})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp.Credentials, gc.HasLen, 1)
+	c.Check(resp.Credentials[0].Tag, gc.Equals, "cloudcred-"+jimmtest.TestCloudName+"_charlie@canonical.com_cred")
+
+	_, err = client.ListCloudCredentials(&apiparams.ListCloudCredentialsRequest{
+		OwnerTag: "user-bob@canonical.com",
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = client.ListCloudCredentials(&apiparams.ListCloudCredentialsRequest{
+		OwnerTag: "bob",
+	})
+	c.Check(err, gc.ErrorMatches, `"bob" is not a valid tag \(bad request\)`)
+}
@@ -31,16 +31,25 @@ type JIMM interface {
 	LoginService
 	ModelManager
 	AddAuditLogEntry(ale *dbmodel.AuditLogEntry)
+	AddCatalogueCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud) error
 	AddCloudToController(ctx context.Context, user *openfga.User, controllerName string, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error
 	AddController(ctx context.Context, u *openfga.User, ctl *dbmodel.Controller) error
 	AddHostedCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud, force bool) error
 	AddGroup(ctx context.Context, user *openfga.User, name string) (*dbmodel.GroupEntry, error)
+	AddMigrationPlan(ctx context.Context, user *openfga.User, params jimm.MigrationPlanParams) (*dbmodel.MigrationPlan, error)
+	AddModelTemplate(ctx context.Context, user *openfga.User, t *dbmodel.ModelTemplate) error
 	AddServiceAccount(ctx context.Context, u *openfga.User, clientId string) error
+	AttachCloudToController(ctx context.Context, user *openfga.User, tag names.CloudTag, controllerName string, force bool) error
 	AuthorizationClient() *openfga.OFGAClient
+	BulkModelAccess(ctx context.Context, user *openfga.User, p jimm.BulkModelAccessParams) (*jimm.BulkModelAccessResult, error)
+	CancelMigrationPlan(ctx context.Context, user *openfga.User, name string) error
+	ControllerVersions(ctx context.Context, user *openfga.User, controllerName string, validateUpgrades bool) ([]jimm.ControllerVersionReport, error)
 	CopyServiceAccountCredential(ctx context.Context, u *openfga.User, svcAcc *openfga.User, cloudCredentialTag names.CloudCredentialTag) (names.CloudCredentialTag, []jujuparams.UpdateCredentialModelResult, error)
 	DB() *db.Database
 	DestroyOffer(ctx context.Context, user *openfga.User, offerURL string, force bool) error
 	EarliestControllerVersion(ctx context.Context) (version.Number, error)
+	EffectiveModelConfig(ctx context.Context, user *openfga.User, args *jimm.ModelCreateArgs) (map[string]jimm.ModelDefault, error)
+	ExtendModel(ctx context.Context, user *openfga.User, mt names.ModelTag, d time.Duration) (time.Time, error)
 	FindApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
 	FindAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error)
 	ForEachCloud(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) error
@@ -49,24 +58,39 @@ type JIMM interface {
 	GetApplicationOffer(ctx context.Context, user *openfga.User, offerURL string) (*jujuparams.ApplicationOfferAdminDetailsV5, error)
 	GetApplicationOfferConsumeDetails(ctx context.Context, user *openfga.User, details *jujuparams.ConsumeOfferDetails, v bakery.Version) error
 	GetCloud(ctx context.Context, u *openfga.User, tag names.CloudTag) (dbmodel.Cloud, error)
+	GetCloudControllerStatuses(ctx context.Context, user *openfga.User, tag names.CloudTag) ([]dbmodel.CloudControllerStatus, error)
 	GetCloudCredential(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (*dbmodel.CloudCredential, error)
 	GetCloudCredentialAttributes(ctx context.Context, u *openfga.User, cred *dbmodel.CloudCredential, hidden bool) (attrs map[string]string, redacted []string, err error)
+	GetCloudCredentialRotation(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) (*dbmodel.CloudCredentialRotation, error)
 	GetControllerConfig(ctx context.Context, u *dbmodel.Identity) (*dbmodel.ControllerConfig, error)
 	GetCredentialStore() credentials.CredentialStore
 	GetJimmControllerAccess(ctx context.Context, user *openfga.User, tag names.UserTag) (string, error)
+	GetMigrationPlan(ctx context.Context, user *openfga.User, name string) (*dbmodel.MigrationPlan, error)
 	GetUserCloudAccess(ctx context.Context, user *openfga.User, cloud names.CloudTag) (string, error)
 	GetUserControllerAccess(ctx context.Context, user *openfga.User, controller names.ControllerTag) (string, error)
 	GetUserModelAccess(ctx context.Context, user *openfga.User, model names.ModelTag) (string, error)
 	GrantAuditLogAccess(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error
 	GrantCloudAccess(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error
+	GrantCloudRegionAccess(ctx context.Context, user *openfga.User, rt jimmnames.CloudRegionTag, ut names.UserTag, access string) error
 	GrantModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error
 	GrantOfferAccess(ctx context.Context, u *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) error
 	GrantServiceAccountAccess(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag, tags []string) error
+	GroupModelDefaults(ctx context.Context, user *openfga.User, groupName string) (dbmodel.GroupModelDefaults, error)
 	InitiateInternalMigration(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetController string) (jujuparams.InitiateMigrationResult, error)
 	InitiateMigration(ctx context.Context, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error)
 	ListApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
+	ListDeletedModels(ctx context.Context, user *openfga.User, uuid string) ([]dbmodel.DeletedModel, error)
 	ListGroups(ctx context.Context, user *openfga.User) ([]dbmodel.GroupEntry, error)
+	ListCloudCredentials(ctx context.Context, user *openfga.User, p jimm.ListCloudCredentialsParams) ([]dbmodel.CloudCredential, error)
+	ListCloudCredentialVersions(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag) ([]credentials.CredentialVersion, error)
+	ListMigrationPlans(ctx context.Context, user *openfga.User) ([]dbmodel.MigrationPlan, error)
+	ListModelTemplates(ctx context.Context, user *openfga.User) ([]dbmodel.ModelTemplate, error)
+	ListUserModels(ctx context.Context, user *openfga.User, filter jimm.ModelListFilter, pageSize int, continuationToken string) ([]jimm.UserModel, string, error)
+	ModelDrift(ctx context.Context, user *openfga.User, p jimm.ModelDriftParams) ([]jimm.ModelDrift, error)
+	ModelLabels(ctx context.Context, user *openfga.User, mt names.ModelTag) (map[string]string, error)
+	ModelQuotas(ctx context.Context, user *openfga.User, entity string) ([]jimm.ModelQuotaUsage, error)
 	Offer(ctx context.Context, user *openfga.User, offer jimm.AddApplicationOfferParams) error
+	PauseMigrationPlan(ctx context.Context, user *openfga.User, name string) error
 	ParseTag(ctx context.Context, key string) (*ofganames.Tag, error)
 	PubSubHub() *pubsub.Hub
 	PurgeLogs(ctx context.Context, user *openfga.User, before time.Time) (int64, error)
@@ -75,18 +99,33 @@ type JIMM interface {
 	RemoveCloudFromController(ctx context.Context, u *openfga.User, controllerName string, ct names.CloudTag) error
 	RemoveController(ctx context.Context, user *openfga.User, controllerName string, force bool) error
 	RemoveGroup(ctx context.Context, user *openfga.User, name string) error
+	RemoveModelQuota(ctx context.Context, user *openfga.User, entity, cloudName string) error
+	RemoveModelTemplate(ctx context.Context, user *openfga.User, name string) error
+	RestoreCloudCredentialVersion(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, version int) ([]jujuparams.UpdateCredentialModelResult, error)
+	ResumeMigrationPlan(ctx context.Context, user *openfga.User, name string) error
 	ResourceTag() names.ControllerTag
 	RevokeAuditLogAccess(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error
+	RotateCloudCredential(ctx context.Context, user *openfga.User, args jimm.RotateCloudCredentialArgs) (*dbmodel.CloudCredentialRotation, error)
+	RotateControllerCredentials(ctx context.Context, user *openfga.User, controllerName string) error
 	RevokeCloudAccess(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error
 	RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error
+	RevokeCloudRegionAccess(ctx context.Context, user *openfga.User, rt jimmnames.CloudRegionTag, ut names.UserTag, access string) error
 	RevokeModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error
 	RevokeOfferAccess(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) (err error)
+	SetCloudCredentialGroup(ctx context.Context, user *openfga.User, tag names.CloudCredentialTag, group string) error
 	SetControllerConfig(ctx context.Context, u *openfga.User, args jujuparams.ControllerConfigSet) error
 	SetControllerDeprecated(ctx context.Context, user *openfga.User, controllerName string, deprecated bool) error
+	SetGroupModelDefaults(ctx context.Context, user *openfga.User, groupName string, priority *int, configs map[string]interface{}) error
+	SetModelLabels(ctx context.Context, user *openfga.User, mt names.ModelTag, labels map[string]string, remove []string) (map[string]string, error)
+	SetModelQuota(ctx context.Context, user *openfga.User, entity string, quota *dbmodel.ModelQuota) error
 	ToJAASTag(ctx context.Context, tag *ofganames.Tag, resolveUUIDs bool) (string, error)
+	TransferModelOwnership(ctx context.Context, user *openfga.User, mt names.ModelTag, newOwner names.UserTag, keepAccess bool) error
+	UnsetGroupModelDefaults(ctx context.Context, user *openfga.User, groupName string, keys []string) error
 	UpdateApplicationOffer(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) error
+	UpdateCatalogueCloud(ctx context.Context, user *openfga.User, tag names.CloudTag, cloud jujuparams.Cloud) ([]dbmodel.CloudControllerStatus, error)
 	UpdateCloud(ctx context.Context, u *openfga.User, ct names.CloudTag, cloud jujuparams.Cloud) error
 	UpdateCloudCredential(ctx context.Context, u *openfga.User, args jimm.UpdateCloudCredentialArgs) ([]jujuparams.UpdateCredentialModelResult, error)
+	UpdateModelTemplate(ctx context.Context, user *openfga.User, t *dbmodel.ModelTemplate) error
 	UserLogin(ctx context.Context, identityName string) (*openfga.User, error)
 }
 
@@ -0,0 +1,86 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// credentialrotation contains the RPC methods for the staged rotation of
+// cloud credentials.
+
+// RotateCloudCredential replaces the attributes of the credential in the
+// request using a staged rollout across the controllers hosting models
+// that use the credential.
+func (r *controllerRoot) RotateCloudCredential(ctx context.Context, req apiparams.RotateCloudCredentialRequest) (apiparams.CloudCredentialRotation, error) {
+	const op = errors.Op("jujuapi.RotateCloudCredential")
+
+	tag, err := r.rotationCredentialTag(req.CloudCredentialRotationRequest)
+	if err != nil {
+		return apiparams.CloudCredentialRotation{}, errors.E(op, err)
+	}
+	rot, err := r.jimm.RotateCloudCredential(ctx, r.user, jimm.RotateCloudCredentialArgs{
+		CredentialTag: tag,
+		Credential:    req.Credential,
+	})
+	if err != nil {
+		return apiparams.CloudCredentialRotation{}, errors.E(op, err)
+	}
+	return credentialRotationParams(tag, rot), nil
+}
+
+// GetCloudCredentialRotation returns the most recent rotation of the
+// credential in the request.
+func (r *controllerRoot) GetCloudCredentialRotation(ctx context.Context, req apiparams.CloudCredentialRotationRequest) (apiparams.CloudCredentialRotation, error) {
+	const op = errors.Op("jujuapi.GetCloudCredentialRotation")
+
+	tag, err := r.rotationCredentialTag(req)
+	if err != nil {
+		return apiparams.CloudCredentialRotation{}, errors.E(op, err)
+	}
+	rot, err := r.jimm.GetCloudCredentialRotation(ctx, r.user, tag)
+	if err != nil {
+		return apiparams.CloudCredentialRotation{}, errors.E(op, err)
+	}
+	return credentialRotationParams(tag, rot), nil
+}
+
+// rotationCredentialTag returns the tag of the credential identified in
+// the request, the credential is owned by the authenticated user unless
+// an owner is specified.
+func (r *controllerRoot) rotationCredentialTag(req apiparams.CloudCredentialRotationRequest) (names.CloudCredentialTag, error) {
+	owner := r.user.Name
+	if req.OwnerTag != "" {
+		ut, err := names.ParseUserTag(req.OwnerTag)
+		if err != nil {
+			return names.CloudCredentialTag{}, errors.E(err, errors.CodeBadRequest)
+		}
+		owner = ut.Id()
+	}
+	id := fmt.Sprintf("%s/%s/%s", req.Cloud, owner, req.CredentialName)
+	if !names.IsValidCloudCredential(id) {
+		return names.CloudCredentialTag{}, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid cloud credential %q", id))
+	}
+	return names.NewCloudCredentialTag(id), nil
+}
+
+func credentialRotationParams(tag names.CloudCredentialTag, rot *dbmodel.CloudCredentialRotation) apiparams.CloudCredentialRotation {
+	return apiparams.CloudCredentialRotation{
+		CredentialTag:      tag.String(),
+		CreatedBy:          rot.CreatedBy,
+		Status:             rot.Status,
+		StatusMessage:      rot.StatusMessage,
+		CanaryController:   rot.CanaryController,
+		UpdatedControllers: rot.UpdatedControllers,
+		StartedAt:          rot.CreatedAt,
+		UpdatedAt:          rot.UpdatedAt,
+	}
+}
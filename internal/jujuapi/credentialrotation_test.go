@@ -0,0 +1,98 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	jujuparams "github.com/juju/juju/rpc/params"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type credentialRotationSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&credentialRotationSuite{})
+
+func (s *credentialRotationSuite) TestRotateCloudCredential(c *gc.C) {
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	req := apiparams.CloudCredentialRotationRequest{
+		Cloud:          jimmtest.TestCloudName,
+		CredentialName: "cred",
+	}
+	_, err := client.GetCloudCredentialRotation(&req)
+	c.Check(err, gc.ErrorMatches, `cloud credential rotation not found \(not found\)`)
+
+	rot, err := client.RotateCloudCredential(&apiparams.RotateCloudCredentialRequest{
+		CloudCredentialRotationRequest: req,
+		Credential:                     jujuparams.CloudCredential{AuthType: "empty"},
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(rot.CredentialTag, gc.Equals, "cloudcred-"+jimmtest.TestCloudName+"_charlie@canonical.com_cred")
+	c.Check(rot.CreatedBy, gc.Equals, "charlie@canonical.com")
+	c.Check(rot.Status, gc.Equals, "completed")
+	c.Check(rot.CanaryController, gc.Equals, "controller-1")
+	c.Check(rot.UpdatedControllers, jc.DeepEquals, []string{"controller-1"})
+
+	latest, err := client.GetCloudCredentialRotation(&req)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(latest.CredentialTag, gc.Equals, rot.CredentialTag)
+	c.Check(latest.Status, gc.Equals, "completed")
+	c.Check(latest.StartedAt.Equal(rot.StartedAt), gc.Equals, true)
+
+	// An administrator can view the rotation of another user's
+	// credential.
+	conn2 := s.open(c, nil, "alice")
+	defer conn2.Close()
+	client2 := api.NewClient(conn2)
+
+	req.OwnerTag = "user-charlie@canonical.com"
+	latest, err = client2.GetCloudCredentialRotation(&req)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(latest.Status, gc.Equals, "completed")
+}
+
+func (s *credentialRotationSuite) TestRotateCloudCredentialInvalid(c *gc.C) {
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.RotateCloudCredential(&apiparams.RotateCloudCredentialRequest{
+		CloudCredentialRotationRequest: apiparams.CloudCredentialRotationRequest{
+			Cloud:          jimmtest.TestCloudName,
+			CredentialName: "bad cred",
+		},
+		Credential: jujuparams.CloudCredential{AuthType: "empty"},
+	})
+	c.Check(err, gc.ErrorMatches, `invalid cloud credential "`+jimmtest.TestCloudName+`/charlie@canonical.com/bad cred" \(bad request\)`)
+
+	_, err = client.GetCloudCredentialRotation(&apiparams.CloudCredentialRotationRequest{
+		Cloud:          jimmtest.TestCloudName,
+		CredentialName: "cred",
+		OwnerTag:       "charlie",
+	})
+	c.Check(err, gc.ErrorMatches, `"charlie" is not a valid tag \(bad request\)`)
+}
+
+func (s *credentialRotationSuite) TestRotateCloudCredentialUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.RotateCloudCredential(&apiparams.RotateCloudCredentialRequest{
+		CloudCredentialRotationRequest: apiparams.CloudCredentialRotationRequest{
+			Cloud:          jimmtest.TestCloudName,
+			CredentialName: "cred",
+			OwnerTag:       "user-charlie@canonical.com",
+		},
+		Credential: jujuparams.CloudCredential{AuthType: "empty"},
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
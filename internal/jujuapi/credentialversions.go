@@ -0,0 +1,57 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// credentialversions contains the RPC methods for listing and restoring
+// previous versions of cloud credentials.
+
+// ListCloudCredentialVersions returns the versions of the attributes of
+// the credential in the request that are kept by JIMM's credential store.
+func (r *controllerRoot) ListCloudCredentialVersions(ctx context.Context, req apiparams.ListCloudCredentialVersionsRequest) (apiparams.ListCloudCredentialVersionsResponse, error) {
+	const op = errors.Op("jujuapi.ListCloudCredentialVersions")
+
+	tag, err := names.ParseCloudCredentialTag(req.CredentialTag)
+	if err != nil {
+		return apiparams.ListCloudCredentialVersionsResponse{}, errors.E(op, err, errors.CodeBadRequest)
+	}
+	versions, err := r.jimm.ListCloudCredentialVersions(ctx, r.user, tag)
+	if err != nil {
+		return apiparams.ListCloudCredentialVersionsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListCloudCredentialVersionsResponse{
+		Versions: make([]apiparams.CloudCredentialVersion, len(versions)),
+	}
+	for i, v := range versions {
+		resp.Versions[i] = apiparams.CloudCredentialVersion{
+			Version:     v.Version,
+			CreatedTime: v.CreatedTime,
+			Deleted:     v.Deleted,
+		}
+	}
+	return resp, nil
+}
+
+// RestoreCloudCredentialVersion restores the given version of the
+// attributes of the credential in the request.
+func (r *controllerRoot) RestoreCloudCredentialVersion(ctx context.Context, req apiparams.RestoreCloudCredentialVersionRequest) (apiparams.RestoreCloudCredentialVersionResponse, error) {
+	const op = errors.Op("jujuapi.RestoreCloudCredentialVersion")
+
+	tag, err := names.ParseCloudCredentialTag(req.CredentialTag)
+	if err != nil {
+		return apiparams.RestoreCloudCredentialVersionResponse{}, errors.E(op, err, errors.CodeBadRequest)
+	}
+	models, err := r.jimm.RestoreCloudCredentialVersion(ctx, r.user, tag, req.Version)
+	if err != nil {
+		return apiparams.RestoreCloudCredentialVersionResponse{Models: models}, errors.E(op, err)
+	}
+	return apiparams.RestoreCloudCredentialVersionResponse{Models: models}, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"context"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type credentialVersionsSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&credentialVersionsSuite{})
+
+func (s *credentialVersionsSuite) TestCloudCredentialVersions(c *gc.C) {
+	ctx := context.Background()
+
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/alice@canonical.com/cred-v")
+	for _, password := range []string{"one", "two"} {
+		s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{
+			AuthType:   "userpass",
+			Attributes: map[string]string{"username": "alice", "password": password},
+		})
+	}
+
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	resp, err := client.ListCloudCredentialVersions(&apiparams.ListCloudCredentialVersionsRequest{
+		CredentialTag: cct.String(),
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp.Versions, gc.HasLen, 2)
+	c.Check(resp.Versions[0].Version, gc.Equals, 1)
+	c.Check(resp.Versions[0].Deleted, gc.Equals, false)
+	c.Check(resp.Versions[1].Version, gc.Equals, 2)
+
+	rresp, err := client.RestoreCloudCredentialVersion(&apiparams.RestoreCloudCredentialVersionRequest{
+		CredentialTag: cct.String(),
+		Version:       1,
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(rresp.Models, gc.HasLen, 0)
+
+	attrs, err := s.JIMM.CredentialStore.Get(ctx, cct)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(attrs, jc.DeepEquals, map[string]string{"username": "alice", "password": "one"})
+
+	_, err = client.RestoreCloudCredentialVersion(&apiparams.RestoreCloudCredentialVersionRequest{
+		CredentialTag: cct.String(),
+		Version:       10,
+	})
+	c.Check(err, gc.ErrorMatches, `.*\(not found\)`)
+}
+
+func (s *credentialVersionsSuite) TestCloudCredentialVersionsInvalidTag(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.ListCloudCredentialVersions(&apiparams.ListCloudCredentialVersionsRequest{
+		CredentialTag: "invalid",
+	})
+	c.Check(err, gc.ErrorMatches, `"invalid" is not a valid tag \(bad request\)`)
+
+	_, err = client.RestoreCloudCredentialVersion(&apiparams.RestoreCloudCredentialVersionRequest{
+		CredentialTag: "invalid",
+		Version:       1,
+	})
+	c.Check(err, gc.ErrorMatches, `"invalid" is not a valid tag \(bad request\)`)
+}
+
+func (s *credentialVersionsSuite) TestCloudCredentialVersionsUnauthorized(c *gc.C) {
+	// Only JIMM administrators may access credential versions, even
+	// those of their own credentials.
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.ListCloudCredentialVersions(&apiparams.ListCloudCredentialVersionsRequest{
+		CredentialTag: s.Credential2.ResourceTag().String(),
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = client.RestoreCloudCredentialVersion(&apiparams.RestoreCloudCredentialVersionRequest{
+		CredentialTag: s.Credential2.ResourceTag().String(),
+		Version:       1,
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/canonical/ofga"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// deletedmodel contains the RPC methods for inspecting the tombstones of
+// models that have been removed from JIMM.
+
+// ListDeletedModels returns the tombstones of deleted models.
+func (r *controllerRoot) ListDeletedModels(ctx context.Context, req apiparams.ListDeletedModelsRequest) (apiparams.ListDeletedModelsResponse, error) {
+	const op = errors.Op("jujuapi.ListDeletedModels")
+
+	models, err := r.jimm.ListDeletedModels(ctx, r.user, req.ModelUUID)
+	if err != nil {
+		return apiparams.ListDeletedModelsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListDeletedModelsResponse{
+		Models: make([]apiparams.DeletedModel, len(models)),
+	}
+	for i := range models {
+		resp.Models[i] = r.deletedModelToParams(ctx, &models[i])
+	}
+	return resp, nil
+}
+
+// deletedModelToParams converts a deleted model tombstone to its API
+// representation. The entities in the recorded tuples are converted to
+// JAAS tags where possible.
+func (r *controllerRoot) deletedModelToParams(ctx context.Context, dm *dbmodel.DeletedModel) apiparams.DeletedModel {
+	m := apiparams.DeletedModel{
+		UUID:            dm.UUID,
+		Name:            dm.Name,
+		Owner:           dm.OwnerIdentityName,
+		Controller:      dm.ControllerName,
+		Cloud:           dm.CloudName,
+		Region:          dm.CloudRegionName,
+		CloudCredential: dm.CloudCredentialName,
+		Type:            dm.Type,
+		Life:            dm.Life,
+		Labels:          dm.Labels,
+		CreatedAt:       dm.ModelCreatedAt,
+		DeletedAt:       dm.DeletedAt,
+	}
+	toTag := func(s string) string {
+		e, err := ofga.ParseEntity(s)
+		if err != nil {
+			return s
+		}
+		tag, err := r.jimm.ToJAASTag(ctx, &e, false)
+		if err != nil {
+			return s
+		}
+		return tag
+	}
+	for _, t := range dm.Tuples {
+		m.Tuples = append(m.Tuples, apiparams.RelationshipTuple{
+			Object:       toTag(t.Object),
+			Relation:     t.Relation,
+			TargetObject: toTag(t.Target),
+		})
+	}
+	return m
+}
@@ -0,0 +1,73 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"context"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type deletedModelSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&deletedModelSuite{})
+
+func (s *deletedModelSuite) TestListDeletedModels(c *gc.C) {
+	deletedAt := time.Now().UTC().Truncate(time.Millisecond)
+	dm := dbmodel.DeletedModel{
+		DeletedAt: deletedAt,
+		Tuples: dbmodel.DeletedModelTuples{{
+			Object:   "user:bob@canonical.com",
+			Relation: "reader",
+			Target:   "model:" + s.Model3.UUID.String,
+		}},
+	}
+	dm.FromModel(s.Model3)
+	err := s.JIMM.Database.AddDeletedModel(context.Background(), &dm)
+	c.Assert(err, gc.Equals, nil)
+
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	resp, err := client.ListDeletedModels(&apiparams.ListDeletedModelsRequest{})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp.Models, gc.HasLen, 1)
+	m := resp.Models[0]
+	c.Check(m.UUID, gc.Equals, s.Model3.UUID.String)
+	c.Check(m.Name, gc.Equals, "model-3")
+	c.Check(m.Owner, gc.Equals, "charlie@canonical.com")
+	c.Check(m.Controller, gc.Equals, s.Model3.Controller.Name)
+	c.Check(m.Cloud, gc.Equals, s.Model3.CloudRegion.Cloud.Name)
+	c.Check(m.Region, gc.Equals, s.Model3.CloudRegion.Name)
+	c.Check(m.CloudCredential, gc.Equals, "cred")
+	c.Check(m.DeletedAt.Equal(deletedAt), gc.Equals, true)
+	c.Check(m.Tuples, jc.DeepEquals, []apiparams.RelationshipTuple{{
+		Object:       "user-bob@canonical.com",
+		Relation:     "reader",
+		TargetObject: "model-" + s.Model3.UUID.String,
+	}})
+
+	resp, err = client.ListDeletedModels(&apiparams.ListDeletedModelsRequest{
+		ModelUUID: s.Model2.UUID.String,
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Models, gc.HasLen, 0)
+}
+
+func (s *deletedModelSuite) TestListDeletedModelsUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.ListDeletedModels(&apiparams.ListDeletedModelsRequest{})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"sort"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// groupmodeldefaults contains the RPC methods for managing the model
+// defaults of groups and reporting the effective config of new models.
+
+// SetGroupModelDefaults sets model defaults for the group in the request.
+func (r *controllerRoot) SetGroupModelDefaults(ctx context.Context, req apiparams.SetGroupModelDefaultsRequest) error {
+	const op = errors.Op("jujuapi.SetGroupModelDefaults")
+
+	if err := r.jimm.SetGroupModelDefaults(ctx, r.user, req.Group, req.Priority, req.Config); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// UnsetGroupModelDefaults unsets model defaults for the group in the
+// request.
+func (r *controllerRoot) UnsetGroupModelDefaults(ctx context.Context, req apiparams.UnsetGroupModelDefaultsRequest) error {
+	const op = errors.Op("jujuapi.UnsetGroupModelDefaults")
+
+	if err := r.jimm.UnsetGroupModelDefaults(ctx, r.user, req.Group, req.Keys); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// GroupModelDefaults returns the model defaults of the group in the
+// request.
+func (r *controllerRoot) GroupModelDefaults(ctx context.Context, req apiparams.GroupModelDefaultsRequest) (apiparams.GroupModelDefaults, error) {
+	const op = errors.Op("jujuapi.GroupModelDefaults")
+
+	defaults, err := r.jimm.GroupModelDefaults(ctx, r.user, req.Group)
+	if err != nil {
+		return apiparams.GroupModelDefaults{}, errors.E(op, err)
+	}
+	return apiparams.GroupModelDefaults{
+		Group:    defaults.Group.Name,
+		Priority: defaults.Priority,
+		Config:   defaults.Defaults,
+	}, nil
+}
+
+// EffectiveModelConfig returns the config a model described by the
+// request would be added with if the authenticated user added it, along
+// with the source of each value.
+func (r *controllerRoot) EffectiveModelConfig(ctx context.Context, req apiparams.EffectiveModelConfigRequest) (apiparams.EffectiveModelConfigResponse, error) {
+	const op = errors.Op("jujuapi.EffectiveModelConfig")
+
+	args := jimm.ModelCreateArgs{
+		Owner:       r.user.ResourceTag(),
+		CloudRegion: req.CloudRegion,
+		Config:      req.Config,
+	}
+	if req.CloudTag != "" {
+		ct, err := names.ParseCloudTag(req.CloudTag)
+		if err != nil {
+			return apiparams.EffectiveModelConfigResponse{}, errors.E(op, errors.CodeBadRequest, err)
+		}
+		args.Cloud = ct
+	}
+	config, err := r.jimm.EffectiveModelConfig(ctx, r.user, &args)
+	if err != nil {
+		return apiparams.EffectiveModelConfigResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.EffectiveModelConfigResponse{
+		Config: make([]apiparams.EffectiveModelConfigValue, 0, len(config)),
+	}
+	for k, v := range config {
+		resp.Config = append(resp.Config, apiparams.EffectiveModelConfigValue{
+			Key:    k,
+			Value:  v.Value,
+			Source: v.Source,
+			Group:  v.Group,
+		})
+	}
+	sort.Slice(resp.Config, func(i, j int) bool {
+		return resp.Config[i].Key < resp.Config[j].Key
+	})
+	return resp, nil
+}
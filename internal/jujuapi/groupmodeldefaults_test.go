@@ -0,0 +1,136 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type groupModelDefaultsSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&groupModelDefaultsSuite{})
+
+func (s *groupModelDefaultsSuite) TestGroupModelDefaults(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.AddGroup(&apiparams.AddGroupRequest{Name: "devs"})
+	c.Assert(err, gc.Equals, nil)
+	err = client.AddRelation(&apiparams.AddRelationRequest{
+		Tuples: []apiparams.RelationshipTuple{{
+			Object:       "user-bob@canonical.com",
+			Relation:     "member",
+			TargetObject: "group-devs",
+		}},
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	_, err = client.GroupModelDefaults(&apiparams.GroupModelDefaultsRequest{Group: "devs"})
+	c.Check(err, gc.ErrorMatches, `.*\(not found\)`)
+
+	priority := 5
+	err = client.SetGroupModelDefaults(&apiparams.SetGroupModelDefaultsRequest{
+		Group:    "devs",
+		Priority: &priority,
+		Config: map[string]interface{}{
+			"logging-config": "<root>=INFO",
+			"ftp-proxy":      "ftp.example.com",
+		},
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	defaults, err := client.GroupModelDefaults(&apiparams.GroupModelDefaultsRequest{Group: "devs"})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(defaults, jc.DeepEquals, apiparams.GroupModelDefaults{
+		Group:    "devs",
+		Priority: 5,
+		Config: map[string]interface{}{
+			"logging-config": "<root>=INFO",
+			"ftp-proxy":      "ftp.example.com",
+		},
+	})
+
+	err = client.UnsetGroupModelDefaults(&apiparams.UnsetGroupModelDefaultsRequest{
+		Group: "devs",
+		Keys:  []string{"ftp-proxy"},
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	defaults, err = client.GroupModelDefaults(&apiparams.GroupModelDefaultsRequest{Group: "devs"})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(defaults.Config, jc.DeepEquals, map[string]interface{}{
+		"logging-config": "<root>=INFO",
+	})
+
+	// The group's defaults apply to models added by its members.
+	conn2 := s.open(c, nil, "bob")
+	defer conn2.Close()
+	client2 := api.NewClient(conn2)
+
+	resp, err := client2.EffectiveModelConfig(&apiparams.EffectiveModelConfigRequest{
+		CloudTag: "cloud-" + jimmtest.TestCloudName,
+		Config: map[string]interface{}{
+			"http-proxy": "http.example.com",
+		},
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Config, jc.DeepEquals, []apiparams.EffectiveModelConfigValue{{
+		Key:    "http-proxy",
+		Value:  "http.example.com",
+		Source: "explicit",
+	}, {
+		Key:    "logging-config",
+		Value:  "<root>=INFO",
+		Source: "group",
+		Group:  "devs",
+	}})
+}
+
+func (s *groupModelDefaultsSuite) TestGroupModelDefaultsInvalid(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.AddGroup(&apiparams.AddGroupRequest{Name: "devs"})
+	c.Assert(err, gc.Equals, nil)
+
+	err = client.SetGroupModelDefaults(&apiparams.SetGroupModelDefaultsRequest{
+		Group:  "devs",
+		Config: map[string]interface{}{"agent-version": "3.0.0"},
+	})
+	c.Check(err, gc.ErrorMatches, `agent-version cannot have a default value \(bad request\)`)
+
+	_, err = client.EffectiveModelConfig(&apiparams.EffectiveModelConfigRequest{
+		CloudTag: "not-a-cloud-tag",
+	})
+	c.Check(err, gc.ErrorMatches, `.*\(bad request\)`)
+}
+
+func (s *groupModelDefaultsSuite) TestGroupModelDefaultsUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	err := client.SetGroupModelDefaults(&apiparams.SetGroupModelDefaultsRequest{
+		Group:  "devs",
+		Config: map[string]interface{}{"logging-config": "<root>=INFO"},
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	err = client.UnsetGroupModelDefaults(&apiparams.UnsetGroupModelDefaultsRequest{
+		Group: "devs",
+		Keys:  []string{"logging-config"},
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = client.GroupModelDefaults(&apiparams.GroupModelDefaultsRequest{Group: "devs"})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
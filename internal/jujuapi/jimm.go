@@ -18,6 +18,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
@@ -26,6 +27,7 @@ import (
 func init() {
 	facadeInit["JIMM"] = func(r *controllerRoot) []int {
 		addControllerMethod := rpc.Method(r.AddController)
+		controllerVersionsMethod := rpc.Method(r.ControllerVersions)
 		disableControllerUUIDMaskingMethod := rpc.Method(r.DisableControllerUUIDMasking)
 		findAuditEventsMethod := rpc.Method(r.FindAuditEvents)
 		grantAuditLogAccessMethod := rpc.Method(r.GrantAuditLogAccess)
@@ -33,6 +35,42 @@ func init() {
 		listControllersMethod := rpc.Method(r.ListControllers)
 		removeControllerMethod := rpc.Method(r.RemoveController)
 		revokeAuditLogAccessMethod := rpc.Method(r.RevokeAuditLogAccess)
+		rotateControllerCredentialsMethod := rpc.Method(r.RotateControllerCredentials)
+		setModelQuotaMethod := rpc.Method(r.SetModelQuota)
+		removeModelQuotaMethod := rpc.Method(r.RemoveModelQuota)
+		modelQuotasMethod := rpc.Method(r.ModelQuotas)
+		extendModelMethod := rpc.Method(r.ExtendModel)
+		setModelLabelsMethod := rpc.Method(r.SetModelLabels)
+		listModelsMethod := rpc.Method(r.listLabelledModels)
+		listModelSummariesMethod := rpc.Method(r.listLabelledModelSummaries)
+		modelInfoMethod := rpc.Method(r.labelledModelInfo)
+		watchModelSummariesMethod := rpc.Method(r.watchLabelledModelSummaries)
+		transferModelOwnershipMethod := rpc.Method(r.TransferModelOwnership)
+		addModelTemplateMethod := rpc.Method(r.AddModelTemplate)
+		updateModelTemplateMethod := rpc.Method(r.UpdateModelTemplate)
+		removeModelTemplateMethod := rpc.Method(r.RemoveModelTemplate)
+		listModelTemplatesMethod := rpc.Method(r.ListModelTemplates)
+		listDeletedModelsMethod := rpc.Method(r.ListDeletedModels)
+		bulkModelAccessMethod := rpc.Method(r.BulkModelAccess)
+		modelDriftMethod := rpc.Method(r.ModelDrift)
+		setGroupModelDefaultsMethod := rpc.Method(r.SetGroupModelDefaults)
+		unsetGroupModelDefaultsMethod := rpc.Method(r.UnsetGroupModelDefaults)
+		groupModelDefaultsMethod := rpc.Method(r.GroupModelDefaults)
+		effectiveModelConfigMethod := rpc.Method(r.EffectiveModelConfig)
+		listCloudCredentialsMethod := rpc.Method(r.ListCloudCredentials)
+		setCloudCredentialGroupMethod := rpc.Method(r.SetCloudCredentialGroup)
+		rotateCloudCredentialMethod := rpc.Method(r.RotateCloudCredential)
+		getCloudCredentialRotationMethod := rpc.Method(r.GetCloudCredentialRotation)
+		listCloudCredentialVersionsMethod := rpc.Method(r.ListCloudCredentialVersions)
+		restoreCloudCredentialVersionMethod := rpc.Method(r.RestoreCloudCredentialVersion)
+		addCatalogueCloudMethod := rpc.Method(r.AddCatalogueCloud)
+		attachCloudToControllerMethod := rpc.Method(r.AttachCloudToController)
+		updateCatalogueCloudMethod := rpc.Method(r.UpdateCatalogueCloud)
+		cloudControllerStatusMethod := rpc.Method(r.CloudControllerStatus)
+		addMigrationPlanMethod := rpc.Method(r.AddMigrationPlan)
+		getMigrationPlanMethod := rpc.Method(r.GetMigrationPlan)
+		listMigrationPlansMethod := rpc.Method(r.ListMigrationPlans)
+		updateMigrationPlanMethod := rpc.Method(r.UpdateMigrationPlan)
 		setControllerDeprecatedMethod := rpc.Method(r.SetControllerDeprecated)
 		fullModelStatusMethod := rpc.Method(r.FullModelStatus)
 		updateMigratedModelMethod := rpc.Method(r.UpdateMigratedModel)
@@ -57,6 +95,7 @@ func init() {
 
 		// JIMM Generic RPC
 		r.AddMethod("JIMM", 4, "AddController", addControllerMethod)
+		r.AddMethod("JIMM", 4, "ControllerVersions", controllerVersionsMethod)
 		r.AddMethod("JIMM", 4, "DisableControllerUUIDMasking", disableControllerUUIDMaskingMethod)
 		r.AddMethod("JIMM", 4, "FindAuditEvents", findAuditEventsMethod)
 		r.AddMethod("JIMM", 4, "FullModelStatus", fullModelStatusMethod)
@@ -65,6 +104,7 @@ func init() {
 		r.AddMethod("JIMM", 4, "ListControllers", listControllersMethod)
 		r.AddMethod("JIMM", 4, "RemoveController", removeControllerMethod)
 		r.AddMethod("JIMM", 4, "RevokeAuditLogAccess", revokeAuditLogAccessMethod)
+		r.AddMethod("JIMM", 4, "RotateControllerCredentials", rotateControllerCredentialsMethod)
 		r.AddMethod("JIMM", 4, "SetControllerDeprecated", setControllerDeprecatedMethod)
 		r.AddMethod("JIMM", 4, "UpdateMigratedModel", updateMigratedModelMethod)
 		r.AddMethod("JIMM", 4, "AddCloudToController", addCloudToControllerMethod)
@@ -88,6 +128,56 @@ func init() {
 		r.AddMethod("JIMM", 4, "UpdateServiceAccountCredentials", updateServiceAccountCredentials)
 		r.AddMethod("JIMM", 4, "ListServiceAccountCredentials", listServiceAccountCredentials)
 		r.AddMethod("JIMM", 4, "GrantServiceAccountAccess", grantServiceAccountAccess)
+		// JIMM Model quotas
+		r.AddMethod("JIMM", 4, "SetModelQuota", setModelQuotaMethod)
+		r.AddMethod("JIMM", 4, "RemoveModelQuota", removeModelQuotaMethod)
+		r.AddMethod("JIMM", 4, "ModelQuotas", modelQuotasMethod)
+		// JIMM ephemeral models
+		r.AddMethod("JIMM", 4, "ExtendModel", extendModelMethod)
+		// JIMM Model labels
+		r.AddMethod("JIMM", 4, "SetModelLabels", setModelLabelsMethod)
+		r.AddMethod("JIMM", 4, "ListModels", listModelsMethod)
+		r.AddMethod("JIMM", 4, "ListModelSummaries", listModelSummariesMethod)
+		r.AddMethod("JIMM", 4, "ModelInfo", modelInfoMethod)
+		r.AddMethod("JIMM", 4, "WatchModelSummaries", watchModelSummariesMethod)
+		// JIMM Model ownership
+		r.AddMethod("JIMM", 4, "TransferModelOwnership", transferModelOwnershipMethod)
+		// JIMM Model templates
+		r.AddMethod("JIMM", 4, "AddModelTemplate", addModelTemplateMethod)
+		r.AddMethod("JIMM", 4, "UpdateModelTemplate", updateModelTemplateMethod)
+		r.AddMethod("JIMM", 4, "RemoveModelTemplate", removeModelTemplateMethod)
+		r.AddMethod("JIMM", 4, "ListModelTemplates", listModelTemplatesMethod)
+		// JIMM Deleted models
+		r.AddMethod("JIMM", 4, "ListDeletedModels", listDeletedModelsMethod)
+		// JIMM Bulk model access
+		r.AddMethod("JIMM", 4, "BulkModelAccess", bulkModelAccessMethod)
+		// JIMM Model config drift
+		r.AddMethod("JIMM", 4, "ModelDrift", modelDriftMethod)
+		// JIMM Group model defaults
+		r.AddMethod("JIMM", 4, "SetGroupModelDefaults", setGroupModelDefaultsMethod)
+		r.AddMethod("JIMM", 4, "UnsetGroupModelDefaults", unsetGroupModelDefaultsMethod)
+		r.AddMethod("JIMM", 4, "GroupModelDefaults", groupModelDefaultsMethod)
+		r.AddMethod("JIMM", 4, "EffectiveModelConfig", effectiveModelConfigMethod)
+		// JIMM Group-owned cloud credentials
+		r.AddMethod("JIMM", 4, "SetCloudCredentialGroup", setCloudCredentialGroupMethod)
+		// JIMM Cloud credential validity
+		r.AddMethod("JIMM", 4, "ListCloudCredentials", listCloudCredentialsMethod)
+		// JIMM Cloud credential rotation
+		r.AddMethod("JIMM", 4, "RotateCloudCredential", rotateCloudCredentialMethod)
+		r.AddMethod("JIMM", 4, "GetCloudCredentialRotation", getCloudCredentialRotationMethod)
+		// JIMM Cloud credential versions
+		r.AddMethod("JIMM", 4, "ListCloudCredentialVersions", listCloudCredentialVersionsMethod)
+		r.AddMethod("JIMM", 4, "RestoreCloudCredentialVersion", restoreCloudCredentialVersionMethod)
+		// JIMM Cloud catalogue
+		r.AddMethod("JIMM", 4, "AddCatalogueCloud", addCatalogueCloudMethod)
+		r.AddMethod("JIMM", 4, "AttachCloudToController", attachCloudToControllerMethod)
+		r.AddMethod("JIMM", 4, "UpdateCatalogueCloud", updateCatalogueCloudMethod)
+		r.AddMethod("JIMM", 4, "CloudControllerStatus", cloudControllerStatusMethod)
+		// JIMM Migration plans
+		r.AddMethod("JIMM", 4, "AddMigrationPlan", addMigrationPlanMethod)
+		r.AddMethod("JIMM", 4, "GetMigrationPlan", getMigrationPlanMethod)
+		r.AddMethod("JIMM", 4, "ListMigrationPlans", listMigrationPlansMethod)
+		r.AddMethod("JIMM", 4, "UpdateMigrationPlan", updateMigrationPlanMethod)
 
 		return []int{4}
 	}
@@ -275,6 +365,65 @@ func (r *controllerRoot) SetControllerDeprecated(ctx context.Context, req apipar
 	return ctl.ToAPIControllerInfo(), nil
 }
 
+// RotateControllerCredentials generates and sets a new password for the
+// user JIMM uses to connect to the specified controller.
+func (r *controllerRoot) RotateControllerCredentials(ctx context.Context, req apiparams.RotateControllerCredentialsRequest) error {
+	const op = errors.Op("jujuapi.RotateControllerCredentials")
+
+	if err := r.jimm.RotateControllerCredentials(ctx, r.user, req.Name); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ControllerVersions returns the agent versions of the controllers
+// managed by JIMM and of the models they host. If requested, every model
+// is also validated to see whether it can be upgraded and the failures
+// are summarised for each controller.
+func (r *controllerRoot) ControllerVersions(ctx context.Context, req apiparams.ControllerVersionsRequest) (apiparams.ControllerVersionsResponse, error) {
+	const op = errors.Op("jujuapi.ControllerVersions")
+
+	reports, err := r.jimm.ControllerVersions(ctx, r.user, req.Name, req.ValidateUpgrades)
+	if err != nil {
+		return apiparams.ControllerVersionsResponse{}, errors.E(op, err)
+	}
+
+	resp := apiparams.ControllerVersionsResponse{
+		Controllers: make([]apiparams.ControllerVersions, len(reports)),
+	}
+	for i, report := range reports {
+		cv := apiparams.ControllerVersions{
+			Name:         report.Controller.Name,
+			UUID:         report.Controller.UUID,
+			AgentVersion: report.Controller.AgentVersion,
+		}
+		if req.ValidateUpgrades {
+			cv.UpgradeSummary = new(apiparams.UpgradeSummary)
+		}
+		if report.Err != nil {
+			cv.Error = report.Err.Error()
+		}
+		for _, mr := range report.Models {
+			mv := apiparams.ModelVersion{
+				UUID:         mr.Model.UUID.String,
+				Name:         mr.Model.Name,
+				Owner:        mr.Model.OwnerIdentityName,
+				AgentVersion: mr.Model.Status.Version,
+			}
+			if req.ValidateUpgrades && report.Err == nil {
+				cv.UpgradeSummary.Checked++
+				if mr.UpgradeErr != nil {
+					cv.UpgradeSummary.Blocked++
+					mv.UpgradeError = mr.UpgradeErr.Error()
+				}
+			}
+			cv.Models = append(cv.Models, mv)
+		}
+		resp.Controllers[i] = cv
+	}
+	return resp, nil
+}
+
 // maxLimit is the maximum number of audit-log entries that will be
 // returned from the audit log, no matter how many are requested.
 const maxLimit = 1000
@@ -434,6 +583,39 @@ func (r *controllerRoot) ImportModel(ctx context.Context, req apiparams.ImportMo
 	return nil
 }
 
+// ExtendModel extends the expiry time of an ephemeral model.
+func (r *controllerRoot) ExtendModel(ctx context.Context, req apiparams.ExtendModelRequest) (apiparams.ExtendModelResponse, error) {
+	const op = errors.Op("jujuapi.ExtendModel")
+
+	mt, err := names.ParseModelTag(req.ModelTag)
+	if err != nil {
+		return apiparams.ExtendModelResponse{}, errors.E(op, err, errors.CodeBadRequest)
+	}
+	expiresAt, err := r.jimm.ExtendModel(ctx, r.user, mt, req.Duration)
+	if err != nil {
+		return apiparams.ExtendModelResponse{}, errors.E(op, err)
+	}
+	return apiparams.ExtendModelResponse{ExpiresAt: expiresAt}, nil
+}
+
+// TransferModelOwnership transfers ownership of a model to another user.
+func (r *controllerRoot) TransferModelOwnership(ctx context.Context, req apiparams.TransferModelOwnershipRequest) error {
+	const op = errors.Op("jujuapi.TransferModelOwnership")
+
+	mt, err := names.ParseModelTag(req.ModelTag)
+	if err != nil {
+		return errors.E(op, err, errors.CodeBadRequest)
+	}
+	ut, err := names.ParseUserTag(req.NewOwnerTag)
+	if err != nil {
+		return errors.E(op, err, errors.CodeBadRequest)
+	}
+	if err := r.jimm.TransferModelOwnership(ctx, r.user, mt, ut, req.KeepAccess); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 // RemoveCloudFromController removes the specified cloud from a specific controller.
 func (r *controllerRoot) RemoveCloudFromController(ctx context.Context, req apiparams.RemoveCloudFromControllerRequest) error {
 	const op = errors.Op("jujuapi.RemoveCloudFromController")
@@ -461,6 +643,19 @@ func (r *controllerRoot) CrossModelQuery(ctx context.Context, req apiparams.Cros
 	if err != nil {
 		return apiparams.CrossModelQueryResponse{}, errors.E(op, errors.Code("failed to get models for user"))
 	}
+	if req.LabelSelector != "" {
+		sel, err := jimm.ParseLabelSelector(req.LabelSelector)
+		if err != nil {
+			return apiparams.CrossModelQueryResponse{}, errors.E(op, err)
+		}
+		selected := models[:0]
+		for _, m := range models {
+			if sel.Matches(m.Labels) {
+				selected = append(selected, m)
+			}
+		}
+		models = selected
+	}
 
 	switch strings.TrimSpace(strings.ToLower(req.Type)) {
 	case "jq":
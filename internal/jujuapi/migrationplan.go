@@ -0,0 +1,117 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// migrationplan contains the RPC methods for managing scheduled model
+// migration plans.
+
+// AddMigrationPlan adds a scheduled migration plan.
+func (r *controllerRoot) AddMigrationPlan(ctx context.Context, req apiparams.AddMigrationPlanRequest) (apiparams.MigrationPlan, error) {
+	const op = errors.Op("jujuapi.AddMigrationPlan")
+
+	plan, err := r.jimm.AddMigrationPlan(ctx, r.user, jimm.MigrationPlanParams{
+		Name:             req.Name,
+		TargetController: req.TargetController,
+		StartAt:          req.StartAt,
+		MaxConcurrency:   req.MaxConcurrency,
+		MaxFailures:      req.MaxFailures,
+		ModelUUIDs:       req.ModelUUIDs,
+	})
+	if err != nil {
+		return apiparams.MigrationPlan{}, errors.E(op, err)
+	}
+	return migrationPlanToParams(plan), nil
+}
+
+// GetMigrationPlan returns the named migration plan.
+func (r *controllerRoot) GetMigrationPlan(ctx context.Context, req apiparams.GetMigrationPlanRequest) (apiparams.MigrationPlan, error) {
+	const op = errors.Op("jujuapi.GetMigrationPlan")
+
+	plan, err := r.jimm.GetMigrationPlan(ctx, r.user, req.Name)
+	if err != nil {
+		return apiparams.MigrationPlan{}, errors.E(op, err)
+	}
+	return migrationPlanToParams(plan), nil
+}
+
+// ListMigrationPlans returns all the migration plans.
+func (r *controllerRoot) ListMigrationPlans(ctx context.Context) (apiparams.ListMigrationPlansResponse, error) {
+	const op = errors.Op("jujuapi.ListMigrationPlans")
+
+	plans, err := r.jimm.ListMigrationPlans(ctx, r.user)
+	if err != nil {
+		return apiparams.ListMigrationPlansResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListMigrationPlansResponse{
+		Plans: make([]apiparams.MigrationPlan, len(plans)),
+	}
+	for i := range plans {
+		resp.Plans[i] = migrationPlanToParams(&plans[i])
+	}
+	return resp, nil
+}
+
+// UpdateMigrationPlan pauses, resumes or cancels a migration plan.
+func (r *controllerRoot) UpdateMigrationPlan(ctx context.Context, req apiparams.UpdateMigrationPlanRequest) error {
+	const op = errors.Op("jujuapi.UpdateMigrationPlan")
+
+	var err error
+	switch req.Action {
+	case "pause":
+		err = r.jimm.PauseMigrationPlan(ctx, r.user, req.Name)
+	case "resume":
+		err = r.jimm.ResumeMigrationPlan(ctx, r.user, req.Name)
+	case "cancel":
+		err = r.jimm.CancelMigrationPlan(ctx, r.user, req.Name)
+	default:
+		err = errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid action %q", req.Action))
+	}
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// migrationPlanToParams converts a migration plan to its API
+// representation.
+func migrationPlanToParams(p *dbmodel.MigrationPlan) apiparams.MigrationPlan {
+	plan := apiparams.MigrationPlan{
+		Name:                p.Name,
+		TargetController:    p.TargetControllerName,
+		StartAt:             p.StartAt,
+		MaxConcurrency:      p.MaxConcurrency,
+		MaxFailures:         p.MaxFailures,
+		ConsecutiveFailures: p.ConsecutiveFailures,
+		Status:              p.Status,
+		StatusMessage:       p.StatusMessage,
+		CreatedBy:           p.CreatedBy,
+		CreatedAt:           p.CreatedAt,
+		Models:              make([]apiparams.MigrationPlanModel, len(p.Models)),
+	}
+	for i, m := range p.Models {
+		plan.Models[i] = apiparams.MigrationPlanModel{
+			ModelUUID: m.ModelUUID,
+			Status:    m.Status,
+			Error:     m.Error,
+		}
+		if m.StartedAt.Valid {
+			t := m.StartedAt.Time
+			plan.Models[i].StartedAt = &t
+		}
+		if m.CompletedAt.Valid {
+			t := m.CompletedAt.Time
+			plan.Models[i].CompletedAt = &t
+		}
+	}
+	return plan
+}
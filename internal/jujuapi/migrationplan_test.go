@@ -0,0 +1,144 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type migrationPlanSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&migrationPlanSuite{})
+
+func (s *migrationPlanSuite) TestMigrationPlans(c *gc.C) {
+	s.AddController(c, "controller-2", s.APIInfo(c))
+
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	startAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	plan, err := client.AddMigrationPlan(&apiparams.AddMigrationPlanRequest{
+		Name:             "plan-1",
+		TargetController: "controller-2",
+		StartAt:          startAt,
+		MaxConcurrency:   2,
+		ModelUUIDs:       []string{s.Model2.UUID.String, s.Model3.UUID.String},
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(plan.Name, gc.Equals, "plan-1")
+	c.Check(plan.TargetController, gc.Equals, "controller-2")
+	c.Check(plan.StartAt.Equal(startAt), gc.Equals, true)
+	c.Check(plan.MaxConcurrency, gc.Equals, 2)
+	c.Check(plan.Status, gc.Equals, "pending")
+	c.Check(plan.CreatedBy, gc.Equals, "alice@canonical.com")
+	c.Check(plan.Models, jc.DeepEquals, []apiparams.MigrationPlanModel{{
+		ModelUUID: s.Model2.UUID.String,
+		Status:    "pending",
+	}, {
+		ModelUUID: s.Model3.UUID.String,
+		Status:    "pending",
+	}})
+
+	_, err = client.AddMigrationPlan(&apiparams.AddMigrationPlanRequest{
+		Name:             "plan-1",
+		TargetController: "controller-2",
+		ModelUUIDs:       []string{s.Model2.UUID.String},
+	})
+	c.Check(err, gc.ErrorMatches, `migration plan "plan-1" already exists \(already exists\)`)
+
+	err = client.UpdateMigrationPlan(&apiparams.UpdateMigrationPlanRequest{
+		Name:   "plan-1",
+		Action: "pause",
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	plan, err = client.GetMigrationPlan(&apiparams.GetMigrationPlanRequest{Name: "plan-1"})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(plan.Status, gc.Equals, "paused")
+	c.Check(plan.StatusMessage, gc.Equals, "paused by alice@canonical.com")
+
+	err = client.UpdateMigrationPlan(&apiparams.UpdateMigrationPlanRequest{
+		Name:   "plan-1",
+		Action: "resume",
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	err = client.UpdateMigrationPlan(&apiparams.UpdateMigrationPlanRequest{
+		Name:   "plan-1",
+		Action: "cancel",
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	plans, err := client.ListMigrationPlans()
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(plans, gc.HasLen, 1)
+	c.Check(plans[0].Name, gc.Equals, "plan-1")
+	c.Check(plans[0].Status, gc.Equals, "cancelled")
+	c.Check(plans[0].StatusMessage, gc.Equals, "cancelled by alice@canonical.com")
+
+	err = client.UpdateMigrationPlan(&apiparams.UpdateMigrationPlanRequest{
+		Name:   "plan-1",
+		Action: "resume",
+	})
+	c.Check(err, gc.ErrorMatches, `migration plan "plan-1" is cancelled \(bad request\)`)
+}
+
+func (s *migrationPlanSuite) TestAddMigrationPlanInvalid(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.AddMigrationPlan(&apiparams.AddMigrationPlanRequest{
+		Name:             "plan-1",
+		TargetController: "controller-1",
+		ModelUUIDs:       []string{s.Model2.UUID.String},
+	})
+	c.Check(err, gc.ErrorMatches, `model "`+s.Model2.UUID.String+`" is already hosted on controller "controller-1" \(bad request\)`)
+
+	_, err = client.AddMigrationPlan(&apiparams.AddMigrationPlanRequest{
+		Name:             "plan-1",
+		TargetController: "controller-2",
+		ModelUUIDs:       []string{s.Model2.UUID.String},
+	})
+	c.Check(err, gc.ErrorMatches, `controller "controller-2" not found \(not found\)`)
+
+	err = client.UpdateMigrationPlan(&apiparams.UpdateMigrationPlanRequest{
+		Name:   "plan-1",
+		Action: "restart",
+	})
+	c.Check(err, gc.ErrorMatches, `invalid action "restart" \(bad request\)`)
+}
+
+func (s *migrationPlanSuite) TestMigrationPlansUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.AddMigrationPlan(&apiparams.AddMigrationPlanRequest{
+		Name:             "plan-1",
+		TargetController: "controller-1",
+		ModelUUIDs:       []string{s.Model.UUID.String},
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = client.GetMigrationPlan(&apiparams.GetMigrationPlanRequest{Name: "plan-1"})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = client.ListMigrationPlans()
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	err = client.UpdateMigrationPlan(&apiparams.UpdateMigrationPlanRequest{
+		Name:   "plan-1",
+		Action: "pause",
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// modeldrift contains the RPC methods for comparing model config with
+// the model defaults.
+
+// ModelDrift reports the models matching the selector in the request
+// whose config has drifted from the model defaults. Models whose config
+// matches the defaults are not included in the response.
+func (r *controllerRoot) ModelDrift(ctx context.Context, req apiparams.ModelDriftRequest) (apiparams.ModelDriftResponse, error) {
+	const op = errors.Op("jujuapi.ModelDrift")
+
+	sel, err := jimm.ParseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return apiparams.ModelDriftResponse{}, errors.E(op, err)
+	}
+	p := jimm.ModelDriftParams{
+		Selector: jimm.ModelSelector{
+			Controller:    req.Controller,
+			NameGlob:      req.NameGlob,
+			LabelSelector: sel,
+		},
+		Apply: req.Apply,
+		Force: req.Force,
+	}
+	if req.OwnerTag != "" {
+		ut, err := names.ParseUserTag(req.OwnerTag)
+		if err != nil {
+			return apiparams.ModelDriftResponse{}, errors.E(op, err, errors.CodeBadRequest)
+		}
+		p.Selector.Owner = ut.Id()
+	}
+	results, err := r.jimm.ModelDrift(ctx, r.user, p)
+	if err != nil {
+		return apiparams.ModelDriftResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ModelDriftResponse{
+		Models: []apiparams.ModelDrift{},
+	}
+	for _, res := range results {
+		if len(res.Drift) == 0 && res.Err == nil {
+			continue
+		}
+		md := apiparams.ModelDrift{
+			UUID:    res.Model.UUID.String,
+			Name:    res.Model.Name,
+			Owner:   res.Model.OwnerIdentityName,
+			Applied: res.Applied,
+		}
+		if res.Err != nil {
+			md.Error = res.Err.Error()
+		}
+		for _, d := range res.Drift {
+			md.Drift = append(md.Drift, apiparams.ConfigDrift{
+				Key:      d.Key,
+				Expected: d.Expected,
+				Actual:   d.Actual,
+				Source:   d.Source,
+				Group:    d.Group,
+			})
+		}
+		resp.Models = append(resp.Models, md)
+	}
+	return resp, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type modelDriftSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&modelDriftSuite{})
+
+func (s *modelDriftSuite) TestModelDrift(c *gc.C) {
+	ctx := context.Background()
+
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	req := apiparams.ModelDriftRequest{
+		NameGlob: "model-2",
+	}
+	resp, err := client.ModelDrift(&req)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Models, gc.HasLen, 0)
+
+	// Defaults set after the model was added are reported as drift.
+	charlie := dbmodel.Identity{Name: "charlie@canonical.com"}
+	err = s.JIMM.Database.GetIdentity(ctx, &charlie)
+	c.Assert(err, gc.Equals, nil)
+	err = s.JIMM.SetModelDefaults(ctx, &charlie, names.NewCloudTag(jimmtest.TestCloudName), "", map[string]interface{}{
+		"logging-config": "<root>=DEBUG",
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	resp, err = client.ModelDrift(&req)
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp.Models, gc.HasLen, 1)
+	md := resp.Models[0]
+	c.Check(md.UUID, gc.Equals, s.Model2.UUID.String)
+	c.Check(md.Name, gc.Equals, "model-2")
+	c.Check(md.Owner, gc.Equals, "charlie@canonical.com")
+	c.Check(md.Applied, gc.Equals, false)
+	c.Check(md.Error, gc.Equals, "")
+	c.Assert(md.Drift, gc.HasLen, 1)
+	c.Check(md.Drift[0].Key, gc.Equals, "logging-config")
+	c.Check(md.Drift[0].Expected, gc.Equals, "<root>=DEBUG")
+	c.Check(md.Drift[0].Source, gc.Equals, "cloud")
+
+	req.Apply = true
+	resp, err = client.ModelDrift(&req)
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp.Models, gc.HasLen, 1)
+	c.Check(resp.Models[0].Applied, gc.Equals, true)
+	c.Check(resp.Models[0].Error, gc.Equals, "")
+
+	req.Apply = false
+	resp, err = client.ModelDrift(&req)
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Models, gc.HasLen, 0)
+}
+
+func (s *modelDriftSuite) TestModelDriftNotAdministrator(c *gc.C) {
+	ctx := context.Background()
+
+	charlie := dbmodel.Identity{Name: "charlie@canonical.com"}
+	err := s.JIMM.Database.GetIdentity(ctx, &charlie)
+	c.Assert(err, gc.Equals, nil)
+	err = s.JIMM.SetModelDefaults(ctx, &charlie, names.NewCloudTag(jimmtest.TestCloudName), "", map[string]interface{}{
+		"logging-config": "<root>=DEBUG",
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	// bob can read model-3 but does not administer it, so it is not
+	// checked.
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	resp, err := client.ModelDrift(&apiparams.ModelDriftRequest{
+		OwnerTag: "user-charlie@canonical.com",
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Models, gc.HasLen, 0)
+}
+
+func (s *modelDriftSuite) TestModelDriftInvalid(c *gc.C) {
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.ModelDrift(&apiparams.ModelDriftRequest{
+		OwnerTag: "charlie",
+	})
+	c.Check(err, gc.ErrorMatches, `"charlie" is not a valid tag \(bad request\)`)
+
+	_, err = client.ModelDrift(&apiparams.ModelDriftRequest{
+		LabelSelector: "Env=prod",
+	})
+	c.Check(err, gc.ErrorMatches, `invalid label key "Env" \(bad request\)`)
+}
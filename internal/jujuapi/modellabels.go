@@ -0,0 +1,199 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"fmt"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// modellabels contains the RPC methods for labelling models and for
+// selecting models by label via the JIMM facade. The JIMM facade versions
+// of ListModels, ListModelSummaries, ModelInfo and WatchModelSummaries
+// extend the juju methods of the same names with model labels. The JIMM
+// facade versions of ListModels and ListModelSummaries also support
+// server-side filtering and paging.
+
+// SetModelLabels sets and removes labels on a model.
+func (r *controllerRoot) SetModelLabels(ctx context.Context, req apiparams.SetModelLabelsRequest) (apiparams.SetModelLabelsResponse, error) {
+	const op = errors.Op("jujuapi.SetModelLabels")
+
+	mt, err := names.ParseModelTag(req.ModelTag)
+	if err != nil {
+		return apiparams.SetModelLabelsResponse{}, errors.E(op, err, errors.CodeBadRequest)
+	}
+	labels, err := r.jimm.SetModelLabels(ctx, r.user, mt, req.Labels, req.Remove)
+	if err != nil {
+		return apiparams.SetModelLabelsResponse{}, errors.E(op, err)
+	}
+	return apiparams.SetModelLabelsResponse{Labels: labels}, nil
+}
+
+// forEachSelectedModel calls the given function for each model the
+// authenticated user has access to whose labels match the given selector.
+func (r *controllerRoot) forEachSelectedModel(ctx context.Context, selector string, f func(*dbmodel.Model, jujuparams.UserAccessPermission) error) error {
+	sel, err := jimm.ParseLabelSelector(selector)
+	if err != nil {
+		return err
+	}
+	return r.jimm.ForEachUserModel(ctx, r.user, func(m *dbmodel.Model, access jujuparams.UserAccessPermission) error {
+		if !sel.Matches(m.Labels) {
+			return nil
+		}
+		return f(m, access)
+	})
+}
+
+// listUserModels returns the page of models, with the access level of
+// the authenticated user, that match the given request.
+func (r *controllerRoot) listUserModels(ctx context.Context, req apiparams.ListModelsRequest) ([]jimm.UserModel, string, error) {
+	sel, err := jimm.ParseLabelSelector(req.LabelSelector)
+	if err != nil {
+		return nil, "", err
+	}
+	filter := jimm.ModelListFilter{
+		Controller:    req.Controller,
+		Cloud:         req.Cloud,
+		CloudRegion:   req.Region,
+		Life:          req.Life,
+		Status:        req.Status,
+		NamePrefix:    req.NamePrefix,
+		LabelSelector: sel,
+	}
+	if req.OwnerTag != "" {
+		ut, err := names.ParseUserTag(req.OwnerTag)
+		if err != nil {
+			return nil, "", errors.E(err, errors.CodeBadRequest)
+		}
+		filter.Owner = ut.Id()
+	}
+	return r.jimm.ListUserModels(ctx, r.user, filter, req.PageSize, req.ContinuationToken)
+}
+
+// listLabelledModels returns the models, with their labels, that the
+// authenticated user has access to and that match the filters in the
+// request. The results are paged if the request specifies a page size.
+func (r *controllerRoot) listLabelledModels(ctx context.Context, req apiparams.ListModelsRequest) (apiparams.ListModelsResponse, error) {
+	const op = errors.Op("jujuapi.ListModels")
+
+	models, ct, err := r.listUserModels(ctx, req)
+	if err != nil {
+		return apiparams.ListModelsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListModelsResponse{
+		Models:            make([]apiparams.UserModel, len(models)),
+		ContinuationToken: ct,
+	}
+	for i, um := range models {
+		resp.Models[i].Model = um.Model.ToJujuModel()
+		resp.Models[i].Labels = um.Model.Labels
+	}
+	return resp, nil
+}
+
+// listLabelledModelSummaries returns the summaries, with labels, of the
+// models that the authenticated user has access to and that match the
+// filters in the request. The results are paged if the request specifies
+// a page size.
+func (r *controllerRoot) listLabelledModelSummaries(ctx context.Context, req apiparams.ListModelsRequest) (apiparams.ListModelSummariesResponse, error) {
+	const op = errors.Op("jujuapi.ListModelSummaries")
+
+	models, ct, err := r.listUserModels(ctx, req)
+	if err != nil {
+		return apiparams.ListModelSummariesResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListModelSummariesResponse{
+		Models:            make([]apiparams.ModelSummary, len(models)),
+		ContinuationToken: ct,
+	}
+	for i, um := range models {
+		ms := apiparams.ModelSummary{
+			ModelSummary: um.Model.ToJujuModelSummary(),
+			Labels:       um.Model.Labels,
+		}
+		ms.UserAccess = um.Access
+		if r.controllerUUIDMasking {
+			ms.ControllerUUID = r.params.ControllerUUID
+		}
+		resp.Models[i] = ms
+	}
+	return resp, nil
+}
+
+// labelledModelInfo returns the model information, with labels, for each
+// of the given models.
+func (r *controllerRoot) labelledModelInfo(ctx context.Context, args jujuparams.Entities) (apiparams.ModelInfoResponse, error) {
+	const op = errors.Op("jujuapi.ModelInfo")
+
+	results, err := r.ModelInfo(ctx, args)
+	if err != nil {
+		return apiparams.ModelInfoResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ModelInfoResponse{
+		Results: make([]apiparams.ModelInfoResult, len(results.Results)),
+	}
+	for i, result := range results.Results {
+		if result.Error != nil {
+			resp.Results[i].Error = result.Error
+			continue
+		}
+		mi := apiparams.ModelInfo{
+			ModelInfo: *result.Result,
+		}
+		mi.Labels, err = r.jimm.ModelLabels(ctx, r.user, names.NewModelTag(result.Result.UUID))
+		if err != nil {
+			resp.Results[i].Error = mapError(errors.E(op, err))
+			continue
+		}
+		resp.Results[i].Result = &mi
+	}
+	return resp, nil
+}
+
+// watchLabelledModelSummaries starts a model summary watcher for the
+// models the authenticated user has access to and that match the label
+// selector. The selector is re-evaluated whenever the set of watched
+// models is refreshed.
+func (r *controllerRoot) watchLabelledModelSummaries(ctx context.Context, req apiparams.WatchModelSummariesRequest) (jujuparams.SummaryWatcherID, error) {
+	const op = errors.Op("jujuapi.WatchModelSummaries")
+
+	if _, err := jimm.ParseLabelSelector(req.LabelSelector); err != nil {
+		return jujuparams.SummaryWatcherID{}, errors.E(op, err)
+	}
+
+	err := r.setupUUIDGenerator()
+	if err != nil {
+		return jujuparams.SummaryWatcherID{}, errors.E(op, err)
+	}
+
+	id := fmt.Sprintf("%v", r.generator.Next())
+
+	getModels := func(ctx context.Context) ([]string, error) {
+		var modelUUIDs []string
+		err := r.forEachSelectedModel(ctx, req.LabelSelector, func(m *dbmodel.Model, _ jujuparams.UserAccessPermission) error {
+			modelUUIDs = append(modelUUIDs, m.UUID.String)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.E(err)
+		}
+		return modelUUIDs, nil
+	}
+	watcher, err := newModelSummaryWatcher(ctx, id, r.jimm.PubSubHub(), getModels)
+	if err != nil {
+		return jujuparams.SummaryWatcherID{}, errors.E(op, err)
+	}
+	r.watchers.register(watcher)
+
+	return jujuparams.SummaryWatcherID{
+		WatcherID: id,
+	}, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	jujuparams "github.com/juju/juju/rpc/params"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type modelLabelsSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&modelLabelsSuite{})
+
+func (s *modelLabelsSuite) TestSetModelLabels(c *gc.C) {
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	resp, err := client.SetModelLabels(&apiparams.SetModelLabelsRequest{
+		ModelTag: s.Model2.Tag().String(),
+		Labels:   map[string]string{"env": "prod", "team": "a"},
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Labels, jc.DeepEquals, map[string]string{"env": "prod", "team": "a"})
+
+	resp, err = client.SetModelLabels(&apiparams.SetModelLabelsRequest{
+		ModelTag: s.Model2.Tag().String(),
+		Remove:   []string{"team"},
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Labels, jc.DeepEquals, map[string]string{"env": "prod"})
+
+	_, err = client.SetModelLabels(&apiparams.SetModelLabelsRequest{
+		ModelTag: s.Model3.Tag().String(),
+		Labels:   map[string]string{"env": "dev"},
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	lmr, err := client.ListModels(&apiparams.ListModelsRequest{
+		LabelSelector: "env=prod",
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(lmr.Models, gc.HasLen, 1)
+	c.Check(lmr.Models[0].UUID, gc.Equals, s.Model2.UUID.String)
+	c.Check(lmr.Models[0].Labels, jc.DeepEquals, map[string]string{"env": "prod"})
+
+	mir, err := client.ModelInfo(&jujuparams.Entities{
+		Entities: []jujuparams.Entity{{Tag: s.Model3.Tag().String()}},
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(mir.Results, gc.HasLen, 1)
+	c.Assert(mir.Results[0].Error, gc.IsNil)
+	c.Check(mir.Results[0].Result.Labels, jc.DeepEquals, map[string]string{"env": "dev"})
+}
+
+func (s *modelLabelsSuite) TestSetModelLabelsInvalidLabel(c *gc.C) {
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.SetModelLabels(&apiparams.SetModelLabelsRequest{
+		ModelTag: s.Model2.Tag().String(),
+		Labels:   map[string]string{"Env": "prod"},
+	})
+	c.Check(err, gc.ErrorMatches, `invalid label key "Env" \(bad request\)`)
+
+	_, err = client.SetModelLabels(&apiparams.SetModelLabelsRequest{
+		ModelTag: "invalid-model-tag",
+	})
+	c.Check(err, gc.ErrorMatches, `"invalid-model-tag" is not a valid tag \(bad request\)`)
+
+	_, err = client.ListModels(&apiparams.ListModelsRequest{
+		LabelSelector: "Env=prod",
+	})
+	c.Check(err, gc.ErrorMatches, `invalid label key "Env" \(bad request\)`)
+}
+
+func (s *modelLabelsSuite) TestSetModelLabelsUnauthorized(c *gc.C) {
+	// bob only has read access to model-3.
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	_, err := client.SetModelLabels(&apiparams.SetModelLabelsRequest{
+		ModelTag: s.Model3.Tag().String(),
+		Labels:   map[string]string{"env": "dev"},
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
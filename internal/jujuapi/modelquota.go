@@ -0,0 +1,99 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// modelquota contains the RPC methods for managing model quotas via the JIMM facade.
+
+// SetModelQuota sets the model quota for an identity or group.
+func (r *controllerRoot) SetModelQuota(ctx context.Context, req apiparams.SetModelQuotaRequest) error {
+	const op = errors.Op("jujuapi.SetModelQuota")
+
+	quota := dbmodel.ModelQuota{
+		MaxModels:   nullInt64(req.MaxModels),
+		MaxMachines: nullInt64(req.MaxMachines),
+		MaxCores:    nullInt64(req.MaxCores),
+		MaxUnits:    nullInt64(req.MaxUnits),
+	}
+	if req.Cloud != "" {
+		quota.CloudName = sql.NullString{String: req.Cloud, Valid: true}
+	}
+	if err := r.jimm.SetModelQuota(ctx, r.user, req.Entity, &quota); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RemoveModelQuota removes the model quota for an identity or group.
+func (r *controllerRoot) RemoveModelQuota(ctx context.Context, req apiparams.RemoveModelQuotaRequest) error {
+	const op = errors.Op("jujuapi.RemoveModelQuota")
+
+	if err := r.jimm.RemoveModelQuota(ctx, r.user, req.Entity, req.Cloud); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ModelQuotas returns the model quotas that apply to an identity, along
+// with the identity's current usage, or the quotas assigned to a group.
+func (r *controllerRoot) ModelQuotas(ctx context.Context, req apiparams.ModelQuotasRequest) (apiparams.ModelQuotasResponse, error) {
+	const op = errors.Op("jujuapi.ModelQuotas")
+
+	usages, err := r.jimm.ModelQuotas(ctx, r.user, req.Entity)
+	if err != nil {
+		return apiparams.ModelQuotasResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ModelQuotasResponse{
+		Quotas: make([]apiparams.ModelQuota, len(usages)),
+	}
+	for i, u := range usages {
+		q := apiparams.ModelQuota{
+			ModelQuotaLimits: apiparams.ModelQuotaLimits{
+				MaxModels:   int64Ptr(u.Quota.MaxModels),
+				MaxMachines: int64Ptr(u.Quota.MaxMachines),
+				MaxCores:    int64Ptr(u.Quota.MaxCores),
+				MaxUnits:    int64Ptr(u.Quota.MaxUnits),
+			},
+			Cloud: u.Quota.CloudName.String,
+		}
+		if u.Group != nil {
+			q.Entity = u.Group.ResourceTag().Kind() + "-" + u.Group.Name
+		} else {
+			q.Entity = names.NewUserTag(u.Quota.IdentityName.String).String()
+		}
+		if u.Usage != nil {
+			q.Usage = &apiparams.ModelQuotaUsage{
+				Models:   u.Usage.Models,
+				Machines: u.Usage.Machines,
+				Cores:    u.Usage.Cores,
+				Units:    u.Usage.Units,
+			}
+		}
+		resp.Quotas[i] = q
+	}
+	return resp, nil
+}
+
+func nullInt64(v *int64) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *v, Valid: true}
+}
+
+func int64Ptr(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type modelQuotaSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&modelQuotaSuite{})
+
+func (s *modelQuotaSuite) TestModelQuotas(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	maxModels := int64(2)
+	maxUnits := int64(10)
+	err := client.SetModelQuota(&apiparams.SetModelQuotaRequest{
+		ModelQuotaLimits: apiparams.ModelQuotaLimits{
+			MaxModels: &maxModels,
+		},
+		Entity: "user-bob@canonical.com",
+	})
+	c.Assert(err, gc.Equals, nil)
+	err = client.SetModelQuota(&apiparams.SetModelQuotaRequest{
+		ModelQuotaLimits: apiparams.ModelQuotaLimits{
+			MaxUnits: &maxUnits,
+		},
+		Entity: "user-bob@canonical.com",
+		Cloud:  jimmtest.TestCloudName,
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	resp, err := client.ModelQuotas(&apiparams.ModelQuotasRequest{
+		Entity: "user-bob@canonical.com",
+	})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp.Quotas, gc.HasLen, 2)
+	for _, q := range resp.Quotas {
+		c.Check(q.Entity, gc.Equals, "user-bob@canonical.com")
+		c.Assert(q.Usage, gc.Not(gc.IsNil))
+		c.Check(q.Usage.Models, gc.Equals, int64(1))
+		switch q.Cloud {
+		case "":
+			c.Check(q.ModelQuotaLimits, jc.DeepEquals, apiparams.ModelQuotaLimits{MaxModels: &maxModels})
+		case jimmtest.TestCloudName:
+			c.Check(q.ModelQuotaLimits, jc.DeepEquals, apiparams.ModelQuotaLimits{MaxUnits: &maxUnits})
+		default:
+			c.Errorf("unexpected quota cloud %q", q.Cloud)
+		}
+	}
+
+	err = client.RemoveModelQuota(&apiparams.RemoveModelQuotaRequest{
+		Entity: "user-bob@canonical.com",
+		Cloud:  jimmtest.TestCloudName,
+	})
+	c.Assert(err, gc.Equals, nil)
+
+	// bob can view their own quotas.
+	conn2 := s.open(c, nil, "bob")
+	defer conn2.Close()
+	client2 := api.NewClient(conn2)
+
+	resp, err = client2.ModelQuotas(&apiparams.ModelQuotasRequest{})
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(resp.Quotas, gc.HasLen, 1)
+	c.Check(resp.Quotas[0].Entity, gc.Equals, "user-bob@canonical.com")
+	c.Check(resp.Quotas[0].Cloud, gc.Equals, "")
+	c.Check(resp.Quotas[0].MaxModels, jc.DeepEquals, &maxModels)
+}
+
+func (s *modelQuotaSuite) TestSetModelQuotaInvalid(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	maxModels := int64(2)
+	err := client.SetModelQuota(&apiparams.SetModelQuotaRequest{
+		ModelQuotaLimits: apiparams.ModelQuotaLimits{
+			MaxModels: &maxModels,
+		},
+		Entity: "user-bob@canonical.com",
+		Cloud:  "no-such-cloud",
+	})
+	c.Check(err, gc.ErrorMatches, `cloud "no-such-cloud" not found \(not found\)`)
+
+	maxModels = -1
+	err = client.SetModelQuota(&apiparams.SetModelQuotaRequest{
+		ModelQuotaLimits: apiparams.ModelQuotaLimits{
+			MaxModels: &maxModels,
+		},
+		Entity: "user-bob@canonical.com",
+	})
+	c.Check(err, gc.ErrorMatches, `quota limits cannot be negative \(bad request\)`)
+}
+
+func (s *modelQuotaSuite) TestModelQuotasUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	maxModels := int64(5)
+	err := client.SetModelQuota(&apiparams.SetModelQuotaRequest{
+		ModelQuotaLimits: apiparams.ModelQuotaLimits{
+			MaxModels: &maxModels,
+		},
+		Entity: "user-bob@canonical.com",
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	err = client.RemoveModelQuota(&apiparams.RemoveModelQuotaRequest{
+		Entity: "user-bob@canonical.com",
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = client.ModelQuotas(&apiparams.ModelQuotasRequest{
+		Entity: "user-charlie@canonical.com",
+	})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
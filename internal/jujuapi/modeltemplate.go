@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// modeltemplate contains the RPC methods for managing model templates via the JIMM facade.
+
+// AddModelTemplate adds a model template.
+func (r *controllerRoot) AddModelTemplate(ctx context.Context, req apiparams.AddModelTemplateRequest) error {
+	const op = errors.Op("jujuapi.AddModelTemplate")
+
+	t := modelTemplateFromParams(req.ModelTemplate)
+	if err := r.jimm.AddModelTemplate(ctx, r.user, &t); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// UpdateModelTemplate replaces an existing model template.
+func (r *controllerRoot) UpdateModelTemplate(ctx context.Context, req apiparams.UpdateModelTemplateRequest) error {
+	const op = errors.Op("jujuapi.UpdateModelTemplate")
+
+	t := modelTemplateFromParams(req.ModelTemplate)
+	if err := r.jimm.UpdateModelTemplate(ctx, r.user, &t); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RemoveModelTemplate removes a model template.
+func (r *controllerRoot) RemoveModelTemplate(ctx context.Context, req apiparams.RemoveModelTemplateRequest) error {
+	const op = errors.Op("jujuapi.RemoveModelTemplate")
+
+	if err := r.jimm.RemoveModelTemplate(ctx, r.user, req.Name); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListModelTemplates returns the model templates.
+func (r *controllerRoot) ListModelTemplates(ctx context.Context, req apiparams.ListModelTemplatesRequest) (apiparams.ListModelTemplatesResponse, error) {
+	const op = errors.Op("jujuapi.ListModelTemplates")
+
+	templates, err := r.jimm.ListModelTemplates(ctx, r.user)
+	if err != nil {
+		return apiparams.ListModelTemplatesResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListModelTemplatesResponse{
+		Templates: []apiparams.ModelTemplate{},
+	}
+	for _, t := range templates {
+		if req.Name != "" && t.Name != req.Name {
+			continue
+		}
+		resp.Templates = append(resp.Templates, modelTemplateToParams(t))
+	}
+	if req.Name != "" && len(resp.Templates) == 0 {
+		return apiparams.ListModelTemplatesResponse{}, errors.E(op, errors.CodeNotFound, "model template not found")
+	}
+	return resp, nil
+}
+
+// modelTemplateFromParams converts an API model template to a database
+// model template.
+func modelTemplateFromParams(p apiparams.ModelTemplate) dbmodel.ModelTemplate {
+	t := dbmodel.ModelTemplate{
+		Name:             p.Name,
+		Description:      p.Description,
+		CloudRegion:      p.Region,
+		CloudCredentials: p.Credentials,
+		Config:           p.Config,
+		Access:           p.Access,
+		Labels:           p.Labels,
+	}
+	if p.Cloud != "" {
+		t.CloudName = sql.NullString{String: p.Cloud, Valid: true}
+	}
+	return t
+}
+
+// modelTemplateToParams converts a database model template to an API
+// model template.
+func modelTemplateToParams(t dbmodel.ModelTemplate) apiparams.ModelTemplate {
+	return apiparams.ModelTemplate{
+		Name:        t.Name,
+		Description: t.Description,
+		Cloud:       t.CloudName.String,
+		Region:      t.CloudRegion,
+		Credentials: t.CloudCredentials,
+		Config:      t.Config,
+		Access:      t.Access,
+		Labels:      t.Labels,
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/jimmtest"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+type modelTemplateSuite struct {
+	websocketSuite
+}
+
+var _ = gc.Suite(&modelTemplateSuite{})
+
+func (s *modelTemplateSuite) TestModelTemplates(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	tmpl := apiparams.ModelTemplate{
+		Name:        "small",
+		Description: "A small model",
+		Cloud:       jimmtest.TestCloudName,
+		Region:      jimmtest.TestCloudRegionName,
+		Credentials: []string{"cred"},
+		Config:      map[string]interface{}{"logging-config": "<root>=DEBUG"},
+		Labels:      map[string]string{"size": "small"},
+	}
+	err := client.AddModelTemplate(&apiparams.AddModelTemplateRequest{ModelTemplate: tmpl})
+	c.Assert(err, gc.Equals, nil)
+
+	err = client.AddModelTemplate(&apiparams.AddModelTemplateRequest{ModelTemplate: tmpl})
+	c.Check(err, gc.ErrorMatches, `model template "small" already exists \(already exists\)`)
+
+	tmpl.Description = "A smaller model"
+	err = client.UpdateModelTemplate(&apiparams.UpdateModelTemplateRequest{ModelTemplate: tmpl})
+	c.Assert(err, gc.Equals, nil)
+
+	// Any user can list the model templates.
+	conn2 := s.open(c, nil, "bob")
+	defer conn2.Close()
+	client2 := api.NewClient(conn2)
+
+	resp, err := client2.ListModelTemplates(&apiparams.ListModelTemplatesRequest{})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Templates, jc.DeepEquals, []apiparams.ModelTemplate{tmpl})
+
+	resp, err = client2.ListModelTemplates(&apiparams.ListModelTemplatesRequest{Name: "small"})
+	c.Assert(err, gc.Equals, nil)
+	c.Check(resp.Templates, jc.DeepEquals, []apiparams.ModelTemplate{tmpl})
+
+	err = client.RemoveModelTemplate(&apiparams.RemoveModelTemplateRequest{Name: "small"})
+	c.Assert(err, gc.Equals, nil)
+
+	_, err = client2.ListModelTemplates(&apiparams.ListModelTemplatesRequest{Name: "small"})
+	c.Check(err, gc.ErrorMatches, `model template not found \(not found\)`)
+}
+
+func (s *modelTemplateSuite) TestAddModelTemplateInvalid(c *gc.C) {
+	conn := s.open(c, nil, "alice")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	err := client.AddModelTemplate(&apiparams.AddModelTemplateRequest{
+		ModelTemplate: apiparams.ModelTemplate{
+			Name:   "no-cloud",
+			Region: jimmtest.TestCloudRegionName,
+		},
+	})
+	c.Check(err, gc.ErrorMatches, `region specified without a cloud \(bad request\)`)
+
+	err = client.AddModelTemplate(&apiparams.AddModelTemplateRequest{
+		ModelTemplate: apiparams.ModelTemplate{
+			Name:   "bad-access",
+			Access: map[string]string{"admins": "administrator"},
+		},
+	})
+	c.Check(err, gc.ErrorMatches, `invalid access "administrator" for group "admins", expected reader or writer \(bad request\)`)
+}
+
+func (s *modelTemplateSuite) TestModelTemplatesUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+	client := api.NewClient(conn)
+
+	tmpl := apiparams.ModelTemplate{
+		Name: "small",
+	}
+	err := client.AddModelTemplate(&apiparams.AddModelTemplateRequest{ModelTemplate: tmpl})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	err = client.UpdateModelTemplate(&apiparams.UpdateModelTemplateRequest{ModelTemplate: tmpl})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	err = client.RemoveModelTemplate(&apiparams.RemoveModelTemplateRequest{Name: "small"})
+	c.Check(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Canonical.
+
+package jujuclient
+
+import (
+	"sync"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// A BreakerState is the state of a controller's circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed is the normal state of a circuit breaker, dials to
+	// the controller are attempted.
+	BreakerClosed BreakerState = iota
+
+	// BreakerHalfOpen is the state of a circuit breaker that has been
+	// open for its cooldown period. A single trial dial is allowed, if
+	// it succeeds the breaker closes, otherwise it opens again.
+	BreakerHalfOpen
+
+	// BreakerOpen is the state of a circuit breaker for a controller
+	// that is known to be down. Dials fail immediately.
+	BreakerOpen
+)
+
+// String implements fmt.Stringer.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerStatus holds a snapshot of the state of a controller's circuit
+// breaker.
+type BreakerStatus struct {
+	// State is the current state of the breaker.
+	State string `json:"state"`
+
+	// ConsecutiveFailures is the number of dials that have failed since
+	// the last successful dial.
+	ConsecutiveFailures int `json:"consecutive-failures"`
+
+	// OpenedAt is the time the breaker last opened. It is nil if the
+	// breaker is closed.
+	OpenedAt *time.Time `json:"opened-at,omitempty"`
+
+	// LastError is the error from the most recent failed dial.
+	LastError string `json:"last-error,omitempty"`
+}
+
+// A breaker is a circuit breaker for a single controller.
+type breaker struct {
+	controller string
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+	lastErr  string
+}
+
+// allow reports whether a dial to the controller should be attempted at
+// the given time. If the breaker has been open for longer than cooldown
+// it moves to the half-open state and a single caller is allowed to
+// attempt a dial.
+func (b *breaker) allow(now time.Time, cooldown time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if now.Sub(b.openedAt) < cooldown {
+			return false
+		}
+		b.setState(BreakerHalfOpen)
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// success records a successful dial, closing the breaker.
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.probing = false
+	b.lastErr = ""
+	b.setState(BreakerClosed)
+}
+
+// release records that a dial was abandoned without learning anything
+// about the state of the controller.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// failure records a failed dial. The breaker opens if the trial dial of
+// a half-open breaker failed or the number of consecutive failures has
+// reached threshold.
+func (b *breaker) failure(now time.Time, threshold int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.probing = false
+	if err != nil {
+		b.lastErr = err.Error()
+	}
+	if b.state == BreakerHalfOpen || (b.state == BreakerClosed && b.failures >= threshold) {
+		b.openedAt = now
+		b.setState(BreakerOpen)
+		servermon.JujuCircuitBreakerTripCount.WithLabelValues(b.controller).Inc()
+	}
+}
+
+// setState changes the state of the breaker and updates the breaker
+// state metric. The caller must hold b.mu.
+func (b *breaker) setState(s BreakerState) {
+	b.state = s
+	servermon.JujuCircuitBreakerState.WithLabelValues(b.controller).Set(float64(s))
+}
+
+// status returns a snapshot of the breaker's state.
+func (b *breaker) status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := BreakerStatus{
+		State:               b.state.String(),
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastErr,
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		st.OpenedAt = &openedAt
+	}
+	return st
+}
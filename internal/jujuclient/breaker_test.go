@@ -0,0 +1,140 @@
+// Copyright 2024 Canonical.
+
+package jujuclient_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jujuclient"
+)
+
+// unreachableAddress returns an address on which nothing is listening.
+func unreachableAddress(c *qt.C) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	addr := l.Addr().String()
+	l.Close()
+	return addr
+}
+
+func TestDialerCircuitBreaker(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	d := &jujuclient.Dialer{
+		DialAttempts:     2,
+		InitialBackoff:   time.Millisecond,
+		BreakerThreshold: 2,
+		BreakerCooldown:  time.Hour,
+	}
+	ctl := dbmodel.Controller{
+		Name:          "test-controller",
+		PublicAddress: unreachableAddress(c),
+	}
+
+	_, err := d.Dial(ctx, &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Check(d.BreakerStatus()["test-controller"].State, qt.Equals, "closed")
+	c.Check(d.BreakerStatus()["test-controller"].ConsecutiveFailures, qt.Equals, 1)
+
+	_, err = d.Dial(ctx, &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.Not(qt.IsNil))
+	status := d.BreakerStatus()["test-controller"]
+	c.Check(status.State, qt.Equals, "open")
+	c.Check(status.ConsecutiveFailures, qt.Equals, 2)
+	c.Check(status.OpenedAt, qt.Not(qt.IsNil))
+
+	// Whilst the breaker is open dials fail immediately.
+	_, err = d.Dial(ctx, &ctl, names.ModelTag{}, nil)
+	c.Check(err, qt.ErrorMatches, `controller "test-controller" is unavailable`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeConnectionFailed)
+}
+
+func TestDialerCircuitBreakerHalfOpen(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	d := &jujuclient.Dialer{
+		BreakerThreshold: 1,
+		BreakerCooldown:  time.Millisecond,
+	}
+	ctl := dbmodel.Controller{
+		Name:          "test-controller",
+		PublicAddress: unreachableAddress(c),
+	}
+
+	_, err := d.Dial(ctx, &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Check(d.BreakerStatus()["test-controller"].State, qt.Equals, "open")
+
+	// Once the cooldown has passed a trial dial is attempted, which
+	// opens the breaker again when it fails.
+	time.Sleep(10 * time.Millisecond)
+	_, err = d.Dial(ctx, &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.Not(qt.ErrorMatches), `controller "test-controller" is unavailable`)
+	c.Check(d.BreakerStatus()["test-controller"].State, qt.Equals, "open")
+	c.Check(d.BreakerStatus()["test-controller"].ConsecutiveFailures, qt.Equals, 2)
+}
+
+func TestDialerCircuitBreakerCancelDuringBackoff(t *testing.T) {
+	c := qt.New(t)
+
+	d := &jujuclient.Dialer{
+		DialAttempts:     2,
+		InitialBackoff:   time.Hour,
+		BreakerThreshold: 1,
+		BreakerCooldown:  time.Hour,
+	}
+	ctl := dbmodel.Controller{
+		Name:          "test-controller",
+		PublicAddress: unreachableAddress(c),
+	}
+
+	// Cancelling the dial whilst waiting to retry says nothing about
+	// the state of the controller, so it is not counted as a failure.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := d.Dial(ctx, &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Check(d.BreakerStatus()["test-controller"].State, qt.Equals, "closed")
+	c.Check(d.BreakerStatus()["test-controller"].ConsecutiveFailures, qt.Equals, 0)
+}
+
+func TestDialerConnectTimeout(t *testing.T) {
+	c := qt.New(t)
+
+	// A listener that accepts connections but never completes the
+	// websocket handshake.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	d := &jujuclient.Dialer{
+		ConnectTimeout: 50 * time.Millisecond,
+	}
+	ctl := dbmodel.Controller{
+		Name:          "test-controller",
+		PublicAddress: l.Addr().String(),
+	}
+	start := time.Now()
+	_, err = d.Dial(context.Background(), &ctl, names.ModelTag{}, nil)
+	c.Assert(err, qt.Not(qt.IsNil))
+	c.Check(time.Since(start) < 5*time.Second, qt.IsTrue)
+}
@@ -11,10 +11,13 @@ package jujuclient
 import (
 	"context"
 	"encoding/base64"
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"sort"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -43,6 +46,82 @@ const (
 // connection to provide a jimm API.
 type Dialer struct {
 	JWTService *jimmjwx.JWTService
+
+	// ConnectTimeout is the maximum time allowed for a single attempt
+	// to connect and log in to a controller. If this is zero an attempt
+	// is only limited by the context passed to Dial.
+	ConnectTimeout time.Duration
+
+	// CallTimeout is the maximum time allowed for an RPC call made on a
+	// connection. Watcher Next calls, which are expected to block until
+	// there are changes, are not limited. If this is zero calls are only
+	// limited by their context.
+	CallTimeout time.Duration
+
+	// DialAttempts is the maximum number of attempts Dial will make to
+	// connect to an unreachable controller. If this is zero a single
+	// attempt is made.
+	DialAttempts int
+
+	// InitialBackoff is the time to wait before retrying a failed dial.
+	// The wait doubles after every subsequent failure up to MaxBackoff.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum time to wait between dial attempts. If
+	// this is zero the wait is not limited.
+	MaxBackoff time.Duration
+
+	// BreakerThreshold is the number of consecutive failed dials to a
+	// controller after which the controller is considered to be down
+	// and further dials fail immediately. If this is zero no circuit
+	// breaking is performed.
+	BreakerThreshold int
+
+	// BreakerCooldown is the length of time dials to a controller that
+	// is considered down will fail immediately. Once the cooldown has
+	// passed a single trial dial is made to see if the controller has
+	// recovered.
+	BreakerCooldown time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*breaker
+}
+
+// getBreaker returns the circuit breaker for the given controller, or nil
+// if circuit breaking is disabled.
+func (d *Dialer) getBreaker(controller string) *breaker {
+	if d.BreakerThreshold <= 0 {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.breakers == nil {
+		d.breakers = make(map[string]*breaker)
+	}
+	b, ok := d.breakers[controller]
+	if !ok {
+		b = &breaker{controller: controller}
+		d.breakers[controller] = b
+		b.setState(BreakerClosed)
+	}
+	return b
+}
+
+// BreakerStatus returns the current state of the circuit breaker of every
+// controller that has been dialed, keyed by controller name.
+func (d *Dialer) BreakerStatus() map[string]BreakerStatus {
+	d.mu.Lock()
+	breakers := make([]*breaker, 0, len(d.breakers))
+	for _, b := range d.breakers {
+		breakers = append(breakers, b)
+	}
+	d.mu.Unlock()
+
+	status := make(map[string]BreakerStatus, len(breakers))
+	for _, b := range breakers {
+		status[b.controller] = b.status()
+	}
+	return status
 }
 
 func (d *Dialer) createLoginRequest(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag, p map[string]string) (*jujuparams.LoginRequest, error) {
@@ -73,28 +152,102 @@ func (d *Dialer) createLoginRequest(ctx context.Context, ctl *dbmodel.Controller
 	}, nil
 }
 
-// Dial implements jimm.Dialer.
+// Dial implements jimm.Dialer. If the controller cannot be reached the
+// dial is retried, with exponential backoff, up to DialAttempts times.
+// If circuit breaking is enabled and the controller is known to be down
+// Dial fails immediately with an error with the code
+// CodeConnectionFailed.
 func (d *Dialer) Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag, requiredPermissions map[string]string) (jimm.API, error) {
 	const op = errors.Op("jujuclient.Dial")
 
-	conn, err := rpc.Dial(ctx, ctl, modelTag, "")
+	b := d.getBreaker(ctl.Name)
+	if b != nil && !b.allow(time.Now(), d.BreakerCooldown) {
+		return nil, errors.E(op, errors.CodeConnectionFailed, fmt.Sprintf("controller %q is unavailable", ctl.Name))
+	}
+
+	backoff := d.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		api, unreachable, err := d.dial(ctx, ctl, modelTag, requiredPermissions)
+		if err == nil || !unreachable {
+			// The controller responded, even if it refused the
+			// login it is not down.
+			if b != nil {
+				b.success()
+			}
+			if err != nil {
+				return nil, err
+			}
+			return api, nil
+		}
+		if ctx.Err() != nil {
+			// The caller gave up, this says nothing about the
+			// state of the controller.
+			if b != nil {
+				b.release()
+			}
+			return nil, err
+		}
+		if attempt >= d.DialAttempts {
+			if b != nil {
+				b.failure(time.Now(), d.BreakerThreshold, err)
+			}
+			return nil, err
+		}
+		zapctx.Warn(ctx, "failed to dial controller, retrying", zap.String("controller", ctl.Name), zap.Int("attempt", attempt), zap.Duration("backoff", backoff), zap.Error(err))
+		servermon.JujuDialRetryCount.WithLabelValues(ctl.Name).Inc()
+		t := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			// As above, the caller gave up before all the attempts
+			// were made.
+			t.Stop()
+			if b != nil {
+				b.release()
+			}
+			return nil, err
+		case <-t.C:
+		}
+		backoff *= 2
+		if d.MaxBackoff > 0 && backoff > d.MaxBackoff {
+			backoff = d.MaxBackoff
+		}
+	}
+}
+
+// dial makes a single attempt to connect and log in to the controller.
+// If the attempt fails because the controller could not be reached, as
+// opposed to the controller rejecting the login, unreachable will be
+// true.
+func (d *Dialer) dial(ctx context.Context, ctl *dbmodel.Controller, modelTag names.ModelTag, requiredPermissions map[string]string) (_ *Connection, unreachable bool, _ error) {
+	const op = errors.Op("jujuclient.Dial")
+
+	dialCtx := ctx
+	if d.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+		dialCtx, cancel = context.WithTimeout(ctx, d.ConnectTimeout)
+		defer cancel()
+	}
+
+	conn, err := rpc.Dial(dialCtx, ctl, modelTag, "")
 	if err != nil {
-		return nil, err
+		return nil, true, err
 	}
 	if conn == nil {
-		return nil, errors.E(op, errors.CodeConnectionFailed, err)
+		return nil, true, errors.E(op, errors.CodeConnectionFailed, err)
 	}
 	client := rpc.NewClient(conn)
 
-	loginRequest, err := d.createLoginRequest(ctx, ctl, modelTag, requiredPermissions)
+	loginRequest, err := d.createLoginRequest(dialCtx, ctl, modelTag, requiredPermissions)
 	if err != nil {
-		return nil, errors.E(op, err)
+		client.Close()
+		return nil, false, errors.E(op, err)
 	}
 
 	var res jujuparams.LoginResult
-	if err := client.Call(ctx, "Admin", 3, "", "Login", loginRequest, &res); err != nil {
+	if err := client.Call(dialCtx, "Admin", 3, "", "Login", loginRequest, &res); err != nil {
 		client.Close()
-		return nil, errors.E(op, errors.CodeConnectionFailed, "authentication failed", err)
+		var rpcErr *rpc.Error
+		return nil, !stderrors.As(err, &rpcErr), errors.E(op, errors.CodeConnectionFailed, "authentication failed", err)
 	}
 
 	ct, err := names.ParseControllerTag(res.ControllerTag)
@@ -130,7 +283,33 @@ func (d *Dialer) Dial(ctx context.Context, ctl *dbmodel.Controller, modelTag nam
 		ctl:                ctl,
 		mt:                 modelTag,
 		redialCount:        new(atomic.Int32),
-	}, nil
+	}, false, nil
+}
+
+// CheckPasswordLogin implements jimm.PasswordLoginChecker. A new
+// connection is made to the controller and a login is attempted using
+// the given username and password rather than a JWT. The connection is
+// closed once the login has completed.
+func (d *Dialer) CheckPasswordLogin(ctx context.Context, ctl *dbmodel.Controller, username, password string) error {
+	const op = errors.Op("jujuclient.CheckPasswordLogin")
+
+	conn, err := rpc.Dial(ctx, ctl, names.ModelTag{}, "")
+	if err != nil {
+		return errors.E(op, errors.CodeConnectionFailed, err)
+	}
+	client := rpc.NewClient(conn)
+	defer client.Close()
+
+	loginRequest := jujuparams.LoginRequest{
+		AuthTag:       names.NewUserTag(username).String(),
+		Credentials:   password,
+		ClientVersion: jujuClientVersion,
+	}
+	var res jujuparams.LoginResult
+	if err := client.Call(ctx, "Admin", 3, "", "Login", &loginRequest, &res); err != nil {
+		return errors.E(op, errors.CodeConnectionFailed, "authentication failed", err)
+	}
+	return nil
 }
 
 const pingTimeout = 15 * time.Second
@@ -249,6 +428,12 @@ func (c *Connection) Call(ctx context.Context, facade string, version int, id, m
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.JujuCallErrorCount, &err, labels...)
 
+	if c.dialer != nil && c.dialer.CallTimeout > 0 && !isWatcherNext(facade, method) {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.dialer.CallTimeout)
+		defer cancel()
+	}
+
 	err = c.client.Call(ctx, facade, version, id, method, args, resp)
 	if err != nil {
 		if rpcErr, ok := err.(*rpc.Error); ok {
@@ -276,6 +461,12 @@ func (c *Connection) Call(ctx context.Context, facade string, version int, id, m
 	return nil
 }
 
+// isWatcherNext returns whether the given method is a watcher's Next
+// method, which blocks until there are changes to report.
+func isWatcherNext(facade, method string) bool {
+	return method == "Next" && strings.HasSuffix(facade, "Watcher")
+}
+
 // CallHighestFacadeVersion calls the specified method on the highest supported version of
 // the facade.
 func (c *Connection) CallHighestFacadeVersion(ctx context.Context, facade string, versions []int, id, method string, args, resp interface{}) error {
@@ -309,3 +309,30 @@ func (c Connection) ChangeModelCredential(ctx context.Context, model names.Model
 	}
 	return out.OneError()
 }
+
+// ModelGet returns the config of the model the connection is logged in
+// to. This method must be called on a connection to the model. This uses
+// the ModelGet method on the ModelConfig facade version 3.
+func (c Connection) ModelGet(ctx context.Context) (map[string]jujuparams.ConfigValue, error) {
+	const op = errors.Op("jujuclient.ModelGet")
+	var resp jujuparams.ModelConfigResults
+	if err := c.CallHighestFacadeVersion(ctx, "ModelConfig", []int{3}, "", "ModelGet", nil, &resp); err != nil {
+		return nil, errors.E(op, jujuerrors.Cause(err))
+	}
+	return resp.Config, nil
+}
+
+// ModelSet sets the given values in the config of the model the
+// connection is logged in to. This method must be called on a connection
+// to the model. This uses the ModelSet method on the ModelConfig facade
+// version 3.
+func (c Connection) ModelSet(ctx context.Context, config map[string]interface{}) error {
+	const op = errors.Op("jujuclient.ModelSet")
+	args := jujuparams.ModelSet{
+		Config: config,
+	}
+	if err := c.CallHighestFacadeVersion(ctx, "ModelConfig", []int{3}, "", "ModelSet", &args, nil); err != nil {
+		return errors.E(op, jujuerrors.Cause(err))
+	}
+	return nil
+}
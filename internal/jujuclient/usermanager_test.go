@@ -0,0 +1,48 @@
+// Copyright 2024 Canonical.
+
+package jujuclient_test
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm"
+)
+
+type usermanagerSuite struct {
+	jujuclientSuite
+}
+
+var _ = gc.Suite(&usermanagerSuite{})
+
+func (s *usermanagerSuite) TestSetPassword(c *gc.C) {
+	ctx := context.Background()
+
+	info := s.APIInfo(c)
+	ctl := dbmodel.Controller{
+		UUID:          s.ControllerConfig.ControllerUUID(),
+		Name:          s.ControllerConfig.ControllerName(),
+		CACertificate: info.CACert,
+		PublicAddress: info.Addrs[0],
+	}
+
+	err := s.API.SetPassword(ctx, info.Tag.(names.UserTag), "new-password")
+	c.Assert(err, gc.Equals, nil)
+
+	plc := s.Dialer.(jimm.PasswordLoginChecker)
+	err = plc.CheckPasswordLogin(ctx, &ctl, info.Tag.Id(), "new-password")
+	c.Assert(err, gc.Equals, nil)
+
+	err = plc.CheckPasswordLogin(ctx, &ctl, info.Tag.Id(), info.Password)
+	c.Assert(err, gc.ErrorMatches, `authentication failed`)
+}
+
+func (s *usermanagerSuite) TestSetPasswordUnknownUser(c *gc.C) {
+	ctx := context.Background()
+
+	err := s.API.SetPassword(ctx, names.NewUserTag("no-such-user"), "new-password")
+	c.Assert(err, gc.ErrorMatches, `.*"no-such-user".* not found`)
+}
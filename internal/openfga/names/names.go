@@ -35,13 +35,17 @@ var (
 	CanAddModelRelation cofga.Relation = "can_addmodel"
 	// AuditLogViewer represents an audit_log_viewer relation between entities.
 	AuditLogViewerRelation cofga.Relation = "audit_log_viewer"
+	// UserRelation represents a user relation between entities.
+	UserRelation cofga.Relation = "user"
+	// CloudRelation represents a cloud relation between entities.
+	CloudRelation cofga.Relation = "cloud"
 	// NoRelation is returned when there is no relation.
 	NoRelation cofga.Relation = ""
 )
 
 // allRelations contains a slice of all valid relations.
 // NB: Add any new relations from the above to this slice.
-var allRelations = []cofga.Relation{MemberRelation, AdministratorRelation, ControllerRelation, ModelRelation, ConsumerRelation, ReaderRelation, WriterRelation, CanAddModelRelation, AuditLogViewerRelation, NoRelation}
+var allRelations = []cofga.Relation{MemberRelation, AdministratorRelation, ControllerRelation, ModelRelation, ConsumerRelation, ReaderRelation, WriterRelation, CanAddModelRelation, AuditLogViewerRelation, UserRelation, CloudRelation, NoRelation}
 
 // EveryoneUser is the username representing all users and is treated uniquely when used in OpenFGA tuples.
 const EveryoneUser = "everyone@external"
@@ -59,7 +63,9 @@ type ResourceTagger interface {
 		names.ModelTag |
 		names.ApplicationOfferTag |
 		names.CloudTag |
-		jimmnames.ServiceAccountTag
+		names.CloudCredentialTag |
+		jimmnames.ServiceAccountTag |
+		jimmnames.CloudRegionTag
 
 	Id() string
 	Kind() string
@@ -108,7 +114,8 @@ func BlankKindTag(kind string) (*Tag, error) {
 	case names.UserTagKind, jimmnames.GroupTagKind,
 		names.ControllerTagKind, names.ModelTagKind,
 		names.ApplicationOfferTagKind, names.CloudTagKind,
-		jimmnames.ServiceAccountTagKind:
+		names.CloudCredentialTagKind, jimmnames.ServiceAccountTagKind,
+		jimmnames.CloudRegionTagKind:
 		return &Tag{
 			Kind: cofga.Kind(kind),
 		}, nil
@@ -167,6 +174,10 @@ func ParseRelation(relationString string) (cofga.Relation, error) {
 		return CanAddModelRelation, nil
 	case AuditLogViewerRelation.String():
 		return AuditLogViewerRelation, nil
+	case UserRelation.String():
+		return UserRelation, nil
+	case CloudRelation.String():
+		return CloudRelation, nil
 	default:
 		return cofga.Relation(""), errors.E(op, fmt.Sprintf("unknown relation %s", relationString))
 
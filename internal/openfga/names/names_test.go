@@ -42,6 +42,9 @@ func (s *namesSuite) TestFromResourceTag(c *gc.C) {
 	result = ofganames.ConvertTag(names.NewCloudTag("test"))
 	c.Assert(result, gc.DeepEquals, ofganames.NewTag("test", names.CloudTagKind, ""))
 
+	result = ofganames.ConvertTag(names.NewCloudCredentialTag("test/eve/cred"))
+	c.Assert(result, gc.DeepEquals, ofganames.NewTag("test/eve/cred", names.CloudCredentialTagKind, ""))
+
 	result = ofganames.ConvertTag(jimmnames.NewGroupTag(id.String()))
 	c.Assert(result, gc.DeepEquals, ofganames.NewTag(id.String(), jimmnames.GroupTagKind, ""))
 }
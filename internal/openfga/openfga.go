@@ -17,7 +17,7 @@ import (
 
 var (
 	// resourceTypes contains a list of all resource kinds (i.e. tags) used throughout JIMM.
-	resourceTypes = [...]string{names.UserTagKind, names.ModelTagKind, names.ControllerTagKind, names.ApplicationOfferTagKind, jimmnames.GroupTagKind, jimmnames.ServiceAccountTagKind}
+	resourceTypes = [...]string{names.UserTagKind, names.ModelTagKind, names.ControllerTagKind, names.ApplicationOfferTagKind, jimmnames.GroupTagKind, jimmnames.ServiceAccountTagKind, names.CloudCredentialTagKind, jimmnames.CloudRegionTagKind}
 )
 
 // Tuple represents a relation between an object and a target.
@@ -45,6 +45,10 @@ var (
 	ApplicationOfferType Kind = jimmnames.ApplicationOfferTagKind
 	// CloudType represents a cloud object.
 	CloudType Kind = names.CloudTagKind
+	// CloudRegionType represents a cloud region object.
+	CloudRegionType Kind = jimmnames.CloudRegionTagKind
+	// CloudCredentialType represents a cloud credential object.
+	CloudCredentialType Kind = names.CloudCredentialTagKind
 	// ControllerType represents a controller object.
 	ControllerType Kind = names.ControllerTagKind
 	// GroupType represents a group object.
@@ -322,6 +326,49 @@ func (o *OFGAClient) RemoveCloud(ctx context.Context, cloud names.CloudTag) erro
 	return nil
 }
 
+// RemoveCloudCredential removes a cloud credential.
+func (o *OFGAClient) RemoveCloudCredential(ctx context.Context, credential names.CloudCredentialTag) error {
+	if err := o.removeTuples(
+		ctx,
+		Tuple{
+			Target: ofganames.ConvertTag(credential),
+		},
+	); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// AddCloudCredentialGroup makes the members of the given group
+// administrators of the given cloud credential.
+func (o *OFGAClient) AddCloudCredentialGroup(ctx context.Context, credential names.CloudCredentialTag, group jimmnames.GroupTag) error {
+	if err := o.AddRelation(ctx, cloudCredentialGroupTuple(credential, group)); err != nil {
+		// if the tuple already exist we don't return an error.
+		if strings.Contains(err.Error(), "cannot write a tuple which already exists") {
+			return nil
+		}
+		return errors.E(err)
+	}
+	return nil
+}
+
+// RemoveCloudCredentialGroup removes the administrator relation between
+// the members of the given group and the given cloud credential.
+func (o *OFGAClient) RemoveCloudCredentialGroup(ctx context.Context, credential names.CloudCredentialTag, group jimmnames.GroupTag) error {
+	if err := o.RemoveRelation(ctx, cloudCredentialGroupTuple(credential, group)); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+func cloudCredentialGroupTuple(credential names.CloudCredentialTag, group jimmnames.GroupTag) Tuple {
+	return Tuple{
+		Object:   ofganames.ConvertTagWithRelation(group, ofganames.MemberRelation),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(credential),
+	}
+}
+
 // AddCloudController adds a controller relation between a controller and
 // a cloud.
 func (o *OFGAClient) AddCloudController(ctx context.Context, cloud names.CloudTag, controller names.ControllerTag) error {
@@ -339,6 +386,37 @@ func (o *OFGAClient) AddCloudController(ctx context.Context, cloud names.CloudTa
 	return nil
 }
 
+// AddCloudRegion adds a cloud relation between a cloud and one of its
+// regions, so that users able to add models to the cloud can add models
+// to the region.
+func (o *OFGAClient) AddCloudRegion(ctx context.Context, region jimmnames.CloudRegionTag) error {
+	if err := o.AddRelation(ctx, Tuple{
+		Object:   ofganames.ConvertTag(region.Cloud()),
+		Relation: ofganames.CloudRelation,
+		Target:   ofganames.ConvertTag(region),
+	}); err != nil {
+		// if the tuple already exist we don't return an error.
+		if strings.Contains(err.Error(), "cannot write a tuple which already exists") {
+			return nil
+		}
+		return errors.E(err)
+	}
+	return nil
+}
+
+// RemoveCloudRegion removes a cloud region.
+func (o *OFGAClient) RemoveCloudRegion(ctx context.Context, region jimmnames.CloudRegionTag) error {
+	if err := o.removeTuples(
+		ctx,
+		Tuple{
+			Target: ofganames.ConvertTag(region),
+		},
+	); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
 // AddController adds a controller relation between JIMM and the added controller. Meaning
 // JIMM admins also have administrator access to the added controller
 func (o *OFGAClient) AddController(ctx context.Context, jimm names.ControllerTag, controller names.ControllerTag) error {
@@ -44,6 +44,16 @@ func (u *User) IsAllowedAddModel(ctx context.Context, resource names.CloudTag) (
 	return allowed, nil
 }
 
+// IsAllowedAddModelInRegion returns true if the user can add models in
+// the cloud region, either directly or through access to the whole cloud.
+func (u *User) IsAllowedAddModelInRegion(ctx context.Context, resource jimmnames.CloudRegionTag) (bool, error) {
+	allowed, err := checkRelation(ctx, u, resource, ofganames.CanAddModelRelation)
+	if err != nil {
+		return false, errors.E(err)
+	}
+	return allowed, nil
+}
+
 // IsApplicationOfferConsumer returns true if user has consumer relation to the application offer.
 func (u *User) IsApplicationOfferConsumer(ctx context.Context, resource names.ApplicationOfferTag) (bool, error) {
 	isConsumer, err := checkRelation(ctx, u, resource, ofganames.ConsumerRelation)
@@ -111,6 +121,30 @@ func (u *User) GetCloudAccess(ctx context.Context, resource names.CloudTag) Rela
 	return ofganames.NoRelation
 }
 
+// GetCloudCredentialAccess returns the relation the user has to the
+// specified cloud credential. The credential's owner is not recorded in
+// OpenFGA, so callers should check ownership separately.
+func (u *User) GetCloudCredentialAccess(ctx context.Context, resource names.CloudCredentialTag) Relation {
+	isCredentialAdmin, err := IsAdministrator(ctx, u, resource)
+	if err != nil {
+		zapctx.Error(ctx, "openfga check failed", zap.Error(err))
+		return ofganames.NoRelation
+	}
+	if isCredentialAdmin {
+		return ofganames.AdministratorRelation
+	}
+	isCredentialUser, err := checkRelation(ctx, u, resource, ofganames.UserRelation)
+	if err != nil {
+		zapctx.Error(ctx, "openfga check failed", zap.Error(err))
+		return ofganames.NoRelation
+	}
+	if isCredentialUser {
+		return ofganames.UserRelation
+	}
+
+	return ofganames.NoRelation
+}
+
 // GetAuditLogViewerAccess returns if the user has audit log viewer relation with the given controller.
 func (u *User) GetAuditLogViewerAccess(ctx context.Context, resource names.ControllerTag) Relation {
 	hasAccess, err := checkRelation(ctx, u, resource, ofganames.AuditLogViewerRelation)
@@ -233,6 +267,18 @@ func (u *User) UnsetCloudAccess(ctx context.Context, resource names.CloudTag, re
 	return unsetMultipleResourceAccesses(ctx, u, resource, relations, 0)
 }
 
+// SetCloudRegionAccess adds a direct relation between the user and the cloud region.
+// Note that the action is idempotent (does not return error if the relation already exists).
+func (u *User) SetCloudRegionAccess(ctx context.Context, resource jimmnames.CloudRegionTag, relation Relation) error {
+	return setResourceAccess(ctx, u, resource, relation)
+}
+
+// UnsetCloudRegionAccess removes direct relations between the user and the cloud region.
+// Note that the action is idempotent (i.e., does not return error if the relation does not exist).
+func (u *User) UnsetCloudRegionAccess(ctx context.Context, resource jimmnames.CloudRegionTag, relations ...Relation) error {
+	return unsetMultipleResourceAccesses(ctx, u, resource, relations, 0)
+}
+
 // SetApplicationOfferAccess adds a direct relation between the user and the application offer.
 // Note that the action is idempotent (does not return error if the relation already exists).
 func (u *User) SetApplicationOfferAccess(ctx context.Context, resource names.ApplicationOfferTag, relation Relation) error {
@@ -272,8 +318,35 @@ func (u *User) ListApplicationOffers(ctx context.Context, relation ofga.Relation
 	return appOfferUUIDs, err
 }
 
+// ListCloudCredentials returns a slice of the IDs of the cloud
+// credentials that the user has the relation <relation> to.
+func (u *User) ListCloudCredentials(ctx context.Context, relation ofga.Relation) ([]string, error) {
+	entities, err := u.client.ListObjects(ctx, ofganames.ConvertTag(u.ResourceTag()), relation, CloudCredentialType, nil)
+	if err != nil {
+		return nil, err
+	}
+	credentialIDs := make([]string, len(entities))
+	for i, credential := range entities {
+		credentialIDs[i] = credential.ID
+	}
+	return credentialIDs, err
+}
+
+// ListGroups returns a slice of the UUIDs of the groups the user is a member of.
+func (u *User) ListGroups(ctx context.Context) ([]string, error) {
+	entities, err := u.client.ListObjects(ctx, ofganames.ConvertTag(u.ResourceTag()), ofganames.MemberRelation, GroupType, nil)
+	if err != nil {
+		return nil, err
+	}
+	groupUUIDs := make([]string, len(entities))
+	for i, group := range entities {
+		groupUUIDs[i] = group.ID
+	}
+	return groupUUIDs, err
+}
+
 type administratorT interface {
-	names.ControllerTag | names.ModelTag | names.ApplicationOfferTag | names.CloudTag
+	names.ControllerTag | names.ModelTag | names.ApplicationOfferTag | names.CloudTag | names.CloudCredentialTag
 
 	Id() string
 	Kind() string
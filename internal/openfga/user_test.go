@@ -271,6 +271,67 @@ func (s *userTestSuite) TestSetCloudAccess(c *gc.C) {
 	c.Assert(relation, gc.DeepEquals, ofganames.NoRelation)
 }
 
+func (s *userTestSuite) TestCloudCredentialAccess(c *gc.C) {
+	ctx := context.Background()
+
+	group := jimmnames.NewGroupTag(uuid.NewString())
+	credential1 := names.NewCloudCredentialTag("test-cloud/bob/cred-1")
+	credential2 := names.NewCloudCredentialTag("test-cloud/bob/cred-2")
+
+	eve := names.NewUserTag("eve")
+	alice := names.NewUserTag("alice")
+
+	tuples := []openfga.Tuple{{
+		Object:   ofganames.ConvertTag(eve),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group),
+	}, {
+		Object:   ofganames.ConvertTagWithRelation(group, ofganames.MemberRelation),
+		Relation: ofganames.UserRelation,
+		Target:   ofganames.ConvertTag(credential1),
+	}, {
+		Object:   ofganames.ConvertTagWithRelation(group, ofganames.MemberRelation),
+		Relation: ofganames.UserRelation,
+		Target:   ofganames.ConvertTag(credential2),
+	}, {
+		Object:   ofganames.ConvertTag(alice),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(credential1),
+	}}
+	err := s.ofgaClient.AddRelation(ctx, tuples...)
+	c.Assert(err, gc.IsNil)
+	i, err := dbmodel.NewIdentity("adam")
+	c.Assert(err, gc.IsNil)
+	eveIdentity, err := dbmodel.NewIdentity(eve.Id())
+	c.Assert(err, gc.IsNil)
+	aliceIdentity, err := dbmodel.NewIdentity(alice.Id())
+	c.Assert(err, gc.IsNil)
+
+	adamUser := openfga.NewUser(i, s.ofgaClient)
+	eveUser := openfga.NewUser(eveIdentity, s.ofgaClient)
+	aliceUser := openfga.NewUser(aliceIdentity, s.ofgaClient)
+
+	relation := aliceUser.GetCloudCredentialAccess(ctx, credential1)
+	c.Assert(relation, gc.DeepEquals, ofganames.AdministratorRelation)
+
+	relation = eveUser.GetCloudCredentialAccess(ctx, credential1)
+	c.Assert(relation, gc.DeepEquals, ofganames.UserRelation)
+
+	relation = adamUser.GetCloudCredentialAccess(ctx, credential1)
+	c.Assert(relation, gc.DeepEquals, ofganames.NoRelation)
+
+	credentialIDs, err := eveUser.ListCloudCredentials(ctx, ofganames.UserRelation)
+	c.Assert(err, gc.IsNil)
+	sort.Strings(credentialIDs)
+	c.Assert(credentialIDs, gc.DeepEquals, []string{credential1.Id(), credential2.Id()})
+
+	err = s.ofgaClient.RemoveCloudCredential(ctx, credential1)
+	c.Assert(err, gc.IsNil)
+
+	relation = aliceUser.GetCloudCredentialAccess(ctx, credential1)
+	c.Assert(relation, gc.DeepEquals, ofganames.NoRelation)
+}
+
 func (s *userTestSuite) TestControllerAccess(c *gc.C) {
 	ctx := context.Background()
 
@@ -577,6 +638,43 @@ func (s *userTestSuite) TestListApplicationOffers(c *gc.C) {
 	c.Assert(offerUUIDs, gc.DeepEquals, wantUUIDs)
 }
 
+func (s *userTestSuite) TestListGroups(c *gc.C) {
+	ctx := context.Background()
+
+	group1 := jimmnames.NewGroupTag(uuid.NewString())
+	group2 := jimmnames.NewGroupTag(uuid.NewString())
+	group3 := jimmnames.NewGroupTag(uuid.NewString())
+
+	adam := names.NewUserTag("adam")
+
+	tuples := []openfga.Tuple{{
+		Object:   ofganames.ConvertTag(adam),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group1),
+	}, {
+		Object:   ofganames.ConvertTagWithRelation(group1, ofganames.MemberRelation),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group2),
+	}, {
+		Object:   ofganames.ConvertTag(names.NewUserTag("eve")),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group3),
+	}}
+	err := s.ofgaClient.AddRelation(ctx, tuples...)
+	c.Assert(err, gc.IsNil)
+
+	adamIdentity, err := dbmodel.NewIdentity(adam.Name())
+	c.Assert(err, gc.IsNil)
+
+	adamUser := openfga.NewUser(adamIdentity, s.ofgaClient)
+	groupUUIDs, err := adamUser.ListGroups(ctx)
+	c.Assert(err, gc.IsNil)
+	wantUUIDs := []string{group1.Id(), group2.Id()}
+	sort.Strings(wantUUIDs)
+	sort.Strings(groupUUIDs)
+	c.Assert(groupUUIDs, gc.DeepEquals, wantUUIDs)
+}
+
 func (s *userTestSuite) TestUnsetMultipleResourceAccesses(c *gc.C) {
 	ctx := context.Background()
 
@@ -44,7 +44,7 @@ func (d Dialer) DialWebsocket(ctx context.Context, url string) (*websocket.Conn,
 	dialer := websocket.Dialer{
 		TLSClientConfig: d.TLSConfig,
 	}
-	conn, _, err := dialer.DialContext(context.Background(), url, nil)
+	conn, _, err := dialer.DialContext(ctx, url, nil)
 	if err != nil {
 		zapctx.Error(ctx, "BasicDial failed", zap.Error(err))
 		return nil, errors.E(op, err)
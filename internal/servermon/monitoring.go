@@ -83,6 +83,49 @@ var (
 		Name:      "error_total",
 		Help:      "The number of juju call errors.",
 	}, []string{"facade", "method", "controller"})
+	JujuDialRetryCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "juju",
+		Name:      "dial_retries_total",
+		Help:      "The number of retried juju controller dials.",
+	}, []string{"controller"})
+	JujuCircuitBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "juju",
+		Name:      "circuit_breaker_state",
+		Help:      "The state of the circuit breaker for each juju controller (0 closed, 1 half-open, 2 open).",
+	}, []string{"controller"})
+	JujuCircuitBreakerTripCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "juju",
+		Name:      "circuit_breaker_trips_total",
+		Help:      "The number of times the circuit breaker for a juju controller has opened.",
+	}, []string{"controller"})
+	ConnectionPoolHitCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "connection_pool",
+		Name:      "hits_total",
+		Help:      "The number of controller connections served from the connection pool.",
+	}, []string{"controller"})
+	ConnectionPoolMissCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "connection_pool",
+		Name:      "misses_total",
+		Help:      "The number of controller connection requests that required a new connection.",
+	}, []string{"controller"})
+	ConnectionPoolWaitDurationHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "jimm",
+		Subsystem: "connection_pool",
+		Name:      "wait_duration_seconds",
+		Help:      "Histogram of the time spent waiting for a controller connection in seconds.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+	}, []string{"controller"})
+	ConnectionPoolSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "connection_pool",
+		Name:      "connections",
+		Help:      "The number of pooled connections to each controller.",
+	}, []string{"controller"})
 	ConcurrentWebsocketConnections = promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: "jimm",
 		Subsystem: "websocket",
@@ -101,6 +144,18 @@ var (
 		Name:      "models_created_fail_total",
 		Help:      "The number of fails attempting to create models.",
 	})
+	ModelQuotaExceededCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "quota",
+		Name:      "models_rejected_total",
+		Help:      "The number of model creations rejected because a model quota was reached.",
+	}, []string{"limit"})
+	ModelQuotaSoftLimitBreachCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "quota",
+		Name:      "soft_limit_breaches_total",
+		Help:      "The number of times the usage of an identity's models was found to exceed a soft quota limit.",
+	}, []string{"limit"})
 	MonitorDeltasReceivedCount = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "jimm",
 		Subsystem: "monitor",
@@ -131,6 +186,12 @@ var (
 		Name:      "controller",
 		Help:      "The number of controllers managed by JIMM.",
 	})
+	InvalidCloudCredentialCount = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "system",
+		Name:      "invalid_cloud_credential",
+		Help:      "The number of cloud credentials known to be invalid.",
+	})
 )
 
 // DurationObserver returns a function that, when run with `defer` will
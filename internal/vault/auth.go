@@ -0,0 +1,89 @@
+// Copyright 2024 Canonical.
+
+package vault
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// defaultKubernetesTokenPath is the path of the service account token
+// mounted in a kubernetes pod.
+//
+//nolint:gosec // Thinks credentials hardcoded.
+const defaultKubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// NewKubernetesAuth returns an auth method that logs in to vault's
+// kubernetes auth method as the given role, using the service account
+// token read from tokenPath. If mount is empty the auth method is
+// expected to be mounted at "kubernetes". If tokenPath is empty the
+// token of the pod's service account is used.
+func NewKubernetesAuth(role, mount, tokenPath string) api.AuthMethod {
+	if mount == "" {
+		mount = "kubernetes"
+	}
+	if tokenPath == "" {
+		tokenPath = defaultKubernetesTokenPath
+	}
+	return &jwtAuth{
+		role:      role,
+		mount:     mount,
+		tokenPath: tokenPath,
+	}
+}
+
+// NewJWTAuth returns an auth method that logs in to vault's JWT auth
+// method as the given role, using the JWT read from tokenPath. If mount
+// is empty the auth method is expected to be mounted at "jwt".
+func NewJWTAuth(role, mount, tokenPath string) api.AuthMethod {
+	if mount == "" {
+		mount = "jwt"
+	}
+	return &jwtAuth{
+		role:      role,
+		mount:     mount,
+		tokenPath: tokenPath,
+	}
+}
+
+// A jwtAuth logs in to a vault auth method that exchanges a JWT for a
+// vault token, such as the kubernetes and JWT auth methods.
+type jwtAuth struct {
+	role  string
+	mount string
+
+	// tokenPath is the path of the file holding the JWT. The file is
+	// read on every login so that a token that is rotated by its issuer
+	// is picked up.
+	tokenPath string
+}
+
+// Login implements api.AuthMethod.
+func (a *jwtAuth) Login(ctx context.Context, client *api.Client) (*api.Secret, error) {
+	const op = errors.Op("vault.jwtAuth.Login")
+
+	if a.role == "" {
+		return nil, errors.E(op, "no role specified")
+	}
+	if a.tokenPath == "" {
+		return nil, errors.E(op, "no token path specified")
+	}
+	jwt, err := os.ReadFile(a.tokenPath)
+	if err != nil {
+		return nil, errors.E(op, err, "cannot read token")
+	}
+	secret, err := client.Logical().WriteWithContext(ctx, path.Join("auth", a.mount, "login"), map[string]interface{}{
+		"role": a.role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return secret, nil
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	goerr "errors"
+	"fmt"
 	"net/http"
 	"path"
 	"sync"
@@ -20,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm/credentials"
 	"github.com/canonical/jimm/v3/internal/servermon"
 )
 
@@ -48,6 +50,10 @@ type VaultStore struct {
 	// RoleSecretID is the AppRole secret ID.
 	RoleSecretID string
 
+	// AuthMethod, if set, is used to log in to vault instead of the
+	// AppRole auth method. See NewKubernetesAuth and NewJWTAuth.
+	AuthMethod api.AuthMethod
+
 	// KVPath is the root path in the vault for JIMM's key-value
 	// storage.
 	KVPath string
@@ -121,6 +127,69 @@ func (s *VaultStore) Put(ctx context.Context, tag names.CloudCredentialTag, attr
 	return nil
 }
 
+// ListCloudCredentialVersions returns the versions of the attributes of
+// the given cloud credential held in the vault service's KV v2 engine,
+// oldest first.
+func (s *VaultStore) ListCloudCredentialVersions(ctx context.Context, tag names.CloudCredentialTag) (_ []credentials.CredentialVersion, err error) {
+	const op = errors.Op("vault.ListCloudCredentialVersions")
+
+	durationObserver := servermon.DurationObserver(servermon.VaultCallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	versions, err := client.KVv2(s.KVPath).GetVersionsAsList(ctx, s.path(tag))
+	if goerr.Is(err, api.ErrSecretNotFound) {
+		return nil, errors.E(op, errors.CodeNotFound, "cloud credential not found")
+	}
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	result := make([]credentials.CredentialVersion, len(versions))
+	for i, v := range versions {
+		result[i] = credentials.CredentialVersion{
+			Version:     v.Version,
+			CreatedTime: v.CreatedTime,
+			Deleted:     v.Destroyed || !v.DeletionTime.IsZero(),
+		}
+	}
+	return result, nil
+}
+
+// GetCloudCredentialVersion retrieves the given version of the attributes
+// of the given cloud credential from the vault service's KV v2 engine.
+func (s *VaultStore) GetCloudCredentialVersion(ctx context.Context, tag names.CloudCredentialTag, version int) (_ map[string]string, err error) {
+	const op = errors.Op("vault.GetCloudCredentialVersion")
+
+	durationObserver := servermon.DurationObserver(servermon.VaultCallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	secret, err := client.KVv2(s.KVPath).GetVersion(ctx, s.path(tag), version)
+	if err != nil && !goerr.Is(err, api.ErrSecretNotFound) {
+		return nil, errors.E(op, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, errors.E(op, errors.CodeNotFound, fmt.Sprintf("version %d of cloud credential not found", version))
+	}
+	attr := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		if s, ok := v.(string); ok {
+			attr[k] = s
+		}
+	}
+	return attr, nil
+}
+
 // delete removes the attributes associated with the cloud-credential in
 // the vault service.
 func (s *VaultStore) delete(ctx context.Context, tag names.CloudCredentialTag) (err error) {
@@ -462,32 +531,123 @@ func (s *VaultStore) deleteControllerCredentials(ctx context.Context, controller
 
 const ttlLeeway time.Duration = 5 * time.Second
 
-func (s *VaultStore) client(ctx context.Context) (*api.Client, error) {
-	const op = errors.Op("vault.client")
+// loginRetryInterval is how long RenewToken waits before trying to log in
+// again after a failed login.
+const loginRetryInterval = 10 * time.Second
+
+// RenewToken logs in to vault and keeps the resulting token renewed until
+// the given context is canceled, logging in again whenever the token can
+// no longer be renewed. While RenewToken is running the store does not
+// need to log in when it is used. RenewToken always returns a non-nil
+// error.
+func (s *VaultStore) RenewToken(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		secret, err := s.login(ctx)
+		client := s.client_
+		s.mu.Unlock()
+		if err != nil {
+			zapctx.Error(ctx, "cannot log in to vault", zap.Error(err))
+			if err := sleep(ctx, loginRetryInterval); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := s.watchToken(ctx, client, secret); err != nil {
+			return err
+		}
+	}
+}
 
+// watchToken renews the token in the given login secret for as long as
+// possible. A nil error is returned when the token can no longer be
+// renewed.
+func (s *VaultStore) watchToken(ctx context.Context, client *api.Client, secret *api.Secret) error {
+	renewable, _ := secret.TokenIsRenewable()
+	if !renewable {
+		s.mu.Lock()
+		d := time.Until(s.expires)
+		s.mu.Unlock()
+		if d < loginRetryInterval {
+			d = loginRetryInterval
+		}
+		return sleep(ctx, d)
+	}
+	w, err := client.NewLifetimeWatcher(&api.LifetimeWatcherInput{Secret: secret})
+	if err != nil {
+		zapctx.Error(ctx, "cannot watch vault token", zap.Error(err))
+		return sleep(ctx, loginRetryInterval)
+	}
+	go w.Start()
+	defer w.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-w.DoneCh():
+			if err != nil {
+				zapctx.Warn(ctx, "cannot renew vault token", zap.Error(err))
+			}
+			return nil
+		case r := <-w.RenewCh():
+			ttl, err := r.Secret.TokenTTL()
+			if err != nil {
+				continue
+			}
+			s.mu.Lock()
+			if s.client_ == client {
+				s.expires = r.RenewedAt.Add(ttl - ttlLeeway)
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *VaultStore) client(ctx context.Context) (*api.Client, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	now := time.Now()
-	if now.Before(s.expires) {
+	if time.Now().Before(s.expires) {
 		return s.client_, nil
 	}
+	if _, err := s.login(ctx); err != nil {
+		return nil, err
+	}
+	return s.client_, nil
+}
+
+// login logs in to vault, replacing the client used by the store. The
+// login secret is returned so that the token can be renewed. s.mu must be
+// held when calling login.
+func (s *VaultStore) login(ctx context.Context) (*api.Secret, error) {
+	const op = errors.Op("vault.login")
 
-	roleSecretID := &auth.SecretID{
-		FromString: s.RoleSecretID,
+	now := time.Now()
+	authMethod := s.AuthMethod
+	if authMethod == nil {
+		roleSecretID := &auth.SecretID{
+			FromString: s.RoleSecretID,
+		}
+		appRoleAuth, err := auth.NewAppRoleAuth(
+			s.RoleID,
+			roleSecretID,
+		)
+		if err != nil {
+			zapctx.Error(ctx, "unable to initialize approle auth method", zap.Error(err))
+			return nil, errors.E(op, err, "unable to initialize approle auth method")
+		}
+		authMethod = appRoleAuth
 	}
-	appRoleAuth, err := auth.NewAppRoleAuth(
-		s.RoleID,
-		roleSecretID,
-	)
+
+	// Log in using a copy of the client so that the configured client
+	// is not modified.
+	client, err := s.Client.Clone()
 	if err != nil {
-		zapctx.Error(ctx, "unable to initialize approle auth method", zap.Error(err))
-		return nil, errors.E(op, err, "unable to initialize approle auth method")
+		return nil, errors.E(op, err)
 	}
-
-	authInfo, err := s.Client.Auth().Login(ctx, appRoleAuth)
+	authInfo, err := client.Auth().Login(ctx, authMethod)
 	if err != nil {
-		zapctx.Error(ctx, "unable to login to approle auth method", zap.Error(err))
-		return nil, errors.E(op, err, "unable to login to approle auth method")
+		zapctx.Error(ctx, "unable to login to vault", zap.Error(err))
+		return nil, errors.E(op, err, "unable to login to vault")
 	}
 	if authInfo == nil {
 		return nil, errors.E(op, "no auth info was returned after login")
@@ -501,13 +661,28 @@ func (s *VaultStore) client(ctx context.Context) (*api.Client, error) {
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
-	s.client_, err = s.Client.Clone()
-	if err != nil {
-		return nil, errors.E(op, err)
+	client.SetToken(tok)
+	s.client_ = client
+	if ttl == 0 {
+		// The token does not expire.
+		s.expires = now.AddDate(100, 0, 0)
+	} else {
+		s.expires = now.Add(ttl - ttlLeeway)
+	}
+	return authInfo, nil
+}
+
+// sleep waits for the given duration, or until the given context is
+// canceled in which case the context's error is returned.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
 	}
-	s.client_.SetToken(tok)
-	s.expires = now.Add(ttl - ttlLeeway)
-	return s.client_, nil
 }
 
 func (s *VaultStore) path(tag names.CloudCredentialTag) string {
@@ -18,6 +18,7 @@ import (
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 
+	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimmtest"
 	"github.com/canonical/jimm/v3/internal/vault"
 )
@@ -98,6 +99,81 @@ func TestVaultCloudCredentialAtrributeStoreEmpty(t *testing.T) {
 	c.Check(attr, qt.HasLen, 0)
 }
 
+func TestVaultCloudCredentialVersions(t *testing.T) {
+	c := qt.New(t)
+
+	st := newStore(c)
+	ctx := context.Background()
+	tag := names.NewCloudCredentialTag("aws/alice@canonical.com/" + c.Name())
+
+	_, err := st.ListCloudCredentialVersions(ctx, tag)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = st.Put(ctx, tag, map[string]string{"a": "1"})
+	c.Assert(err, qt.IsNil)
+	err = st.Put(ctx, tag, map[string]string{"a": "2"})
+	c.Assert(err, qt.IsNil)
+	err = st.Put(ctx, tag, nil)
+	c.Assert(err, qt.IsNil)
+
+	versions, err := st.ListCloudCredentialVersions(ctx, tag)
+	c.Assert(err, qt.IsNil)
+	c.Assert(versions, qt.HasLen, 2)
+	c.Check(versions[0].Version, qt.Equals, 1)
+	c.Check(versions[0].Deleted, qt.IsFalse)
+	c.Check(versions[1].Version, qt.Equals, 2)
+	c.Check(versions[1].Deleted, qt.IsTrue)
+
+	attr, err := st.GetCloudCredentialVersion(ctx, tag, 1)
+	c.Assert(err, qt.IsNil)
+	c.Check(attr, qt.DeepEquals, map[string]string{"a": "1"})
+
+	_, err = st.GetCloudCredentialVersion(ctx, tag, 2)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
+
+func TestVaultRenewToken(t *testing.T) {
+	c := qt.New(t)
+
+	st := newStore(c)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- st.RenewToken(ctx)
+	}()
+
+	// The store can be used while the token is being renewed.
+	tag := names.NewCloudCredentialTag("aws/alice@canonical.com/" + c.Name())
+	err := st.Put(ctx, tag, map[string]string{"a": "A"})
+	c.Assert(err, qt.IsNil)
+	attr, err := st.Get(ctx, tag)
+	c.Assert(err, qt.IsNil)
+	c.Check(attr, qt.DeepEquals, map[string]string{"a": "A"})
+
+	cancel()
+	select {
+	case err := <-done:
+		c.Check(err, qt.Equals, context.Canceled)
+	case <-time.After(time.Minute):
+		c.Fatal("RenewToken did not stop")
+	}
+}
+
+func TestJWTAuthWithoutToken(t *testing.T) {
+	c := qt.New(t)
+
+	client, _, _, _, ok := jimmtest.VaultClient(c)
+	if !ok {
+		c.Skip("vault not available")
+	}
+	st := &vault.VaultStore{
+		Client:     client,
+		AuthMethod: vault.NewJWTAuth("jimm", "", c.TempDir()+"/missing"),
+	}
+	_, err := st.Get(context.Background(), names.NewCloudCredentialTag("aws/alice@canonical.com/"+c.Name()))
+	c.Check(err, qt.ErrorMatches, `unable to login to vault`)
+}
+
 func TestVaultControllerCredentialsStoreRoundTrip(t *testing.T) {
 	c := qt.New(t)
 
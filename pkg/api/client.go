@@ -95,6 +95,254 @@ func (c *Client) SetControllerDeprecated(req *params.SetControllerDeprecatedRequ
 	return info, err
 }
 
+// RotateControllerCredentials generates a new password for the user JIMM
+// uses to connect to a controller.
+func (c *Client) RotateControllerCredentials(req *params.RotateControllerCredentialsRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RotateControllerCredentials", req, nil)
+}
+
+// SetModelQuota sets the model quota for an identity or group.
+func (c *Client) SetModelQuota(req *params.SetModelQuotaRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetModelQuota", req, nil)
+}
+
+// RemoveModelQuota removes the model quota for an identity or group.
+func (c *Client) RemoveModelQuota(req *params.RemoveModelQuotaRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveModelQuota", req, nil)
+}
+
+// ModelQuotas returns the model quotas that apply to an identity, along
+// with the identity's usage, or the quotas assigned to a group.
+func (c *Client) ModelQuotas(req *params.ModelQuotasRequest) (params.ModelQuotasResponse, error) {
+	var resp params.ModelQuotasResponse
+	err := c.caller.APICall("JIMM", 4, "", "ModelQuotas", req, &resp)
+	return resp, err
+}
+
+// ExtendModel extends the expiry time of an ephemeral model.
+func (c *Client) ExtendModel(req *params.ExtendModelRequest) (params.ExtendModelResponse, error) {
+	var resp params.ExtendModelResponse
+	err := c.caller.APICall("JIMM", 4, "", "ExtendModel", req, &resp)
+	return resp, err
+}
+
+// SetModelLabels sets and removes labels on a model.
+func (c *Client) SetModelLabels(req *params.SetModelLabelsRequest) (params.SetModelLabelsResponse, error) {
+	var resp params.SetModelLabelsResponse
+	err := c.caller.APICall("JIMM", 4, "", "SetModelLabels", req, &resp)
+	return resp, err
+}
+
+// ListModels returns the models, with their labels, that the user has
+// access to and that match the filters in the request. If the request
+// specifies a page size the continuation token in the response can be
+// used to retrieve the next page.
+func (c *Client) ListModels(req *params.ListModelsRequest) (params.ListModelsResponse, error) {
+	var resp params.ListModelsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListModels", req, &resp)
+	return resp, err
+}
+
+// ListModelSummaries returns the summaries, with labels, of the models
+// that the user has access to and that match the filters in the request.
+// If the request specifies a page size the continuation token in the
+// response can be used to retrieve the next page.
+func (c *Client) ListModelSummaries(req *params.ListModelsRequest) (params.ListModelSummariesResponse, error) {
+	var resp params.ListModelSummariesResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListModelSummaries", req, &resp)
+	return resp, err
+}
+
+// ModelInfo returns the model information, with labels, of the given
+// models.
+func (c *Client) ModelInfo(req *jujuparams.Entities) (params.ModelInfoResponse, error) {
+	var resp params.ModelInfoResponse
+	err := c.caller.APICall("JIMM", 4, "", "ModelInfo", req, &resp)
+	return resp, err
+}
+
+// TransferModelOwnership transfers ownership of a model to another user.
+func (c *Client) TransferModelOwnership(req *params.TransferModelOwnershipRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "TransferModelOwnership", req, nil)
+}
+
+// AddModelTemplate adds a model template.
+func (c *Client) AddModelTemplate(req *params.AddModelTemplateRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "AddModelTemplate", req, nil)
+}
+
+// UpdateModelTemplate replaces an existing model template.
+func (c *Client) UpdateModelTemplate(req *params.UpdateModelTemplateRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "UpdateModelTemplate", req, nil)
+}
+
+// RemoveModelTemplate removes a model template.
+func (c *Client) RemoveModelTemplate(req *params.RemoveModelTemplateRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveModelTemplate", req, nil)
+}
+
+// ListModelTemplates returns the model templates.
+func (c *Client) ListModelTemplates(req *params.ListModelTemplatesRequest) (params.ListModelTemplatesResponse, error) {
+	var resp params.ListModelTemplatesResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListModelTemplates", req, &resp)
+	return resp, err
+}
+
+// ListDeletedModels returns the tombstones of models removed from JIMM.
+func (c *Client) ListDeletedModels(req *params.ListDeletedModelsRequest) (params.ListDeletedModelsResponse, error) {
+	var resp params.ListDeletedModelsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListDeletedModels", req, &resp)
+	return resp, err
+}
+
+// BulkModelAccess grants or revokes access to every model matching the
+// selector in the request.
+func (c *Client) BulkModelAccess(req *params.BulkModelAccessRequest) (params.BulkModelAccessResponse, error) {
+	var resp params.BulkModelAccessResponse
+	err := c.caller.APICall("JIMM", 4, "", "BulkModelAccess", req, &resp)
+	return resp, err
+}
+
+// ModelDrift reports the models whose config has drifted from the model
+// defaults, optionally re-applying the defaults.
+func (c *Client) ModelDrift(req *params.ModelDriftRequest) (params.ModelDriftResponse, error) {
+	var resp params.ModelDriftResponse
+	err := c.caller.APICall("JIMM", 4, "", "ModelDrift", req, &resp)
+	return resp, err
+}
+
+// SetGroupModelDefaults sets model defaults for a group.
+func (c *Client) SetGroupModelDefaults(req *params.SetGroupModelDefaultsRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetGroupModelDefaults", req, nil)
+}
+
+// UnsetGroupModelDefaults unsets model defaults for a group.
+func (c *Client) UnsetGroupModelDefaults(req *params.UnsetGroupModelDefaultsRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "UnsetGroupModelDefaults", req, nil)
+}
+
+// GroupModelDefaults returns the model defaults of a group.
+func (c *Client) GroupModelDefaults(req *params.GroupModelDefaultsRequest) (params.GroupModelDefaults, error) {
+	var resp params.GroupModelDefaults
+	err := c.caller.APICall("JIMM", 4, "", "GroupModelDefaults", req, &resp)
+	return resp, err
+}
+
+// EffectiveModelConfig returns the config a new model would be added
+// with, along with the source of each value.
+func (c *Client) EffectiveModelConfig(req *params.EffectiveModelConfigRequest) (params.EffectiveModelConfigResponse, error) {
+	var resp params.EffectiveModelConfigResponse
+	err := c.caller.APICall("JIMM", 4, "", "EffectiveModelConfig", req, &resp)
+	return resp, err
+}
+
+// SetCloudCredentialGroup sets the group that owns a cloud credential.
+func (c *Client) SetCloudCredentialGroup(req *params.SetCloudCredentialGroupRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetCloudCredentialGroup", req, nil)
+}
+
+// ListCloudCredentials lists cloud credentials along with the result of
+// their most recent validity check.
+func (c *Client) ListCloudCredentials(req *params.ListCloudCredentialsRequest) (params.ListCloudCredentialsResponse, error) {
+	var resp params.ListCloudCredentialsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListCloudCredentials", req, &resp)
+	return resp, err
+}
+
+// RotateCloudCredential replaces the attributes of a cloud credential
+// using a staged rollout across controllers.
+func (c *Client) RotateCloudCredential(req *params.RotateCloudCredentialRequest) (params.CloudCredentialRotation, error) {
+	var resp params.CloudCredentialRotation
+	err := c.caller.APICall("JIMM", 4, "", "RotateCloudCredential", req, &resp)
+	return resp, err
+}
+
+// GetCloudCredentialRotation returns the most recent rotation of a cloud
+// credential.
+func (c *Client) GetCloudCredentialRotation(req *params.CloudCredentialRotationRequest) (params.CloudCredentialRotation, error) {
+	var resp params.CloudCredentialRotation
+	err := c.caller.APICall("JIMM", 4, "", "GetCloudCredentialRotation", req, &resp)
+	return resp, err
+}
+
+// ListCloudCredentialVersions lists the versions of the attributes of a
+// cloud credential kept by JIMM's credential store.
+func (c *Client) ListCloudCredentialVersions(req *params.ListCloudCredentialVersionsRequest) (params.ListCloudCredentialVersionsResponse, error) {
+	var resp params.ListCloudCredentialVersionsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListCloudCredentialVersions", req, &resp)
+	return resp, err
+}
+
+// RestoreCloudCredentialVersion restores a previous version of the
+// attributes of a cloud credential.
+func (c *Client) RestoreCloudCredentialVersion(req *params.RestoreCloudCredentialVersionRequest) (params.RestoreCloudCredentialVersionResponse, error) {
+	var resp params.RestoreCloudCredentialVersionResponse
+	err := c.caller.APICall("JIMM", 4, "", "RestoreCloudCredentialVersion", req, &resp)
+	return resp, err
+}
+
+// AddCatalogueCloud adds a cloud to JIMM's cloud catalogue without
+// adding it to any controller.
+func (c *Client) AddCatalogueCloud(req *params.AddCatalogueCloudRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "AddCatalogueCloud", req, nil)
+}
+
+// AttachCloudToController adds a cloud in JIMM's cloud catalogue to a
+// controller.
+func (c *Client) AttachCloudToController(req *params.AttachCloudToControllerRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "AttachCloudToController", req, nil)
+}
+
+// UpdateCatalogueCloud updates the definition of a cloud in JIMM's cloud
+// catalogue and every controller hosting it.
+func (c *Client) UpdateCatalogueCloud(req *params.UpdateCatalogueCloudRequest) (params.CloudControllerStatusResponse, error) {
+	var resp params.CloudControllerStatusResponse
+	err := c.caller.APICall("JIMM", 4, "", "UpdateCatalogueCloud", req, &resp)
+	return resp, err
+}
+
+// CloudControllerStatus returns the status of a cloud on every
+// controller hosting it.
+func (c *Client) CloudControllerStatus(req *params.CloudControllerStatusRequest) (params.CloudControllerStatusResponse, error) {
+	var resp params.CloudControllerStatusResponse
+	err := c.caller.APICall("JIMM", 4, "", "CloudControllerStatus", req, &resp)
+	return resp, err
+}
+
+// AddMigrationPlan adds a scheduled migration plan.
+func (c *Client) AddMigrationPlan(req *params.AddMigrationPlanRequest) (params.MigrationPlan, error) {
+	var resp params.MigrationPlan
+	err := c.caller.APICall("JIMM", 4, "", "AddMigrationPlan", req, &resp)
+	return resp, err
+}
+
+// GetMigrationPlan returns the named migration plan.
+func (c *Client) GetMigrationPlan(req *params.GetMigrationPlanRequest) (params.MigrationPlan, error) {
+	var resp params.MigrationPlan
+	err := c.caller.APICall("JIMM", 4, "", "GetMigrationPlan", req, &resp)
+	return resp, err
+}
+
+// ListMigrationPlans returns all the migration plans.
+func (c *Client) ListMigrationPlans() ([]params.MigrationPlan, error) {
+	var resp params.ListMigrationPlansResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListMigrationPlans", nil, &resp)
+	return resp.Plans, err
+}
+
+// UpdateMigrationPlan pauses, resumes or cancels a migration plan.
+func (c *Client) UpdateMigrationPlan(req *params.UpdateMigrationPlanRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "UpdateMigrationPlan", req, nil)
+}
+
+// ControllerVersions returns the agent versions of the controllers and
+// models managed by JIMM.
+func (c *Client) ControllerVersions(req *params.ControllerVersionsRequest) (params.ControllerVersionsResponse, error) {
+	var resp params.ControllerVersionsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ControllerVersions", req, &resp)
+	return resp, err
+}
+
 // FullModelStatus returns the full status of the juju model.
 func (c *Client) FullModelStatus(req *params.FullModelStatusRequest) (jujuparams.FullStatus, error) {
 	var status jujuparams.FullStatus
@@ -240,6 +240,1030 @@ type SetControllerDeprecatedRequest struct {
 	Deprecated bool `json:"deprecated"`
 }
 
+// A RotateControllerCredentialsRequest is the request that is sent in a
+// RotateControllerCredentials method.
+type RotateControllerCredentialsRequest struct {
+	// Name is the name of the controller whose credentials should be
+	// rotated.
+	Name string `json:"name"`
+}
+
+// A ControllerVersionsRequest is the request that is sent in a
+// ControllerVersions method.
+type ControllerVersionsRequest struct {
+	// Name is the name of the controller to report on. If this is empty
+	// all controllers are reported.
+	Name string `json:"name,omitempty"`
+
+	// ValidateUpgrades specifies whether each model should be checked to
+	// see if it is in a state that can be upgraded.
+	ValidateUpgrades bool `json:"validate-upgrades,omitempty"`
+}
+
+// A ControllerVersionsResponse is the response that is sent from a
+// ControllerVersions method.
+type ControllerVersionsResponse struct {
+	// Controllers contains the version information for each controller.
+	Controllers []ControllerVersions `json:"controllers"`
+}
+
+// ControllerVersions holds the agent version of a controller and of
+// each of the models it hosts.
+type ControllerVersions struct {
+	// Name is the name of the controller.
+	Name string `json:"name"`
+
+	// UUID is the UUID of the controller.
+	UUID string `json:"uuid"`
+
+	// AgentVersion is the version of the juju agent running on the
+	// controller.
+	AgentVersion string `json:"agent-version"`
+
+	// Models contains the version information for each model hosted on
+	// the controller.
+	Models []ModelVersion `json:"models,omitempty"`
+
+	// UpgradeSummary summarises the results of validating whether the
+	// models hosted on the controller can be upgraded. This is only
+	// present if upgrade validation was requested.
+	UpgradeSummary *UpgradeSummary `json:"upgrade-summary,omitempty"`
+
+	// Error contains any error that occurred whilst validating model
+	// upgrades on the controller.
+	Error string `json:"error,omitempty"`
+}
+
+// ModelVersion holds the agent version of a model.
+type ModelVersion struct {
+	// UUID is the UUID of the model.
+	UUID string `json:"uuid"`
+
+	// Name is the name of the model.
+	Name string `json:"name"`
+
+	// Owner is the name of the owner of the model.
+	Owner string `json:"owner"`
+
+	// AgentVersion is the version of the juju agent running the model, as
+	// last reported by the controller.
+	AgentVersion string `json:"agent-version"`
+
+	// UpgradeError contains the reason the model cannot be upgraded, if
+	// upgrade validation was requested and failed.
+	UpgradeError string `json:"upgrade-error,omitempty"`
+}
+
+// UpgradeSummary summarises the upgrade readiness of the models on a
+// controller.
+type UpgradeSummary struct {
+	// Checked is the number of models that were validated.
+	Checked int `json:"checked"`
+
+	// Blocked is the number of models that failed validation.
+	Blocked int `json:"blocked"`
+}
+
+// ModelQuotaLimits holds the limits of a model quota. A limit that is
+// not set is not enforced.
+type ModelQuotaLimits struct {
+	// MaxModels is the maximum number of models that may be owned.
+	MaxModels *int64 `json:"max-models,omitempty" yaml:"max-models,omitempty"`
+
+	// MaxMachines is the soft limit on the total number of machines in
+	// the models owned.
+	MaxMachines *int64 `json:"max-machines,omitempty" yaml:"max-machines,omitempty"`
+
+	// MaxCores is the soft limit on the total number of cores in the
+	// models owned.
+	MaxCores *int64 `json:"max-cores,omitempty" yaml:"max-cores,omitempty"`
+
+	// MaxUnits is the soft limit on the total number of units in the
+	// models owned.
+	MaxUnits *int64 `json:"max-units,omitempty" yaml:"max-units,omitempty"`
+}
+
+// A SetModelQuotaRequest is the request that is sent in a SetModelQuota
+// method.
+type SetModelQuotaRequest struct {
+	ModelQuotaLimits
+
+	// Entity is the user, service account or group the quota is
+	// assigned to, for example "user-alice@canonical.com" or
+	// "group-admins".
+	Entity string `json:"entity"`
+
+	// Cloud is the name of the cloud the quota applies to. If this is
+	// empty the quota applies to models on all clouds.
+	Cloud string `json:"cloud,omitempty"`
+}
+
+// A RemoveModelQuotaRequest is the request that is sent in a
+// RemoveModelQuota method.
+type RemoveModelQuotaRequest struct {
+	// Entity is the user, service account or group the quota is
+	// assigned to.
+	Entity string `json:"entity"`
+
+	// Cloud is the name of the cloud the quota applies to.
+	Cloud string `json:"cloud,omitempty"`
+}
+
+// A ModelQuotasRequest is the request that is sent in a ModelQuotas
+// method.
+type ModelQuotasRequest struct {
+	// Entity is the user, service account or group to report the quotas
+	// of. If this is empty the quotas of the authenticated user are
+	// reported.
+	Entity string `json:"entity,omitempty"`
+}
+
+// A ModelQuotasResponse is the response that is sent from a ModelQuotas
+// method.
+type ModelQuotasResponse struct {
+	// Quotas contains the model quotas that apply to the entity.
+	Quotas []ModelQuota `json:"quotas"`
+}
+
+// A ModelQuota holds a model quota and the usage measured against it.
+type ModelQuota struct {
+	ModelQuotaLimits `yaml:",inline"`
+
+	// Entity is the user, service account or group the quota is
+	// assigned to.
+	Entity string `json:"entity" yaml:"entity"`
+
+	// Cloud is the name of the cloud the quota applies to. It is empty
+	// if the quota applies to all clouds.
+	Cloud string `json:"cloud,omitempty" yaml:"cloud,omitempty"`
+
+	// Usage holds the current usage measured against the quota. It is
+	// only present when the quotas of an identity are requested.
+	Usage *ModelQuotaUsage `json:"usage,omitempty" yaml:"usage,omitempty"`
+}
+
+// ModelQuotaUsage holds the resources used by an identity's models.
+type ModelQuotaUsage struct {
+	// Models is the number of models owned.
+	Models int64 `json:"models" yaml:"models"`
+
+	// Machines is the total number of machines in the models.
+	Machines int64 `json:"machines" yaml:"machines"`
+
+	// Cores is the total number of cores in the models.
+	Cores int64 `json:"cores" yaml:"cores"`
+
+	// Units is the total number of units in the models.
+	Units int64 `json:"units" yaml:"units"`
+}
+
+// An ExtendModelRequest is the request that is sent in an ExtendModel
+// method.
+type ExtendModelRequest struct {
+	// ModelTag is the tag of the ephemeral model to extend.
+	ModelTag string `json:"model-tag"`
+
+	// Duration is the time by which to extend the model's expiry.
+	Duration time.Duration `json:"duration"`
+}
+
+// An ExtendModelResponse is the response that is sent from an ExtendModel
+// method.
+type ExtendModelResponse struct {
+	// ExpiresAt is the new expiry time of the model.
+	ExpiresAt time.Time `json:"expires-at"`
+}
+
+// A SetModelLabelsRequest is the request that is sent in a
+// SetModelLabels method.
+type SetModelLabelsRequest struct {
+	// ModelTag is the tag of the model to label.
+	ModelTag string `json:"model-tag"`
+
+	// Labels holds the labels to set on the model.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Remove holds the keys of the labels to remove from the model.
+	Remove []string `json:"remove,omitempty"`
+}
+
+// A SetModelLabelsResponse is the response that is sent from a
+// SetModelLabels method.
+type SetModelLabelsResponse struct {
+	// Labels holds the labels on the model after the update.
+	Labels map[string]string `json:"labels"`
+}
+
+// A ListModelsRequest is the request that is sent in a ListModels or
+// ListModelSummaries method.
+type ListModelsRequest struct {
+	// LabelSelector restricts the models returned to those whose labels
+	// match the selector.
+	LabelSelector string `json:"label-selector,omitempty"`
+
+	// Controller restricts the models returned to those hosted on the
+	// named controller.
+	Controller string `json:"controller,omitempty"`
+
+	// Cloud restricts the models returned to those hosted on the named
+	// cloud.
+	Cloud string `json:"cloud,omitempty"`
+
+	// Region restricts the models returned to those hosted in the named
+	// cloud region.
+	Region string `json:"region,omitempty"`
+
+	// OwnerTag restricts the models returned to those owned by the user
+	// with the given tag.
+	OwnerTag string `json:"owner-tag,omitempty"`
+
+	// Life restricts the models returned to those with the given life
+	// status.
+	Life string `json:"life,omitempty"`
+
+	// Status restricts the models returned to those with the given
+	// status.
+	Status string `json:"status,omitempty"`
+
+	// NamePrefix restricts the models returned to those whose name
+	// starts with the given prefix.
+	NamePrefix string `json:"name-prefix,omitempty"`
+
+	// PageSize is the maximum number of models to return. If this is
+	// zero all matching models are returned.
+	PageSize int `json:"page-size,omitempty"`
+
+	// ContinuationToken is the token returned with the previous page of
+	// results, if any.
+	ContinuationToken string `json:"continuation-token,omitempty"`
+}
+
+// A ListModelsResponse is the response that is sent from a ListModels
+// method.
+type ListModelsResponse struct {
+	// Models holds the matching models.
+	Models []UserModel `json:"models"`
+
+	// ContinuationToken is the token to use to retrieve the next page
+	// of results. It is empty when there are no more results.
+	ContinuationToken string `json:"continuation-token,omitempty"`
+}
+
+// A UserModel holds a model the user has access to and its labels.
+type UserModel struct {
+	jujuparams.UserModel
+
+	// Labels holds the labels attached to the model.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// A ListModelSummariesResponse is the response that is sent from a
+// ListModelSummaries method.
+type ListModelSummariesResponse struct {
+	// Models holds the summaries of the matching models.
+	Models []ModelSummary `json:"models"`
+
+	// ContinuationToken is the token to use to retrieve the next page
+	// of results. It is empty when there are no more results.
+	ContinuationToken string `json:"continuation-token,omitempty"`
+}
+
+// A ModelSummary holds a model summary and the model's labels.
+type ModelSummary struct {
+	jujuparams.ModelSummary
+
+	// Labels holds the labels attached to the model.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// A ModelInfoResponse is the response that is sent from a ModelInfo
+// method.
+type ModelInfoResponse struct {
+	// Results holds a result for each requested model.
+	Results []ModelInfoResult `json:"results"`
+}
+
+// A ModelInfoResult holds the information about a single model, or the
+// error encountered retrieving it.
+type ModelInfoResult struct {
+	Result *ModelInfo        `json:"result,omitempty"`
+	Error  *jujuparams.Error `json:"error,omitempty"`
+}
+
+// A ModelInfo holds model information and the model's labels.
+type ModelInfo struct {
+	jujuparams.ModelInfo
+
+	// Labels holds the labels attached to the model.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// A WatchModelSummariesRequest is the request that is sent in a
+// WatchModelSummaries method.
+type WatchModelSummariesRequest struct {
+	// LabelSelector restricts the watched models to those whose labels
+	// match the selector.
+	LabelSelector string `json:"label-selector,omitempty"`
+}
+
+// A TransferModelOwnershipRequest is the request that is sent in a
+// TransferModelOwnership method.
+type TransferModelOwnershipRequest struct {
+	// ModelTag is the tag of the model to transfer.
+	ModelTag string `json:"model-tag"`
+
+	// NewOwnerTag is the tag of the user that will own the model.
+	NewOwnerTag string `json:"new-owner-tag"`
+
+	// KeepAccess determines whether the previous owner retains their
+	// access to the model.
+	KeepAccess bool `json:"keep-access,omitempty"`
+}
+
+// A ModelTemplate is a named set of model settings that can be applied
+// when a model is created.
+type ModelTemplate struct {
+	// Name is the unique name of the template.
+	Name string `json:"name" yaml:"name"`
+
+	// Description is a human readable description of the template.
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+
+	// Cloud is the name of the cloud models created from the template
+	// are hosted on.
+	Cloud string `json:"cloud,omitempty" yaml:"cloud,omitempty"`
+
+	// Region is the name of the cloud region models created from the
+	// template are hosted in.
+	Region string `json:"region,omitempty" yaml:"region,omitempty"`
+
+	// Credentials holds the names of the model owner's cloud credentials
+	// to use for models created from the template, in order of
+	// preference.
+	Credentials []string `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+
+	// Config holds the model config applied to models created from the
+	// template.
+	Config map[string]interface{} `json:"config,omitempty" yaml:"config,omitempty"`
+
+	// Access holds the relation, either "reader" or "writer", granted to
+	// the members of each named group on models created from the
+	// template.
+	Access map[string]string `json:"access,omitempty" yaml:"access,omitempty"`
+
+	// Labels holds the labels attached to models created from the
+	// template.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// An AddModelTemplateRequest is the request that is sent in an
+// AddModelTemplate method.
+type AddModelTemplateRequest struct {
+	ModelTemplate
+}
+
+// An UpdateModelTemplateRequest is the request that is sent in an
+// UpdateModelTemplate method. The template with the same name is
+// replaced.
+type UpdateModelTemplateRequest struct {
+	ModelTemplate
+}
+
+// A RemoveModelTemplateRequest is the request that is sent in a
+// RemoveModelTemplate method.
+type RemoveModelTemplateRequest struct {
+	// Name is the name of the template to remove.
+	Name string `json:"name"`
+}
+
+// A ListModelTemplatesRequest is the request that is sent in a
+// ListModelTemplates method.
+type ListModelTemplatesRequest struct {
+	// Name, if set, restricts the result to the template with the given
+	// name.
+	Name string `json:"name,omitempty"`
+}
+
+// A ListModelTemplatesResponse is the response that is sent from a
+// ListModelTemplates method.
+type ListModelTemplatesResponse struct {
+	// Templates holds the model templates.
+	Templates []ModelTemplate `json:"templates"`
+}
+
+// A DeletedModel is the tombstone of a model that has been removed from
+// JIMM.
+type DeletedModel struct {
+	// UUID is the UUID of the deleted model.
+	UUID string `json:"uuid" yaml:"uuid"`
+
+	// Name is the name of the deleted model.
+	Name string `json:"name" yaml:"name"`
+
+	// Owner is the name of the identity that owned the model.
+	Owner string `json:"owner" yaml:"owner"`
+
+	// Controller is the name of the controller that hosted the model.
+	Controller string `json:"controller,omitempty" yaml:"controller,omitempty"`
+
+	// Cloud is the name of the cloud that hosted the model.
+	Cloud string `json:"cloud,omitempty" yaml:"cloud,omitempty"`
+
+	// Region is the name of the cloud region that hosted the model.
+	Region string `json:"region,omitempty" yaml:"region,omitempty"`
+
+	// CloudCredential is the name of the cloud credential used by the
+	// model.
+	CloudCredential string `json:"cloud-credential,omitempty" yaml:"cloud-credential,omitempty"`
+
+	// Type is the type of the model.
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// Life is the life status of the model when it was removed.
+	Life string `json:"life,omitempty" yaml:"life,omitempty"`
+
+	// Labels holds the labels that were attached to the model.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// CreatedAt is the time the model was added to JIMM.
+	CreatedAt time.Time `json:"created-at" yaml:"created-at"`
+
+	// DeletedAt is the time the model was removed from JIMM.
+	DeletedAt time.Time `json:"deleted-at" yaml:"deleted-at"`
+
+	// Tuples holds the relationship tuples that referred to the model
+	// when it was removed.
+	Tuples []RelationshipTuple `json:"tuples,omitempty" yaml:"tuples,omitempty"`
+}
+
+// A ListDeletedModelsRequest is the request that is sent in a
+// ListDeletedModels method.
+type ListDeletedModelsRequest struct {
+	// ModelUUID, if set, restricts the results to the tombstones of the
+	// model with the given UUID.
+	ModelUUID string `json:"model-uuid,omitempty"`
+}
+
+// A ListDeletedModelsResponse is the response that is sent from a
+// ListDeletedModels method.
+type ListDeletedModelsResponse struct {
+	// Models holds the tombstones of the deleted models, most recently
+	// deleted first.
+	Models []DeletedModel `json:"models"`
+}
+
+// A BulkModelAccessRequest is the request that is sent in a
+// BulkModelAccess method.
+type BulkModelAccessRequest struct {
+	// Revoke determines whether access is revoked, rather than granted.
+	Revoke bool `json:"revoke,omitempty"`
+
+	// Entity is the tag of the user or group whose access is changed.
+	Entity string `json:"entity"`
+
+	// Access is the access level, one of "read", "write" or "admin".
+	Access string `json:"access"`
+
+	// OwnerTag selects the models owned by the user with the given tag.
+	OwnerTag string `json:"owner-tag,omitempty"`
+
+	// Controller selects the models hosted on the named controller.
+	Controller string `json:"controller,omitempty"`
+
+	// NameGlob selects the models whose name matches the glob pattern.
+	NameGlob string `json:"name-glob,omitempty"`
+
+	// LabelSelector selects the models whose labels match the selector.
+	LabelSelector string `json:"label-selector,omitempty"`
+
+	// DryRun determines whether the affected models are only listed,
+	// without changing any access.
+	DryRun bool `json:"dry-run,omitempty"`
+}
+
+// A BulkModelAccessResponse is the response that is sent from a
+// BulkModelAccess method.
+type BulkModelAccessResponse struct {
+	// CorrelationID identifies the audit log entry recording the
+	// change. It is empty for a dry run.
+	CorrelationID string `json:"correlation-id,omitempty" yaml:"correlation-id,omitempty"`
+
+	// Models holds the models whose access was, or in a dry run would
+	// be, changed.
+	Models []BulkModelAccessModel `json:"models" yaml:"models"`
+}
+
+// A BulkModelAccessModel identifies a model affected by a bulk change to
+// model access.
+type BulkModelAccessModel struct {
+	// UUID is the UUID of the model.
+	UUID string `json:"uuid" yaml:"uuid"`
+
+	// Name is the name of the model.
+	Name string `json:"name" yaml:"name"`
+
+	// Owner is the name of the model owner.
+	Owner string `json:"owner" yaml:"owner"`
+}
+
+// A ModelDriftRequest is the request that is sent in a ModelDrift
+// method.
+type ModelDriftRequest struct {
+	// OwnerTag selects the models owned by the user with the given tag.
+	OwnerTag string `json:"owner-tag,omitempty"`
+
+	// Controller selects the models hosted on the named controller.
+	Controller string `json:"controller,omitempty"`
+
+	// NameGlob selects the models whose name matches the glob pattern.
+	NameGlob string `json:"name-glob,omitempty"`
+
+	// LabelSelector selects the models whose labels match the selector.
+	LabelSelector string `json:"label-selector,omitempty"`
+
+	// Apply determines whether the model defaults are re-applied to
+	// models whose config has drifted.
+	Apply bool `json:"apply,omitempty"`
+
+	// Force determines whether the model defaults are also re-applied
+	// to models added before the sources of their config were recorded.
+	Force bool `json:"force,omitempty"`
+}
+
+// A ModelDriftResponse is the response that is sent from a ModelDrift
+// method.
+type ModelDriftResponse struct {
+	// Models holds the models whose config has drifted from the model
+	// defaults, or that could not be checked.
+	Models []ModelDrift `json:"models" yaml:"models"`
+}
+
+// A ModelDrift holds the config drift of a single model.
+type ModelDrift struct {
+	// UUID is the UUID of the model.
+	UUID string `json:"uuid" yaml:"uuid"`
+
+	// Name is the name of the model.
+	Name string `json:"name" yaml:"name"`
+
+	// Owner is the name of the model owner.
+	Owner string `json:"owner" yaml:"owner"`
+
+	// Drift holds the config values that differ from the model
+	// defaults.
+	Drift []ConfigDrift `json:"drift,omitempty" yaml:"drift,omitempty"`
+
+	// Applied reports whether the model defaults were re-applied.
+	Applied bool `json:"applied,omitempty" yaml:"applied,omitempty"`
+
+	// Error holds any error encountered checking or updating the model.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// A ConfigDrift is a model config value that differs from the value in
+// the model defaults.
+type ConfigDrift struct {
+	// Key is the config key.
+	Key string `json:"key" yaml:"key"`
+
+	// Expected is the value from the model defaults.
+	Expected interface{} `json:"expected" yaml:"expected"`
+
+	// Actual is the value in the model config, it is omitted if the key
+	// is not set in the model.
+	Actual interface{} `json:"actual,omitempty" yaml:"actual,omitempty"`
+
+	// Source is where the expected value comes from, one of "group",
+	// "identity", "cloud" or "region".
+	Source string `json:"source" yaml:"source"`
+
+	// Group is the name of the group the expected value comes from when
+	// Source is "group".
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+}
+
+// A SetCloudCredentialGroupRequest is the request that is sent in a
+// SetCloudCredentialGroup method.
+type SetCloudCredentialGroupRequest struct {
+	// CloudCredentialTag is the tag of the cloud credential.
+	CloudCredentialTag string `json:"cloud-credential-tag"`
+
+	// Group is the name of the group that will own the credential. If
+	// this is empty the credential reverts to being owned by the
+	// identity in its tag.
+	Group string `json:"group,omitempty"`
+}
+
+// A ListCloudCredentialsRequest is the request that is sent in a
+// ListCloudCredentials method.
+type ListCloudCredentialsRequest struct {
+	// OwnerTag selects the credentials owned by the user with the given
+	// tag. If this is empty JIMM administrators see all credentials and
+	// other users see their own.
+	OwnerTag string `json:"owner-tag,omitempty"`
+
+	// Invalid selects only the credentials that failed their last
+	// check.
+	Invalid bool `json:"invalid,omitempty"`
+}
+
+// A ListCloudCredentialsResponse is the response that is sent from a
+// ListCloudCredentials method.
+type ListCloudCredentialsResponse struct {
+	// Credentials holds the matching cloud credentials.
+	Credentials []CloudCredentialStatus `json:"credentials" yaml:"credentials"`
+}
+
+// A CloudCredentialStatus holds the validity of a cloud credential.
+type CloudCredentialStatus struct {
+	// Tag is the tag of the cloud credential.
+	Tag string `json:"tag" yaml:"tag"`
+
+	// AuthType is the authentication type of the credential.
+	AuthType string `json:"auth-type" yaml:"auth-type"`
+
+	// Valid reports whether the credential is valid, it is omitted if
+	// the validity of the credential is not known.
+	Valid *bool `json:"valid,omitempty" yaml:"valid,omitempty"`
+
+	// LastChecked is the time the credential was last checked against
+	// a controller, it is omitted if the credential has not been
+	// checked.
+	LastChecked *time.Time `json:"last-checked,omitempty" yaml:"last-checked,omitempty"`
+
+	// Models holds the number of models using the credential.
+	Models int `json:"models" yaml:"models"`
+}
+
+// A CloudCredentialRotationRequest identifies the cloud credential in
+// a GetCloudCredentialRotation method.
+type CloudCredentialRotationRequest struct {
+	// Cloud is the name of the credential's cloud.
+	Cloud string `json:"cloud"`
+
+	// CredentialName is the name of the credential.
+	CredentialName string `json:"credential-name"`
+
+	// OwnerTag is the tag of the user owning the credential, if this is
+	// empty the credential is owned by the authenticated user.
+	OwnerTag string `json:"owner-tag,omitempty"`
+}
+
+// A RotateCloudCredentialRequest is the request that is sent in a
+// RotateCloudCredential method.
+type RotateCloudCredentialRequest struct {
+	CloudCredentialRotationRequest
+
+	// Credential holds the new auth-type and attributes of the
+	// credential.
+	Credential jujuparams.CloudCredential `json:"credential"`
+}
+
+// A CloudCredentialRotation describes a staged rotation of a cloud
+// credential.
+type CloudCredentialRotation struct {
+	// CredentialTag is the tag of the rotated credential.
+	CredentialTag string `json:"credential-tag" yaml:"credential-tag"`
+
+	// CreatedBy is the name of the user that started the rotation.
+	CreatedBy string `json:"created-by" yaml:"created-by"`
+
+	// Status is the status of the rotation, one of "validating",
+	// "canary", "rolling-out", "completed", "failed" or "rolled-back".
+	Status string `json:"status" yaml:"status"`
+
+	// StatusMessage explains the status, for example why the rotation
+	// was rolled back.
+	StatusMessage string `json:"status-message,omitempty" yaml:"status-message,omitempty"`
+
+	// CanaryController is the controller the new attributes were
+	// applied to first.
+	CanaryController string `json:"canary-controller,omitempty" yaml:"canary-controller,omitempty"`
+
+	// UpdatedControllers holds the controllers the new attributes were
+	// applied to. If the rotation was rolled back these controllers
+	// have had the previous attributes restored.
+	UpdatedControllers []string `json:"updated-controllers,omitempty" yaml:"updated-controllers,omitempty"`
+
+	// StartedAt is the time the rotation was started.
+	StartedAt time.Time `json:"started-at" yaml:"started-at"`
+
+	// UpdatedAt is the time the rotation status last changed.
+	UpdatedAt time.Time `json:"updated-at" yaml:"updated-at"`
+}
+
+// A ListCloudCredentialVersionsRequest is the request that is sent in a
+// ListCloudCredentialVersions method.
+type ListCloudCredentialVersionsRequest struct {
+	// CredentialTag is the tag of the cloud credential.
+	CredentialTag string `json:"credential-tag"`
+}
+
+// A CloudCredentialVersion describes a stored version of the attributes
+// of a cloud credential.
+type CloudCredentialVersion struct {
+	// Version is the version number.
+	Version int `json:"version" yaml:"version"`
+
+	// CreatedTime is the time the version was stored.
+	CreatedTime time.Time `json:"created-time" yaml:"created-time"`
+
+	// Deleted is true if the version has been deleted and so cannot be
+	// restored.
+	Deleted bool `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+}
+
+// A ListCloudCredentialVersionsResponse is the response that is sent in
+// a ListCloudCredentialVersions method.
+type ListCloudCredentialVersionsResponse struct {
+	// Versions holds the stored versions, oldest first.
+	Versions []CloudCredentialVersion `json:"versions" yaml:"versions"`
+}
+
+// A RestoreCloudCredentialVersionRequest is the request that is sent in a
+// RestoreCloudCredentialVersion method.
+type RestoreCloudCredentialVersionRequest struct {
+	// CredentialTag is the tag of the cloud credential.
+	CredentialTag string `json:"credential-tag"`
+
+	// Version is the version of the attributes to restore.
+	Version int `json:"version"`
+}
+
+// A RestoreCloudCredentialVersionResponse is the response that is sent in
+// a RestoreCloudCredentialVersion method.
+type RestoreCloudCredentialVersionResponse struct {
+	// Models holds the result of applying the restored attributes to
+	// the models using the credential.
+	Models []jujuparams.UpdateCredentialModelResult `json:"models,omitempty" yaml:"models,omitempty"`
+}
+
+// An AddCatalogueCloudRequest is the request that is sent in an
+// AddCatalogueCloud method.
+type AddCatalogueCloudRequest struct {
+	// Name is the name of the cloud.
+	Name string `json:"name"`
+
+	// Cloud is the definition of the cloud.
+	Cloud jujuparams.Cloud `json:"cloud"`
+}
+
+// An AttachCloudToControllerRequest is the request that is sent in an
+// AttachCloudToController method.
+type AttachCloudToControllerRequest struct {
+	// CloudName is the name of the cloud in the cloud catalogue.
+	CloudName string `json:"cloud-name"`
+
+	// ControllerName is the name of the controller that will host the
+	// cloud.
+	ControllerName string `json:"controller-name"`
+
+	// Force adds the cloud to the controller even if the controller
+	// considers the cloud incompatible.
+	Force bool `json:"force,omitempty"`
+}
+
+// An UpdateCatalogueCloudRequest is the request that is sent in an
+// UpdateCatalogueCloud method.
+type UpdateCatalogueCloudRequest struct {
+	// Name is the name of the cloud.
+	Name string `json:"name"`
+
+	// Cloud is the new definition of the cloud.
+	Cloud jujuparams.Cloud `json:"cloud"`
+}
+
+// A CloudControllerStatusRequest is the request that is sent in a
+// CloudControllerStatus method.
+type CloudControllerStatusRequest struct {
+	// Name is the name of the cloud.
+	Name string `json:"name"`
+}
+
+// A CloudControllerStatus describes whether a controller hosting a cloud
+// has the definition of the cloud held in the cloud catalogue.
+type CloudControllerStatus struct {
+	// Controller is the name of the controller.
+	Controller string `json:"controller" yaml:"controller"`
+
+	// Status is either "synced" or "error".
+	Status string `json:"status" yaml:"status"`
+
+	// Message holds the reason for an error status.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// UpdatedTime is the time the status was last updated.
+	UpdatedTime time.Time `json:"updated-time" yaml:"updated-time"`
+}
+
+// A CloudControllerStatusResponse is the response that is sent in
+// CloudControllerStatus and UpdateCatalogueCloud methods.
+type CloudControllerStatusResponse struct {
+	// Controllers holds the status of the cloud on each controller
+	// hosting it.
+	Controllers []CloudControllerStatus `json:"controllers" yaml:"controllers"`
+}
+
+// A SetGroupModelDefaultsRequest is the request that is sent in a
+// SetGroupModelDefaults method.
+type SetGroupModelDefaultsRequest struct {
+	// Group is the name of the group.
+	Group string `json:"group"`
+
+	// Priority orders the defaults of the groups a user is a member of,
+	// the defaults of groups with a higher priority take precedence. If
+	// this is nil the existing priority is kept.
+	Priority *int `json:"priority,omitempty"`
+
+	// Config holds the default model config values to set.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// An UnsetGroupModelDefaultsRequest is the request that is sent in an
+// UnsetGroupModelDefaults method.
+type UnsetGroupModelDefaultsRequest struct {
+	// Group is the name of the group.
+	Group string `json:"group"`
+
+	// Keys holds the default model config keys to unset.
+	Keys []string `json:"keys"`
+}
+
+// A GroupModelDefaultsRequest is the request that is sent in a
+// GroupModelDefaults method.
+type GroupModelDefaultsRequest struct {
+	// Group is the name of the group.
+	Group string `json:"group"`
+}
+
+// A GroupModelDefaults holds the model defaults of a group.
+type GroupModelDefaults struct {
+	// Group is the name of the group.
+	Group string `json:"group" yaml:"group"`
+
+	// Priority orders the defaults of the groups a user is a member of.
+	Priority int `json:"priority" yaml:"priority"`
+
+	// Config holds the default model config values.
+	Config map[string]interface{} `json:"config" yaml:"config"`
+}
+
+// An EffectiveModelConfigRequest is the request that is sent in an
+// EffectiveModelConfig method. It describes a model the authenticated
+// user could add.
+type EffectiveModelConfigRequest struct {
+	// CloudTag is the tag of the cloud the model would be added to.
+	CloudTag string `json:"cloud-tag,omitempty"`
+
+	// CloudRegion is the cloud region the model would be added to.
+	CloudRegion string `json:"region,omitempty"`
+
+	// Config holds the config that would be given when adding the
+	// model, including any model template.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// An EffectiveModelConfigResponse is the response that is sent from an
+// EffectiveModelConfig method.
+type EffectiveModelConfigResponse struct {
+	// Config holds the config the model would be added with, ordered by
+	// key.
+	Config []EffectiveModelConfigValue `json:"config" yaml:"config"`
+}
+
+// An EffectiveModelConfigValue is a config value a model would be added
+// with, along with where the value comes from.
+type EffectiveModelConfigValue struct {
+	// Key is the config key.
+	Key string `json:"key" yaml:"key"`
+
+	// Value is the config value.
+	Value interface{} `json:"value" yaml:"value"`
+
+	// Source is where the value comes from, one of "group",
+	// "identity", "cloud", "region", "template" or "explicit", in order
+	// of increasing precedence.
+	Source string `json:"source" yaml:"source"`
+
+	// Group is the name of the group the value comes from when Source
+	// is "group".
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+}
+
+// An AddMigrationPlanRequest is the request that is sent in an
+// AddMigrationPlan method.
+type AddMigrationPlanRequest struct {
+	// Name is the unique name of the plan.
+	Name string `json:"name"`
+
+	// TargetController is the name of the controller the models are
+	// migrated to.
+	TargetController string `json:"target-controller"`
+
+	// StartAt is the earliest time at which migrations are started. If
+	// this is zero migrations start immediately.
+	StartAt time.Time `json:"start-at,omitempty"`
+
+	// MaxConcurrency is the maximum number of models migrated at the
+	// same time. If this is zero models are migrated one at a time.
+	MaxConcurrency int `json:"max-concurrency,omitempty"`
+
+	// MaxFailures is the number of consecutive failed migrations after
+	// which the plan is paused. If this is zero the plan is never paused
+	// automatically.
+	MaxFailures int `json:"max-failures,omitempty"`
+
+	// ModelUUIDs holds the UUIDs of the models to migrate in the order
+	// they are migrated.
+	ModelUUIDs []string `json:"model-uuids"`
+}
+
+// A MigrationPlan is a scheduled batch of model migrations.
+type MigrationPlan struct {
+	// Name is the name of the plan.
+	Name string `json:"name" yaml:"name"`
+
+	// TargetController is the name of the controller the models are
+	// migrated to.
+	TargetController string `json:"target-controller" yaml:"target-controller"`
+
+	// StartAt is the earliest time at which migrations are started.
+	StartAt time.Time `json:"start-at" yaml:"start-at"`
+
+	// MaxConcurrency is the maximum number of models migrated at the
+	// same time.
+	MaxConcurrency int `json:"max-concurrency" yaml:"max-concurrency"`
+
+	// MaxFailures is the number of consecutive failed migrations after
+	// which the plan is paused.
+	MaxFailures int `json:"max-failures,omitempty" yaml:"max-failures,omitempty"`
+
+	// ConsecutiveFailures is the number of migrations that have failed
+	// since the last successful migration.
+	ConsecutiveFailures int `json:"consecutive-failures,omitempty" yaml:"consecutive-failures,omitempty"`
+
+	// Status is the status of the plan, one of "pending", "running",
+	// "paused", "completed" or "cancelled".
+	Status string `json:"status" yaml:"status"`
+
+	// StatusMessage explains the status of the plan.
+	StatusMessage string `json:"status-message,omitempty" yaml:"status-message,omitempty"`
+
+	// CreatedBy is the name of the identity that created the plan.
+	CreatedBy string `json:"created-by" yaml:"created-by"`
+
+	// CreatedAt is the time the plan was created.
+	CreatedAt time.Time `json:"created-at" yaml:"created-at"`
+
+	// Models holds the models in the plan in migration order.
+	Models []MigrationPlanModel `json:"models" yaml:"models"`
+}
+
+// A MigrationPlanModel is the migration status of a model in a
+// MigrationPlan.
+type MigrationPlanModel struct {
+	// ModelUUID is the UUID of the model.
+	ModelUUID string `json:"model-uuid" yaml:"model-uuid"`
+
+	// Status is the migration status of the model, one of "pending",
+	// "migrating", "done" or "failed".
+	Status string `json:"status" yaml:"status"`
+
+	// Error holds the error from a failed migration.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// StartedAt is the time the migration was initiated.
+	StartedAt *time.Time `json:"started-at,omitempty" yaml:"started-at,omitempty"`
+
+	// CompletedAt is the time the migration completed or failed.
+	CompletedAt *time.Time `json:"completed-at,omitempty" yaml:"completed-at,omitempty"`
+}
+
+// A GetMigrationPlanRequest is the request that is sent in a
+// GetMigrationPlan method.
+type GetMigrationPlanRequest struct {
+	// Name is the name of the plan.
+	Name string `json:"name"`
+}
+
+// A ListMigrationPlansResponse is the response that is sent from a
+// ListMigrationPlans method.
+type ListMigrationPlansResponse struct {
+	// Plans holds the migration plans ordered by start time.
+	Plans []MigrationPlan `json:"plans"`
+}
+
+// An UpdateMigrationPlanRequest is the request that is sent in an
+// UpdateMigrationPlan method.
+type UpdateMigrationPlanRequest struct {
+	// Name is the name of the plan.
+	Name string `json:"name"`
+
+	// Action is the action to perform on the plan, one of "pause",
+	// "resume" or "cancel".
+	Action string `json:"action"`
+}
+
 // FullModelStatusRequest is the request that is sent in a FullModelStatus method.
 type FullModelStatusRequest struct {
 	ModelTag string
@@ -366,6 +1390,10 @@ type ListRelationshipTuplesResponse struct {
 type CrossModelQueryRequest struct {
 	Type  string `json:"type"`
 	Query string `json:"query"`
+
+	// LabelSelector restricts the query to models whose labels match
+	// the selector.
+	LabelSelector string `json:"label-selector,omitempty"`
 }
 
 // CrossModelJqQueryResponse holds results for a cross-model query that has been filtered utilising JQ.
@@ -0,0 +1,77 @@
+// Copyright 2024 Canonical.
+
+package names
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/juju/names/v5"
+)
+
+const (
+	// CloudRegionTagKind represents the resource "kind" that cloud
+	// regions are represented as.
+	CloudRegionTagKind = "cloudregion"
+)
+
+var validRegionName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// CloudRegionTag represents a region of a cloud, the id of the tag is
+// of the form <cloud>/<region>.
+// Implements juju names.Tag
+type CloudRegionTag struct {
+	cloud  string
+	region string
+}
+
+// Id implements juju names.Tag
+func (t CloudRegionTag) Id() string { return t.cloud + "/" + t.region }
+
+// Kind implements juju names.Tag
+func (t CloudRegionTag) Kind() string { return CloudRegionTagKind }
+
+// String implements juju names.Tag
+func (t CloudRegionTag) String() string { return CloudRegionTagKind + "-" + t.Id() }
+
+// Cloud returns the tag of the cloud the region belongs to.
+func (t CloudRegionTag) Cloud() names.CloudTag { return names.NewCloudTag(t.cloud) }
+
+// Region returns the name of the region.
+func (t CloudRegionTag) Region() string { return t.region }
+
+// NewCloudRegionTag creates a CloudRegionTag for the cloud region with
+// the given id, of the form <cloud>/<region>. NewCloudRegionTag panics
+// if the id is not valid.
+func NewCloudRegionTag(id string) CloudRegionTag {
+	if !IsValidCloudRegion(id) {
+		panic(fmt.Sprintf("invalid cloud region tag %q", id))
+	}
+	cloud, region, _ := strings.Cut(id, "/")
+	return CloudRegionTag{cloud: cloud, region: region}
+}
+
+// ParseCloudRegionTag parses a cloud region tag string.
+func ParseCloudRegionTag(tag string) (CloudRegionTag, error) {
+	t, err := ParseTag(tag)
+	if err != nil {
+		return CloudRegionTag{}, err
+	}
+	rt, ok := t.(CloudRegionTag)
+	if !ok {
+		return CloudRegionTag{}, invalidTagError(tag, CloudRegionTagKind)
+	}
+	return rt, nil
+}
+
+// IsValidCloudRegion verifies that the given id is of the form
+// <cloud>/<region> where cloud is a valid cloud name and region is a
+// valid region name.
+func IsValidCloudRegion(id string) bool {
+	cloud, region, ok := strings.Cut(id, "/")
+	if !ok {
+		return false
+	}
+	return names.IsValidCloud(cloud) && validRegionName.MatchString(region)
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Canonical.
+
+package names_test
+
+import (
+	"fmt"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/pkg/names"
+)
+
+func TestParseCloudRegionTag(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		about          string
+		tag            string
+		expectedCloud  string
+		expectedRegion string
+		err            string
+	}{{
+		about:          "Valid cloud region tag",
+		tag:            "cloudregion-aws/eu-west-1",
+		expectedCloud:  "aws",
+		expectedRegion: "eu-west-1",
+	}, {
+		about: "Invalid cloud region tag (no region)",
+		tag:   "cloudregion-aws",
+		err:   ".*is not a valid cloudregion tag",
+	}, {
+		about: "Invalid cloud region tag (empty region)",
+		tag:   "cloudregion-aws/",
+		err:   ".*is not a valid cloudregion tag",
+	}, {
+		about: "Invalid cloud region tag (no prefix)",
+		tag:   "aws/eu-west-1",
+		err:   ".*is not a valid tag",
+	}}
+	for _, test := range tests {
+		test := test
+		c.Run(test.about, func(c *qt.C) {
+			rt, err := names.ParseCloudRegionTag(test.tag)
+			if test.err == "" {
+				c.Assert(err, qt.IsNil)
+				c.Assert(rt.Id(), qt.Equals, test.expectedCloud+"/"+test.expectedRegion)
+				c.Assert(rt.Kind(), qt.Equals, "cloudregion")
+				c.Assert(rt.String(), qt.Equals, test.tag)
+				c.Assert(rt.Cloud().Id(), qt.Equals, test.expectedCloud)
+				c.Assert(rt.Region(), qt.Equals, test.expectedRegion)
+			} else {
+				c.Assert(err, qt.ErrorMatches, test.err)
+			}
+		})
+	}
+}
+
+func TestIsValidCloudRegion(t *testing.T) {
+	c := qt.New(t)
+	tests := []struct {
+		id            string
+		expectedValid bool
+	}{{
+		id:            "aws/eu-west-1",
+		expectedValid: true,
+	}, {
+		id:            "maas/default",
+		expectedValid: true,
+	}, {
+		id:            "aws",
+		expectedValid: false,
+	}, {
+		id:            "aws/",
+		expectedValid: false,
+	}, {
+		id:            "/eu-west-1",
+		expectedValid: false,
+	}, {
+		id:            "aws/eu west",
+		expectedValid: false,
+	}, {
+		id:            "",
+		expectedValid: false,
+	}}
+	for i, test := range tests {
+		test := test
+		c.Run(fmt.Sprintf("test case %d", i), func(c *qt.C) {
+			c.Assert(names.IsValidCloudRegion(test.id), qt.Equals, test.expectedValid)
+		})
+	}
+}
@@ -52,6 +52,11 @@ func ParseTag(tag string) (names.Tag, error) {
 			return nil, invalidTagError(tag, kind)
 		}
 		return NewServiceAccountTag(id), nil
+	case CloudRegionTagKind:
+		if !IsValidCloudRegion(id) {
+			return nil, invalidTagError(tag, kind)
+		}
+		return NewCloudRegionTag(id), nil
 	default:
 		return names.ParseTag(tag)
 	}